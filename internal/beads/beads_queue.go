@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // QueueFields holds structured fields for queue beads.
@@ -21,6 +22,8 @@ type QueueFields struct {
 	ProcessingCount int    // Number of items currently being processed
 	CompletedCount  int    // Number of items completed
 	FailedCount     int    // Number of items that failed
+	ClaimTTL        string // Claim lease duration, e.g. "30m" (empty = claims never expire)
+	MaxAttempts     int    // Expired claims before an item is dead-lettered (0 = DefaultQueueMaxAttempts)
 	CreatedBy       string // Who created this queue
 	CreatedAt       string // ISO 8601 timestamp of creation
 }
@@ -32,6 +35,11 @@ const (
 	QueueStatusClosed = "closed"
 )
 
+// DefaultQueueMaxAttempts is how many times an item's claims may expire
+// before it is dead-lettered. Claims only expire on queues with a claim_ttl:
+// one not renewed within the TTL is released back to the queue.
+const DefaultQueueMaxAttempts = 3
+
 // Queue processing order constants
 const (
 	QueueOrderFIFO     = "fifo"
@@ -79,6 +87,13 @@ func FormatQueueDescription(title string, fields *QueueFields) string {
 	lines = append(lines, fmt.Sprintf("completed_count: %d", fields.CompletedCount))
 	lines = append(lines, fmt.Sprintf("failed_count: %d", fields.FailedCount))
 
+	if fields.ClaimTTL != "" {
+		lines = append(lines, fmt.Sprintf("claim_ttl: %s", fields.ClaimTTL))
+	}
+	if fields.MaxAttempts > 0 {
+		lines = append(lines, fmt.Sprintf("max_attempts: %d", fields.MaxAttempts))
+	}
+
 	if fields.CreatedBy != "" {
 		lines = append(lines, fmt.Sprintf("created_by: %s", fields.CreatedBy))
 	}
@@ -145,6 +160,12 @@ func ParseQueueFields(description string) *QueueFields {
 			if v, err := strconv.Atoi(value); err == nil {
 				fields.FailedCount = v
 			}
+		case "claim_ttl":
+			fields.ClaimTTL = value
+		case "max_attempts":
+			if v, err := strconv.Atoi(value); err == nil {
+				fields.MaxAttempts = v
+			}
 		case "created_by":
 			fields.CreatedBy = value
 		case "created_at":
@@ -155,6 +176,25 @@ func ParseQueueFields(description string) *QueueFields {
 	return fields
 }
 
+// LeaseTTL returns how long a claim on this queue's items lasts without
+// renewal, or 0 if claims never expire. Leases are opt-in: queues created
+// without a claim_ttl (or with an invalid one) keep claims until released.
+func (f *QueueFields) LeaseTTL() time.Duration {
+	if d, err := time.ParseDuration(f.ClaimTTL); err == nil && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// ClaimAttemptLimit returns how many claims on an item may expire before
+// it is dead-lettered.
+func (f *QueueFields) ClaimAttemptLimit() int {
+	if f.MaxAttempts > 0 {
+		return f.MaxAttempts
+	}
+	return DefaultQueueMaxAttempts
+}
+
 // QueueBeadID returns the queue bead ID for a given queue name.
 // Format: hq-q-<name> for town-level queues, gt-q-<name> for rig-level queues.
 func QueueBeadID(name string, isTownLevel bool) string {
@@ -3,6 +3,7 @@ package beads
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMatchClaimPattern(t *testing.T) {
@@ -268,6 +269,31 @@ processing_count: 2`,
 	}
 }
 
+func TestQueueLeaseFields(t *testing.T) {
+	fields := &QueueFields{Name: "scavenge", ClaimTTL: "15m", MaxAttempts: 5}
+	got := ParseQueueFields(FormatQueueDescription("Queue: scavenge", fields))
+	if got.ClaimTTL != "15m" || got.MaxAttempts != 5 {
+		t.Fatalf("round trip = %q/%d, want 15m/5", got.ClaimTTL, got.MaxAttempts)
+	}
+	if got.LeaseTTL() != 15*time.Minute {
+		t.Errorf("LeaseTTL = %v, want 15m", got.LeaseTTL())
+	}
+	if got.ClaimAttemptLimit() != 5 {
+		t.Errorf("ClaimAttemptLimit = %d, want 5", got.ClaimAttemptLimit())
+	}
+
+	if plain := ParseQueueFields("name: plain"); plain.LeaseTTL() != 0 {
+		t.Errorf("unset TTL: LeaseTTL = %v, want 0 (no expiry)", plain.LeaseTTL())
+	}
+	defaults := ParseQueueFields("name: plain\nclaim_ttl: soon")
+	if defaults.LeaseTTL() != 0 {
+		t.Errorf("invalid TTL: LeaseTTL = %v, want 0 (no expiry)", defaults.LeaseTTL())
+	}
+	if defaults.ClaimAttemptLimit() != DefaultQueueMaxAttempts {
+		t.Errorf("ClaimAttemptLimit = %d, want %d", defaults.ClaimAttemptLimit(), DefaultQueueMaxAttempts)
+	}
+}
+
 func TestQueueBeadID(t *testing.T) {
 	tests := []struct {
 		name        string
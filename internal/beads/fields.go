@@ -198,6 +198,7 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+	ConvoyDueAt     string // Convoy deadline (ISO 8601) for deadline boost
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -272,6 +273,9 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "convoy_due_at", "convoy-due-at", "convoydueat":
+			fields.ConvoyDueAt = value
+			hasFields = true
 		}
 	}
 
@@ -336,6 +340,9 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if fields.ConvoyDueAt != "" {
+		lines = append(lines, "convoy_due_at: "+fields.ConvoyDueAt)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -382,6 +389,9 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"convoy_due_at":      true,
+		"convoy-due-at":      true,
+		"convoydueat":        true,
 	}
 
 	// Collect non-MR lines from existing description
@@ -0,0 +1,234 @@
+// Package budget enforces daily spend limits on top of the gt costs log.
+//
+// Limits are configured under "budget" in settings/config.json, per rig,
+// per role, per convoy and per issue. Spend is today's total of session
+// costs recorded by gt costs record. A soft limit escalates and downgrades
+// models; a hard limit blocks new polecats in its scope and pauses that
+// scope's patrols. Everything resets at the start of the next day.
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+)
+
+// ErrExceeded is returned when a hard limit blocks an action.
+var ErrExceeded = errors.New("budget exceeded")
+
+// Scope identifies what a limit applies to.
+type Scope string
+
+const (
+	ScopeRig    Scope = "rig"
+	ScopeRole   Scope = "role"
+	ScopeConvoy Scope = "convoy"
+	ScopeIssue  Scope = "issue"
+)
+
+// Level is how far spend has progressed against a limit.
+type Level string
+
+const (
+	LevelOK   Level = "ok"
+	LevelSoft Level = "soft"
+	LevelHard Level = "hard"
+)
+
+// ConvoyLookup returns the convoys tracking an issue.
+type ConvoyLookup func(issueID string) []string
+
+// Spend is today's spend aggregated per scope key.
+type Spend struct {
+	Total   float64
+	Rigs    map[string]float64
+	Roles   map[string]float64
+	Convoys map[string]float64
+	Issues  map[string]float64
+}
+
+// Tally aggregates spend entries from costs.ReadSpendSince; raw log entries
+// carry cumulative session cost and would be counted once per turn. Convoy
+// spend is the spend of work items tracked by each convoy; it is only
+// computed when convoysOf is set, since resolving convoys queries beads
+// once per work item.
+func Tally(entries []costs.LogEntry, convoysOf ConvoyLookup) *Spend {
+	s := &Spend{
+		Rigs:    make(map[string]float64),
+		Roles:   make(map[string]float64),
+		Convoys: make(map[string]float64),
+		Issues:  make(map[string]float64),
+	}
+	for _, e := range entries {
+		s.Total += e.CostUSD
+		if e.Rig != "" {
+			s.Rigs[e.Rig] += e.CostUSD
+		}
+		if e.Role != "" {
+			s.Roles[e.Role] += e.CostUSD
+		}
+		if e.WorkItem != "" {
+			s.Issues[e.WorkItem] += e.CostUSD
+		}
+	}
+	if convoysOf != nil {
+		for issue, spent := range s.Issues {
+			for _, c := range convoysOf(issue) {
+				s.Convoys[c] += spent
+			}
+		}
+	}
+	return s
+}
+
+// Status is spend against one configured limit.
+type Status struct {
+	Scope    Scope              `json:"scope"`
+	Key      string             `json:"key"`
+	SpentUSD float64            `json:"spent_usd"`
+	Limit    config.BudgetLimit `json:"limit"`
+	Level    Level              `json:"level"`
+}
+
+// ID uniquely identifies the limit, e.g. "rig:gastown".
+func (s Status) ID() string {
+	return string(s.Scope) + ":" + s.Key
+}
+
+// String describes the status for logs and escalations.
+func (s Status) String() string {
+	limit := s.Limit.Hard
+	if s.Level != LevelHard && s.Limit.Soft > 0 {
+		limit = s.Limit.Soft
+	}
+	return fmt.Sprintf("%s %s spent $%.2f today (%s limit $%.2f)", s.Scope, s.Key, s.SpentUSD, s.Level, limit)
+}
+
+// Evaluate checks spend against every configured limit. Rig and role limits
+// are reported even with no spend; per-convoy and per-issue limits are
+// reported for each convoy or issue that has spend. Results are sorted by
+// scope then key.
+func Evaluate(cfg *config.BudgetConfig, spend *Spend) []Status {
+	if cfg == nil || spend == nil {
+		return nil
+	}
+	var out []Status
+	add := func(scope Scope, key string, spent float64, limit config.BudgetLimit) {
+		out = append(out, Status{Scope: scope, Key: key, SpentUSD: spent, Limit: limit, Level: levelFor(spent, limit)})
+	}
+	for rig, limit := range cfg.Rigs {
+		add(ScopeRig, rig, spend.Rigs[rig], limit)
+	}
+	for role, limit := range cfg.Roles {
+		add(ScopeRole, role, spend.Roles[role], limit)
+	}
+	if cfg.Convoy != nil {
+		for convoy, spent := range spend.Convoys {
+			add(ScopeConvoy, convoy, spent, *cfg.Convoy)
+		}
+	}
+	if cfg.Issue != nil {
+		for issue, spent := range spend.Issues {
+			add(ScopeIssue, issue, spent, *cfg.Issue)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Scope != out[j].Scope {
+			return out[i].Scope < out[j].Scope
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func levelFor(spent float64, limit config.BudgetLimit) Level {
+	switch {
+	case limit.Hard > 0 && spent >= limit.Hard:
+		return LevelHard
+	case limit.Soft > 0 && spent >= limit.Soft:
+		return LevelSoft
+	default:
+		return LevelOK
+	}
+}
+
+// Blocking returns the hard-limited statuses that cover a new polecat in
+// rig working on issue (tracked by convoys).
+func Blocking(statuses []Status, rig, issue string, convoys []string) []Status {
+	var out []Status
+	for _, s := range statuses {
+		if s.Level != LevelHard {
+			continue
+		}
+		switch s.Scope {
+		case ScopeRig:
+			if s.Key == rig {
+				out = append(out, s)
+			}
+		case ScopeRole:
+			if s.Key == "polecat" {
+				out = append(out, s)
+			}
+		case ScopeIssue:
+			if issue != "" && s.Key == issue {
+				out = append(out, s)
+			}
+		case ScopeConvoy:
+			for _, c := range convoys {
+				if s.Key == c {
+					out = append(out, s)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Check evaluates today's spend for a town. Returns nil statuses when no
+// budget is configured.
+func Check(townRoot string, convoysOf ConvoyLookup) (*config.BudgetConfig, []Status, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	cfg := settings.Budget
+	if cfg == nil {
+		return nil, nil, nil
+	}
+
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	entries, err := costs.ReadSpendSince(costs.LogPath(), dayStart)
+	if err != nil {
+		return cfg, nil, err
+	}
+	if cfg.Convoy == nil {
+		convoysOf = nil // Skip per-item convoy lookups nobody needs
+	}
+	return cfg, Evaluate(cfg, Tally(entries, convoysOf)), nil
+}
+
+// CheckSpawn returns an ErrExceeded error if a hard limit forbids spawning
+// a polecat in rig for issue. Failures to read settings or the cost log
+// fail open: a broken budget must not stop the town.
+func CheckSpawn(townRoot, rig, issue string, convoysOf ConvoyLookup) error {
+	cfg, statuses, err := Check(townRoot, convoysOf)
+	if err != nil || cfg == nil {
+		return nil
+	}
+
+	var convoys []string
+	if issue != "" && cfg.Convoy != nil && convoysOf != nil {
+		convoys = convoysOf(issue)
+	}
+	blocking := Blocking(statuses, rig, issue, convoys)
+	if len(blocking) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s\nRaise the limit under budget in settings/config.json, or wait until tomorrow", ErrExceeded, blocking[0])
+}
@@ -0,0 +1,124 @@
+package budget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+)
+
+func testEntries() []costs.LogEntry {
+	return []costs.LogEntry{
+		{Role: "polecat", Rig: "gastown", WorkItem: "gt-1", CostUSD: 4},
+		{Role: "polecat", Rig: "gastown", WorkItem: "gt-2", CostUSD: 3},
+		{Role: "witness", Rig: "gastown", CostUSD: 1},
+		{Role: "mayor", CostUSD: 2},
+	}
+}
+
+func TestTally(t *testing.T) {
+	convoysOf := func(issue string) []string {
+		if issue == "gt-1" || issue == "gt-2" {
+			return []string{"hq-cv-a"}
+		}
+		return nil
+	}
+	s := Tally(testEntries(), convoysOf)
+
+	if s.Total != 10 {
+		t.Errorf("Total = %v, want 10", s.Total)
+	}
+	if s.Rigs["gastown"] != 8 {
+		t.Errorf("Rigs[gastown] = %v, want 8", s.Rigs["gastown"])
+	}
+	if s.Roles["polecat"] != 7 {
+		t.Errorf("Roles[polecat] = %v, want 7", s.Roles["polecat"])
+	}
+	if s.Issues["gt-1"] != 4 {
+		t.Errorf("Issues[gt-1] = %v, want 4", s.Issues["gt-1"])
+	}
+	if s.Convoys["hq-cv-a"] != 7 {
+		t.Errorf("Convoys[hq-cv-a] = %v, want 7", s.Convoys["hq-cv-a"])
+	}
+
+	if s := Tally(testEntries(), nil); len(s.Convoys) != 0 {
+		t.Errorf("convoys tallied without lookup: %v", s.Convoys)
+	}
+}
+
+func TestEvaluateAndBlocking(t *testing.T) {
+	cfg := &config.BudgetConfig{
+		Rigs:   map[string]config.BudgetLimit{"gastown": {Soft: 5, Hard: 20}, "beads": {Soft: 5}},
+		Roles:  map[string]config.BudgetLimit{"polecat": {Hard: 7}},
+		Issue:  &config.BudgetLimit{Soft: 3.5},
+		Convoy: &config.BudgetLimit{Hard: 6},
+	}
+	spend := Tally(testEntries(), func(string) []string { return []string{"hq-cv-a"} })
+	statuses := Evaluate(cfg, spend)
+
+	levels := make(map[string]Level)
+	for _, s := range statuses {
+		levels[s.ID()] = s.Level
+	}
+	want := map[string]Level{
+		"rig:gastown":    LevelSoft,
+		"rig:beads":      LevelOK, // configured limits report even without spend
+		"role:polecat":   LevelHard,
+		"issue:gt-1":     LevelSoft,
+		"issue:gt-2":     LevelOK,
+		"convoy:hq-cv-a": LevelHard,
+	}
+	for id, lvl := range want {
+		if levels[id] != lvl {
+			t.Errorf("%s level = %q, want %q", id, levels[id], lvl)
+		}
+	}
+	if len(statuses) != len(want) {
+		t.Errorf("got %d statuses, want %d: %v", len(statuses), len(want), statuses)
+	}
+
+	blocking := Blocking(statuses, "gastown", "gt-9", []string{"hq-cv-a"})
+	if len(blocking) != 2 {
+		t.Errorf("Blocking = %v, want polecat role and convoy", blocking)
+	}
+	cfg.Roles = nil
+	if b := Blocking(Evaluate(cfg, spend), "gastown", "gt-9", nil); len(b) != 0 {
+		t.Errorf("Blocking with no hard limits covering spawn = %v", b)
+	}
+}
+
+func TestCheckSpawn(t *testing.T) {
+	townRoot := t.TempDir()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// No budget configured: never blocks.
+	if err := CheckSpawn(townRoot, "gastown", "", nil); err != nil {
+		t.Fatalf("CheckSpawn without budget: %v", err)
+	}
+
+	settings := config.NewTownSettings()
+	settings.Budget = &config.BudgetConfig{Rigs: map[string]config.BudgetLimit{"gastown": {Hard: 1}}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"session_id":"gt-gastown-toast","role":"polecat","rig":"gastown","cost_usd":2.5,"ended_at":"` +
+		time.Now().Format(time.RFC3339) + `"}` + "\n"
+	if err := os.MkdirAll(filepath.Dir(costs.LogPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(costs.LogPath(), []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckSpawn(townRoot, "gastown", "", nil); !errors.Is(err, ErrExceeded) {
+		t.Errorf("CheckSpawn(gastown) = %v, want ErrExceeded", err)
+	}
+	if err := CheckSpawn(townRoot, "beads", "", nil); err != nil {
+		t.Errorf("CheckSpawn(beads) = %v, want nil", err)
+	}
+}
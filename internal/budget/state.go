@@ -0,0 +1,154 @@
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// PausedBy is recorded as the pauser when a deacon role limit pauses
+// patrols, so the daemon only resumes pauses it created.
+const PausedBy = "budget"
+
+// State is the daemon's record of enforcement actions taken today.
+type State struct {
+	// Date is the day (YYYY-MM-DD) this state applies to.
+	Date string `json:"date"`
+	// CheckedAt is when the daemon last evaluated budgets.
+	CheckedAt time.Time `json:"checked_at"`
+	// Statuses is the result of the last evaluation.
+	Statuses []Status `json:"statuses,omitempty"`
+	// Escalated maps Status.ID to the highest level already acted on.
+	Escalated map[string]Level `json:"escalated,omitempty"`
+
+	// Downgraded is set once soft-limit downgrades changed RoleAgents.
+	Downgraded bool `json:"downgraded,omitempty"`
+	// PrevRoleAgents and PrevCostTier restore tier-managed roles at day end.
+	PrevRoleAgents map[string]string `json:"prev_role_agents,omitempty"`
+	PrevCostTier   string            `json:"prev_cost_tier,omitempty"`
+
+	// PausedPatrols is set once a deacon role limit paused the Deacon.
+	PausedPatrols bool `json:"paused_patrols,omitempty"`
+	// ParkedRigs lists the rigs parked by their rig limit today.
+	ParkedRigs []string `json:"parked_rigs,omitempty"`
+}
+
+// StatePath returns the path of the budget state file.
+func StatePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "budget.json")
+}
+
+// LoadState reads the budget state. A missing file yields an empty state.
+func LoadState(townRoot string) (*State, error) {
+	data, err := os.ReadFile(StatePath(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing budget state: %w", err)
+	}
+	return &s, nil
+}
+
+// SaveState writes the budget state.
+func SaveState(townRoot string, s *State) error {
+	if err := os.MkdirAll(filepath.Dir(StatePath(townRoot)), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(StatePath(townRoot), s)
+}
+
+// SoftTier returns the cost tier applied at a soft limit.
+func SoftTier(cfg *config.BudgetConfig) config.CostTier {
+	if cfg != nil && config.IsValidTier(cfg.SoftTier) {
+		return config.CostTier(cfg.SoftTier)
+	}
+	return config.TierBudget
+}
+
+// AffectedRoles returns the roles to downgrade when s crosses its soft
+// limit: the role itself for role limits, polecats otherwise (they do the
+// rig, convoy and issue work).
+func AffectedRoles(s Status) []string {
+	if s.Scope == ScopeRole {
+		return []string{s.Key}
+	}
+	return []string{"polecat"}
+}
+
+// Downgrade moves roles onto tier's agents, leaving other roles alone.
+// The first downgrade of the day snapshots the tier-managed roles into
+// state so Restore can undo it. Reports whether settings changed.
+func Downgrade(settings *config.TownSettings, state *State, tier config.CostTier, roles []string) (bool, error) {
+	scratch := config.NewTownSettings()
+	if err := config.ApplyCostTier(scratch, tier); err != nil {
+		return false, err
+	}
+
+	if !state.Downgraded {
+		state.PrevRoleAgents = make(map[string]string)
+		for _, role := range config.TierManagedRoles {
+			if agent, ok := settings.RoleAgents[role]; ok {
+				state.PrevRoleAgents[role] = agent
+			}
+		}
+		state.PrevCostTier = settings.CostTier
+	}
+
+	if settings.RoleAgents == nil {
+		settings.RoleAgents = make(map[string]string)
+	}
+	if settings.Agents == nil {
+		settings.Agents = make(map[string]*config.RuntimeConfig)
+	}
+	changed := false
+	for _, role := range roles {
+		agent := scratch.RoleAgents[role]
+		if agent == "" || settings.RoleAgents[role] == agent {
+			continue
+		}
+		settings.RoleAgents[role] = agent
+		if rc, ok := scratch.Agents[agent]; ok {
+			if _, exists := settings.Agents[agent]; !exists {
+				settings.Agents[agent] = rc
+			}
+		}
+		changed = true
+	}
+	if changed {
+		state.Downgraded = true
+		settings.CostTier = config.GetCurrentTier(settings)
+	}
+	return changed, nil
+}
+
+// Restore undoes the day's downgrades on tier-managed roles.
+func Restore(settings *config.TownSettings, state *State) {
+	if !state.Downgraded {
+		return
+	}
+	if settings.RoleAgents == nil {
+		settings.RoleAgents = make(map[string]string)
+	}
+	for _, role := range config.TierManagedRoles {
+		if agent, ok := state.PrevRoleAgents[role]; ok {
+			settings.RoleAgents[role] = agent
+		} else {
+			delete(settings.RoleAgents, role)
+		}
+	}
+	settings.CostTier = state.PrevCostTier
+	state.Downgraded = false
+	state.PrevRoleAgents = nil
+	state.PrevCostTier = ""
+}
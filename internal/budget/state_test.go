@@ -0,0 +1,84 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+func TestDowngradeAndRestore(t *testing.T) {
+	settings := config.NewTownSettings()
+	settings.RoleAgents["witness"] = "claude-sonnet"
+	settings.RoleAgents["custom-role"] = "gemini"
+	state := &State{}
+
+	changed, err := Downgrade(settings, state, config.TierBudget, []string{"polecat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("Downgrade reported no change")
+	}
+	if settings.RoleAgents["polecat"] != "claude-sonnet" {
+		t.Errorf("polecat agent = %q, want claude-sonnet", settings.RoleAgents["polecat"])
+	}
+	if settings.Agents["claude-sonnet"] == nil {
+		t.Error("tier agent preset not added")
+	}
+	if settings.RoleAgents["mayor"] != "" {
+		t.Errorf("unaffected role changed: mayor = %q", settings.RoleAgents["mayor"])
+	}
+
+	// A second downgrade keeps the original snapshot.
+	if _, err := Downgrade(settings, state, config.TierBudget, []string{"witness"}); err != nil {
+		t.Fatal(err)
+	}
+	if state.PrevRoleAgents["witness"] != "claude-sonnet" {
+		t.Errorf("snapshot overwritten: %v", state.PrevRoleAgents)
+	}
+
+	Restore(settings, state)
+	if _, ok := settings.RoleAgents["polecat"]; ok {
+		t.Errorf("polecat not restored to default: %q", settings.RoleAgents["polecat"])
+	}
+	if settings.RoleAgents["witness"] != "claude-sonnet" {
+		t.Errorf("witness = %q, want claude-sonnet", settings.RoleAgents["witness"])
+	}
+	if settings.RoleAgents["custom-role"] != "gemini" {
+		t.Error("non-tier role touched by restore")
+	}
+	if state.Downgraded {
+		t.Error("state still marked downgraded")
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+
+	s, err := LoadState(townRoot)
+	if err != nil || s == nil || s.Date != "" {
+		t.Fatalf("LoadState(missing) = %+v, %v", s, err)
+	}
+
+	s.Date = "2026-01-02"
+	s.Escalated = map[string]Level{"rig:gastown": LevelSoft}
+	if err := SaveState(townRoot, s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Date != "2026-01-02" || got.Escalated["rig:gastown"] != LevelSoft {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestAffectedRoles(t *testing.T) {
+	if r := AffectedRoles(Status{Scope: ScopeRole, Key: "witness"}); len(r) != 1 || r[0] != "witness" {
+		t.Errorf("role scope = %v", r)
+	}
+	if r := AffectedRoles(Status{Scope: ScopeConvoy, Key: "hq-cv-a"}); len(r) != 1 || r[0] != "polecat" {
+		t.Errorf("convoy scope = %v", r)
+	}
+}
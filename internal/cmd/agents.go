@@ -69,7 +69,9 @@ var agentsCmd = &cobra.Command{
 Shows Mayor, Deacon, Witnesses, Refineries, and Crew workers.
 Polecats are hidden (use 'gt polecat list' to see them).
 
-The menu appears as a tmux popup for quick session switching.`,
+The menu appears as a tmux popup for quick session switching.
+
+Use 'gt agents probe' to check installed agent CLIs against their presets.`,
 	RunE: runAgents,
 }
 
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/deps"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+var (
+	agentsProbeJSON   bool
+	agentsProbePin    bool
+	agentsProbePinMin string
+	agentsProbePinMax string
+)
+
+var agentsProbeCmd = &cobra.Command{
+	Use:   "probe [agent...]",
+	Short: "Probe agent CLIs for version and flag compatibility",
+	Long: `Probe agent CLIs and record their versions and supported flags.
+
+Each agent's CLI is run with --version and --help. The version is checked
+against the agent's pin (min/max, inclusive) and the help text is checked for
+the flags its preset relies on (resume, fork, non-interactive, default args).
+Results are recorded in settings/agent-probes.json.
+
+Once an agent is probed, spawning a session on it re-checks the installed
+version; if it changed, the agent is re-probed and spawning is refused when
+it falls outside the pin or has dropped a required flag. 'gt doctor' reports
+the same.
+
+With no arguments, probes the town's default agent, role agents, fallback
+chains and every agent probed before.
+
+Pins are taken from --pin-min/--pin-max, else the previous probe, else the
+preset's defaults. --pin pins the agent to the version found now.
+
+Examples:
+  gt agents probe                       # Probe configured agents
+  gt agents probe claude --pin          # Pin claude to its current version
+  gt agents probe codex --pin-min 0.40  # Require codex >= 0.40
+  gt agents probe --json`,
+	RunE: runAgentsProbe,
+}
+
+func init() {
+	agentsProbeCmd.Flags().BoolVar(&agentsProbeJSON, "json", false, "Output as JSON")
+	agentsProbeCmd.Flags().BoolVar(&agentsProbePin, "pin", false, "Pin each agent to its currently installed version")
+	agentsProbeCmd.Flags().StringVar(&agentsProbePinMin, "pin-min", "", "Minimum allowed version (inclusive)")
+	agentsProbeCmd.Flags().StringVar(&agentsProbePinMax, "pin-max", "", "Maximum allowed version (inclusive)")
+	agentsCmd.AddCommand(agentsProbeCmd)
+}
+
+func runAgentsProbe(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	reg, err := deps.LoadAgentProbes(townRoot)
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		names = agentsToProbe(townRoot, reg)
+	}
+
+	var probes []*deps.AgentProbe
+	seen := make(map[string]bool)
+	for _, name := range names {
+		preset := deps.ResolveAgentPresetName(townRoot, "", name)
+		if preset == "" {
+			if len(args) > 0 {
+				return fmt.Errorf("unknown agent %q", name)
+			}
+			continue // custom commands have no preset to check against
+		}
+		if seen[preset] {
+			continue
+		}
+		seen[preset] = true
+
+		info := config.GetAgentPresetByName(preset)
+		minVersion, maxVersion := deps.AgentPins(info, reg.Agents[preset])
+		if agentsProbePinMin != "" {
+			minVersion = agentsProbePinMin
+		}
+		if agentsProbePinMax != "" {
+			maxVersion = agentsProbePinMax
+		}
+		probe := deps.ProbeAgent(context.Background(), deps.ExecRunner, preset, info, minVersion, maxVersion)
+		if agentsProbePin && probe.Version != "" {
+			probe = deps.ProbeAgent(context.Background(), deps.ExecRunner, preset, info, probe.Version, probe.Version)
+		}
+		reg.Agents[preset] = probe
+		probes = append(probes, probe)
+	}
+
+	if err := deps.SaveAgentProbes(townRoot, reg); err != nil {
+		return fmt.Errorf("saving agent probes: %w", err)
+	}
+
+	if agentsProbeJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(probes)
+	}
+
+	if len(probes) == 0 {
+		fmt.Println("No agents to probe.")
+		return nil
+	}
+	fmt.Println(style.Bold.Render("Agent CLI Probes"))
+	fmt.Println()
+	fmt.Printf("   %-10s %-10s %-16s %s\n", "Agent", "Version", "Pin", "Status")
+	incompatible := 0
+	for _, p := range probes {
+		version := p.Version
+		if version == "" {
+			version = "?"
+		}
+		status := style.Success.Render("ok")
+		if !p.Compatible() {
+			incompatible++
+			status = style.Error.Render(strings.Join(p.Problems, "; "))
+		}
+		fmt.Printf("   %-10s %-10s %-16s %s\n", p.Agent, version, formatAgentPin(p.MinVersion, p.MaxVersion), status)
+	}
+	if incompatible > 0 {
+		fmt.Printf("\n%s %d agent(s) incompatible; sessions will not spawn on them\n", style.Warning.Render("⚠"), incompatible)
+	}
+	return nil
+}
+
+// agentsToProbe lists the agents the town is configured to use plus any
+// already probed, sorted.
+func agentsToProbe(townRoot string, reg *deps.AgentProbeRegistry) []string {
+	set := make(map[string]bool)
+	if settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot)); err == nil {
+		set[settings.DefaultAgent] = true
+		for _, agent := range settings.RoleAgents {
+			set[agent] = true
+		}
+		for _, chain := range settings.AgentFallback {
+			for _, agent := range chain {
+				set[agent] = true
+			}
+		}
+	}
+	for _, name := range reg.ProbedAgents() {
+		set[name] = true
+	}
+	delete(set, "")
+	if len(set) == 0 {
+		set["claude"] = true
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatAgentPin renders a version pin as "1.2-1.4", ">=1.2", "<=1.4" or "-".
+func formatAgentPin(minVersion, maxVersion string) string {
+	switch {
+	case minVersion != "" && minVersion == maxVersion:
+		return "=" + minVersion
+	case minVersion != "" && maxVersion != "":
+		return minVersion + "-" + maxVersion
+	case minVersion != "":
+		return ">=" + minVersion
+	case maxVersion != "":
+		return "<=" + maxVersion
+	}
+	return "-"
+}
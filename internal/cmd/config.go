@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -601,11 +602,14 @@ var configSetCmd = &cobra.Command{
 Supported keys:
   convoy.notify_on_complete   Push notification to Mayor session on convoy
                               completion (true/false, default: false)
+  convoy.max_parallel         Default number of convoy issues dispatched at
+                              once (default: 1)
   cli_theme                   CLI color scheme ("dark", "light", "auto")
   default_agent               Default agent preset name
 
 Examples:
   gt config set convoy.notify_on_complete true
+  gt config set convoy.max_parallel 4
   gt config set cli_theme dark
   gt config set default_agent claude`,
 	Args: cobra.ExactArgs(2),
@@ -621,6 +625,8 @@ var configGetCmd = &cobra.Command{
 Supported keys:
   convoy.notify_on_complete   Push notification to Mayor session on convoy
                               completion (true/false, default: false)
+  convoy.max_parallel         Default number of convoy issues dispatched at
+                              once (default: 1)
   cli_theme                   CLI color scheme
   default_agent               Default agent preset name
 
@@ -657,6 +663,16 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 		townSettings.Convoy.NotifyOnComplete = b
 
+	case "convoy.max_parallel":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid value for %s: %q (expected a positive integer)", key, value)
+		}
+		if townSettings.Convoy == nil {
+			townSettings.Convoy = &config.ConvoyConfig{}
+		}
+		townSettings.Convoy.MaxParallel = n
+
 	case "cli_theme":
 		switch value {
 		case "dark", "light", "auto":
@@ -669,7 +685,7 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		townSettings.DefaultAgent = value
 
 	default:
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent", key)
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  convoy.max_parallel\n  cli_theme\n  default_agent", key)
 	}
 
 	if err := config.SaveTownSettings(settingsPath, townSettings); err != nil {
@@ -703,6 +719,13 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 			value = "false"
 		}
 
+	case "convoy.max_parallel":
+		n := 1
+		if townSettings.Convoy != nil && townSettings.Convoy.MaxParallel > 0 {
+			n = townSettings.Convoy.MaxParallel
+		}
+		value = strconv.Itoa(n)
+
 	case "cli_theme":
 		value = townSettings.CLITheme
 		if value == "" {
@@ -716,7 +739,7 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		}
 
 	default:
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent", key)
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  convoy.max_parallel\n  cli_theme\n  default_agent", key)
 	}
 
 	fmt.Println(value)
@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
+	convoypkg "github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 	"github.com/xcawolfe-amzn/gastown/internal/tui/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
@@ -62,73 +63,39 @@ func looksLikeIssueID(s string) bool {
 
 // Convoy command flags
 var (
-	convoyMolecule     string
-	convoyNotify       string
-	convoyOwner        string
-	convoyOwned        bool
-	convoyMerge        string
-	convoyStatusJSON   bool
-	convoyListJSON     bool
-	convoyListStatus   string
-	convoyListAll      bool
-	convoyListTree     bool
-	convoyInteractive  bool
-	convoyStrandedJSON bool
-	convoyCloseReason  string
-	convoyCloseNotify  string
-	convoyCloseForce   bool
-	convoyCheckDryRun  bool
-	convoyLandForce    bool
-	convoyLandKeep     bool
-	convoyLandDryRun   bool
+	convoyMolecule      string
+	convoyNotify        string
+	convoyOwner         string
+	convoyOwned         bool
+	convoyMerge         string
+	convoyDue           string
+	convoySLA           string
+	convoyMaxParallel   int
+	convoyLanding       string
+	convoyLandOrder     string
+	convoyStatusJSON    bool
+	convoyListJSON      bool
+	convoyListStatus    string
+	convoyListAll       bool
+	convoyListTree      bool
+	convoyListOverdue   bool
+	convoyInteractive   bool
+	convoyStrandedJSON  bool
+	convoyCloseReason   string
+	convoyCloseNotify   string
+	convoyCloseForce    bool
+	convoyCheckDryRun   bool
+	convoyLandForce     bool
+	convoyLandKeep      bool
+	convoyLandDryRun    bool
+	convoyOverdueJSON   bool
+	convoyOverdueEsc    bool
+	convoyAbandonReason string
+	convoyAbandonDryRun bool
+	convoyImportDryRun  bool
+	convoyImportSling   bool
 )
 
-const (
-	convoyStatusOpen   = "open"
-	convoyStatusClosed = "closed"
-)
-
-func normalizeConvoyStatus(status string) string {
-	return strings.ToLower(strings.TrimSpace(status))
-}
-
-func ensureKnownConvoyStatus(status string) error {
-	switch normalizeConvoyStatus(status) {
-	case convoyStatusOpen, convoyStatusClosed:
-		return nil
-	default:
-		return fmt.Errorf(
-			"unsupported convoy status %q (expected %q or %q)",
-			status,
-			convoyStatusOpen,
-			convoyStatusClosed,
-		)
-	}
-}
-
-func validateConvoyStatusTransition(currentStatus, targetStatus string) error {
-	current := normalizeConvoyStatus(currentStatus)
-	target := normalizeConvoyStatus(targetStatus)
-
-	if err := ensureKnownConvoyStatus(current); err != nil {
-		return err
-	}
-	if err := ensureKnownConvoyStatus(target); err != nil {
-		return err
-	}
-	if current == target {
-		return nil
-	}
-	if (current == convoyStatusOpen && target == convoyStatusClosed) ||
-		(current == convoyStatusClosed && target == convoyStatusOpen) {
-		return nil
-	}
-	// With only two valid statuses, identity and both cross-transitions are
-	// covered above. This is unreachable unless new statuses are added to
-	// ensureKnownConvoyStatus without updating this function.
-	return fmt.Errorf("illegal convoy status transition %q -> %q", currentStatus, targetStatus)
-}
-
 var convoyCmd = &cobra.Command{
 	Use:     "convoy",
 	GroupID: GroupWork,
@@ -149,7 +116,8 @@ WHAT IS A CONVOY:
   - Persistent tracking unit with an ID (hq-*)
   - Tracks issues across rigs (frontend+backend, beads+gastown, etc.)
   - Auto-closes when all tracked issues complete → notifies subscribers
-  - Can be reopened by adding more issues
+  - Can be reopened by adding more issues, or with 'gt convoy reopen'
+  - Can be abandoned (closed without completing) with 'gt convoy abandon'
 
 WHAT IS A SWARM:
   - Ephemeral: "the workers currently assigned to a convoy's issues"
@@ -163,11 +131,15 @@ TRACKING SEMANTICS:
 
 COMMANDS:
   create    Create a convoy tracking specified issues
-  add       Add issues to an existing convoy (reopens if closed)
+  add       Add issues to an existing convoy (reopens if closed, not abandoned)
   close     Close a convoy (verifies all items done, or use --force)
   land      Land an owned convoy (cleanup worktrees, close convoy)
+  abandon   Abandon a convoy (release work, nuke idle polecats, notify owner)
+  reopen    Reopen a closed or abandoned convoy
+  import    Create or update a convoy and its issues from a spec file
   status    Show convoy progress, tracked issues, and active workers
-  list      List convoys (the dashboard view)`,
+  list      List convoys (the dashboard view)
+  overdue   Show convoys past or projected past their deadline`,
 }
 
 var convoyCreateCmd = &cobra.Command{
@@ -187,6 +159,25 @@ The --merge flag sets the merge strategy for all work in the convoy:
   mr      Create merge-request bead, refinery processes (default)
   local   Keep on feature branch (for upstream PRs, human review)
 
+The --due flag sets a deadline (date, "date HH:MM" or RFC 3339); --sla sets
+one relative to now (36h, 3d, 2w). Convoys with a deadline are projected
+against historical issue cycle time: at-risk and overdue convoys are flagged
+in 'gt convoy list', fed first, and their MRs are boosted in the merge queue.
+An overdue convoy is escalated once (see 'gt convoy overdue').
+
+The --max-parallel flag lets the convoy observers dispatch up to N ready
+issues at once instead of one at a time. Blocked issues wait for their
+blockers, and rigs at max_polecats are skipped. Defaults to the town's
+convoy.max_parallel setting (see 'gt config set'), else 1.
+
+The --landing flag coordinates landing across rigs:
+  independent  Each MR lands on its own (default)
+  atomic       MRs merge into integration/<convoy-id> in each rig; once all
+               tracked issues are merged, every rig's branch is merged to
+               main, tested, and pushed together (nothing lands if any fails)
+  ordered      The refinery holds a rig's MRs until every earlier rig in
+               --land-order has landed (default order: tracked issue order)
+
 Examples:
   gt convoy create "Deploy v2.0" gt-abc bd-xyz
   gt convoy create "Release prep" gt-abc --notify           # defaults to mayor/
@@ -194,7 +185,12 @@ Examples:
   gt convoy create "Feature rollout" gt-a gt-b --owner mayor/ --notify ops/
   gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release
   gt convoy create --owned "Manual deploy" gt-abc           # caller-managed lifecycle
-  gt convoy create "Quick fix" gt-abc --merge=direct        # bypass refinery`,
+  gt convoy create "Quick fix" gt-abc --merge=direct        # bypass refinery
+  gt convoy create "Sprint work" gt-abc --due=2026-01-15    # deadline
+  gt convoy create "Hotfix" gt-abc --sla=36h                # due 36h from now
+  gt convoy create "Cleanup" gt-a gt-b gt-c --max-parallel=3  # work 3 at once
+  gt convoy create "API v2" gt-api bd-client --landing=atomic
+  gt convoy create "API v2" gt-api bd-client --landing=ordered --land-order=gastown,beads`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runConvoyCreate,
 }
@@ -217,8 +213,9 @@ var convoyListCmd = &cobra.Command{
 
 Examples:
   gt convoy list              # Open convoys only (default)
-  gt convoy list --all        # All convoys (open + closed)
+  gt convoy list --all        # All convoys (open + closed + abandoned)
   gt convoy list --status=closed  # Recently landed
+  gt convoy list --status=abandoned  # Abandoned without completing
   gt convoy list --tree       # Show convoy + child status tree
   gt convoy list --json`,
 	RunE: runConvoyList,
@@ -229,7 +226,8 @@ var convoyAddCmd = &cobra.Command{
 	Short: "Add issues to an existing convoy",
 	Long: `Add issues to an existing convoy.
 
-If the convoy is closed, it will be automatically reopened.
+If the convoy is closed, it will be automatically reopened. An abandoned
+convoy must be reopened explicitly with 'gt convoy reopen' first.
 
 Examples:
   gt convoy add hq-cv-abc gt-new-issue
@@ -331,6 +329,11 @@ func init() {
 	convoyCreateCmd.Flags().Lookup("notify").NoOptDefVal = "mayor/"
 	convoyCreateCmd.Flags().BoolVar(&convoyOwned, "owned", false, "Mark convoy as caller-managed lifecycle (no automatic witness/refinery registration)")
 	convoyCreateCmd.Flags().StringVar(&convoyMerge, "merge", "", "Merge strategy: direct (push to main), mr (merge queue, default), local (keep on branch)")
+	convoyCreateCmd.Flags().StringVar(&convoyDue, "due", "", "Deadline: YYYY-MM-DD (end of day), \"YYYY-MM-DD HH:MM\" or RFC 3339")
+	convoyCreateCmd.Flags().StringVar(&convoySLA, "sla", "", "Deadline relative to now (e.g. 36h, 3d, 2w)")
+	convoyCreateCmd.Flags().IntVar(&convoyMaxParallel, "max-parallel", 0, "Dispatch up to N ready issues at once (default: town convoy.max_parallel, else 1)")
+	convoyCreateCmd.Flags().StringVar(&convoyLanding, "landing", "", "Cross-rig landing policy: independent (default), atomic, or ordered")
+	convoyCreateCmd.Flags().StringVar(&convoyLandOrder, "land-order", "", "Comma-separated rig order for --landing=ordered")
 
 
 	// Status flags
@@ -338,9 +341,10 @@ func init() {
 
 	// List flags
 	convoyListCmd.Flags().BoolVar(&convoyListJSON, "json", false, "Output as JSON")
-	convoyListCmd.Flags().StringVar(&convoyListStatus, "status", "", "Filter by status (open, closed)")
+	convoyListCmd.Flags().StringVar(&convoyListStatus, "status", "", "Filter by status (open, closed, abandoned)")
 	convoyListCmd.Flags().BoolVar(&convoyListAll, "all", false, "Show all convoys (open and closed)")
 	convoyListCmd.Flags().BoolVar(&convoyListTree, "tree", false, "Show convoy + child status tree")
+	convoyListCmd.Flags().BoolVar(&convoyListOverdue, "overdue", false, "Show only overdue and at-risk convoys")
 
 	// Interactive TUI flag (on parent command)
 	convoyCmd.Flags().BoolVarP(&convoyInteractive, "interactive", "i", false, "Interactive tree view")
@@ -361,6 +365,18 @@ func init() {
 	convoyLandCmd.Flags().BoolVar(&convoyLandKeep, "keep-worktrees", false, "Skip worktree cleanup")
 	convoyLandCmd.Flags().BoolVar(&convoyLandDryRun, "dry-run", false, "Show what would happen without acting")
 
+	// Overdue flags
+	convoyOverdueCmd.Flags().BoolVar(&convoyOverdueJSON, "json", false, "Output as JSON")
+	convoyOverdueCmd.Flags().BoolVar(&convoyOverdueEsc, "escalate", false, "Escalate overdue convoys not yet escalated")
+
+	// Abandon flags
+	convoyAbandonCmd.Flags().StringVar(&convoyAbandonReason, "reason", "", "Why the convoy is abandoned (required)")
+	convoyAbandonCmd.Flags().BoolVar(&convoyAbandonDryRun, "dry-run", false, "Show what would be released and nuked without doing it")
+
+	// Import flags
+	convoyImportCmd.Flags().BoolVar(&convoyImportDryRun, "dry-run", false, "Show what would be created and updated without doing it")
+	convoyImportCmd.Flags().BoolVar(&convoyImportSling, "sling", false, "Dispatch ready issues after importing (up to the convoy's max parallel)")
+
 	// Add subcommands
 	convoyCmd.AddCommand(convoyCreateCmd)
 	convoyCmd.AddCommand(convoyStatusCmd)
@@ -370,6 +386,10 @@ func init() {
 	convoyCmd.AddCommand(convoyStrandedCmd)
 	convoyCmd.AddCommand(convoyCloseCmd)
 	convoyCmd.AddCommand(convoyLandCmd)
+	convoyCmd.AddCommand(convoyOverdueCmd)
+	convoyCmd.AddCommand(convoyAbandonCmd)
+	convoyCmd.AddCommand(convoyReopenCmd)
+	convoyCmd.AddCommand(convoyImportCmd)
 
 	rootCmd.AddCommand(convoyCmd)
 }
@@ -396,6 +416,17 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid --merge value %q: must be direct, mr, or local", convoyMerge)
 		}
 	}
+	dueAt, sla, err := resolveConvoyDeadline(convoyDue, convoySLA, time.Now())
+	if err != nil {
+		return err
+	}
+	if convoyMaxParallel < 0 {
+		return fmt.Errorf("invalid --max-parallel %d: must be positive", convoyMaxParallel)
+	}
+	landingLine, err := resolveConvoyLanding(convoyLanding, convoyLandOrder)
+	if err != nil {
+		return err
+	}
 
 	// If first arg looks like an issue ID (has beads prefix), treat all args as issues
 	// and auto-generate a name from the first issue's title
@@ -445,6 +476,15 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		description += fmt.Sprintf("\nMolecule: %s", convoyMolecule)
 	}
+	if !dueAt.IsZero() {
+		description += "\n" + convoyDeadlineLines(dueAt, sla)
+	}
+	if convoyMaxParallel > 0 {
+		description += "\n" + convoyMaxParallelLine(convoyMaxParallel)
+	}
+	if landingLine != "" {
+		description += "\n" + landingLine
+	}
 
 	// Generate convoy ID with cv- prefix
 	convoyID := fmt.Sprintf("hq-cv-%s", generateShortID())
@@ -517,6 +557,15 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		fmt.Printf("  Molecule: %s\n", convoyMolecule)
 	}
+	if !dueAt.IsZero() {
+		fmt.Printf("  Due:      %s\n", formatConvoyDue(dueAt, sla))
+	}
+	if convoyMaxParallel > 0 {
+		fmt.Printf("  Parallel: %d issue(s) at a time\n", convoyMaxParallel)
+	}
+	if landingLine != "" {
+		fmt.Printf("  Landing:  %s\n", formatConvoyLanding(landingLine))
+	}
 	if convoyOwned {
 		fmt.Printf("  Owned:    %s\n", style.Warning.Render("caller-managed lifecycle"))
 	}
@@ -539,51 +588,27 @@ func runConvoyAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Validate convoy exists and get its status
-	showArgs := []string{"show", convoyID, "--json"}
-	showCmd := exec.Command("bd", showArgs...)
-	showCmd.Dir = townBeads
-	var stdout bytes.Buffer
-	showCmd.Stdout = &stdout
-
-	if err := showCmd.Run(); err != nil {
-		return fmt.Errorf("convoy '%s' not found", convoyID)
-	}
-
-	var convoys []struct {
-		ID     string `json:"id"`
-		Title  string `json:"title"`
-		Status string `json:"status"`
-		Type   string `json:"issue_type"`
-	}
-	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
-		return fmt.Errorf("parsing convoy data: %w", err)
+	// Validate convoy exists and get its lifecycle state
+	convoy, err := showConvoyBead(townBeads, convoyID)
+	if err != nil {
+		return err
 	}
 
-	if len(convoys) == 0 {
-		return fmt.Errorf("convoy '%s' not found", convoyID)
-	}
-
-	convoy := convoys[0]
-
-	// Verify it's actually a convoy type
-	if convoy.Type != "convoy" {
-		return fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, convoy.Type)
-	}
-	if err := ensureKnownConvoyStatus(convoy.Status); err != nil {
-		return fmt.Errorf("convoy '%s' has invalid lifecycle state: %w", convoyID, err)
+	// Abandoned convoys need an explicit reopen: adding work must not
+	// silently undo a decision to drop the convoy.
+	state := convoy.lifecycle()
+	if state == convoyStatusAbandoned {
+		return fmt.Errorf("convoy '%s' was abandoned\n  Use 'gt convoy reopen %s' first", convoyID, convoyID)
 	}
 
 	// If convoy is closed, reopen it
 	reopened := false
-	if normalizeConvoyStatus(convoy.Status) == convoyStatusClosed {
-		// closed→open is always valid; ensureKnownConvoyStatus above guarantees
-		// the current status is known, so no additional transition check needed.
-		reopenArgs := []string{"update", convoyID, "--status=open"}
-		reopenCmd := exec.Command("bd", reopenArgs...)
-		reopenCmd.Dir = townBeads
-		if err := reopenCmd.Run(); err != nil {
-			return fmt.Errorf("couldn't reopen convoy: %w", err)
+	if state == convoyStatusClosed {
+		if err := validateConvoyStatusTransition(state, convoyStatusOpen); err != nil {
+			return fmt.Errorf("can't reopen convoy '%s': %w", convoyID, err)
+		}
+		if err := reopenConvoy(townBeads, convoyID, false); err != nil {
+			return err
 		}
 		reopened = true
 		fmt.Printf("%s Reopened convoy %s\n", style.Bold.Render("↺"), convoyID)
@@ -669,11 +694,12 @@ func checkSingleConvoy(townBeads, convoyID string, dryRun bool) error {
 	}
 
 	var convoys []struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Status      string `json:"status"`
-		Type        string `json:"issue_type"`
-		Description string `json:"description"`
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Status      string   `json:"status"`
+		Type        string   `json:"issue_type"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels,omitempty"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return fmt.Errorf("parsing convoy data: %w", err)
@@ -726,6 +752,11 @@ func checkSingleConvoy(townBeads, convoyID string, dryRun bool) error {
 		return nil
 	}
 
+	// Atomic convoys land every rig's integration branch before closing
+	if !landConvoyBeforeClose(townBeads, convoyID, convoy.Title, convoy.Description, convoy.Labels, tracked) {
+		return nil
+	}
+
 	// Actually close the convoy
 	reason := "All tracked issues completed"
 	if len(tracked) == 0 {
@@ -740,6 +771,7 @@ func checkSingleConvoy(townBeads, convoyID string, dryRun bool) error {
 	}
 
 	fmt.Printf("%s Auto-closed convoy 🚚 %s: %s\n", style.Bold.Render("✓"), convoyID, convoy.Title)
+	recordConvoyCycleTimes(townBeads, tracked)
 
 	// Send completion notification
 	notifyConvoyCompletion(townBeads, convoyID, convoy.Title)
@@ -756,47 +788,22 @@ func runConvoyClose(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get convoy details
-	showArgs := []string{"show", convoyID, "--json"}
-	showCmd := exec.Command("bd", showArgs...)
-	showCmd.Dir = townBeads
-	var stdout bytes.Buffer
-	showCmd.Stdout = &stdout
-
-	if err := showCmd.Run(); err != nil {
-		return fmt.Errorf("convoy '%s' not found", convoyID)
-	}
-
-	var convoys []struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Status      string `json:"status"`
-		Type        string `json:"issue_type"`
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
-		return fmt.Errorf("parsing convoy data: %w", err)
-	}
-
-	if len(convoys) == 0 {
-		return fmt.Errorf("convoy '%s' not found", convoyID)
-	}
-
-	convoy := convoys[0]
-
-	// Verify it's actually a convoy type
-	if convoy.Type != "convoy" {
-		return fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, convoy.Type)
-	}
-	if err := ensureKnownConvoyStatus(convoy.Status); err != nil {
-		return fmt.Errorf("convoy '%s' has invalid lifecycle state: %w", convoyID, err)
+	convoy, err := showConvoyBead(townBeads, convoyID)
+	if err != nil {
+		return err
 	}
 
 	// Idempotent: if already closed, just report it
-	if normalizeConvoyStatus(convoy.Status) == convoyStatusClosed {
+	state := convoy.lifecycle()
+	if state == convoyStatusAbandoned {
+		fmt.Printf("%s Convoy %s is already closed (abandoned)\n", style.Dim.Render("○"), convoyID)
+		return nil
+	}
+	if state == convoyStatusClosed {
 		fmt.Printf("%s Convoy %s is already closed\n", style.Dim.Render("○"), convoyID)
 		return nil
 	}
-	if err := validateConvoyStatusTransition(convoy.Status, convoyStatusClosed); err != nil {
+	if err := validateConvoyStatusTransition(state, convoyStatusClosed); err != nil {
 		return fmt.Errorf("can't close convoy '%s': %w", convoyID, err)
 	}
 
@@ -949,9 +956,11 @@ func runConvoyLand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, convoy.Type)
 	}
 
-	// Verify the convoy is owned
-	if !hasLabel(convoy.Labels, "gt:owned") {
-		return fmt.Errorf("convoy '%s' is not an owned convoy\n  Only convoys created with --owned can be landed.\n  Use %s instead for non-owned convoys.",
+	// Verify the convoy is owned, or lands atomically across rigs
+	landingPolicy, _ := convoyLandingPolicy(convoy.Description)
+	atomic := landingPolicy == "atomic"
+	if !hasLabel(convoy.Labels, "gt:owned") && !atomic {
+		return fmt.Errorf("convoy '%s' is not an owned convoy\n  Only convoys created with --owned or --landing=atomic can be landed.\n  Use %s instead for non-owned convoys.",
 			convoyID, style.Bold.Render("gt convoy close"))
 	}
 
@@ -997,6 +1006,12 @@ func runConvoyLand(cmd *cobra.Command, args []string) error {
 	if convoyLandDryRun {
 		fmt.Printf("%s Dry run — would land convoy 🚚 %s: %s\n\n", style.Warning.Render("⚠"), convoyID, convoy.Title)
 		fmt.Printf("  Tracked: %d issue(s) (%d closed, %d open)\n", len(tracked), len(tracked)-len(openIssues), len(openIssues))
+		if atomic {
+			fmt.Printf("  Atomic landing:\n")
+			if err := landAtomicConvoy(filepath.Dir(townBeads), convoyID, convoy.Description, tracked, false, true); err != nil {
+				return err
+			}
+		}
 		if !convoyLandKeep {
 			worktrees := findConvoyWorktrees(tracked)
 			fmt.Printf("  Worktrees to clean: %d\n", len(worktrees))
@@ -1010,6 +1025,19 @@ func runConvoyLand(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Phase 0: Atomic convoys land every rig's integration branch together
+	if atomic {
+		fmt.Printf("  Landing integration branches...\n")
+		if err := landAtomicConvoy(filepath.Dir(townBeads), convoyID, convoy.Description, tracked, false, false); err != nil {
+			return fmt.Errorf("landing convoy: %w", err)
+		}
+		if hasLabel(convoy.Labels, "gt:landing-failed") {
+			clearCmd := exec.Command("bd", "update", convoyID, "--remove-label=gt:landing-failed")
+			clearCmd.Dir = townBeads
+			_ = clearCmd.Run()
+		}
+	}
+
 	// Phase 1: Clean up polecat worktrees
 	if !convoyLandKeep {
 		worktrees := findConvoyWorktrees(tracked)
@@ -1139,8 +1167,9 @@ func removePolecatWorktree(wt convoyWorktreeInfo) error {
 type strandedConvoyInfo struct {
 	ID          string   `json:"id"`
 	Title       string   `json:"title"`
-	ReadyCount  int      `json:"ready_count"`
-	ReadyIssues []string `json:"ready_issues"`
+	ReadyCount  int             `json:"ready_count"`
+	ReadyIssues []string        `json:"ready_issues"`
+	Deadline    *convoyDeadline `json:"deadline,omitempty"`
 }
 
 // readyIssueInfo holds info about a ready (stranded) issue.
@@ -1174,7 +1203,7 @@ func runConvoyStranded(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s Found %d stranded convoy(s):\n\n", style.Warning.Render("⚠"), len(stranded))
 	for _, s := range stranded {
-		fmt.Printf("  🚚 %s: %s\n", s.ID, s.Title)
+		fmt.Printf("  🚚 %s: %s%s\n", s.ID, s.Title, s.Deadline.tag())
 		if s.ReadyCount == 0 {
 			fmt.Printf("     Empty convoy (0 tracked issues) — needs cleanup\n")
 		} else {
@@ -1234,12 +1263,15 @@ func findStrandedConvoys(townBeads string) ([]strandedConvoyInfo, error) {
 	}
 
 	var convoys []struct {
-		ID    string `json:"id"`
-		Title string `json:"title"`
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Status      string `json:"status"`
+		Description string `json:"description"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return nil, fmt.Errorf("parsing convoy list: %w", err)
 	}
+	deadlines := newConvoyDeadlineAssessor(townBeads)
 
 	// Check each convoy for stranded state
 	for _, convoy := range convoys {
@@ -1274,10 +1306,13 @@ func findStrandedConvoys(townBeads string) ([]strandedConvoyInfo, error) {
 				Title:       convoy.Title,
 				ReadyCount:  len(readyIssues),
 				ReadyIssues: readyIssues,
+				Deadline:    deadlines.assessTracked(convoy.ID, convoy.Status, convoy.Description, tracked),
 			})
 		}
 	}
 
+	// Feed the most urgent deadlines first: overdue, then at risk.
+	sortByDeadline(stranded)
 	return stranded, nil
 }
 
@@ -1347,9 +1382,11 @@ func checkAndCloseCompletedConvoys(townBeads string, dryRun bool) ([]struct{ ID,
 	}
 
 	var convoys []struct {
-		ID     string `json:"id"`
-		Title  string `json:"title"`
-		Status string `json:"status"`
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Status      string   `json:"status"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels,omitempty"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return nil, fmt.Errorf("parsing convoy list: %w", err)
@@ -1383,6 +1420,11 @@ func checkAndCloseCompletedConvoys(townBeads string, dryRun bool) ([]struct{ ID,
 				continue
 			}
 
+			// Atomic convoys land every rig's integration branch before closing
+			if !landConvoyBeforeClose(townBeads, convoy.ID, convoy.Title, convoy.Description, convoy.Labels, tracked) {
+				continue
+			}
+
 			// Close the convoy
 			reason := "All tracked issues completed"
 			if len(tracked) == 0 {
@@ -1398,6 +1440,7 @@ func checkAndCloseCompletedConvoys(townBeads string, dryRun bool) ([]struct{ ID,
 			}
 
 			closed = append(closed, struct{ ID, Title string }{convoy.ID, convoy.Title})
+			recordConvoyCycleTimes(townBeads, tracked)
 
 			// Check if convoy has notify address and send notification
 			notifyConvoyCompletion(townBeads, convoy.ID, convoy.Title)
@@ -1519,6 +1562,7 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		ClosedAt    string   `json:"closed_at,omitempty"`
 		DependsOn   []string `json:"depends_on,omitempty"`
 		Labels      []string `json:"labels,omitempty"`
+		CloseReason string   `json:"close_reason,omitempty"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return fmt.Errorf("parsing convoy data: %w", err)
@@ -1529,6 +1573,8 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	convoy := convoys[0]
+	// Report the lifecycle state, which distinguishes abandoned from closed
+	convoy.Status = convoypkg.Lifecycle(convoy.Status, convoy.Labels)
 
 	// Check if convoy is owned (caller-managed lifecycle)
 	isOwned := hasLabel(convoy.Labels, "gt:owned")
@@ -1545,6 +1591,9 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			completed++
 		}
 	}
+	deadline := newConvoyDeadlineAssessor(townBeads).assessTracked(convoy.ID, convoy.Status, convoy.Description, tracked)
+
+	maxParallel, ownParallel := resolveConvoyMaxParallel(townBeads, convoy.Description)
 
 	if convoyStatusJSON {
 		lifecycle := "system-managed"
@@ -1561,6 +1610,10 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Tracked       []trackedIssueInfo `json:"tracked"`
 			Completed     int                `json:"completed"`
 			Total         int                `json:"total"`
+			Deadline      *convoyDeadline    `json:"deadline,omitempty"`
+			MaxParallel   int                `json:"max_parallel"`
+			Landing       string             `json:"landing"`
+			AbandonReason string             `json:"abandon_reason,omitempty"`
 		}
 		out := jsonStatus{
 			ID:            convoy.ID,
@@ -1572,6 +1625,12 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 			Tracked:       tracked,
 			Completed:     completed,
 			Total:         len(tracked),
+			Deadline:      deadline,
+			MaxParallel:   maxParallel,
+		}
+		out.Landing, _ = convoyLandingPolicy(convoy.Description)
+		if convoy.Status == convoyStatusAbandoned {
+			out.AbandonReason = convoyAbandonReasonOf(convoy.CloseReason)
 		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -1592,10 +1651,23 @@ func runConvoyStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Merge:     %s\n", merge)
 	}
 	fmt.Printf("  Progress:  %d/%d completed\n", completed, len(tracked))
+	fmt.Printf("  Parallel:  %s\n", formatConvoyMaxParallel(maxParallel, ownParallel))
+	if landing := formatConvoyLanding(convoy.Description); landing != "" {
+		fmt.Printf("  Landing:   %s\n", landing)
+	}
+	if deadline != nil {
+		fmt.Printf("  Due:       %s%s\n", formatConvoyDue(*deadline.DueAt, deadline.SLA), deadline.tag())
+		if deadline.ProjectedAt != nil {
+			fmt.Printf("  Projected: %s\n", deadline.ProjectedAt.Local().Format("2006-01-02 15:04"))
+		}
+	}
 	fmt.Printf("  Created:   %s\n", convoy.CreatedAt)
 	if convoy.ClosedAt != "" {
 		fmt.Printf("  Closed:    %s\n", convoy.ClosedAt)
 	}
+	if convoy.Status == convoyStatusAbandoned {
+		fmt.Printf("  Abandoned: %s\n", convoyAbandonReasonOf(convoy.CloseReason))
+	}
 
 	if len(tracked) > 0 {
 		fmt.Printf("\n  %s\n", style.Bold.Render("Tracked Issues:"))
@@ -1693,8 +1765,17 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 
 	// List convoy-type issues
 	listArgs := []string{"list", "--type=convoy", "--json"}
-	if convoyListStatus != "" {
-		listArgs = append(listArgs, "--status="+convoyListStatus)
+	listStatus := normalizeConvoyStatus(convoyListStatus)
+	if listStatus != "" {
+		if err := ensureKnownConvoyStatus(listStatus); err != nil {
+			return err
+		}
+		// Abandoned convoys are closed beads; filter on lifecycle below.
+		bdStatus := listStatus
+		if bdStatus == convoyStatusAbandoned {
+			bdStatus = convoyStatusClosed
+		}
+		listArgs = append(listArgs, "--status="+bdStatus)
 	} else if convoyListAll {
 		listArgs = append(listArgs, "--all")
 	}
@@ -1710,16 +1791,40 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 	}
 
 	var convoys []struct {
-		ID        string   `json:"id"`
-		Title     string   `json:"title"`
-		Status    string   `json:"status"`
-		CreatedAt string   `json:"created_at"`
-		Labels    []string `json:"labels"`
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Status      string   `json:"status"`
+		CreatedAt   string   `json:"created_at"`
+		Labels      []string `json:"labels"`
+		Description string   `json:"description"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return fmt.Errorf("parsing convoy list: %w", err)
 	}
 
+	// Report lifecycle state: closed (completed) vs abandoned
+	kept := convoys[:0]
+	for _, c := range convoys {
+		c.Status = convoypkg.Lifecycle(c.Status, c.Labels)
+		if listStatus == "" || c.Status == listStatus {
+			kept = append(kept, c)
+		}
+	}
+	convoys = kept
+
+	// Assess deadlines up front: --overdue filters on them and both
+	// output formats show them.
+	deadlines := newConvoyDeadlineAssessor(townBeads)
+	if convoyListOverdue {
+		filtered := convoys[:0]
+		for _, c := range convoys {
+			if deadlines.assess(c.ID, c.Status, c.Description).needsAttention() {
+				filtered = append(filtered, c)
+			}
+		}
+		convoys = filtered
+	}
+
 	if convoyListJSON {
 		// Enrich each convoy with tracked issues and completion counts
 		type convoyListEntry struct {
@@ -1730,6 +1835,7 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 			Tracked   []trackedIssueInfo `json:"tracked"`
 			Completed int                `json:"completed"`
 			Total     int                `json:"total"`
+			Deadline  *convoyDeadline    `json:"deadline,omitempty"`
 		}
 		enriched := make([]convoyListEntry, 0, len(convoys))
 		for _, c := range convoys {
@@ -1755,6 +1861,7 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 				Tracked:   tracked,
 				Completed: completed,
 				Total:     len(tracked),
+				Deadline:  deadlines.assess(c.ID, c.Status, c.Description),
 			})
 		}
 		enc := json.NewEncoder(os.Stdout)
@@ -1763,6 +1870,10 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(convoys) == 0 {
+		if convoyListOverdue {
+			fmt.Println("No overdue or at-risk convoys.")
+			return nil
+		}
 		fmt.Println("No convoys found.")
 		fmt.Println("Create a convoy with: gt convoy create <name> [issues...]")
 		return nil
@@ -1770,7 +1881,7 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 
 	// Tree view: show convoys with their child issues
 	if convoyListTree {
-		return printConvoyTree(townBeads, convoys)
+		return printConvoyTree(townBeads, convoys, deadlines)
 	}
 
 	fmt.Printf("%s\n\n", style.Bold.Render("Convoys"))
@@ -1780,7 +1891,8 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 		if hasLabel(c.Labels, "gt:owned") {
 			ownedTag = " " + style.Warning.Render("[owned]")
 		}
-		fmt.Printf("  %d. 🚚 %s: %s %s%s\n", i+1, c.ID, c.Title, status, ownedTag)
+		dueTag := deadlines.assess(c.ID, c.Status, c.Description).tag()
+		fmt.Printf("  %d. 🚚 %s: %s %s%s%s\n", i+1, c.ID, c.Title, status, ownedTag, dueTag)
 	}
 	fmt.Printf("\nUse 'gt convoy status <id>' or 'gt convoy status <n>' for detailed view.\n")
 
@@ -1789,12 +1901,13 @@ func runConvoyList(cmd *cobra.Command, args []string) error {
 
 // printConvoyTree displays convoys with their child issues in a tree format.
 func printConvoyTree(townBeads string, convoys []struct {
-	ID        string   `json:"id"`
-	Title     string   `json:"title"`
-	Status    string   `json:"status"`
-	CreatedAt string   `json:"created_at"`
-	Labels    []string `json:"labels"`
-}) error {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"created_at"`
+	Labels      []string `json:"labels"`
+	Description string   `json:"description"`
+}, deadlines *convoyDeadlineAssessor) error {
 	for _, c := range convoys {
 		// Get tracked issues for this convoy
 		tracked, err := getTrackedIssues(townBeads, c.ID)
@@ -1821,7 +1934,8 @@ func printConvoyTree(townBeads string, convoys []struct {
 		if hasLabel(c.Labels, "gt:owned") {
 			ownedTag = " " + style.Warning.Render("[owned]")
 		}
-		fmt.Printf("🚚 %s: %s%s%s\n", c.ID, c.Title, progress, ownedTag)
+		dueTag := deadlines.assess(c.ID, c.Status, c.Description).tag()
+		fmt.Printf("🚚 %s: %s%s%s%s\n", c.ID, c.Title, progress, ownedTag, dueTag)
 
 		// Print tracked issues as tree children
 		for i, t := range tracked {
@@ -1887,6 +2001,8 @@ func formatConvoyStatus(status string) string {
 		return style.Warning.Render("●")
 	case "closed":
 		return style.Success.Render("✓")
+	case "abandoned":
+		return style.Dim.Render("✗ abandoned")
 	case "in_progress":
 		return style.Info.Render("→")
 	default:
@@ -1905,6 +2021,8 @@ type trackedIssueInfo struct {
 	Assignee  string `json:"assignee,omitempty"`   // Assigned agent (e.g., gastown/polecats/goose)
 	Worker    string `json:"worker,omitempty"`     // Worker currently assigned (e.g., gastown/nux)
 	WorkerAge string `json:"worker_age,omitempty"` // How long worker has been on this issue
+	CreatedAt string `json:"created_at,omitempty"`
+	ClosedAt  string `json:"closed_at,omitempty"`
 }
 
 // trackedDependency is dep-list data enriched with fresh issue details.
@@ -1916,6 +2034,8 @@ type trackedDependency struct {
 	Assignee       string   `json:"assignee"`
 	DependencyType string   `json:"dependency_type"`
 	Labels         []string `json:"labels"`
+	CreatedAt      string   `json:"created_at"`
+	ClosedAt       string   `json:"closed_at"`
 	Blocked        bool     `json:"-"`
 }
 
@@ -1944,6 +2064,12 @@ func applyFreshIssueDetails(dep *trackedDependency, details *issueDetails) {
 	if dep.IssueType == "" {
 		dep.IssueType = details.IssueType
 	}
+	if details.CreatedAt != "" {
+		dep.CreatedAt = details.CreatedAt
+	}
+	if details.ClosedAt != "" {
+		dep.ClosedAt = details.ClosedAt
+	}
 }
 
 // getTrackedIssues uses bd dep list to get issues tracked by a convoy.
@@ -2006,6 +2132,8 @@ func getTrackedIssues(townBeads, convoyID string) ([]trackedIssueInfo, error) {
 			IssueType: dep.IssueType,
 			Blocked:   dep.Blocked,
 			Assignee:  dep.Assignee,
+			CreatedAt: dep.CreatedAt,
+			ClosedAt:  dep.ClosedAt,
 		}
 
 		// Add worker info if available
@@ -2034,6 +2162,8 @@ type issueDetailsJSON struct {
 	BlockedBy      []string          `json:"blocked_by"`
 	BlockedByCount int               `json:"blocked_by_count"`
 	Dependencies   []issueDependency `json:"dependencies"`
+	CreatedAt      string            `json:"created_at"`
+	ClosedAt       string            `json:"closed_at"`
 }
 
 func (issue issueDetailsJSON) toIssueDetails() *issueDetails {
@@ -2046,6 +2176,8 @@ func (issue issueDetailsJSON) toIssueDetails() *issueDetails {
 		BlockedBy:      issue.BlockedBy,
 		BlockedByCount: issue.BlockedByCount,
 		Dependencies:   issue.Dependencies,
+		CreatedAt:      issue.CreatedAt,
+		ClosedAt:       issue.ClosedAt,
 	}
 }
 
@@ -2098,6 +2230,8 @@ type issueDetails struct {
 	BlockedBy      []string
 	BlockedByCount int
 	Dependencies   []issueDependency
+	CreatedAt      string
+	ClosedAt       string
 }
 
 func (d issueDetails) IsBlocked() bool {
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+)
+
+var convoyOverdueCmd = &cobra.Command{
+	Use:   "overdue",
+	Short: "Show convoys past or projected past their deadline",
+	Long: `Show open convoys that are overdue or at risk of missing their deadline.
+
+A convoy gets a deadline from 'gt convoy create --due/--sla'. Its completion
+is projected from the median cycle time (created to closed) of its closed
+issues, topped up with issues from recently landed convoys, with remaining
+issues worked as many at a time as are in progress now.
+
+  overdue   deadline passed with issues still open
+  at risk   projected to land after the deadline (or, with no history,
+            less than 4h left)
+
+Each run also records every deadline convoy's standing for the refinery
+and the convoy feed, which favor work from at-risk and overdue convoys.
+
+With --escalate, each overdue convoy is escalated once ('gt escalate',
+severity high) and labeled gt:sla-breached. The daemon runs this every
+heartbeat.
+
+Examples:
+  gt convoy overdue
+  gt convoy overdue --json
+  gt convoy overdue --escalate`,
+	Args: cobra.NoArgs,
+	RunE: runConvoyOverdue,
+}
+
+// convoyDeadline is a convoy's deadline standing as shown by list, status,
+// stranded and overdue.
+type convoyDeadline struct {
+	convoy.Assessment
+	SLA string `json:"sla,omitempty"`
+}
+
+// needsAttention reports whether the convoy is overdue or at risk.
+func (d *convoyDeadline) needsAttention() bool {
+	return d != nil && (d.Status == convoy.DeadlineOverdue || d.Status == convoy.DeadlineAtRisk)
+}
+
+// tag renders a short deadline marker for list lines, e.g. " [overdue 3h]".
+func (d *convoyDeadline) tag() string {
+	if d == nil || d.DueAt == nil {
+		return ""
+	}
+	now := time.Now()
+	switch d.Status {
+	case convoy.DeadlineOverdue:
+		return " " + style.Error.Render("[overdue "+formatConvoyDuration(now.Sub(*d.DueAt))+"]")
+	case convoy.DeadlineAtRisk:
+		return " " + style.Warning.Render("[at risk, due in "+formatConvoyDuration(d.DueAt.Sub(now))+"]")
+	}
+	return " " + style.Dim.Render("[due in "+formatConvoyDuration(d.DueAt.Sub(now))+"]")
+}
+
+// resolveConvoyDeadline turns --due/--sla into a deadline. Returns a zero
+// time when neither is set.
+func resolveConvoyDeadline(due, sla string, now time.Time) (time.Time, string, error) {
+	switch {
+	case due != "" && sla != "":
+		return time.Time{}, "", fmt.Errorf("use --due or --sla, not both")
+	case due != "":
+		t, err := convoy.ParseDue(due, now)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		if !t.After(now) {
+			return time.Time{}, "", fmt.Errorf("--due %s is in the past", due)
+		}
+		return t, "", nil
+	case sla != "":
+		d, err := convoy.ParseSLA(sla)
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		return now.Add(d), sla, nil
+	}
+	return time.Time{}, "", nil
+}
+
+// convoyDeadlineLines returns the description lines recording a deadline.
+func convoyDeadlineLines(due time.Time, sla string) string {
+	return convoy.DeadlineLines(due, sla)
+}
+
+// formatConvoyDue renders a deadline as "2026-01-15 17:00 (SLA 3d)".
+func formatConvoyDue(due time.Time, sla string) string {
+	out := due.Local().Format("2006-01-02 15:04")
+	if sla != "" {
+		out += " (SLA " + sla + ")"
+	}
+	return out
+}
+
+// formatConvoyDuration renders a duration as "3d4h", "5h" or "20m".
+func formatConvoyDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d >= 24*time.Hour:
+		days := int(d.Hours()) / 24
+		if hours := int(d.Hours()) % 24; hours > 0 {
+			return fmt.Sprintf("%dd%dh", days, hours)
+		}
+		return fmt.Sprintf("%dd", days)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// convoyDeadlineAssessor assesses convoy deadlines, loading the town's
+// cycle-time history once and caching results by convoy.
+type convoyDeadlineAssessor struct {
+	townBeads string
+	now       time.Time
+	history   []time.Duration
+	loaded    bool
+	cache     map[string]*convoyDeadline
+}
+
+func newConvoyDeadlineAssessor(townBeads string) *convoyDeadlineAssessor {
+	return &convoyDeadlineAssessor{
+		townBeads: townBeads,
+		now:       time.Now(),
+		cache:     make(map[string]*convoyDeadline),
+	}
+}
+
+// assess returns the deadline standing of an open convoy, fetching its
+// tracked issues if it has a deadline. Returns nil for convoys without one.
+func (a *convoyDeadlineAssessor) assess(id, status, description string) *convoyDeadline {
+	if d, ok := a.cache[id]; ok {
+		return d
+	}
+	if _, _, ok := convoy.ParseDeadline(description); !ok || normalizeConvoyStatus(status) != convoyStatusOpen {
+		a.cache[id] = nil
+		return nil
+	}
+	tracked, err := getTrackedIssues(a.townBeads, id)
+	if err != nil {
+		style.PrintWarning("couldn't assess deadline of %s: %v", id, err)
+		a.cache[id] = nil
+		return nil
+	}
+	return a.assessTracked(id, status, description, tracked)
+}
+
+// assessTracked is assess for callers that already have the tracked issues.
+func (a *convoyDeadlineAssessor) assessTracked(id, status, description string, tracked []trackedIssueInfo) *convoyDeadline {
+	if d, ok := a.cache[id]; ok {
+		return d
+	}
+	due, sla, ok := convoy.ParseDeadline(description)
+	if !ok || normalizeConvoyStatus(status) != convoyStatusOpen {
+		a.cache[id] = nil
+		return nil
+	}
+	if !a.loaded {
+		if h, err := convoy.LoadCycleHistory(filepath.Dir(a.townBeads)); err == nil {
+			a.history = h.Durations()
+		}
+		a.loaded = true
+	}
+
+	remaining, inFlight := 0, 0
+	for _, t := range tracked {
+		switch t.Status {
+		case "closed", "tombstone":
+		case "in_progress", "hooked":
+			remaining++
+			inFlight++
+		default:
+			remaining++
+		}
+	}
+	cycle := convoy.EstimateCycleTime(convoyCycleSamples(tracked), a.history)
+	d := &convoyDeadline{
+		Assessment: convoy.Assess(due, a.now, remaining, inFlight, cycle),
+		SLA:        sla,
+	}
+	a.cache[id] = d
+	return d
+}
+
+// sortByDeadline orders stranded convoys most urgent first (overdue, at
+// risk, then by due date), keeping the existing order otherwise.
+func sortByDeadline(stranded []strandedConvoyInfo) {
+	sort.SliceStable(stranded, func(i, j int) bool {
+		di, dj := stranded[i].Deadline, stranded[j].Deadline
+		ri, rj := deadlineRank(di), deadlineRank(dj)
+		if ri != rj {
+			return ri > rj
+		}
+		if di != nil && dj != nil && di.DueAt != nil && dj.DueAt != nil {
+			return di.DueAt.Before(*dj.DueAt)
+		}
+		return false
+	})
+}
+
+func deadlineRank(d *convoyDeadline) int {
+	if d == nil {
+		return 0
+	}
+	return d.Status.Rank()
+}
+
+// convoyCycleSamples returns cycle times of a convoy's closed issues.
+func convoyCycleSamples(tracked []trackedIssueInfo) []convoy.CycleSample {
+	var samples []convoy.CycleSample
+	for _, t := range tracked {
+		if s, ok := convoy.IssueCycleSample(t.ID, t.Status, t.CreatedAt, t.ClosedAt); ok {
+			samples = append(samples, s)
+		}
+	}
+	return samples
+}
+
+// recordConvoyCycleTimes adds a landed convoy's issue cycle times to the
+// town history used to project other convoys. Best-effort.
+func recordConvoyCycleTimes(townBeads string, tracked []trackedIssueInfo) {
+	if err := convoy.RecordCycleTimes(filepath.Dir(townBeads), convoyCycleSamples(tracked)); err != nil {
+		style.PrintWarning("couldn't record cycle times: %v", err)
+	}
+}
+
+// overdueConvoyInfo is one row of gt convoy overdue.
+type overdueConvoyInfo struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Deadline  *convoyDeadline `json:"deadline"`
+	Escalated bool            `json:"escalated"`
+	// EscalatedNow is set when this run filed the escalation.
+	EscalatedNow bool `json:"escalated_now,omitempty"`
+}
+
+func runConvoyOverdue(cmd *cobra.Command, args []string) error {
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+
+	listCmd := exec.Command("bd", "list", "--type=convoy", "--status=open", "--json")
+	listCmd.Dir = townBeads
+	var stdout bytes.Buffer
+	listCmd.Stdout = &stdout
+	if err := listCmd.Run(); err != nil {
+		return fmt.Errorf("listing convoys: %w", err)
+	}
+	var convoys []struct {
+		ID          string   `json:"id"`
+		Title       string   `json:"title"`
+		Status      string   `json:"status"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
+		return fmt.Errorf("parsing convoy list: %w", err)
+	}
+
+	deadlines := newConvoyDeadlineAssessor(townBeads)
+	snapshot := &convoy.DeadlineSnapshot{CheckedAt: deadlines.now, Convoys: make(map[string]convoy.DeadlineStatus)}
+	overdue := []overdueConvoyInfo{}
+	for _, c := range convoys {
+		d := deadlines.assess(c.ID, c.Status, c.Description)
+		if d != nil {
+			snapshot.Convoys[c.ID] = d.Status
+		}
+		if !d.needsAttention() {
+			continue
+		}
+		info := overdueConvoyInfo{
+			ID:        c.ID,
+			Title:     c.Title,
+			Deadline:  d,
+			Escalated: hasLabel(c.Labels, convoy.LabelSLABreached),
+		}
+		if convoyOverdueEsc && d.Status == convoy.DeadlineOverdue && !info.Escalated {
+			if err := escalateOverdueConvoy(townBeads, info); err != nil {
+				style.PrintWarning("couldn't escalate %s: %v", c.ID, err)
+			} else {
+				info.Escalated = true
+				info.EscalatedNow = true
+			}
+		}
+		overdue = append(overdue, info)
+	}
+	// Feeds the refinery's and the convoy feed's at-risk boosts.
+	if err := convoy.SaveDeadlineSnapshot(filepath.Dir(townBeads), snapshot); err != nil {
+		style.PrintWarning("couldn't record deadline snapshot: %v", err)
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		ri, rj := deadlineRank(overdue[i].Deadline), deadlineRank(overdue[j].Deadline)
+		if ri != rj {
+			return ri > rj
+		}
+		return overdue[i].Deadline.DueAt.Before(*overdue[j].Deadline.DueAt)
+	})
+
+	if convoyOverdueJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(overdue)
+	}
+
+	if len(overdue) == 0 {
+		fmt.Println("No overdue or at-risk convoys.")
+		return nil
+	}
+	fmt.Printf("%s %d convoy(s) overdue or at risk:\n\n", style.Warning.Render("⚠"), len(overdue))
+	for _, o := range overdue {
+		fmt.Printf("  🚚 %s: %s%s\n", o.ID, o.Title, o.Deadline.tag())
+		fmt.Printf("     Due:       %s\n", formatConvoyDue(*o.Deadline.DueAt, o.Deadline.SLA))
+		if o.Deadline.ProjectedAt != nil {
+			fmt.Printf("     Projected: %s\n", o.Deadline.ProjectedAt.Local().Format("2006-01-02 15:04"))
+		}
+		if o.Escalated {
+			fmt.Printf("     %s\n", style.Dim.Render("SLA breach escalated"))
+		}
+	}
+	return nil
+}
+
+// escalateOverdueConvoy files an escalation for a convoy that blew its
+// deadline and labels it so the escalation fires once.
+func escalateOverdueConvoy(townBeads string, o overdueConvoyInfo) error {
+	due := formatConvoyDue(*o.Deadline.DueAt, o.Deadline.SLA)
+	reason := fmt.Sprintf("Convoy %s (%s) missed its deadline %s with work still open. See gt convoy status %s.",
+		o.ID, o.Title, due, o.ID)
+	escCmd := exec.Command("gt", "escalate", "Convoy SLA breached: "+o.ID,
+		"--severity", "high",
+		"--source", "convoy:sla",
+		"--related", o.ID,
+		"--reason", reason)
+	escCmd.Dir = filepath.Dir(townBeads)
+	if out, err := escCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	labelCmd := exec.Command("bd", "update", o.ID, "--add-label="+convoy.LabelSLABreached) //nolint:gosec // G204: args are constructed internally
+	labelCmd.Dir = townBeads
+	if out, err := labelCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("escalated, but labeling failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
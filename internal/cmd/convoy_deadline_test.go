@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+func TestResolveConvoyDeadline(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	due, sla, err := resolveConvoyDeadline("", "3d", now)
+	if err != nil || !due.Equal(now.Add(72*time.Hour)) || sla != "3d" {
+		t.Errorf("--sla 3d = %v, %q, %v", due, sla, err)
+	}
+	due, sla, err = resolveConvoyDeadline("2026-01-15T17:00:00Z", "", now)
+	if err != nil || !due.Equal(time.Date(2026, 1, 15, 17, 0, 0, 0, time.UTC)) || sla != "" {
+		t.Errorf("--due = %v, %q, %v", due, sla, err)
+	}
+	if due, _, err := resolveConvoyDeadline("", "", now); err != nil || !due.IsZero() {
+		t.Errorf("no flags = %v, %v; want zero, nil", due, err)
+	}
+	if _, _, err := resolveConvoyDeadline("2026-01-15", "3d", now); err == nil {
+		t.Error("--due with --sla should fail")
+	}
+	if _, _, err := resolveConvoyDeadline("2026-01-01", "", now); err == nil {
+		t.Error("--due in the past should fail")
+	}
+}
+
+func TestAssessTracked(t *testing.T) {
+	a := newConvoyDeadlineAssessor(t.TempDir() + "/.beads")
+	a.now = time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	desc := "Convoy tracking 3 issues\n" + convoyDeadlineLines(a.now.Add(5*time.Hour), "")
+
+	// Two closed issues took 4h each; two remain, worked one at a time.
+	tracked := []trackedIssueInfo{
+		{ID: "gt-a", Status: "closed", CreatedAt: "2026-01-10T00:00:00Z", ClosedAt: "2026-01-10T04:00:00Z"},
+		{ID: "gt-b", Status: "closed", CreatedAt: "2026-01-10T04:00:00Z", ClosedAt: "2026-01-10T08:00:00Z"},
+		{ID: "gt-c", Status: "in_progress"},
+		{ID: "gt-d", Status: "open"},
+	}
+	d := a.assessTracked("hq-cv-1", "open", desc, tracked)
+	if d == nil || d.Status != convoy.DeadlineAtRisk {
+		t.Fatalf("assessTracked = %+v, want at_risk", d)
+	}
+	if want := a.now.Add(8 * time.Hour); d.ProjectedAt == nil || !d.ProjectedAt.Equal(want) {
+		t.Errorf("ProjectedAt = %v, want %v", d.ProjectedAt, want)
+	}
+
+	if d := a.assessTracked("hq-cv-2", "open", "Convoy tracking 1 issues", tracked); d != nil {
+		t.Errorf("convoy without deadline = %+v, want nil", d)
+	}
+	if d := a.assessTracked("hq-cv-3", "closed", desc, tracked); d != nil {
+		t.Errorf("closed convoy = %+v, want nil", d)
+	}
+}
+
+func TestSortByDeadline(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+	deadline := func(s convoy.DeadlineStatus, due time.Duration) *convoyDeadline {
+		return &convoyDeadline{Assessment: convoy.Assessment{Status: s, DueAt: at(due)}}
+	}
+	stranded := []strandedConvoyInfo{
+		{ID: "none"},
+		{ID: "on-track", Deadline: deadline(convoy.DeadlineOnTrack, 48*time.Hour)},
+		{ID: "at-risk-later", Deadline: deadline(convoy.DeadlineAtRisk, 10*time.Hour)},
+		{ID: "overdue", Deadline: deadline(convoy.DeadlineOverdue, -time.Hour)},
+		{ID: "at-risk-sooner", Deadline: deadline(convoy.DeadlineAtRisk, 2*time.Hour)},
+	}
+	sortByDeadline(stranded)
+
+	want := []string{"overdue", "at-risk-sooner", "at-risk-later", "on-track", "none"}
+	for i, id := range want {
+		if stranded[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, stranded[i].ID, id)
+		}
+	}
+}
@@ -0,0 +1,461 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+)
+
+var convoyImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create or update a convoy and its issues from a spec file",
+	Long: `Create a convoy and the issues it tracks from a spec file.
+
+The spec is YAML (.yaml/.yml), TOML (.toml) or a Markdown checklist (.md)
+listing issues with their dependencies, priorities and target rigs. Every
+issue is created as a bead in its rig, dependencies are added between them,
+and the lot is wrapped in a convoy.
+
+Import is idempotent: bead IDs are derived from the spec key (default: the
+file name) and each issue's key, so the spec can be edited and re-applied.
+Re-applying updates changed titles, descriptions and priorities, creates
+new issues and adds new dependencies. Issues removed from the spec are left
+as they are.
+
+An issue without a key is keyed by a slug of its title, so in YAML and TOML
+give issues a key if their titles may change. Markdown items without a
+#key have the derived key written back into the file on import, which keeps
+them stable from then on.
+
+The due date must be in the future, unless the convoy already has it.
+
+YAML spec:
+  name: Sprint 12
+  rig: gastown               # default rig for issues (else town beads)
+  max_parallel: 2
+  due: 2026-01-20
+  issues:
+    - key: schema
+      title: Design token schema
+      priority: 1
+    - key: oauth
+      title: Add OAuth login
+      rig: beads
+      depends_on: [schema]
+
+TOML uses the same fields, with [[issues]] tables.
+
+Markdown spec:
+  # Sprint 12
+  rig: gastown
+
+  - [ ] Design token schema #schema P1
+  - [ ] Add OAuth login #oauth @beads after:schema
+    Indented lines become the description.
+  - [x] Checked items are closed
+
+Examples:
+  gt convoy import sprint-12.yaml
+  gt convoy import sprint-12.md --dry-run
+  gt convoy import sprint-12.toml --sling   # Dispatch ready issues`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyImport,
+}
+
+// importStep is one spec issue resolved to its bead.
+type importStep struct {
+	Issue    convoy.ImportIssue
+	ID       string
+	Dir      string       // Directory whose beads database holds the issue
+	Existing *beads.Issue // nil when the issue must be created
+}
+
+// labels returns the labels the spec asks for: the type label and any extras.
+func (s importStep) labels() []string {
+	return append([]string{"gt:" + s.Issue.Type}, s.Issue.Labels...)
+}
+
+// update returns the changes needed to bring an existing issue in line with
+// the spec, and the names of the changed fields.
+func (s importStep) update() (beads.UpdateOptions, []string) {
+	var opts beads.UpdateOptions
+	var changed []string
+	if s.Existing == nil {
+		return opts, nil
+	}
+	if s.Existing.Title != s.Issue.Title {
+		opts.Title = &s.Issue.Title
+		changed = append(changed, "title")
+	}
+	if s.Existing.Description != s.Issue.Description {
+		opts.Description = &s.Issue.Description
+		changed = append(changed, "description")
+	}
+	if s.Existing.Priority != *s.Issue.Priority {
+		opts.Priority = s.Issue.Priority
+		changed = append(changed, "priority")
+	}
+	for _, label := range s.labels() {
+		if !beads.HasLabel(s.Existing, label) {
+			opts.AddLabels = append(opts.AddLabels, label)
+		}
+	}
+	if len(opts.AddLabels) > 0 {
+		changed = append(changed, "labels")
+	}
+	return opts, changed
+}
+
+// missingDeps returns the IDs in want that the existing issue does not
+// already depend on.
+func (s importStep) missingDeps(want []string) []string {
+	have := make(map[string]bool)
+	if s.Existing != nil {
+		for _, id := range s.Existing.DependsOn {
+			have[id] = true
+		}
+		for _, dep := range s.Existing.Dependencies {
+			have[dep.ID] = true
+		}
+	}
+	var missing []string
+	for _, id := range want {
+		if !have[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// planImport resolves each spec issue, in dependency order, to its bead ID
+// and beads directory, and loads the beads a previous import created.
+func planImport(spec *convoy.ImportSpec, townRoot string) ([]importStep, error) {
+	ordered, err := spec.Ordered()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []importStep
+	for _, issue := range ordered {
+		prefix, dir := "hq", townRoot
+		if issue.Rig != "" {
+			if _, err := os.Stat(filepath.Join(townRoot, issue.Rig)); err != nil {
+				return nil, fmt.Errorf("issue %q: unknown rig %q", issue.Key, issue.Rig)
+			}
+			prefix = beads.GetPrefixForRig(townRoot, issue.Rig)
+			if dir = beads.GetRigPathForPrefix(townRoot, prefix+"-"); dir == "" {
+				dir = filepath.Join(townRoot, issue.Rig)
+			}
+		}
+
+		step := importStep{
+			Issue: issue,
+			ID:    convoy.ImportIssueID(prefix, spec.Key, issue.Key),
+			Dir:   dir,
+		}
+		if existing, err := beads.New(townRoot).Show(step.ID); err == nil {
+			step.Existing = existing
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// importConvoyDescription builds the description of an imported convoy.
+func importConvoyDescription(spec *convoy.ImportSpec, owner string, due time.Time, issues int) string {
+	description := fmt.Sprintf("Convoy tracking %d issues", issues)
+	if owner != "" {
+		description += fmt.Sprintf("\nOwner: %s", owner)
+	}
+	if spec.Notify != "" {
+		description += fmt.Sprintf("\nNotify: %s", spec.Notify)
+	}
+	if spec.Merge != "" {
+		description += fmt.Sprintf("\nMerge: %s", spec.Merge)
+	}
+	if !due.IsZero() {
+		description += "\n" + convoyDeadlineLines(due, "")
+	}
+	if spec.MaxParallel > 0 {
+		description += "\n" + convoyMaxParallelLine(spec.MaxParallel)
+	}
+	return description
+}
+
+func runConvoyImport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading spec: %w", err)
+	}
+	spec, err := convoy.ParseImportSpec(path, data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	now := time.Now()
+	var due time.Time
+	if spec.Due != "" {
+		if due, err = convoy.ParseDue(spec.Due, now); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+	townRoot := filepath.Dir(townBeads)
+
+	convoyID := convoy.ImportConvoyID(spec.Key)
+	existingConvoy, _ := showConvoyBead(townBeads, convoyID)
+	if existingConvoy != nil && existingConvoy.lifecycle() == convoyStatusAbandoned {
+		return fmt.Errorf("convoy %s is abandoned; use 'gt convoy reopen %s' before re-importing", convoyID, convoyID)
+	}
+	if !due.IsZero() && !due.After(now) {
+		// Re-applying a spec whose deadline has since passed is fine.
+		var current time.Time
+		if existingConvoy != nil {
+			current, _, _ = convoy.ParseDeadline(existingConvoy.Description)
+		}
+		if !current.Equal(due) {
+			return fmt.Errorf("%s: due %s is in the past", path, spec.Due)
+		}
+	}
+
+	steps, err := planImport(spec, townRoot)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	idByKey := make(map[string]string, len(steps))
+	for _, step := range steps {
+		idByKey[step.Issue.Key] = step.ID
+	}
+
+	if convoyImportDryRun {
+		printImportPlan(spec, convoyID, existingConvoy, steps, idByKey)
+		return nil
+	}
+
+	if err := beads.EnsureCustomTypes(townBeads); err != nil {
+		return fmt.Errorf("ensuring custom types: %w", err)
+	}
+
+	// Issues, in dependency order
+	var created, updated, unchanged int
+	for _, step := range steps {
+		if step.Existing == nil {
+			if err := createImportedIssue(step); err != nil {
+				return fmt.Errorf("creating %q: %w", step.Issue.Key, err)
+			}
+			fmt.Printf("  %s %s  %s\n", style.Bold.Render("+"), step.ID, step.Issue.Title)
+			created++
+		} else if opts, changed := step.update(); len(changed) > 0 {
+			if err := beads.New(step.Dir).Update(step.ID, opts); err != nil {
+				return fmt.Errorf("updating %s: %w", step.ID, err)
+			}
+			fmt.Printf("  %s %s  %s %s\n", style.Bold.Render("~"), step.ID, step.Issue.Title,
+				style.Dim.Render("("+strings.Join(changed, ", ")+")"))
+			updated++
+		} else {
+			unchanged++
+		}
+
+		if step.Issue.Done && (step.Existing == nil || step.Existing.Status != "closed") {
+			if err := beads.New(step.Dir).CloseWithReason("Done in "+filepath.Base(path), step.ID); err != nil {
+				style.PrintWarning("couldn't close %s: %v", step.ID, err)
+			}
+		}
+	}
+
+	// Dependencies between imported issues
+	for _, step := range steps {
+		var want []string
+		for _, key := range step.Issue.DependsOn {
+			want = append(want, idByKey[key])
+		}
+		for _, depID := range step.missingDeps(want) {
+			if err := runBdInDir(townRoot, "dep", "add", step.ID, depID); err != nil {
+				style.PrintWarning("couldn't add dependency %s → %s: %v", step.ID, depID, err)
+			}
+		}
+	}
+
+	// The convoy
+	owner := spec.Owner
+	if owner == "" && existingConvoy != nil {
+		for _, line := range strings.Split(existingConvoy.Description, "\n") {
+			if strings.HasPrefix(line, "Owner: ") {
+				owner = strings.TrimPrefix(line, "Owner: ")
+			}
+		}
+	}
+	if owner == "" {
+		owner = detectSender()
+	}
+	description := importConvoyDescription(spec, owner, due, len(steps))
+
+	if existingConvoy == nil {
+		createArgs := []string{
+			"create",
+			"--type=convoy",
+			"--id=" + convoyID,
+			"--title=" + spec.Name,
+			"--description=" + description,
+			"--json",
+		}
+		if beads.NeedsForceForID(convoyID) {
+			createArgs = append(createArgs, "--force")
+		}
+		if err := runBdInDir(townBeads, createArgs...); err != nil {
+			return fmt.Errorf("creating convoy: %w", err)
+		}
+	} else {
+		updateArgs := []string{"update", convoyID}
+		if existingConvoy.Title != spec.Name {
+			updateArgs = append(updateArgs, "--title="+spec.Name)
+		}
+		if existingConvoy.Description != description {
+			updateArgs = append(updateArgs, "--description="+description)
+		}
+		if len(updateArgs) > 2 {
+			if err := runBdInDir(townBeads, updateArgs...); err != nil {
+				return fmt.Errorf("updating convoy: %w", err)
+			}
+		}
+		if existingConvoy.lifecycle() == convoyStatusClosed && (created > 0 || hasOpenImportStep(steps)) {
+			if err := reopenConvoy(townBeads, convoyID, false); err != nil {
+				return err
+			}
+			fmt.Printf("%s Reopened convoy %s\n", style.Bold.Render("↺"), convoyID)
+		}
+	}
+
+	tracked := make(map[string]bool)
+	if existingConvoy != nil {
+		if issues, err := getTrackedIssues(townBeads, convoyID); err == nil {
+			for _, issue := range issues {
+				tracked[issue.ID] = true
+			}
+		}
+	}
+	for _, step := range steps {
+		if tracked[step.ID] {
+			continue
+		}
+		if err := runBdInDir(townBeads, "dep", "add", convoyID, step.ID, "--type=tracks"); err != nil {
+			style.PrintWarning("couldn't track %s: %v", step.ID, err)
+		}
+	}
+
+	if pinned, ok := convoy.PinMarkdownKeys(data, spec); ok {
+		if err := os.WriteFile(path, pinned, 0644); err != nil { //nolint:gosec // G306: the spec is the user's own file
+			style.PrintWarning("couldn't write issue keys back to %s: %v", path, err)
+		} else {
+			fmt.Printf("  %s Added #keys to %s so its items keep their issues when retitled\n", style.Dim.Render("○"), path)
+		}
+	}
+
+	verb := "Created"
+	if existingConvoy != nil {
+		verb = "Updated"
+	}
+	fmt.Printf("\n%s %s convoy 🚚 %s: %s\n", style.Bold.Render("✓"), verb, convoyID, spec.Name)
+	fmt.Printf("  Issues: %d created, %d updated, %d unchanged\n", created, updated, unchanged)
+
+	if convoyImportSling {
+		logger := func(format string, args ...interface{}) {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf(format, args...)))
+		}
+		n := convoy.FeedReadyIssues(townRoot, convoyID, "convoy import", logger)
+		fmt.Printf("  Slung:  %d ready issue(s)\n", n)
+	}
+	return nil
+}
+
+// hasOpenImportStep reports whether any imported issue is still to be done.
+func hasOpenImportStep(steps []importStep) bool {
+	for _, step := range steps {
+		if !step.Issue.Done && (step.Existing == nil || step.Existing.Status != "closed") {
+			return true
+		}
+	}
+	return false
+}
+
+// createImportedIssue creates the bead for a spec issue under its derived ID.
+func createImportedIssue(step importStep) error {
+	createArgs := []string{
+		"create",
+		"--id=" + step.ID,
+		"--title=" + step.Issue.Title,
+		fmt.Sprintf("--priority=%d", *step.Issue.Priority),
+		"--labels=" + strings.Join(step.labels(), ","),
+		"--json",
+	}
+	if step.Issue.Description != "" {
+		createArgs = append(createArgs, "--description="+step.Issue.Description)
+	}
+	if beads.NeedsForceForID(step.ID) {
+		createArgs = append(createArgs, "--force")
+	}
+	return runBdInDir(step.Dir, createArgs...)
+}
+
+// runBdInDir runs a bd command in dir, returning stderr in the error.
+func runBdInDir(dir string, args ...string) error {
+	bdCmd := exec.Command("bd", args...)
+	bdCmd.Dir = dir
+	var stderr bytes.Buffer
+	bdCmd.Stderr = &stderr
+	if err := bdCmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w (%s)", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// printImportPlan shows what an import would do without doing it.
+func printImportPlan(spec *convoy.ImportSpec, convoyID string, existing *convoyBead, steps []importStep, idByKey map[string]string) {
+	action := "create"
+	if existing != nil {
+		action = "update"
+	}
+	fmt.Printf("%s Would %s convoy 🚚 %s: %s\n\n", style.Bold.Render("→"), action, convoyID, spec.Name)
+
+	for _, step := range steps {
+		status := "create"
+		if step.Existing != nil {
+			status = "unchanged"
+			if _, changed := step.update(); len(changed) > 0 {
+				status = "update " + strings.Join(changed, ", ")
+			}
+		}
+		if step.Issue.Done && (step.Existing == nil || step.Existing.Status != "closed") {
+			status += ", close"
+		}
+		rig := step.Issue.Rig
+		if rig == "" {
+			rig = "town"
+		}
+		fmt.Printf("  %s  %-40s %s %s\n", step.ID, step.Issue.Title, style.Dim.Render("@"+rig), style.Dim.Render("("+status+")"))
+
+		var want []string
+		for _, key := range step.Issue.DependsOn {
+			want = append(want, idByKey[key])
+		}
+		if missing := step.missingDeps(want); len(missing) > 0 {
+			fmt.Printf("      after %s\n", strings.Join(missing, ", "))
+		}
+	}
+}
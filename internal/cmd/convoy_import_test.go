@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+func TestImportStepUpdate(t *testing.T) {
+	t.Parallel()
+
+	p := 1
+	step := importStep{
+		Issue: convoy.ImportIssue{Key: "oauth", Title: "Add OAuth login", Type: "task", Priority: &p, Labels: []string{"auth"}},
+		ID:    "gt-abcdefg",
+	}
+	if _, changed := step.update(); changed != nil {
+		t.Fatalf("new issue should have no update, got %v", changed)
+	}
+
+	step.Existing = &beads.Issue{ID: "gt-abcdefg", Title: "Add OAuth login", Priority: 1, Labels: []string{"gt:task", "auth"}}
+	if _, changed := step.update(); len(changed) != 0 {
+		t.Errorf("unchanged issue reported changes: %v", changed)
+	}
+
+	step.Existing = &beads.Issue{ID: "gt-abcdefg", Title: "OAuth", Description: "old", Priority: 2, Labels: []string{"gt:task"}}
+	opts, changed := step.update()
+	if want := []string{"title", "description", "priority", "labels"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if *opts.Title != "Add OAuth login" || *opts.Description != "" || *opts.Priority != 1 || !reflect.DeepEqual(opts.AddLabels, []string{"auth"}) {
+		t.Errorf("opts = %+v", opts)
+	}
+}
+
+func TestImportStepMissingDeps(t *testing.T) {
+	t.Parallel()
+
+	step := importStep{}
+	if got := step.missingDeps([]string{"gt-a", "gt-b"}); !reflect.DeepEqual(got, []string{"gt-a", "gt-b"}) {
+		t.Errorf("new issue missingDeps = %v", got)
+	}
+
+	step.Existing = &beads.Issue{
+		DependsOn:    []string{"gt-a"},
+		Dependencies: []beads.IssueDep{{ID: "gt-c"}},
+	}
+	if got := step.missingDeps([]string{"gt-a", "gt-b", "gt-c"}); !reflect.DeepEqual(got, []string{"gt-b"}) {
+		t.Errorf("missingDeps = %v, want [gt-b]", got)
+	}
+}
+
+func TestImportConvoyDescription(t *testing.T) {
+	t.Parallel()
+
+	spec := &convoy.ImportSpec{Merge: "mr", MaxParallel: 3}
+	due := time.Date(2026, 1, 20, 23, 59, 59, 0, time.UTC)
+	desc := importConvoyDescription(spec, "mayor/", due, 4)
+
+	for _, want := range []string{"Convoy tracking 4 issues", "Owner: mayor/", "Merge: mr", "Due: ", "MaxParallel: 3"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("description missing %q:\n%s", want, desc)
+		}
+	}
+	if desc != importConvoyDescription(spec, "mayor/", due, 4) {
+		t.Error("description is not stable across re-imports")
+	}
+}
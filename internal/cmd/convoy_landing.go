@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/git"
+	"github.com/xcawolfe-amzn/gastown/internal/rig"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+)
+
+// resolveConvoyLanding validates --landing/--land-order and returns the
+// convoy description line recording them ("" for independent landing).
+func resolveConvoyLanding(policy, order string) (string, error) {
+	if policy == "" {
+		if order != "" {
+			return "", fmt.Errorf("--land-order requires --landing=ordered")
+		}
+		return "", nil
+	}
+	if err := convoy.ValidateLanding(policy); err != nil {
+		return "", err
+	}
+	var rigs []string
+	for _, r := range strings.Split(order, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			rigs = append(rigs, r)
+		}
+	}
+	if len(rigs) > 0 && policy != convoy.LandingOrdered {
+		return "", fmt.Errorf("--land-order requires --landing=ordered")
+	}
+	return convoy.LandingLine(policy, rigs), nil
+}
+
+// formatConvoyLanding renders a convoy's landing policy for status output,
+// or "" for independent landing.
+func formatConvoyLanding(description string) string {
+	policy, order := convoy.ParseLanding(description)
+	switch policy {
+	case convoy.LandingAtomic:
+		return "atomic (all rigs land together)"
+	case convoy.LandingOrdered:
+		if len(order) > 0 {
+			return "ordered (" + strings.Join(order, " → ") + ")"
+		}
+		return "ordered"
+	}
+	return ""
+}
+
+// convoyLandingTarget returns the MR target for an issue's work. Atomic
+// convoys merge into their integration branch, created from the rig's
+// default branch on first use; everything else keeps target. An atomic
+// convoy's work must not land on the default branch on its own, so failing
+// to prepare the integration branch is an error rather than a fallback.
+func convoyLandingTarget(g *git.Git, info *ConvoyInfo, defaultBranch, target string) (string, error) {
+	if info == nil || info.Landing != convoy.LandingAtomic {
+		return target, nil
+	}
+	branch := convoy.IntegrationBranch(info.ID)
+	if exists, err := g.RemoteBranchExists("origin", branch); err == nil && exists {
+		return branch, nil
+	}
+	if err := g.Fetch("origin"); err != nil {
+		return "", fmt.Errorf("atomic convoy %s: fetching origin: %w", info.ID, err)
+	}
+	if err := g.CreateBranchFrom(branch, "origin/"+defaultBranch); err != nil {
+		// May exist locally from an earlier attempt; push it below.
+		if exists, _ := g.BranchExists(branch); !exists {
+			return "", fmt.Errorf("atomic convoy %s: creating %s: %w", info.ID, branch, err)
+		}
+	}
+	if err := g.Push("origin", branch, false); err != nil {
+		return "", fmt.Errorf("atomic convoy %s: pushing %s: %w", info.ID, branch, err)
+	}
+	fmt.Printf("  %s Created %s for atomic convoy %s\n", style.Dim.Render("✓"), branch, info.ID)
+	return branch, nil
+}
+
+// convoyRigs returns the rigs of a convoy's tracked issues in landing order.
+func convoyRigs(townRoot, description string, tracked []trackedIssueInfo) []string {
+	_, order := convoy.ParseLanding(description)
+	var trackedRigs []string
+	for _, t := range tracked {
+		trackedRigs = append(trackedRigs, beads.GetRigNameForPrefix(townRoot, beads.ExtractPrefix(t.ID)))
+	}
+	return convoy.LandingOrder(order, trackedRigs)
+}
+
+// rigLanding is one rig's integration branch being landed.
+type rigLanding struct {
+	Rig    string
+	Path   string
+	Target string   // Rig's default branch
+	Git    *git.Git // Rig repo, for ref-only operations
+	Land   *git.Git // Land worktree with the merge prepared (nil if already landed)
+}
+
+// landAtomicConvoy lands an atomic convoy's integration branch in every rig
+// together. Each rig's branch is merged into its default branch in a land
+// worktree and tested; only if every rig merges cleanly and passes is
+// anything pushed. Pushes then go out in landing order and the integration
+// branches are deleted. With dryRun, only the plan is printed.
+func landAtomicConvoy(townRoot, convoyID, description string, tracked []trackedIssueInfo, skipTests, dryRun bool) error {
+	branch := convoy.IntegrationBranch(convoyID)
+	var landings []*rigLanding
+	var cleanups []func()
+	defer func() {
+		for _, cleanup := range cleanups {
+			cleanup()
+		}
+	}()
+
+	// Phase 1: find each rig's integration branch and prepare the merge.
+	for _, rigName := range convoyRigs(townRoot, description, tracked) {
+		r := &rig.Rig{Name: rigName, Path: filepath.Join(townRoot, rigName)}
+		g, err := getRigGit(r.Path)
+		if err != nil {
+			return fmt.Errorf("rig %s: %w", rigName, err)
+		}
+		if err := g.Fetch("origin"); err != nil {
+			return fmt.Errorf("rig %s: fetching from origin: %w", rigName, err)
+		}
+		if exists, err := g.RemoteBranchExists("origin", branch); err != nil || !exists {
+			fmt.Printf("  %s %s: no %s (nothing to land)\n", style.Dim.Render("○"), rigName, branch)
+			continue
+		}
+
+		l := &rigLanding{Rig: rigName, Path: r.Path, Target: r.DefaultBranch(), Git: g}
+		landings = append(landings, l)
+		if merged, err := g.IsAncestor("origin/"+branch, "origin/"+l.Target); err == nil && merged {
+			fmt.Printf("  %s %s: already landed on %s\n", style.Dim.Render("✓"), rigName, l.Target)
+			continue
+		}
+		if dryRun {
+			fmt.Printf("  → %s: merge %s into %s, test, push\n", rigName, branch, l.Target)
+			continue
+		}
+
+		landGit, cleanup, err := createLandWorktree(r.Path, l.Target)
+		if err != nil {
+			return fmt.Errorf("rig %s: %w", rigName, err)
+		}
+		cleanups = append(cleanups, cleanup)
+		_ = landGit.Pull("origin", l.Target) // Non-fatal: worktree starts from the local ref
+
+		fmt.Printf("  Merging %s into %s/%s...\n", branch, rigName, l.Target)
+		mergeMsg := fmt.Sprintf("Land convoy %s\n\nConvoy: %s", branch, convoyID)
+		if err := landGit.MergeNoFF("origin/"+branch, mergeMsg); err != nil {
+			_ = landGit.AbortMerge()
+			return fmt.Errorf("rig %s: merge failed, nothing landed: %w", rigName, err)
+		}
+		if testCmd := getTestCommand(r.Path); testCmd != "" && !skipTests {
+			fmt.Printf("  Running tests in %s: %s\n", rigName, testCmd)
+			if err := runTestCommand(landGit.WorkDir(), testCmd); err != nil {
+				return fmt.Errorf("rig %s: tests failed, nothing landed: %w", rigName, err)
+			}
+		}
+		l.Land = landGit
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	// Phase 2: every rig is green — push in landing order.
+	var landed []string
+	for _, l := range landings {
+		if l.Land == nil {
+			continue
+		}
+		if err := l.Land.PushWithEnv("origin", l.Target, false, []string{"GT_INTEGRATION_LAND=1"}); err != nil {
+			if len(landed) > 0 {
+				return fmt.Errorf("rig %s: push failed after landing %s: %w", l.Rig, strings.Join(landed, ", "), err)
+			}
+			return fmt.Errorf("rig %s: push failed, nothing landed: %w", l.Rig, err)
+		}
+		landed = append(landed, l.Rig)
+		fmt.Printf("  %s Landed %s on %s/%s\n", style.Bold.Render("✓"), branch, l.Rig, l.Target)
+	}
+
+	// Phase 3: delete the integration branches.
+	for _, l := range landings {
+		if err := l.Git.DeleteRemoteBranch("origin", branch); err != nil {
+			style.PrintWarning("couldn't delete %s in %s: %v", branch, l.Rig, err)
+		}
+		_ = l.Git.DeleteBranch(branch, true)
+	}
+	return nil
+}
+
+// landConvoyBeforeClose lands an atomic convoy whose tracked issues are all
+// closed before it is auto-closed. Returns false when the convoy must stay
+// open: landing failed (the convoy is labeled gt:landing-failed and its
+// owner notified) or failed earlier and awaits 'gt convoy land'.
+func landConvoyBeforeClose(townBeads, convoyID, title, description string, labels []string, tracked []trackedIssueInfo) bool {
+	if policy, _ := convoy.ParseLanding(description); policy != convoy.LandingAtomic {
+		return true
+	}
+	if hasLabel(labels, convoy.LabelLandingFailed) {
+		fmt.Printf("%s Convoy %s: atomic landing failed earlier; run 'gt convoy land %s'\n", style.Dim.Render("○"), convoyID, convoyID)
+		return false
+	}
+
+	fmt.Printf("Landing atomic convoy %s...\n", convoyID)
+	err := landAtomicConvoy(filepath.Dir(townBeads), convoyID, description, tracked, false, false)
+	if err == nil {
+		return true
+	}
+
+	style.PrintWarning("atomic landing of convoy %s failed: %v", convoyID, err)
+	labelCmd := exec.Command("bd", "update", convoyID, "--add-label="+convoy.LabelLandingFailed)
+	labelCmd.Dir = townBeads
+	_ = labelCmd.Run()
+
+	subject := fmt.Sprintf("⚠ Convoy landing failed: %s", title)
+	body := fmt.Sprintf("Convoy %s could not land atomically:\n\n%v\n\nAll tracked issues are merged to %s in each rig. Fix the failure, then run: gt convoy land %s",
+		convoyID, err, convoy.IntegrationBranch(convoyID), convoyID)
+	for _, addr := range convoySubscribers(description) {
+		mailCmd := exec.Command("gt", "mail", "send", addr, "-s", subject, "-m", body)
+		mailCmd.Dir = townBeads
+		_ = mailCmd.Run()
+	}
+	return false
+}
+
+// convoyLandingPolicy returns a convoy's landing policy and rig order.
+func convoyLandingPolicy(description string) (string, []string) {
+	return convoy.ParseLanding(description)
+}
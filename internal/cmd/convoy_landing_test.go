@@ -0,0 +1,31 @@
+package cmd
+
+import "testing"
+
+func TestResolveConvoyLanding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy, order string
+		want          string
+		wantErr       bool
+	}{
+		{"", "", "", false},
+		{"", "api", "", true},
+		{"independent", "", "", false},
+		{"atomic", "", "Landing: atomic", false},
+		{"atomic", "api", "", true},
+		{"ordered", " api, client ,", "Landing: ordered api, client", false},
+		{"sideways", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveConvoyLanding(tt.policy, tt.order)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveConvoyLanding(%q, %q) error = %v, wantErr %v", tt.policy, tt.order, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveConvoyLanding(%q, %q) = %q, want %q", tt.policy, tt.order, got, tt.want)
+		}
+	}
+}
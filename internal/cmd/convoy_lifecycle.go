@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
+)
+
+const (
+	convoyStatusOpen      = convoy.StatusOpen
+	convoyStatusClosed    = convoy.StatusClosed
+	convoyStatusAbandoned = convoy.StatusAbandoned
+)
+
+var convoyAbandonCmd = &cobra.Command{
+	Use:   "abandon <convoy-id> --reason=<reason>",
+	Short: "Abandon a convoy without completing it",
+	Long: `Abandon a convoy: close it as ABANDONED rather than completed.
+
+The command:
+  1. Releases hooked and in-progress tracked issues back to open, except
+     issues a polecat or crew member with a live session is still working:
+     those stay hooked and the worker is mailed to stop
+  2. Nukes the polecats of released issues, whose sessions are gone
+  3. Closes the convoy, labeled gt:abandoned, with the reason
+  4. Notifies the owner and subscribers
+
+Unlike 'gt convoy close --force', the convoy is reported as abandoned in
+'gt convoy list' and on the dashboard, not as landed. Adding issues does
+not revive an abandoned convoy; use 'gt convoy reopen'.
+
+Examples:
+  gt convoy abandon hq-cv-abc --reason="superseded by hq-cv-xyz"
+  gt convoy abandon hq-cv-abc --reason="descoped" --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyAbandon,
+}
+
+var convoyReopenCmd = &cobra.Command{
+	Use:   "reopen <convoy-id>",
+	Short: "Reopen a closed or abandoned convoy",
+	Long: `Reopen a closed or abandoned convoy so observers resume feeding it.
+
+Reopening an abandoned convoy clears its gt:abandoned label. Issues released
+when it was abandoned are open again and will be dispatched as usual.
+
+Examples:
+  gt convoy reopen hq-cv-abc`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvoyReopen,
+}
+
+func normalizeConvoyStatus(status string) string {
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+func ensureKnownConvoyStatus(status string) error {
+	if convoy.IsKnownState(normalizeConvoyStatus(status)) {
+		return nil
+	}
+	return fmt.Errorf(
+		"unsupported convoy status %q (expected %q, %q or %q)",
+		status,
+		convoyStatusOpen,
+		convoyStatusClosed,
+		convoyStatusAbandoned,
+	)
+}
+
+func validateConvoyStatusTransition(currentStatus, targetStatus string) error {
+	current := normalizeConvoyStatus(currentStatus)
+	target := normalizeConvoyStatus(targetStatus)
+
+	if err := ensureKnownConvoyStatus(current); err != nil {
+		return err
+	}
+	if err := ensureKnownConvoyStatus(target); err != nil {
+		return err
+	}
+	return convoy.ValidateTransition(current, target)
+}
+
+// convoyBead is the subset of a convoy bead used by lifecycle commands.
+type convoyBead struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	Type        string   `json:"issue_type"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels,omitempty"`
+	CloseReason string   `json:"close_reason,omitempty"`
+}
+
+// lifecycle returns the convoy's lifecycle state.
+func (c *convoyBead) lifecycle() string {
+	return convoy.Lifecycle(c.Status, c.Labels)
+}
+
+// showConvoyBead loads a convoy bead and verifies its type and state.
+func showConvoyBead(townBeads, convoyID string) (*convoyBead, error) {
+	showCmd := exec.Command("bd", "show", convoyID, "--json")
+	showCmd.Dir = townBeads
+	var stdout bytes.Buffer
+	showCmd.Stdout = &stdout
+
+	if err := showCmd.Run(); err != nil {
+		return nil, fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	var convoys []convoyBead
+	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
+		return nil, fmt.Errorf("parsing convoy data: %w", err)
+	}
+	if len(convoys) == 0 {
+		return nil, fmt.Errorf("convoy '%s' not found", convoyID)
+	}
+
+	c := &convoys[0]
+	if c.Type != "convoy" {
+		return nil, fmt.Errorf("'%s' is not a convoy (type: %s)", convoyID, c.Type)
+	}
+	if err := ensureKnownConvoyStatus(c.Status); err != nil {
+		return nil, fmt.Errorf("convoy '%s' has invalid lifecycle state: %w", convoyID, err)
+	}
+	return c, nil
+}
+
+// convoySubscribers returns the Owner and Notify addresses from a convoy
+// description, without duplicates.
+func convoySubscribers(description string) []string {
+	var addrs []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(description, "\n") {
+		var addr string
+		if strings.HasPrefix(line, "Owner: ") {
+			addr = strings.TrimPrefix(line, "Owner: ")
+		} else if strings.HasPrefix(line, "Notify: ") {
+			addr = strings.TrimPrefix(line, "Notify: ")
+		}
+		addr = strings.TrimSpace(addr)
+		if addr != "" && !seen[addr] {
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// abandonPlan is the work to undo when abandoning a convoy.
+type abandonPlan struct {
+	Release []trackedIssueInfo // hooked/in-progress issues to release
+	Nuke    []string           // idle polecats (rig/name) to nuke
+	Running []string           // polecats and crew left running (live session)
+	Kept    []trackedIssueInfo // issues left on a running worker's hook
+}
+
+// planConvoyAbandon decides which tracked issues to release and which of
+// their polecats to nuke. sessionAlive reports whether a worker's session
+// is running. Issues held by a running polecat or crew member are kept, not
+// released: the worker is still on them and is told to stop instead. Crew
+// are never nuked.
+func planConvoyAbandon(tracked []trackedIssueInfo, sessionAlive func(*session.AgentIdentity) bool) abandonPlan {
+	var plan abandonPlan
+	alive := make(map[string]bool)
+	for _, t := range tracked {
+		if t.Status != "hooked" && t.Status != "in_progress" {
+			continue
+		}
+
+		id, err := session.ParseAddress(t.Assignee)
+		if err != nil || (id.Role != session.RolePolecat && id.Role != session.RoleCrew) {
+			plan.Release = append(plan.Release, t)
+			continue
+		}
+		target := id.Address()
+		if id.Role == session.RolePolecat {
+			target = id.Rig + "/" + id.Name // gt polecat nuke form
+		}
+		running, seen := alive[target]
+		if !seen {
+			running = sessionAlive(id)
+			alive[target] = running
+			switch {
+			case running:
+				plan.Running = append(plan.Running, target)
+			case id.Role == session.RolePolecat:
+				plan.Nuke = append(plan.Nuke, target)
+			}
+		}
+		if running {
+			plan.Kept = append(plan.Kept, t)
+		} else {
+			plan.Release = append(plan.Release, t)
+		}
+	}
+	return plan
+}
+
+func runConvoyAbandon(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+	reason := strings.TrimSpace(convoyAbandonReason)
+	if reason == "" {
+		return fmt.Errorf("--reason is required: say why the convoy is abandoned")
+	}
+
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+
+	c, err := showConvoyBead(townBeads, convoyID)
+	if err != nil {
+		return err
+	}
+	state := c.lifecycle()
+	if state == convoyStatusAbandoned {
+		fmt.Printf("%s Convoy %s is already abandoned\n", style.Dim.Render("○"), convoyID)
+		return nil
+	}
+	if err := validateConvoyStatusTransition(state, convoyStatusAbandoned); err != nil {
+		if state == convoyStatusClosed {
+			return fmt.Errorf("can't abandon convoy '%s': it already completed (reopen it first)", convoyID)
+		}
+		return fmt.Errorf("can't abandon convoy '%s': %w", convoyID, err)
+	}
+
+	tracked, err := getTrackedIssues(townBeads, convoyID)
+	if err != nil {
+		return fmt.Errorf("getting tracked issues for %s: %w", convoyID, err)
+	}
+
+	t := tmux.NewTmux()
+	plan := planConvoyAbandon(tracked, func(id *session.AgentIdentity) bool {
+		alive, err := t.HasSession(id.SessionName())
+		return err != nil || alive // unsure → leave it alone
+	})
+
+	if convoyAbandonDryRun {
+		fmt.Printf("Would abandon convoy 🚚 %s: %s\n", convoyID, c.Title)
+		fmt.Printf("  Reason: %s\n", reason)
+		for _, issue := range plan.Release {
+			fmt.Printf("  Would release: %s [%s] %s\n", issue.ID, issue.Status, issue.Assignee)
+		}
+		for _, p := range plan.Nuke {
+			fmt.Printf("  Would nuke:    %s (idle)\n", p)
+		}
+		for _, issue := range plan.Kept {
+			fmt.Printf("  Would keep:    %s on %s (session running; told to stop)\n", issue.ID, issue.Assignee)
+		}
+		return nil
+	}
+
+	// Release work first so nothing keeps landing on an abandoned convoy.
+	bd := beads.New(filepath.Dir(townBeads))
+	released := 0
+	for _, issue := range plan.Release {
+		if err := bd.ReleaseWithReason(issue.ID, fmt.Sprintf("convoy %s abandoned: %s", convoyID, reason)); err != nil {
+			style.PrintWarning("couldn't release %s: %v", issue.ID, err)
+			continue
+		}
+		released++
+	}
+
+	nuked := 0
+	for _, p := range plan.Nuke {
+		nukeCmd := exec.Command("gt", "polecat", "nuke", p)
+		var stderr bytes.Buffer
+		nukeCmd.Stderr = &stderr
+		if err := nukeCmd.Run(); err != nil {
+			style.PrintWarning("couldn't nuke %s: %s", p, strings.TrimSpace(stderr.String()))
+			continue
+		}
+		nuked++
+	}
+
+	// Label before closing: an open convoy with the label is still open,
+	// but a closed one without it would read as completed.
+	labelCmd := exec.Command("bd", "update", convoyID, "--add-label="+convoy.LabelAbandoned)
+	labelCmd.Dir = townBeads
+	if err := labelCmd.Run(); err != nil {
+		return fmt.Errorf("labeling convoy abandoned: %w", err)
+	}
+	closeCmd := exec.Command("bd", "close", convoyID, "-r", convoy.AbandonReasonPrefix+reason)
+	closeCmd.Dir = townBeads
+	if err := closeCmd.Run(); err != nil {
+		return fmt.Errorf("closing convoy: %w", err)
+	}
+
+	fmt.Printf("%s Abandoned convoy 🚚 %s: %s\n", style.Bold.Render("✗"), convoyID, c.Title)
+	fmt.Printf("  Reason:   %s\n", reason)
+	fmt.Printf("  Released: %d issue(s)\n", released)
+	if len(plan.Nuke) > 0 {
+		fmt.Printf("  Nuked:    %d idle polecat(s)\n", nuked)
+	}
+	for _, issue := range plan.Kept {
+		fmt.Printf("  %s %s is still being worked by %s; left hooked\n", style.Warning.Render("⚠"), issue.ID, issue.Assignee)
+		release := "gt done --status DEFERRED"
+		if id, err := session.ParseAddress(issue.Assignee); err == nil && id.Role == session.RoleCrew {
+			release = "gt release " + issue.ID
+		}
+		stopBody := fmt.Sprintf("Convoy %s was abandoned: %s\n\nStop work on %s. Run '%s' to release it.",
+			convoyID, reason, issue.ID, release)
+		mailCmd := exec.Command("gt", "mail", "send", issue.Assignee, "-s", "🚚 Convoy abandoned: stop "+issue.ID, "-m", stopBody)
+		if err := mailCmd.Run(); err != nil {
+			style.PrintWarning("could not notify %s: %v", issue.Assignee, err)
+		}
+	}
+
+	subject := fmt.Sprintf("🚚 Convoy abandoned: %s", c.Title)
+	body := fmt.Sprintf("Convoy %s was abandoned.\n\nReason: %s\nReleased: %d issue(s)", convoyID, reason, released)
+	for _, addr := range convoySubscribers(c.Description) {
+		mailCmd := exec.Command("gt", "mail", "send", addr, "-s", subject, "-m", body)
+		if err := mailCmd.Run(); err != nil {
+			style.PrintWarning("could not notify %s: %v", addr, err)
+		} else {
+			fmt.Printf("  Notified: %s\n", addr)
+		}
+	}
+
+	return nil
+}
+
+func runConvoyReopen(cmd *cobra.Command, args []string) error {
+	convoyID := args[0]
+
+	townBeads, err := getTownBeadsDir()
+	if err != nil {
+		return err
+	}
+
+	c, err := showConvoyBead(townBeads, convoyID)
+	if err != nil {
+		return err
+	}
+	state := c.lifecycle()
+	if state == convoyStatusOpen {
+		fmt.Printf("%s Convoy %s is already open\n", style.Dim.Render("○"), convoyID)
+		return nil
+	}
+	if err := validateConvoyStatusTransition(state, convoyStatusOpen); err != nil {
+		return fmt.Errorf("can't reopen convoy '%s': %w", convoyID, err)
+	}
+
+	if err := reopenConvoy(townBeads, convoyID, state == convoyStatusAbandoned); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Reopened %s convoy 🚚 %s: %s\n", style.Bold.Render("↺"), state, convoyID, c.Title)
+	return nil
+}
+
+// reopenConvoy sets a convoy back to open, clearing the abandoned label.
+func reopenConvoy(townBeads, convoyID string, abandoned bool) error {
+	reopenArgs := []string{"update", convoyID, "--status=open"}
+	if abandoned {
+		reopenArgs = append(reopenArgs, "--remove-label="+convoy.LabelAbandoned)
+	}
+	reopenCmd := exec.Command("bd", reopenArgs...)
+	reopenCmd.Dir = townBeads
+	if err := reopenCmd.Run(); err != nil {
+		return fmt.Errorf("couldn't reopen convoy: %w", err)
+	}
+	return nil
+}
+
+// convoyAbandonReasonOf extracts the reason from an abandoned convoy's close
+// reason.
+func convoyAbandonReasonOf(closeReason string) string {
+	return strings.TrimPrefix(closeReason, convoy.AbandonReasonPrefix)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+// convoyMaxParallelLine returns the description line for --max-parallel,
+// or "" when the flag is unset.
+func convoyMaxParallelLine(n int) string {
+	return convoy.MaxParallelLine(n)
+}
+
+// resolveConvoyMaxParallel returns a convoy's effective parallel dispatch limit and
+// whether it comes from the convoy itself (as opposed to the town default).
+func resolveConvoyMaxParallel(townBeads, description string) (int, bool) {
+	if n := convoy.ParseMaxParallel(description); n > 0 {
+		return n, true
+	}
+	return convoy.ResolveMaxParallel(filepath.Dir(townBeads), ""), false
+}
+
+// formatConvoyMaxParallel renders the parallel limit for gt convoy status.
+func formatConvoyMaxParallel(n int, own bool) string {
+	s := fmt.Sprintf("%d issue(s) at a time", n)
+	if !own {
+		s += " (town default)"
+	}
+	return s
+}
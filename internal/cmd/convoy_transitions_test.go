@@ -1,6 +1,11 @@
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+)
 
 func TestEnsureKnownConvoyStatus(t *testing.T) {
 	t.Parallel()
@@ -11,6 +16,9 @@ func TestEnsureKnownConvoyStatus(t *testing.T) {
 	if err := ensureKnownConvoyStatus(" closed "); err != nil {
 		t.Fatalf("expected closed to be accepted: %v", err)
 	}
+	if err := ensureKnownConvoyStatus("abandoned"); err != nil {
+		t.Fatalf("expected abandoned to be accepted: %v", err)
+	}
 	if err := ensureKnownConvoyStatus("in_progress"); err == nil {
 		t.Fatal("expected unknown status to be rejected")
 	}
@@ -29,6 +37,10 @@ func TestValidateConvoyStatusTransition(t *testing.T) {
 		{name: "closed to open", current: "closed", target: "open", wantErr: false},
 		{name: "same open", current: "open", target: "open", wantErr: false},
 		{name: "same closed", current: "closed", target: "closed", wantErr: false},
+		{name: "open to abandoned", current: "open", target: "abandoned", wantErr: false},
+		{name: "abandoned to open", current: "abandoned", target: "open", wantErr: false},
+		{name: "closed to abandoned", current: "closed", target: "abandoned", wantErr: true},
+		{name: "abandoned to closed", current: "abandoned", target: "closed", wantErr: true},
 		{name: "unknown current", current: "in_progress", target: "closed", wantErr: true},
 		{name: "unknown target", current: "open", target: "archived", wantErr: true},
 	}
@@ -47,3 +59,52 @@ func TestValidateConvoyStatusTransition(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanConvoyAbandon(t *testing.T) {
+	t.Parallel()
+
+	tracked := []trackedIssueInfo{
+		{ID: "gt-done", Status: "closed", Assignee: "gastown/polecats/old"},
+		{ID: "gt-open", Status: "open"},
+		{ID: "gt-hooked", Status: "hooked", Assignee: "gastown/polecats/idle"},
+		{ID: "gt-busy", Status: "in_progress", Assignee: "gastown/polecats/busy"},
+		{ID: "gt-crew", Status: "in_progress", Assignee: "gastown/crew/max"},
+		{ID: "gt-crew-busy", Status: "in_progress", Assignee: "gastown/crew/busy"},
+		{ID: "gt-again", Status: "hooked", Assignee: "gastown/polecats/idle"},
+	}
+	alive := func(id *session.AgentIdentity) bool { return id.Name == "busy" }
+
+	plan := planConvoyAbandon(tracked, alive)
+
+	var released []string
+	for _, issue := range plan.Release {
+		released = append(released, issue.ID)
+	}
+	if want := []string{"gt-hooked", "gt-crew", "gt-again"}; !reflect.DeepEqual(released, want) {
+		t.Errorf("Release = %v, want %v", released, want)
+	}
+	if want := []string{"gastown/idle"}; !reflect.DeepEqual(plan.Nuke, want) {
+		t.Errorf("Nuke = %v, want %v", plan.Nuke, want)
+	}
+	if want := []string{"gastown/busy", "gastown/crew/busy"}; !reflect.DeepEqual(plan.Running, want) {
+		t.Errorf("Running = %v, want %v", plan.Running, want)
+	}
+	// A live polecat or crew member keeps its issue rather than having it
+	// pulled away; an idle crew member's issue is released but not nuked.
+	var kept []string
+	for _, issue := range plan.Kept {
+		kept = append(kept, issue.ID)
+	}
+	if want := []string{"gt-busy", "gt-crew-busy"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("Kept = %v, want %v", kept, want)
+	}
+}
+
+func TestConvoySubscribers(t *testing.T) {
+	t.Parallel()
+
+	desc := "Convoy tracking 2 issues\nOwner: mayor/\nNotify: ops/\nNotify: mayor/\nMerge: mr"
+	if got, want := convoySubscribers(desc), []string{"mayor/", "ops/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("convoySubscribers = %v, want %v", got, want)
+	}
+}
@@ -2,10 +2,8 @@
 package cmd
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,6 +15,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+	"github.com/xcawolfe-amzn/gastown/internal/quota"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
@@ -47,11 +47,25 @@ var (
 var costsCmd = &cobra.Command{
 	Use:     "costs",
 	GroupID: GroupDiag,
-	Short:   "Show costs for running Claude sessions",
-	Long: `Display costs for Claude Code sessions in Gas Town.
-
-Costs are calculated from Claude Code transcript files at ~/.claude/projects/
-by summing token usage from assistant messages and applying model-specific pricing.
+	Short:   "Show costs for running agent sessions",
+	Long: `Display costs for agent sessions in Gas Town.
+
+Costs are calculated from each session's agent transcripts (Claude Code,
+Codex, Gemini CLI, OpenCode) by summing token usage and applying per-model
+pricing. Prices come from a built-in table that can be overridden or extended
+under model_pricing in settings/config.json (USD per million tokens):
+
+  "model_pricing": {
+    "gpt-5-codex": {"input": 1.25, "output": 10, "cache_read": 0.125}
+  },
+  "model_aliases": {
+    "claude-opus-4-6": "claude-opus-4-5"
+  }
+
+Model IDs must match an entry exactly (ignoring a provider prefix or a
+snapshot date) or be aliased to one. Models without a pricing entry are
+counted as $0.00 and flagged in the output, so totals are never silently
+priced at a guess.
 
 Examples:
   gt costs              # Live costs from running sessions
@@ -64,7 +78,8 @@ Examples:
 
 Subcommands:
   gt costs record       # Record session cost to local log file (Stop hook)
-  gt costs digest       # Aggregate log entries into daily digest bead (Deacon patrol)`,
+  gt costs digest       # Aggregate log entries into daily digest bead (Deacon patrol)
+  gt costs budget       # Show today's spend against budget limits`,
 	RunE: runCosts,
 }
 
@@ -74,8 +89,9 @@ var costsRecordCmd = &cobra.Command{
 	Long: `Record the final cost of a session to a local log file.
 
 This command is intended to be called from a Claude Code Stop hook.
-It reads token usage from the Claude Code transcript file (~/.claude/projects/...)
-and calculates the cost based on model pricing, then appends it to
+It reads token usage from the session agent's transcript (selected by
+GT_AGENT; Claude Code when unset) and calculates the cost based on model
+pricing, then appends it to
 ~/.gt/costs.jsonl. This is a simple append operation that never fails
 due to database availability.
 
@@ -161,6 +177,10 @@ type SessionCost struct {
 	Worker  string  `json:"worker,omitempty"`
 	Cost    float64 `json:"cost_usd"`
 	Running bool    `json:"running"`
+
+	Provider string   `json:"provider,omitempty"`
+	Models   []string `json:"models,omitempty"`
+	Unpriced []string `json:"unpriced_models,omitempty"`
 }
 
 // CostEntry is a ledger entry for historical cost tracking.
@@ -173,6 +193,8 @@ type CostEntry struct {
 	StartedAt time.Time `json:"started_at"`
 	EndedAt   time.Time `json:"ended_at"`
 	WorkItem  string    `json:"work_item,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Unpriced  []string  `json:"unpriced_models,omitempty"`
 }
 
 // CostsOutput is the JSON output structure.
@@ -182,61 +204,15 @@ type CostsOutput struct {
 	ByRole   map[string]float64 `json:"by_role,omitempty"`
 	ByRig    map[string]float64 `json:"by_rig,omitempty"`
 	Period   string             `json:"period,omitempty"`
+
+	// UnpricedModels lists models with no pricing entry. Their usage is
+	// counted as $0, so totals are a lower bound while this is non-empty.
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
 }
 
 // costRegex matches cost patterns like "$1.23" or "$12.34"
 var costRegex = regexp.MustCompile(`\$(\d+\.\d{2})`)
 
-// TranscriptMessage represents a message from a Claude Code transcript file.
-type TranscriptMessage struct {
-	Type      string                 `json:"type"`
-	SessionID string                 `json:"sessionId"`
-	CWD       string                 `json:"cwd"`
-	Message   *TranscriptMessageBody `json:"message,omitempty"`
-}
-
-// TranscriptMessageBody contains the message content and usage info.
-type TranscriptMessageBody struct {
-	Model string          `json:"model"`
-	Role  string          `json:"role"`
-	Usage *TranscriptUsage `json:"usage,omitempty"`
-}
-
-// TranscriptUsage contains token usage information.
-type TranscriptUsage struct {
-	InputTokens              int `json:"input_tokens"`
-	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
-	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
-	OutputTokens             int `json:"output_tokens"`
-}
-
-// TokenUsage aggregates token usage across a session.
-type TokenUsage struct {
-	Model                    string
-	InputTokens              int
-	CacheCreationInputTokens int
-	CacheReadInputTokens     int
-	OutputTokens             int
-}
-
-// Model pricing per million tokens (as of Jan 2025).
-// See: https://www.anthropic.com/pricing
-var modelPricing = map[string]struct {
-	InputPerMillion       float64
-	OutputPerMillion      float64
-	CacheReadPerMillion   float64 // 90% discount on input price
-	CacheCreatePerMillion float64 // 25% premium on input price
-}{
-	// Claude Opus 4.5
-	"claude-opus-4-5-20251101": {15.0, 75.0, 1.5, 18.75},
-	// Claude Sonnet 4
-	"claude-sonnet-4-20250514": {3.0, 15.0, 0.3, 3.75},
-	// Claude Haiku 3.5
-	"claude-3-5-haiku-20241022": {1.0, 5.0, 0.1, 1.25},
-	// Fallback for unknown models (use Sonnet pricing)
-	"default": {3.0, 15.0, 0.3, 3.75},
-}
-
 func runCosts(cmd *cobra.Command, args []string) error {
 	// If querying ledger, use ledger functions
 	if costsToday || costsWeek || costsByRole || costsByRig {
@@ -258,6 +234,10 @@ func runLiveCosts() error {
 
 	var costs []SessionCost
 	var total float64
+	var unpriced []string
+
+	townRoot, _ := workspace.FindFromCwd()
+	pricing := loadModelPricing(townRoot)
 
 	for _, sess := range sessions {
 		// Only process Gas Town sessions
@@ -277,15 +257,17 @@ func runLiveCosts() error {
 			continue
 		}
 
-		// Extract cost from Claude transcript
-		cost, err := extractCostFromWorkDir(workDir)
+		// Extract cost from the session agent's transcript
+		agent, _ := t.GetEnvironment(sess, "GT_AGENT")
+		measured, err := measureSessionCost(resolveCostProvider(townRoot, agent), workDir, pricing)
 		if err != nil {
 			if costsVerbose {
 				fmt.Fprintf(os.Stderr, "[costs] could not extract cost for %s: %v\n", sess, err)
 			}
 			// Still include the session with zero cost
-			cost = 0.0
 		}
+		cost := measured.CostUSD
+		unpriced = mergeUnpriced(unpriced, measured.Unpriced...)
 
 		// Check if an agent appears to be running
 		running := t.IsAgentRunning(sess)
@@ -297,6 +279,10 @@ func runLiveCosts() error {
 			Worker:  worker,
 			Cost:    cost,
 			Running: running,
+
+			Provider: measured.Provider,
+			Models:   measured.Models,
+			Unpriced: measured.Unpriced,
 		})
 		total += cost
 	}
@@ -308,12 +294,17 @@ func runLiveCosts() error {
 
 	if costsJSON {
 		return outputCostsJSON(CostsOutput{
-			Sessions: costs,
-			Total:    total,
+			Sessions:       costs,
+			Total:          total,
+			UnpricedModels: unpriced,
 		})
 	}
 
-	return outputCostsHuman(costs, total)
+	if err := outputCostsHuman(costs, total); err != nil {
+		return err
+	}
+	warnUnpriced(unpriced)
+	return nil
 }
 
 func runCostsFromLedger() error {
@@ -362,17 +353,21 @@ func runCostsFromLedger() error {
 	byRole := make(map[string]float64)
 	byRig := make(map[string]float64)
 
+	var unpriced []string
+
 	for _, entry := range entries {
 		total += entry.CostUSD
 		byRole[entry.Role] += entry.CostUSD
 		if entry.Rig != "" {
 			byRig[entry.Rig] += entry.CostUSD
 		}
+		unpriced = mergeUnpriced(unpriced, entry.Unpriced...)
 	}
 
 	// Build output
 	output := CostsOutput{
-		Total: total,
+		Total:          total,
+		UnpricedModels: unpriced,
 	}
 
 	if costsByRole {
@@ -699,144 +694,80 @@ func extractCost(content string) float64 {
 	return cost
 }
 
-// getClaudeProjectDir returns the Claude Code project directory for a working directory.
-// Claude Code stores transcripts in ~/.claude/projects/<path-with-dashes-instead-of-slashes>/
-func getClaudeProjectDir(workDir string) (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-
-	// Convert path to Claude's directory naming: replace / with -
-	// Keep leading slash - it becomes a leading dash in Claude's encoding
-	projectName := strings.ReplaceAll(workDir, "/", "-")
-	return filepath.Join(home, ".claude", "projects", projectName), nil
+// sessionUsage is the priced token usage of one session.
+type sessionUsage struct {
+	Provider string
+	Models   []string
+	CostUSD  float64
+	Unpriced []string // models with no entry in the pricing table
 }
 
-// findLatestTranscript finds the most recently modified .jsonl file in a directory.
-func findLatestTranscript(projectDir string) (string, error) {
-	var latestPath string
-	var latestTime time.Time
-
-	err := filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() && path != projectDir {
-			return fs.SkipDir // Don't recurse into subdirectories
-		}
-		if !d.IsDir() && strings.HasSuffix(path, ".jsonl") {
-			info, err := d.Info()
-			if err != nil {
-				return nil // Skip files we can't stat
-			}
-			if info.ModTime().After(latestTime) {
-				latestTime = info.ModTime()
-				latestPath = path
-			}
-		}
-		return nil
-	})
-
+// measureSessionCost reads token usage for a session's agent runtime in
+// workDir and prices it. Models missing from the pricing table are
+// reported in Unpriced rather than priced at a guess.
+func measureSessionCost(provider, workDir string, pricing map[string]config.ModelPrice) (sessionUsage, error) {
+	result := sessionUsage{Provider: provider}
+	usage, err := costs.Extract(provider, workDir)
 	if err != nil {
-		return "", err
-	}
-	if latestPath == "" {
-		return "", fmt.Errorf("no transcript files found in %s", projectDir)
+		return result, err
 	}
-	return latestPath, nil
+	result.Models = usage.Models()
+	result.CostUSD, result.Unpriced = costs.Cost(usage, pricing)
+	return result, nil
 }
 
-// parseTranscriptUsage reads a transcript file and sums token usage from assistant messages.
-func parseTranscriptUsage(transcriptPath string) (*TokenUsage, error) {
-	file, err := os.Open(transcriptPath)
-	if err != nil {
-		return nil, err
+// resolveCostProvider maps a session's GT_AGENT value (a preset or a custom
+// agent alias) to the provider whose transcripts should be read.
+// Sessions without GT_AGENT predate multi-agent support and run Claude.
+func resolveCostProvider(townRoot, agent string) string {
+	if agent == "" {
+		return "claude"
 	}
-	defer file.Close()
-
-	usage := &TokenUsage{}
-	scanner := bufio.NewScanner(file)
-	// Increase buffer for potentially large JSON lines
-	buf := make([]byte, 0, 256*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-
-		var msg TranscriptMessage
-		if err := json.Unmarshal(line, &msg); err != nil {
-			continue // Skip malformed lines
-		}
-
-		// Only process assistant messages with usage info
-		if msg.Type != "assistant" || msg.Message == nil || msg.Message.Usage == nil {
-			continue
-		}
-
-		// Capture the model (use first one found, they should all be the same)
-		if usage.Model == "" && msg.Message.Model != "" {
-			usage.Model = msg.Message.Model
-		}
-
-		// Sum token usage
-		u := msg.Message.Usage
-		usage.InputTokens += u.InputTokens
-		usage.CacheCreationInputTokens += u.CacheCreationInputTokens
-		usage.CacheReadInputTokens += u.CacheReadInputTokens
-		usage.OutputTokens += u.OutputTokens
+	if _, ok := costs.ForProvider(agent); ok {
+		return agent
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if townRoot != "" {
+		if rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, "", agent); err == nil && rc.Provider != "" {
+			return rc.Provider
+		}
 	}
-
-	return usage, nil
+	return agent
 }
 
-// calculateCost converts token usage to USD cost based on model pricing.
-func calculateCost(usage *TokenUsage) float64 {
-	if usage == nil {
-		return 0.0
-	}
-
-	// Look up pricing for the model
-	pricing, ok := modelPricing[usage.Model]
-	if !ok {
-		pricing = modelPricing["default"]
+// loadModelPricing returns the town's pricing table, or the built-in
+// defaults outside a town.
+func loadModelPricing(townRoot string) map[string]config.ModelPrice {
+	if townRoot == "" {
+		return config.DefaultModelPricing()
 	}
-
-	// Calculate cost (prices are per million tokens)
-	inputCost := float64(usage.InputTokens) / 1_000_000 * pricing.InputPerMillion
-	cacheReadCost := float64(usage.CacheReadInputTokens) / 1_000_000 * pricing.CacheReadPerMillion
-	cacheCreateCost := float64(usage.CacheCreationInputTokens) / 1_000_000 * pricing.CacheCreatePerMillion
-	outputCost := float64(usage.OutputTokens) / 1_000_000 * pricing.OutputPerMillion
-
-	return inputCost + cacheReadCost + cacheCreateCost + outputCost
-}
-
-// extractCostFromWorkDir extracts cost from Claude Code transcript for a working directory.
-// This reads the most recent transcript file and sums all token usage.
-func extractCostFromWorkDir(workDir string) (float64, error) {
-	projectDir, err := getClaudeProjectDir(workDir)
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
 	if err != nil {
-		return 0, fmt.Errorf("getting project dir: %w", err)
+		return config.DefaultModelPricing()
 	}
+	return config.ResolveModelPricing(settings)
+}
 
-	transcriptPath, err := findLatestTranscript(projectDir)
-	if err != nil {
-		return 0, fmt.Errorf("finding transcript: %w", err)
+// warnUnpriced prints a note listing models that gt costs could not price.
+func warnUnpriced(models []string) {
+	if len(models) == 0 {
+		return
 	}
+	fmt.Printf("\n%s No pricing for: %s (counted as $0.00)\n", style.Warning.Render("⚠"), strings.Join(models, ", "))
+	fmt.Printf("  %s\n", style.Dim.Render("Add them under model_pricing in settings/config.json"))
+}
 
-	usage, err := parseTranscriptUsage(transcriptPath)
-	if err != nil {
-		return 0, fmt.Errorf("parsing transcript: %w", err)
+// mergeUnpriced adds models to a sorted, de-duplicated list.
+func mergeUnpriced(list []string, models ...string) []string {
+	for _, m := range models {
+		i := sort.SearchStrings(list, m)
+		if i < len(list) && list[i] == m {
+			continue
+		}
+		list = append(list, "")
+		copy(list[i+1:], list[i:])
+		list[i] = m
 	}
-
-	return calculateCost(usage), nil
+	return list
 }
 
 // getTmuxSessionWorkDir gets the current working directory of a tmux session.
@@ -930,27 +861,17 @@ func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 	// Session count
 	fmt.Printf("\n%s %d sessions\n", style.Dim.Render("Entries:"), len(entries))
 
+	warnUnpriced(output.UnpricedModels)
+
 	return nil
 }
 
 // CostLogEntry represents a single entry in the costs.jsonl log file.
-type CostLogEntry struct {
-	SessionID string    `json:"session_id"`
-	Role      string    `json:"role"`
-	Rig       string    `json:"rig,omitempty"`
-	Worker    string    `json:"worker,omitempty"`
-	CostUSD   float64   `json:"cost_usd"`
-	EndedAt   time.Time `json:"ended_at"`
-	WorkItem  string    `json:"work_item,omitempty"`
-}
+type CostLogEntry = costs.LogEntry
 
 // getCostsLogPath returns the path to the costs log file (~/.gt/costs.jsonl).
 func getCostsLogPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "/tmp/gt-costs.jsonl" // Fallback
-	}
-	return filepath.Join(home, ".gt", "costs.jsonl")
+	return costs.LogPath()
 }
 
 // runCostsRecord captures the final cost from a session and appends it to a local log file.
@@ -987,22 +908,43 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Extract cost from Claude transcript
-	var cost float64
+	// Extract cost from the agent's transcript
+	townRoot, _ := workspace.FindFromCwd()
+	measured := sessionUsage{Provider: resolveCostProvider(townRoot, os.Getenv("GT_AGENT"))}
 	if workDir != "" {
 		var err error
-		cost, err = extractCostFromWorkDir(workDir)
+		measured, err = measureSessionCost(measured.Provider, workDir, loadModelPricing(townRoot))
 		if err != nil {
 			if costsVerbose {
 				fmt.Fprintf(os.Stderr, "[costs] could not extract cost from transcript: %v\n", err)
 			}
-			cost = 0.0
 		}
 	}
+	cost := measured.CostUSD
 
 	// Parse session name
 	role, rig, worker := parseSessionName(session)
 
+	// Attribute polecat spend to the hooked issue when not given explicitly,
+	// so per-issue and per-convoy budgets see it.
+	workItem := recordWorkItem
+	if workItem == "" && workDir != "" && os.Getenv("GT_POLECAT") != "" {
+		workItem = detectHookedBead(workDir, RoleInfo{
+			Role:    RolePolecat,
+			Rig:     os.Getenv("GT_RIG"),
+			Polecat: os.Getenv("GT_POLECAT"),
+		})
+	}
+
+	// Attribute spend to the quota account so gt quota forecast can track
+	// each account's burn rate.
+	var account string
+	if townRoot != "" {
+		if acctCfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot)); err == nil {
+			account = quota.AccountForConfigDir(acctCfg, os.Getenv("CLAUDE_CONFIG_DIR"))
+		}
+	}
+
 	// Build log entry
 	entry := CostLogEntry{
 		SessionID: session,
@@ -1011,7 +953,11 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		Worker:    worker,
 		CostUSD:   cost,
 		EndedAt:   time.Now(),
-		WorkItem:  recordWorkItem,
+		WorkItem:  workItem,
+		Provider:  measured.Provider,
+		Models:    measured.Models,
+		Unpriced:  measured.Unpriced,
+		Account:   account,
 	}
 
 	// Marshal to JSON
@@ -1044,10 +990,10 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output confirmation (silent if cost is zero and no work item)
-	if cost > 0 || recordWorkItem != "" {
+	if cost > 0 || workItem != "" {
 		fmt.Printf("%s Recorded $%.2f for %s", style.Success.Render("✓"), cost, session)
-		if recordWorkItem != "" {
-			fmt.Printf(" (work: %s)", recordWorkItem)
+		if workItem != "" {
+			fmt.Printf(" (work: %s)", workItem)
 		}
 		fmt.Println()
 	}
@@ -1133,6 +1079,9 @@ type CostDigest struct {
 	Sessions     []CostEntry        `json:"sessions,omitempty"`
 	ByRole       map[string]float64 `json:"by_role"`
 	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	ByProvider   map[string]float64 `json:"by_provider,omitempty"`
+
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
 }
 
 // CostDigestPayload is the compact payload stored in the bead.
@@ -1143,6 +1092,9 @@ type CostDigestPayload struct {
 	SessionCount int                `json:"session_count"`
 	ByRole       map[string]float64 `json:"by_role"`
 	ByRig        map[string]float64 `json:"by_rig,omitempty"`
+	ByProvider   map[string]float64 `json:"by_provider,omitempty"`
+
+	UnpricedModels []string `json:"unpriced_models,omitempty"`
 }
 
 // runCostsDigest aggregates session cost entries into a daily digest bead.
@@ -1177,10 +1129,11 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 
 	// Build digest
 	digest := CostDigest{
-		Date:     dateStr,
-		Sessions: costEntries,
-		ByRole:   make(map[string]float64),
-		ByRig:    make(map[string]float64),
+		Date:       dateStr,
+		Sessions:   costEntries,
+		ByRole:     make(map[string]float64),
+		ByRig:      make(map[string]float64),
+		ByProvider: make(map[string]float64),
 	}
 
 	for _, e := range costEntries {
@@ -1190,6 +1143,10 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 		if e.Rig != "" {
 			digest.ByRig[e.Rig] += e.CostUSD
 		}
+		if e.Provider != "" {
+			digest.ByProvider[e.Provider] += e.CostUSD
+		}
+		digest.UnpricedModels = mergeUnpriced(digest.UnpricedModels, e.Unpriced...)
 	}
 
 	if digestDryRun {
@@ -1206,6 +1163,9 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 				fmt.Printf("    %s: $%.2f\n", rig, cost)
 			}
 		}
+		if len(digest.UnpricedModels) > 0 {
+			fmt.Printf("  Unpriced models: %s\n", strings.Join(digest.UnpricedModels, ", "))
+		}
 		return nil
 	}
 
@@ -1232,41 +1192,13 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 
 // querySessionCostEntries reads session cost entries from the local log file for a target date.
 func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
-	logPath := getCostsLogPath()
-
-	// Read log file
-	data, err := os.ReadFile(logPath)
+	logEntries, err := costs.ReadLog(getCostsLogPath(), targetDate)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No log file yet
-		}
-		return nil, fmt.Errorf("reading costs log: %w", err)
+		return nil, err
 	}
 
-	targetDay := targetDate.Format("2006-01-02")
-	var entries []CostEntry
-
-	// Parse each line as a CostLogEntry
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		var logEntry CostLogEntry
-		if err := json.Unmarshal([]byte(line), &logEntry); err != nil {
-			if costsVerbose {
-				fmt.Fprintf(os.Stderr, "[costs] failed to parse log entry: %v\n", err)
-			}
-			continue
-		}
-
-		// Filter by target date
-		if logEntry.EndedAt.Format("2006-01-02") != targetDay {
-			continue
-		}
-
+	entries := make([]CostEntry, 0, len(logEntries))
+	for _, logEntry := range logEntries {
 		entries = append(entries, CostEntry{
 			SessionID: logEntry.SessionID,
 			Role:      logEntry.Role,
@@ -1275,6 +1207,8 @@ func querySessionCostEntries(targetDate time.Time) ([]CostEntry, error) {
 			CostUSD:   logEntry.CostUSD,
 			EndedAt:   logEntry.EndedAt,
 			WorkItem:  logEntry.WorkItem,
+			Provider:  logEntry.Provider,
+			Unpriced:  logEntry.Unpriced,
 		})
 	}
 
@@ -1315,6 +1249,28 @@ func createCostDigestBead(digest CostDigest) (string, error) {
 		desc.WriteString("\n")
 	}
 
+	if len(digest.ByProvider) > 0 {
+		desc.WriteString("## By Agent\n")
+		providers := make([]string, 0, len(digest.ByProvider))
+		for p := range digest.ByProvider {
+			providers = append(providers, p)
+		}
+		sort.Strings(providers)
+		for _, p := range providers {
+			desc.WriteString(fmt.Sprintf("- %s: $%.2f\n", p, digest.ByProvider[p]))
+		}
+		desc.WriteString("\n")
+	}
+
+	if len(digest.UnpricedModels) > 0 {
+		desc.WriteString("## Unpriced Models\n")
+		desc.WriteString("Usage of these models is counted as $0.00; add them to model_pricing in settings/config.json.\n")
+		for _, m := range digest.UnpricedModels {
+			desc.WriteString(fmt.Sprintf("- %s\n", m))
+		}
+		desc.WriteString("\n")
+	}
+
 	// Build compact payload (aggregate only, no per-session details).
 	// Per-session details can be thousands of records and exceed Dolt column limits.
 	compactPayload := CostDigestPayload{
@@ -1323,6 +1279,9 @@ func createCostDigestBead(digest CostDigest) (string, error) {
 		SessionCount: digest.SessionCount,
 		ByRole:       digest.ByRole,
 		ByRig:        digest.ByRig,
+		ByProvider:   digest.ByProvider,
+
+		UnpricedModels: digest.UnpricedModels,
 	}
 	payloadJSON, err := json.Marshal(compactPayload)
 	if err != nil {
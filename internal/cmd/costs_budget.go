@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/budget"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/deacon"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+var costsBudgetJSON bool
+
+var costsBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Show today's spend against budget limits",
+	Long: `Show today's spend against the daily budget limits in settings/config.json.
+
+Limits are set per rig, per role, per convoy and per issue:
+
+  "budget": {
+    "rigs":    {"gastown": {"soft_usd": 40, "hard_usd": 60}},
+    "roles":   {"polecat": {"soft_usd": 100, "hard_usd": 150}},
+    "convoy":  {"soft_usd": 25, "hard_usd": 40},
+    "issue":   {"hard_usd": 10},
+    "soft_tier": "budget"
+  }
+
+Spend is the sum of today's session costs recorded by 'gt costs record'.
+Polecat spend is attributed to its hooked issue, and issue spend rolls up
+into the convoys that track it.
+
+The daemon checks budgets every heartbeat:
+  soft limit  escalate, and move the affected role (polecats, for rig/convoy/
+              issue limits) to soft_tier via the cost tier presets
+  hard limit  escalate critical; gt sling refuses to spawn polecats covered
+              by the limit. A rig limit also parks that rig, and a deacon
+              role limit pauses Deacon patrols
+
+Everything resets at the first heartbeat of the next day.
+
+Examples:
+  gt costs budget          # Burn vs limit
+  gt costs budget --json   # Machine-readable`,
+	RunE: runCostsBudget,
+}
+
+func init() {
+	costsCmd.AddCommand(costsBudgetCmd)
+	costsBudgetCmd.Flags().BoolVar(&costsBudgetJSON, "json", false, "Output as JSON")
+}
+
+// CostsBudgetOutput is the JSON output of gt costs budget.
+type CostsBudgetOutput struct {
+	Configured    bool            `json:"configured"`
+	Statuses      []budget.Status `json:"statuses"`
+	Downgraded    bool            `json:"downgraded,omitempty"`
+	PatrolsPaused bool            `json:"patrols_paused,omitempty"`
+}
+
+func runCostsBudget(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	convoysOf := func(issueID string) []string { return convoy.TrackingConvoys(townRoot, issueID) }
+	cfg, statuses, err := budget.Check(townRoot, convoysOf)
+	if err != nil {
+		return err
+	}
+
+	out := CostsBudgetOutput{Configured: cfg != nil, Statuses: statuses}
+	if state, err := budget.LoadState(townRoot); err == nil && state.Date == time.Now().Format("2006-01-02") {
+		out.Downgraded = state.Downgraded
+	}
+	if paused, ps, _ := deacon.IsPaused(townRoot); paused && ps != nil && ps.PausedBy == budget.PausedBy {
+		out.PatrolsPaused = true
+	}
+
+	if costsBudgetJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if !out.Configured {
+		fmt.Println(style.Dim.Render("No budget configured. Add a \"budget\" section to settings/config.json (see gt costs budget --help)."))
+		return nil
+	}
+
+	fmt.Printf("\n%s Budget (today)\n\n", style.Bold.Render("💸"))
+	if len(statuses) == 0 {
+		fmt.Println(style.Dim.Render("No spend against configured limits yet."))
+	} else {
+		fmt.Printf("%-8s %-24s %10s %10s %10s  %s\n", "Scope", "Key", "Spent", "Soft", "Hard", "Status")
+		fmt.Println(strings.Repeat("─", 76))
+		for _, s := range statuses {
+			fmt.Printf("%-8s %-24s %10s %10s %10s  %s\n",
+				s.Scope, s.Key,
+				fmt.Sprintf("$%.2f", s.SpentUSD),
+				formatBudgetLimit(s.Limit.Soft),
+				formatBudgetLimit(s.Limit.Hard),
+				formatBudgetLevel(s.Level))
+		}
+	}
+
+	if out.Downgraded {
+		fmt.Printf("\n%s Roles downgraded to a cheaper tier until tomorrow\n", style.Warning.Render("⚠"))
+	}
+	if out.PatrolsPaused {
+		fmt.Printf("%s Patrols paused by hard limit until tomorrow\n", style.Error.Render("✗"))
+	}
+	return nil
+}
+
+func formatBudgetLimit(usd float64) string {
+	if usd <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+func formatBudgetLevel(level budget.Level) string {
+	switch level {
+	case budget.LevelHard:
+		return style.Error.Render("● hard")
+	case budget.LevelSoft:
+		return style.Warning.Render("● soft")
+	default:
+		return style.Success.Render("● ok")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/git"
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/polecat"
+	"github.com/xcawolfe-amzn/gastown/internal/protocol"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
@@ -691,6 +692,10 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 				target = autoTarget
 			}
 		}
+		target, err = convoyLandingTarget(g, convoyInfo, defaultBranch, target)
+		if err != nil {
+			return fmt.Errorf("%w (branch %s is pushed; fix and re-run gt done)", err, branch)
+		}
 
 		// Get source issue for priority inheritance
 		var priority int
@@ -729,6 +734,16 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			if agentBeadID != "" {
 				description += fmt.Sprintf("\nagent_bead: %s", agentBeadID)
 			}
+			// Convoy fields drive starvation and deadline scoring in the refinery
+			if convoyInfo != nil {
+				description += fmt.Sprintf("\nconvoy_id: %s", convoyInfo.ID)
+				if convoyInfo.CreatedAt != "" {
+					description += fmt.Sprintf("\nconvoy_created_at: %s", convoyInfo.CreatedAt)
+				}
+				if convoyInfo.DueAt != "" {
+					description += fmt.Sprintf("\nconvoy_due_at: %s", convoyInfo.DueAt)
+				}
+			}
 
 			// Add conflict resolution tracking fields (initialized, updated by Refinery)
 			description += "\nretry_count: 0"
@@ -870,6 +885,10 @@ afterDoltMerge:
 		Subject: fmt.Sprintf("POLECAT_DONE %s", polecatName),
 		Body:    strings.Join(bodyLines, "\n"),
 	}
+	donePayload := protocol.ParsePolecatDonePayload(polecatName, doneNotification.Body)
+	if err := protocol.Attach(doneNotification, protocol.TypePolecatDone, donePayload); err != nil {
+		style.PrintWarning("could not encode POLECAT_DONE payload: %v", err)
+	}
 
 	fmt.Printf("\nNotifying Witness...\n")
 	if err := townRouter.Send(doneNotification); err != nil {
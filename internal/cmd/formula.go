@@ -5,10 +5,12 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/base32"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -25,12 +27,14 @@ import (
 
 // Formula command flags
 var (
-	formulaListJSON   bool
-	formulaShowJSON   bool
-	formulaRunPR      int
-	formulaRunRig     string
-	formulaRunDryRun  bool
-	formulaCreateType string
+	formulaListJSON     bool
+	formulaShowJSON     bool
+	formulaShowResolved bool
+	formulaRunPR        int
+	formulaRunRig       string
+	formulaRunDryRun    bool
+	formulaRunAgent     string
+	formulaCreateType   string
 )
 
 var formulaCmd = &cobra.Command{
@@ -50,6 +54,10 @@ Commands:
   show    Display formula details (steps, variables, composition)
   run     Execute a formula (pour and dispatch)
   create  Create a new formula template
+  install Install formulas from a registry
+  outdated  List registry formulas with newer versions
+  upgrade Upgrade registry formulas
+  test    Run a formula's test cases
 
 Search paths (in order):
   1. .beads/formulas/ (project)
@@ -90,9 +98,14 @@ Shows:
   - Steps with dependencies
   - Composition rules (extends, aspects)
 
+With --resolved, composition is flattened: steps from extended and
+included formulas, overrides, expansions and aspect advice are applied,
+and the resulting standalone steps are shown in execution order.
+
 Examples:
   gt formula show shiny
-  gt formula show rule-of-five --json`,
+  gt formula show rule-of-five --json
+  gt formula show shiny-secure --resolved`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFormulaShow,
 }
@@ -115,6 +128,7 @@ the rig's settings/config.json under workflow.default_formula.
 Options:
   --pr=N      Run formula on GitHub PR #N
   --rig=NAME  Target specific rig (default: current or gastown)
+  --agent=X   Agent runtime for spawned polecats (e.g. sim)
   --dry-run   Show what would happen without executing
 
 Examples:
@@ -154,11 +168,13 @@ func init() {
 
 	// Show flags
 	formulaShowCmd.Flags().BoolVar(&formulaShowJSON, "json", false, "Output as JSON")
+	formulaShowCmd.Flags().BoolVar(&formulaShowResolved, "resolved", false, "Show the formula with composition flattened")
 
 	// Run flags
 	formulaRunCmd.Flags().IntVar(&formulaRunPR, "pr", 0, "GitHub PR number to run formula on")
 	formulaRunCmd.Flags().StringVar(&formulaRunRig, "rig", "", "Target rig (default: current or gastown)")
 	formulaRunCmd.Flags().BoolVar(&formulaRunDryRun, "dry-run", false, "Preview execution without running")
+	formulaRunCmd.Flags().StringVar(&formulaRunAgent, "agent", "", "Agent runtime for spawned polecats (overrides rig/town default)")
 
 	// Create flags
 	formulaCreateCmd.Flags().StringVar(&formulaCreateType, "type", "task", "Formula type: task, workflow, or patrol")
@@ -188,6 +204,9 @@ func runFormulaList(cmd *cobra.Command, args []string) error {
 // runFormulaShow delegates to bd formula show
 func runFormulaShow(cmd *cobra.Command, args []string) error {
 	formulaName := args[0]
+	if formulaShowResolved {
+		return showResolvedFormula(formulaName)
+	}
 	bdArgs := []string{"formula", "show", formulaName}
 	if formulaShowJSON {
 		bdArgs = append(bdArgs, "--json")
@@ -199,6 +218,95 @@ func runFormulaShow(cmd *cobra.Command, args []string) error {
 	return bdCmd.Run()
 }
 
+// showResolvedFormula prints a formula with its composition flattened.
+func showResolvedFormula(name string) error {
+	load := formula.DirLoader(formulaSearchPaths()...)
+	data, err := load(name)
+	if err != nil {
+		return fmt.Errorf("loading formula '%s': %w", name, err)
+	}
+	f, err := formula.ParseWithLoader(data, load)
+	if err != nil {
+		return err
+	}
+
+	if formulaShowJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(f)
+	}
+
+	fmt.Printf("%s (%s, resolved)\n", style.Bold.Render(f.Name), f.Type)
+	if f.Description != "" {
+		fmt.Printf("  %s\n", f.Description)
+	}
+
+	if len(f.Vars) > 0 {
+		names := make([]string, 0, len(f.Vars))
+		for n := range f.Vars {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		fmt.Printf("\nVariables:\n")
+		for _, n := range names {
+			v := f.Vars[n]
+			var attrs []string
+			if v.Required {
+				attrs = append(attrs, "required")
+			}
+			if v.Default != "" {
+				attrs = append(attrs, "default: "+v.Default)
+			}
+			line := "  " + n
+			if len(attrs) > 0 {
+				line += " (" + strings.Join(attrs, ", ") + ")"
+			}
+			if v.Description != "" {
+				line += ": " + v.Description
+			}
+			fmt.Println(line)
+		}
+	}
+
+	order, err := f.TopologicalSort()
+	if err != nil {
+		return err
+	}
+	switch f.Type {
+	case formula.TypeWorkflow:
+		fmt.Printf("\nSteps (%d):\n", len(order))
+		for i, id := range order {
+			step := f.GetStep(id)
+			fmt.Printf("  %2d. %s: %s\n", i+1, id, step.Title)
+			if len(step.Needs) > 0 {
+				fmt.Printf("      %s\n", style.Dim.Render("needs: "+strings.Join(step.Needs, ", ")))
+			}
+		}
+	case formula.TypeExpansion:
+		fmt.Printf("\nTemplates (%d):\n", len(order))
+		for _, id := range order {
+			fmt.Printf("  • %s: %s\n", id, f.GetTemplate(id).Title)
+		}
+	case formula.TypeConvoy:
+		fmt.Printf("\nLegs (%d parallel):\n", len(f.Legs))
+		for _, leg := range f.Legs {
+			fmt.Printf("  • %s: %s\n", leg.ID, leg.Title)
+		}
+		if f.Synthesis != nil {
+			fmt.Printf("\nSynthesis:\n  • %s\n", f.Synthesis.Title)
+		}
+	case formula.TypeAspect:
+		fmt.Printf("\nAspects (%d):\n", len(f.Aspects))
+		for _, aspect := range f.Aspects {
+			fmt.Printf("  • %s: %s\n", aspect.ID, aspect.Title)
+		}
+		for _, adv := range f.Advice {
+			fmt.Printf("  • advice around %s\n", adv.Target)
+		}
+	}
+	return nil
+}
+
 // runFormulaRun executes a formula by spawning a convoy of polecats.
 // For convoy-type formulas, it creates a convoy bead, creates leg beads,
 // and slings each leg to a separate polecat with leg-specific prompts.
@@ -597,6 +705,9 @@ func executeConvoyFormula(f *formula.Formula, formulaName, targetRig string) err
 			"-a", leg.Description,
 			"-s", leg.Title,
 		}
+		if formulaRunAgent != "" {
+			slingArgs = append(slingArgs, "--agent", formulaRunAgent)
+		}
 
 		slingCmd := exec.Command("gt", slingArgs...)
 		slingCmd.Stdout = os.Stdout
@@ -629,9 +740,9 @@ func executeConvoyFormula(f *formula.Formula, formulaName, targetRig string) err
 	return nil
 }
 
-// findFormulaFile searches for a formula file by name
-func findFormulaFile(name string) (string, error) {
-	// Search paths in order
+// formulaSearchPaths returns the directories searched for formula files,
+// in order.
+func formulaSearchPaths() []string {
 	searchPaths := []string{}
 
 	// 1. Project .beads/formulas/
@@ -648,6 +759,12 @@ func findFormulaFile(name string) (string, error) {
 	if home, err := os.UserHomeDir(); err == nil {
 		searchPaths = append(searchPaths, filepath.Join(home, ".beads", "formulas"))
 	}
+	return searchPaths
+}
+
+// findFormulaFile searches for a formula file by name
+func findFormulaFile(name string) (string, error) {
+	searchPaths := formulaSearchPaths()
 
 	// Try each path with common extensions
 	extensions := []string{".formula.toml", ".formula.json"}
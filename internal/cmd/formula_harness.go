@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+)
+
+var formulaTestJSON bool
+
+var formulaTestCmd = &cobra.Command{
+	Use:   "test <name|path>",
+	Short: "Run a formula's test cases",
+	Long: `Run the test cases declared next to a formula, without slinging it.
+
+Tests live beside the formula: shiny.formula.toml is tested by
+shiny.formula.test.toml. Each [[case]] sets input variables and the
+expected results:
+
+  [[case]]
+  name = "prod deploy after one review round"
+  vars = { feature = "login", env = "prod" }
+  steps = ["design", "implement", "review", "fix", "deploy"]   # Resolved steps
+  order = ["design", "implement", "review", "fix", "deploy"]   # DAG order
+  outcomes = { review = ["changes-requested", "approved"] }    # Mocked outcomes
+  failures = { implement = 1 }                                 # Mocked failed attempts
+  path = ["design", "implement", "review", "fix", "review", "deploy"]
+  skipped = ["fix"]
+  status = "done"
+
+  [case.prompts.implement]
+  title = "Implement login"
+  contains = ["login"]
+
+  [[case]]
+  name = "feature is required"
+  error = "missing required variables: feature"
+
+Each case parses and validates the formula (resolving composition),
+checks template variables, sorts the DAG, renders prompts and, with
+mocked outcomes, simulates a run through conditions, loops and retries.
+Exits non-zero if any case fails, so formula changes can gate CI.
+
+Examples:
+  gt formula test shiny
+  gt formula test .beads/formulas/my-flow.formula.toml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFormulaTest,
+}
+
+func init() {
+	formulaTestCmd.Flags().BoolVar(&formulaTestJSON, "json", false, "Output as JSON")
+	formulaCmd.AddCommand(formulaTestCmd)
+}
+
+func runFormulaTest(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	if !strings.HasSuffix(path, ".formula.toml") {
+		found, err := findFormulaFile(path)
+		if err != nil {
+			return err
+		}
+		path = found
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted formula directory
+	if err != nil {
+		return fmt.Errorf("reading formula: %w", err)
+	}
+	suite, err := formula.LoadTestSuite(formula.TestFilePath(path))
+	if err != nil {
+		return err
+	}
+
+	load := formula.DirLoader(append([]string{filepath.Dir(path)}, formulaSearchPaths()...)...)
+	results := suite.Run(data, load)
+	failed := 0
+	for _, r := range results {
+		if !r.Passed() {
+			failed++
+		}
+	}
+
+	if formulaTestJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Passed() {
+				fmt.Printf("%s %s\n", style.Success.Render("✓"), r.Case)
+				continue
+			}
+			fmt.Printf("%s %s\n", style.Error.Render("✗"), r.Case)
+			for _, f := range r.Failures {
+				fmt.Printf("    %s\n", f)
+			}
+		}
+		fmt.Printf("\n%d passed, %d failed\n", len(results)-failed, failed)
+	}
+
+	if failed > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Formula registry command flags
+var (
+	formulaRegistryURL   string
+	formulaInstallForce  bool
+	formulaOutdatedJSON  bool
+	formulaUpgradeForce  bool
+	formulaUpgradeDryRun bool
+)
+
+var formulaInstallCmd = &cobra.Command{
+	Use:   "install <name>[@version]...",
+	Short: "Install formulas from a registry",
+	Long: `Install formulas from a formula registry.
+
+Formulas are downloaded into the town's .beads/formulas/, verified against
+the registry checksum, and recorded in .beads/formulas/.lock.json.
+
+A version pins the formula: an exact version (mol-deploy@1.2.0) or a
+prefix (mol-deploy@1) for the newest match. Without a version the latest
+is installed and 'gt formula upgrade' keeps it current.
+
+A formula you have edited locally is not overwritten without --force.
+
+The registry is --registry, else $GT_FORMULA_REGISTRY; there is no default.
+It may be an HTTP(S) directory, a git repository (URL ending in .git), or
+a local directory, each serving index.json.
+
+Examples:
+  gt formula install mol-polecat-code-review
+  gt formula install mol-deploy@1.2.0
+  gt formula install mol-deploy --registry=https://formulas.acme.corp`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFormulaInstall,
+}
+
+var formulaOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List registry formulas with newer versions",
+	Long: `List formulas installed from a registry that have newer versions.
+
+Pinned formulas are listed but not upgraded automatically. Formulas
+edited locally are flagged; upgrading them requires --force.
+
+Examples:
+  gt formula outdated
+  gt formula outdated --json`,
+	Args: cobra.NoArgs,
+	RunE: runFormulaOutdated,
+}
+
+var formulaUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [name...]",
+	Short: "Upgrade registry formulas to their latest versions",
+	Long: `Upgrade formulas installed from a registry.
+
+Without names, every outdated unpinned formula is upgraded. Naming a
+pinned formula upgrades and unpins it. Locally modified formulas are
+skipped unless --force.
+
+Examples:
+  gt formula upgrade
+  gt formula upgrade mol-deploy
+  gt formula upgrade --dry-run`,
+	RunE: runFormulaUpgrade,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{formulaInstallCmd, formulaOutdatedCmd, formulaUpgradeCmd} {
+		c.Flags().StringVar(&formulaRegistryURL, "registry", "", "Registry URL or directory (default: $GT_FORMULA_REGISTRY)")
+	}
+	formulaInstallCmd.Flags().BoolVar(&formulaInstallForce, "force", false, "Overwrite locally modified formulas")
+	formulaOutdatedCmd.Flags().BoolVar(&formulaOutdatedJSON, "json", false, "Output as JSON")
+	formulaUpgradeCmd.Flags().BoolVar(&formulaUpgradeForce, "force", false, "Upgrade locally modified formulas too")
+	formulaUpgradeCmd.Flags().BoolVar(&formulaUpgradeDryRun, "dry-run", false, "Show what would be upgraded")
+
+	formulaCmd.AddCommand(formulaInstallCmd)
+	formulaCmd.AddCommand(formulaOutdatedCmd)
+	formulaCmd.AddCommand(formulaUpgradeCmd)
+}
+
+// openFormulaRegistry returns the town root, the configured registry and
+// its index. Git registries are cloned under .beads/formulas/.registry/.
+func openFormulaRegistry() (string, *formula.Registry, *formula.RegistryIndex, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	url := formula.RegistryURL(formulaRegistryURL)
+	if url == "" {
+		return "", nil, nil, fmt.Errorf("no formula registry configured: pass --registry or set %s", formula.RegistryEnv)
+	}
+	sum := sha256.Sum256([]byte(url))
+	cacheDir := filepath.Join(townRoot, ".beads", "formulas", ".registry", hex.EncodeToString(sum[:6]))
+
+	reg := formula.NewRegistry(url, cacheDir)
+	idx, err := reg.Index()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return townRoot, reg, idx, nil
+}
+
+func runFormulaInstall(cmd *cobra.Command, args []string) error {
+	townRoot, reg, idx, err := openFormulaRegistry()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, spec := range args {
+		entry, err := formula.InstallFormula(townRoot, reg, idx, spec, formula.InstallOptions{Force: formulaInstallForce})
+		if err != nil {
+			style.PrintWarning("%s: %v", spec, err)
+			failed++
+			continue
+		}
+		name, _ := formula.SplitFormulaSpec(spec)
+		pin := "latest"
+		if entry.Pinned {
+			pin = "pinned"
+		}
+		fmt.Printf("%s Installed %s@%s [%s]\n", style.Bold.Render("✓"), name, entry.Version, pin)
+		fmt.Printf("  %s\n", style.Dim.Render(entry.Checksum))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d formula(s) failed to install", failed, len(args))
+	}
+	return nil
+}
+
+func runFormulaOutdated(cmd *cobra.Command, args []string) error {
+	townRoot, _, idx, err := openFormulaRegistry()
+	if err != nil {
+		return err
+	}
+	outdated, err := formula.OutdatedFormulas(townRoot, idx)
+	if err != nil {
+		return err
+	}
+
+	if formulaOutdatedJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(outdated)
+	}
+	if len(outdated) == 0 {
+		fmt.Println("All registry formulas are up to date.")
+		return nil
+	}
+	fmt.Printf("%-32s %-10s %-10s %s\n", "FORMULA", "CURRENT", "LATEST", "NOTES")
+	for _, o := range outdated {
+		var notes string
+		if o.Pinned {
+			notes = "pinned"
+		}
+		if o.Modified {
+			if notes != "" {
+				notes += ", "
+			}
+			notes += "modified locally"
+		}
+		fmt.Printf("%-32s %-10s %-10s %s\n", o.Name, o.Current, o.Latest, style.Dim.Render(notes))
+	}
+	return nil
+}
+
+func runFormulaUpgrade(cmd *cobra.Command, args []string) error {
+	townRoot, reg, idx, err := openFormulaRegistry()
+	if err != nil {
+		return err
+	}
+
+	if formulaUpgradeDryRun {
+		outdated, err := formula.OutdatedFormulas(townRoot, idx)
+		if err != nil {
+			return err
+		}
+		named := make(map[string]bool)
+		for _, n := range args {
+			named[n] = true
+		}
+		for _, o := range outdated {
+			if len(named) > 0 && !named[o.Name] {
+				continue
+			}
+			switch {
+			case o.Pinned && !named[o.Name]:
+				fmt.Printf("  ○ %s: pinned at %s (latest %s)\n", o.Name, o.Current, o.Latest)
+			case o.Modified && !formulaUpgradeForce:
+				fmt.Printf("  ○ %s: modified locally (use --force)\n", o.Name)
+			default:
+				fmt.Printf("  → %s: %s → %s\n", o.Name, o.Current, o.Latest)
+				if _, rv, err := idx.Resolve(o.Name, o.Latest); err == nil && rv.Changelog != "" {
+					fmt.Printf("    %s\n", style.Dim.Render(rv.Changelog))
+				}
+			}
+		}
+		return nil
+	}
+
+	upgraded, skipped, err := formula.UpgradeFormulas(townRoot, reg, idx, args, formula.InstallOptions{Force: formulaUpgradeForce})
+	names := make([]string, 0, len(upgraded))
+	for name := range upgraded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s Upgraded %s to %s\n", style.Bold.Render("✓"), name, upgraded[name].Version)
+	}
+	names = names[:0]
+	for name := range skipped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %s %s: %s\n", style.Dim.Render("○"), name, skipped[name])
+	}
+	if err != nil {
+		return err
+	}
+	if len(upgraded) == 0 && len(skipped) == 0 {
+		fmt.Println("All registry formulas are up to date.")
+	}
+	return nil
+}
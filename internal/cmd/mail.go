@@ -31,13 +31,26 @@ var (
 	mailReplySubject  string
 	mailReplyMessage  string
 	mailStdin         bool // Read message body from stdin
+	mailSendAt        string
+	mailSendIn        string
+	mailSendCron      string
+	mailAttach        []string
+	mailAttachDiff    []string
+	mailAttachPane    []string
+	mailReadAttach    string
+	mailReadExtract   string
 
 	// Search flags
-	mailSearchFrom    string
-	mailSearchSubject bool
-	mailSearchBody    bool
-	mailSearchArchive bool
-	mailSearchJSON    bool
+	mailSearchFrom     string
+	mailSearchSubject  bool
+	mailSearchBody     bool
+	mailSearchArchive  bool
+	mailSearchJSON     bool
+	mailSearchTown     bool
+	mailSearchIdentity string
+	mailSearchLimit    int
+	mailSearchReindex  bool
+	mailSearchRegex    bool
 
 	// Announces flags
 	mailAnnouncesJSON bool
@@ -48,6 +61,9 @@ var (
 	// Archive flags
 	mailArchiveStale  bool
 	mailArchiveDryRun bool
+
+	// Claim flags
+	mailClaimRenew bool
 )
 
 var mailCmd = &cobra.Command{
@@ -123,6 +139,17 @@ Priority levels:
 
 Use --urgent as shortcut for --priority 0.
 
+Attachments:
+  --attach, --attach-diff and --attach-pane store content outside the
+  message body (deduplicated by hash under the town runtime dir) and list
+  it on the message. Read them with 'gt mail read <id> --attachment N'.
+
+Scheduled delivery:
+  --at, --in and --cron hold the message back until it is due. The daemon
+  releases due mail on its heartbeat, so delivery can lag by a few minutes.
+  --cron recurs the message (e.g. "0 9 * * 1-5"); list and cancel pending
+  deliveries with 'gt mail scheduled'.
+
 Examples:
   gt mail send greenplace/Toast -s "Status check" -m "How's that bug fix going?"
   gt mail send mayor/ -s "Work complete" -m "Finished gt-abc"
@@ -133,6 +160,10 @@ Examples:
   gt mail send --self -s "Handoff" -m "Context for next session"
   gt mail send greenplace/Toast -s "Update" -m "Progress report" --cc overseer
   gt mail send list:oncall -s "Alert" -m "System down"
+  gt mail send mayor/ -s "Review convoy hq-cv-abc" --at 09:00
+  gt mail send --self -s "Re-check polecat nux" --in 30m
+  gt mail send mayor/ -s "Daily standup" --cron "0 9 * * 1-5"
+  gt mail send gastown/refinery -s "Conflict" --attach-diff main...HEAD --attach build.log
 
   # Read body from stdin (avoids shell quoting issues):
   gt mail send mayor/ -s "Update" --stdin <<'BODY'
@@ -175,6 +206,8 @@ The index corresponds to the number shown in 'gt mail inbox' (1-based).
 Examples:
   gt mail read hq-abc123    # Read by message ID
   gt mail read 3            # Read the 3rd message in inbox
+  gt mail read hq-abc123 --attachment 1      # Print the first attachment
+  gt mail read hq-abc123 --extract ./att     # Save all attachments
 
 Use 'gt mail mark-read' to mark messages as read.`,
 	Aliases: []string{"show"},
@@ -321,6 +354,7 @@ var mailClaimCmd = &cobra.Command{
 
 SYNTAX:
   gt mail claim [queue-name]
+  gt mail claim --renew <message-id>
 
 BEHAVIOR:
 1. If queue specified, claim from that queue
@@ -328,13 +362,21 @@ BEHAVIOR:
 3. Add claimed-by and claimed-at labels to the message
 4. Print claimed message details
 
+LEASES:
+On queues created with a claim_ttl, a claim is a lease. Renew it with
+--renew while you work; the daemon releases unrenewed claims back to the
+queue and counts an attempt on the message. Queues without a claim_ttl
+keep claims until they are released. After max_attempts expired claims
+(default 3) the message is moved to the deadletter mailbox.
+
 ELIGIBILITY:
 The caller must match the queue's claim_pattern (stored in the queue bead).
 Pattern examples: "*" (anyone), "gastown/polecats/*" (specific rig crew).
 
 Examples:
   gt mail claim work-requests   # Claim from specific queue
-  gt mail claim                 # Claim from any eligible queue`,
+  gt mail claim                 # Claim from any eligible queue
+  gt mail claim --renew hq-abc  # Extend the lease on a claimed message`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runMailClaim,
 }
@@ -390,31 +432,56 @@ Examples:
 }
 
 var mailSearchCmd = &cobra.Command{
-	Use:   "search <query>",
+	Use:   "search <query>...",
 	Short: "Search messages by content",
-	Long: `Search inbox for messages matching a pattern.
+	Long: `Search mail with a town-wide full-text index.
 
 SYNTAX:
   gt mail search <query> [flags]
 
-The query is a regular expression pattern. Search is case-insensitive by default.
+Words in the query must all appear (case-insensitive, whole words; end a
+word with * to match a prefix). Results are ranked by relevance, with
+subject matches weighted above body matches. If no message has the words,
+the query text is matched as a case-insensitive substring instead. With
+--regex the query text is a regular expression.
+
+FIELD FILTERS (in the query):
+  from:<addr>      Sender address contains <addr>
+  to:<addr>        Recipient, CC, queue or channel contains <addr>
+  thread:<id>      Messages in a thread
+  type:<type>      Message type (task, reply, ...) or protocol type (MERGED, ...)
+  after:<date>     Sent on or after YYYY-MM-DD, RFC 3339, or an age (7d, 12h)
+  before:<date>    Sent before the date
+  is:<state>       unread, read, archived or inbox
+
+SCOPE:
+  By default only your own mailbox is searched. --identity searches another
+  mailbox and --town searches every mailbox you may read. The overseer, mayor
+  and deacon may read all mailboxes; a rig's witness and refinery may read
+  the mailboxes in their rig.
 
 FLAGS:
-  --from <sender>   Filter by sender address (substring match)
+  --from <sender>   Filter by sender address (same as from:)
   --subject         Only search subject lines
   --body            Only search message body
-  --archive         Include archived (closed) messages
+  --archive         Include archived messages
+  --town            Search every mailbox you may read
+  --identity <addr> Search a specific mailbox
+  --limit <n>       Maximum results (default 20, 0 for all)
+  --regex           Treat the query text as a regular expression
+  --reindex         Rebuild the search index from scratch
   --json            Output as JSON
 
-By default, searches both subject and body text.
-
 Examples:
-  gt mail search "urgent"                    # Find messages with "urgent"
-  gt mail search "status.*check" --subject   # Regex in subjects only
-  gt mail search "error" --from witness      # From witness, containing "error"
-  gt mail search "handoff" --archive         # Include archived messages
-  gt mail search "" --from mayor/            # All messages from mayor`,
-	Args: cobra.ExactArgs(1),
+  gt mail search urgent                          # Your mailbox
+  gt mail search status --subject                # Subjects only
+  gt mail search "status.*check" --regex         # Regular expression
+  gt mail search error --from witness            # From witness, containing "error"
+  gt mail search handoff --archive               # Include archived messages
+  gt mail search rebas* to:gastown/refinery --town   # Who told the refinery to rebase?
+  gt mail search type:MERGE_FAILED after:7d --town
+  gt mail search "" --from mayor/                # All messages from mayor`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runMailSearch,
 }
 
@@ -468,6 +535,13 @@ func init() {
 	mailSendCmd.Flags().BoolVar(&mailPermanent, "permanent", false, "Send as permanent (not ephemeral, synced to remote)")
 	mailSendCmd.Flags().BoolVar(&mailSendSelf, "self", false, "Send to self (auto-detect from cwd)")
 	mailSendCmd.Flags().StringArrayVar(&mailCC, "cc", nil, "CC recipients (can be used multiple times)")
+	mailSendCmd.Flags().StringVar(&mailSendAt, "at", "", "Deliver at a time: HH:MM, \"YYYY-MM-DD HH:MM\" or RFC 3339")
+	mailSendCmd.Flags().StringVar(&mailSendIn, "in", "", "Deliver after a delay (e.g. 30m, 2h, 1d)")
+	mailSendCmd.Flags().StringVar(&mailSendCron, "cron", "", "Deliver repeatedly on a cron schedule (e.g. \"0 9 * * 1-5\", @daily)")
+	mailSendCmd.MarkFlagsMutuallyExclusive("at", "in")
+	mailSendCmd.Flags().StringArrayVar(&mailAttach, "attach", nil, "Attach a file (repeatable)")
+	mailSendCmd.Flags().StringArrayVar(&mailAttachDiff, "attach-diff", nil, "Attach the git diff for a ref or range, e.g. main...HEAD (repeatable)")
+	mailSendCmd.Flags().StringArrayVar(&mailAttachPane, "attach-pane", nil, "Attach a capture of a tmux session's pane (repeatable)")
 	_ = mailSendCmd.MarkFlagRequired("subject") // cobra flags: error only at runtime if missing
 
 	// Inbox flags
@@ -479,6 +553,8 @@ func init() {
 
 	// Read flags
 	mailReadCmd.Flags().BoolVar(&mailReadJSON, "json", false, "Output as JSON")
+	mailReadCmd.Flags().StringVar(&mailReadAttach, "attachment", "", "Print one attachment (by number or name) instead of the message")
+	mailReadCmd.Flags().StringVar(&mailReadExtract, "extract", "", "Write all attachments into this directory")
 
 	// Check flags
 	mailCheckCmd.Flags().BoolVar(&mailCheckInject, "inject", false, "Output format for Claude Code hooks")
@@ -500,10 +576,19 @@ func init() {
 	mailSearchCmd.Flags().BoolVar(&mailSearchBody, "body", false, "Only search message body")
 	mailSearchCmd.Flags().BoolVar(&mailSearchArchive, "archive", false, "Include archived messages")
 	mailSearchCmd.Flags().BoolVar(&mailSearchJSON, "json", false, "Output as JSON")
+	mailSearchCmd.Flags().BoolVar(&mailSearchTown, "town", false, "Search every mailbox you may read")
+	mailSearchCmd.Flags().StringVar(&mailSearchIdentity, "identity", "", "Search a specific mailbox (e.g., gastown/refinery)")
+	mailSearchCmd.Flags().IntVar(&mailSearchLimit, "limit", 20, "Maximum results (0 for all)")
+	mailSearchCmd.Flags().BoolVar(&mailSearchRegex, "regex", false, "Treat the query text as a regular expression")
+	mailSearchCmd.Flags().BoolVar(&mailSearchReindex, "reindex", false, "Rebuild the search index from scratch")
+	mailSearchCmd.MarkFlagsMutuallyExclusive("town", "identity")
 
 	// Announces flags
 	mailAnnouncesCmd.Flags().BoolVar(&mailAnnouncesJSON, "json", false, "Output as JSON")
 
+	// Claim flags
+	mailClaimCmd.Flags().BoolVar(&mailClaimRenew, "renew", false, "Renew the lease on a message you have claimed")
+
 	// Clear flags
 	mailClearCmd.Flags().BoolVar(&mailClearAll, "all", false, "Clear all messages (default behavior)")
 
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/events"
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Queue claims are leases on queues created with a claim_ttl. A claimant
+// renews claimed-at with gt mail claim --renew; the daemon runs
+// gt mail queue expire each heartbeat to release claims that outlived
+// their queue's claim_ttl. Claims on other queues never expire.
+
+var (
+	mailQueueExpireDryRun bool
+	mailQueueExpireJSON   bool
+)
+
+var mailQueueExpireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "Release expired queue claims",
+	Long: `Release queue claims whose lease has expired.
+
+A claim expires when its claimant has not renewed it (gt mail claim --renew)
+within the queue's claim_ttl. Queues created without --claim-ttl have no
+lease, so their claims never expire. The message returns to the queue and its
+claim-attempts label is incremented. When the attempts reach the queue's
+max_attempts the message is delivered to the deadletter mailbox and closed
+instead, so work that keeps killing its claimants is surfaced rather than
+retried forever.
+
+The daemon runs this on every heartbeat.
+
+Examples:
+  gt mail queue expire              # Release expired claims now
+  gt mail queue expire --dry-run    # Show what would be released`,
+	Args: cobra.NoArgs,
+	RunE: runMailQueueExpire,
+}
+
+func init() {
+	mailQueueExpireCmd.Flags().BoolVarP(&mailQueueExpireDryRun, "dry-run", "n", false, "Show expired claims without releasing them")
+	mailQueueExpireCmd.Flags().BoolVar(&mailQueueExpireJSON, "json", false, "Output as JSON")
+	mailQueueCmd.AddCommand(mailQueueExpireCmd)
+}
+
+// renewQueueClaim extends the caller's lease on a claimed queue message.
+func renewQueueClaim(bd *beads.Beads, beadsDir, messageID, caller string) error {
+	info, err := getQueueMessageInfo(beadsDir, messageID)
+	if err != nil {
+		return fmt.Errorf("getting message: %w", err)
+	}
+	if info.QueueName == "" {
+		return fmt.Errorf("message %s is not a queue message (no queue label)", messageID)
+	}
+	if info.ClaimedBy == "" {
+		return fmt.Errorf("message %s is not claimed (its lease may have expired; claim it again)", messageID)
+	}
+	if info.ClaimedBy != caller {
+		return fmt.Errorf("message %s was claimed by %s, not %s", messageID, info.ClaimedBy, caller)
+	}
+
+	// Add the new claimed-at before dropping the old ones in the same
+	// update; parseClaimLabels takes the latest if the removal is lost.
+	renewed := "claimed-at:" + time.Now().UTC().Format(time.RFC3339)
+	var remove []string
+	for _, label := range info.claimLabels {
+		if strings.HasPrefix(label, "claimed-at:") && label != renewed {
+			remove = append(remove, label)
+		}
+	}
+	if err := updateQueueMessageLabels(beadsDir, messageID, caller, []string{renewed}, remove); err != nil {
+		return fmt.Errorf("renewing claim: %w", err)
+	}
+
+	var ttl time.Duration
+	if fields, err := lookupQueue(bd, info.QueueName); err == nil {
+		ttl = fields.LeaseTTL()
+	}
+	fmt.Printf("%s Renewed claim on %s\n", style.Bold.Render("✓"), messageID)
+	if ttl > 0 {
+		fmt.Printf("  Lease expires: %s\n", time.Now().Add(ttl).Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("  Lease: %s\n", leaseString(ttl))
+	}
+	return nil
+}
+
+// leaseString describes a queue's claim lease for display.
+func leaseString(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "no expiry"
+	}
+	return ttl.String()
+}
+
+// claimTTLJSON is a queue's claim lease for JSON output: empty if claims
+// never expire.
+func claimTTLJSON(ttl time.Duration) string {
+	if ttl <= 0 {
+		return ""
+	}
+	return ttl.String()
+}
+
+// updateQueueMessageLabels adds and removes labels in a single bd update.
+func updateQueueMessageLabels(beadsDir, messageID, actor string, add, remove []string) error {
+	args := []string{"update", messageID}
+	for _, label := range add {
+		args = append(args, "--add-label="+label)
+	}
+	for _, label := range remove {
+		args = append(args, "--remove-label="+label)
+	}
+
+	cmd := exec.Command("bd", args...)
+	cmd.Env = append(os.Environ(),
+		"BEADS_DIR="+beadsDir,
+		"BD_ACTOR="+actor,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if errMsg != "" {
+			return fmt.Errorf("%s", errMsg)
+		}
+		return err
+	}
+	return nil
+}
+
+// claimExpiry is an expired claim and what expiring it will do.
+type claimExpiry struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Queue      string     `json:"queue"`
+	ClaimedBy  string     `json:"claimed_by,omitempty"`
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty"`
+	Attempts   int        `json:"attempts"`
+	DeadLetter bool       `json:"dead_letter"`
+}
+
+// planClaimExpiry returns the claims in msgs whose lease has run out. An
+// expired claim counts as a failed attempt; reaching maxAttempts
+// dead-letters the message. A ttl of 0 means claims never expire. Claim
+// labels without a claimant (left by an interrupted release) are cleared
+// without counting an attempt.
+func planClaimExpiry(msgs []queueMessage, ttl time.Duration, maxAttempts int, now time.Time) []claimExpiry {
+	var expired []claimExpiry
+	for _, msg := range msgs {
+		if len(msg.claimLabels) == 0 {
+			continue
+		}
+		if msg.ClaimedBy != "" && (ttl <= 0 || msg.ClaimedAt != nil && now.Before(msg.ClaimedAt.Add(ttl))) {
+			continue
+		}
+		exp := claimExpiry{
+			ID:        msg.ID,
+			Title:     msg.Title,
+			ClaimedBy: msg.ClaimedBy,
+			ClaimedAt: msg.ClaimedAt,
+			Attempts:  msg.ClaimAttempts,
+		}
+		if msg.ClaimedBy != "" {
+			exp.Attempts++
+			exp.DeadLetter = exp.Attempts >= maxAttempts
+		}
+		expired = append(expired, exp)
+	}
+	return expired
+}
+
+// runMailQueueExpire releases expired claims across all queues.
+func runMailQueueExpire(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	beadsDir := beads.ResolveBeadsDir(townRoot)
+	b := beads.NewWithBeadsDir(townRoot, beadsDir)
+
+	queues, err := b.ListQueueBeads()
+	if err != nil {
+		return fmt.Errorf("listing queues: %w", err)
+	}
+	ids := make([]string, 0, len(queues))
+	for id := range queues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	now := time.Now()
+	results := []claimExpiry{}
+	for _, id := range ids {
+		fields := beads.ParseQueueFields(queues[id].Description)
+		queueName := fields.Name
+		if queueName == "" {
+			queueName = id
+		}
+		msgs, err := listQueueMessages(beadsDir, queueName)
+		if err != nil {
+			return fmt.Errorf("listing queue %s: %w", queueName, err)
+		}
+		for _, exp := range planClaimExpiry(msgs, fields.LeaseTTL(), fields.ClaimAttemptLimit(), now) {
+			exp.Queue = queueName
+			if !mailQueueExpireDryRun {
+				done, err := expireQueueClaim(router, b, townRoot, beadsDir, &exp)
+				if err != nil {
+					style.PrintWarning("could not expire claim on %s: %v", exp.ID, err)
+					continue
+				}
+				if !done {
+					continue // Renewed or released since we listed it
+				}
+			}
+			results = append(results, exp)
+		}
+	}
+
+	if mailQueueExpireJSON {
+		jsonBytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	verb, deadVerb, clearVerb := "Released", "Dead-lettered", "Cleared"
+	if mailQueueExpireDryRun {
+		verb, deadVerb, clearVerb = "Would release", "Would dead-letter", "Would clear"
+	}
+	for _, exp := range results {
+		switch {
+		case exp.DeadLetter:
+			fmt.Printf("%s %s %s from %s after %d expired claims\n",
+				style.Bold.Render("✗"), deadVerb, exp.ID, exp.Queue, exp.Attempts)
+		case exp.ClaimedBy == "":
+			fmt.Printf("%s %s orphaned claim labels on %s\n", style.Dim.Render("○"), clearVerb, exp.ID)
+		default:
+			fmt.Printf("%s %s %s back to %s (claimed by %s, attempt %d)\n",
+				style.Bold.Render("✓"), verb, exp.ID, exp.Queue, exp.ClaimedBy, exp.Attempts)
+		}
+	}
+	return nil
+}
+
+// expireQueueClaim releases or dead-letters one expired claim. It re-reads
+// the message first and returns false if the claim changed since it was
+// listed, so a renewal racing the daemon wins.
+func expireQueueClaim(router *mail.Router, b *beads.Beads, townRoot, beadsDir string, exp *claimExpiry) (bool, error) {
+	info, err := getQueueMessageInfo(beadsDir, exp.ID)
+	if err != nil {
+		return false, err
+	}
+	if info.ClaimedBy != exp.ClaimedBy || !sameClaimTime(info.ClaimedAt, exp.ClaimedAt) {
+		return false, nil
+	}
+
+	const actor = "daemon"
+	remove := info.claimLabels
+	var add []string
+	if exp.Attempts != info.ClaimAttempts {
+		add = append(add, mail.ClaimAttemptsLabel(exp.Attempts))
+		if info.ClaimAttempts > 0 {
+			remove = append(remove, mail.ClaimAttemptsLabel(info.ClaimAttempts))
+		}
+	}
+
+	if exp.DeadLetter {
+		// Deliver the copy before closing the original: if we die in
+		// between, the next pass dead-letters it again rather than losing it.
+		msg, err := mail.NewMailboxWithBeadsDir("", townRoot, beadsDir).Get(exp.ID)
+		if err != nil {
+			return false, fmt.Errorf("reading message: %w", err)
+		}
+		msg.ClaimAttempts = exp.Attempts
+		reason := fmt.Errorf("claim expired %d times (last claimant %s)", exp.Attempts, exp.ClaimedBy)
+		if _, err := router.DeadLetterQueueMessage(msg, reason); err != nil {
+			return false, err
+		}
+		if err := updateQueueMessageLabels(beadsDir, exp.ID, actor, add, remove); err != nil {
+			return false, err
+		}
+		if err := b.CloseWithReason(reason.Error(), exp.ID); err != nil {
+			return false, fmt.Errorf("closing dead-lettered message: %w", err)
+		}
+	} else if err := updateQueueMessageLabels(beadsDir, exp.ID, actor, add, remove); err != nil {
+		return false, err
+	}
+
+	if exp.ClaimedBy != "" {
+		payload := events.ClaimExpiredPayload(exp.Queue, exp.ID, exp.ClaimedBy, exp.Attempts, exp.DeadLetter)
+		if exp.DeadLetter {
+			_ = events.LogFeed(events.TypeClaimExpired, actor, payload)
+		} else {
+			_ = events.LogAudit(events.TypeClaimExpired, actor, payload)
+		}
+	}
+	return true, nil
+}
+
+func sameClaimTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
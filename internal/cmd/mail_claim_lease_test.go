@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClaimLabels(t *testing.T) {
+	old := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	renewed := old.Add(20 * time.Minute)
+
+	by, at, attempts, labels := parseClaimLabels([]string{
+		"from:mayor/",
+		"queue:scavenge",
+		"claimed-by:gastown/nux",
+		"claimed-at:" + renewed.Format(time.RFC3339),
+		"claimed-at:" + old.Format(time.RFC3339), // left by an interrupted renewal
+		"claim-attempts:2",
+	})
+
+	if by != "gastown/nux" {
+		t.Errorf("claimedBy = %q, want gastown/nux", by)
+	}
+	if at == nil || !at.Equal(renewed) {
+		t.Errorf("claimedAt = %v, want latest %v", at, renewed)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	if len(labels) != 3 {
+		t.Errorf("claimLabels = %v, want claimed-by and both claimed-at", labels)
+	}
+}
+
+func TestPlanClaimExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	claim := func(id, by string, age time.Duration, attempts int) queueMessage {
+		at := now.Add(-age)
+		_, _, _, labels := parseClaimLabels([]string{"claimed-by:" + by, "claimed-at:" + at.Format(time.RFC3339)})
+		return queueMessage{ID: id, ClaimedBy: by, ClaimedAt: &at, ClaimAttempts: attempts, claimLabels: labels}
+	}
+	orphanAt := now.Add(-5 * time.Minute)
+
+	msgs := []queueMessage{
+		{ID: "hq-unclaimed"},
+		claim("hq-fresh", "gastown/nux", 10*time.Minute, 0),
+		claim("hq-stale", "gastown/nux", 2*time.Hour, 0),
+		claim("hq-last", "gastown/toast", time.Hour, 2),
+		{ID: "hq-orphan", ClaimedAt: &orphanAt, claimLabels: []string{"claimed-at:" + orphanAt.Format(time.RFC3339)}},
+	}
+
+	got := planClaimExpiry(msgs, time.Hour, 3, now)
+	want := map[string]struct {
+		attempts   int
+		deadLetter bool
+	}{
+		"hq-stale":  {1, false},
+		"hq-last":   {3, true},
+		"hq-orphan": {0, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("planClaimExpiry returned %d claims, want %d: %+v", len(got), len(want), got)
+	}
+	for _, exp := range got {
+		w, ok := want[exp.ID]
+		if !ok {
+			t.Errorf("unexpected expiry for %s", exp.ID)
+			continue
+		}
+		if exp.Attempts != w.attempts || exp.DeadLetter != w.deadLetter {
+			t.Errorf("%s: attempts=%d deadLetter=%v, want %d/%v", exp.ID, exp.Attempts, exp.DeadLetter, w.attempts, w.deadLetter)
+		}
+	}
+
+	// Without a claim_ttl, claims never expire; orphaned labels still clear.
+	if got := planClaimExpiry(msgs, 0, 3, now); len(got) != 1 || got[0].ID != "hq-orphan" {
+		t.Errorf("planClaimExpiry with no TTL = %+v, want only hq-orphan", got)
+	}
+}
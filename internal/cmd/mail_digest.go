@@ -0,0 +1,539 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/events"
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Mail digest flags
+var (
+	mailDigestWeekly bool
+	mailDigestDryRun bool
+	mailDigestIfDue  bool
+	mailDigestJSON   bool
+	mailDigestHTML   bool
+	mailDigestSend   []string
+)
+
+// mailDigestHour is the local hour after which --if-due produces the day's
+// digest (and the weekly one, once the last is a week old).
+const mailDigestHour = 8
+
+var mailDigestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Compile a human-readable digest for the overseer",
+	Long: `Compile a digest of what needs the overseer's attention.
+
+The overseer gets the same raw mail stream as agents. The digest condenses
+a period (the last day, or week with --weekly) into one report:
+
+  - Unread high-priority and urgent mail in the overseer's inbox
+  - Open escalations
+  - Progress of open convoys
+  - Merges landed and agent deaths, from the town event log
+
+The digest is rendered as Markdown and HTML. It is saved as an event bead
+(category mail.digest) with the Markdown as its description, and the HTML
+is written under the town runtime dir.
+
+--send delivers it through an outbound channel:
+  mail    The overseer's inbox
+  slack   contacts.slack_webhook in settings/escalation.json
+
+The daemon runs 'gt mail digest --if-due --send mail' every heartbeat;
+--if-due makes that a no-op before 08:00 local time, once the day's
+digest exists, and (with --weekly) until the last weekly digest is 7
+days old.
+
+Examples:
+  gt mail digest --dry-run          # Print today's digest, save nothing
+  gt mail digest --weekly --send mail
+  gt mail digest --dry-run --html > digest.html`,
+	Args: cobra.NoArgs,
+	RunE: runMailDigest,
+}
+
+func init() {
+	mailDigestCmd.Flags().BoolVar(&mailDigestWeekly, "weekly", false, "Cover the last 7 days instead of the last day")
+	mailDigestCmd.Flags().BoolVarP(&mailDigestDryRun, "dry-run", "n", false, "Print the digest without saving or sending it")
+	mailDigestCmd.Flags().BoolVar(&mailDigestIfDue, "if-due", false, "Only run if this period's digest is due")
+	mailDigestCmd.Flags().BoolVar(&mailDigestJSON, "json", false, "Print the digest data as JSON (with --dry-run)")
+	mailDigestCmd.Flags().BoolVar(&mailDigestHTML, "html", false, "Print HTML instead of Markdown (with --dry-run)")
+	mailDigestCmd.Flags().StringSliceVar(&mailDigestSend, "send", nil, "Deliver through outbound channels: mail, slack")
+
+	mailCmd.AddCommand(mailDigestCmd)
+}
+
+// mailDigest is the data behind one overseer digest.
+type mailDigest struct {
+	Period      string             `json:"period"` // daily, weekly
+	Start       time.Time          `json:"start"`
+	End         time.Time          `json:"end"`
+	UnreadTotal int                `json:"unread_total"`
+	Unread      []*mail.Message    `json:"unread,omitempty"` // high priority and urgent only
+	Escalations []digestEscalation `json:"escalations,omitempty"`
+	Convoys     []digestConvoy     `json:"convoys,omitempty"`
+	Merges      []digestEvent      `json:"merges,omitempty"`
+	Deaths      []digestEvent      `json:"deaths,omitempty"`
+	Errors      []string           `json:"errors,omitempty"`
+}
+
+// digestEscalation is an open escalation in the digest.
+type digestEscalation struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Severity    string `json:"severity"`
+	EscalatedBy string `json:"escalated_by,omitempty"`
+}
+
+// digestConvoy is an open convoy's progress.
+type digestConvoy struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+// digestEvent is a merge or death from the town event log.
+type digestEvent struct {
+	At      time.Time `json:"at"`
+	Actor   string    `json:"actor"`
+	Summary string    `json:"summary"`
+}
+
+func runMailDigest(cmd *cobra.Command, args []string) error {
+	for _, ch := range mailDigestSend {
+		if ch != "mail" && ch != "slack" {
+			return fmt.Errorf("invalid --send %q: must be mail or slack", ch)
+		}
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	now := time.Now()
+	period := "daily"
+	if mailDigestWeekly {
+		period = "weekly"
+	}
+	today := now.Format("2006-01-02")
+
+	if mailDigestIfDue {
+		_, last, err := findDigestBead(townRoot, mailDigestTitlePrefix(period))
+		if err != nil {
+			return fmt.Errorf("checking last %s digest: %w", period, err)
+		}
+		if !mailDigestDue(period, last, now) {
+			return nil
+		}
+	}
+
+	digest := collectMailDigest(townRoot, period, now)
+	markdown := renderMailDigestMarkdown(digest)
+
+	if mailDigestDryRun {
+		switch {
+		case mailDigestJSON:
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(digest)
+		case mailDigestHTML:
+			html, err := renderMailDigestHTML(digest)
+			if err != nil {
+				return err
+			}
+			fmt.Print(html)
+		default:
+			fmt.Print(markdown)
+		}
+		return nil
+	}
+
+	beadID, err := createDigestBead(townRoot, mailDigestTitle(digest), "mail.digest", mailDigestPayload(digest), markdown, period+" overseer digest")
+	if err != nil {
+		return err
+	}
+	html, err := renderMailDigestHTML(digest)
+	if err != nil {
+		return err
+	}
+	htmlPath := filepath.Join(townRoot, constants.DirRuntime, "digests", fmt.Sprintf("%s-%s.html", period, today))
+	if err := os.MkdirAll(filepath.Dir(htmlPath), 0755); err != nil {
+		return fmt.Errorf("creating digest dir: %w", err)
+	}
+	if err := os.WriteFile(htmlPath, []byte(html), 0644); err != nil { //nolint:gosec // G306: digest is not sensitive
+		return fmt.Errorf("writing HTML digest: %w", err)
+	}
+
+	fmt.Printf("%s Created %s digest %s\n", style.Success.Render("✓"), period, beadID)
+	fmt.Printf("  HTML: %s\n", htmlPath)
+
+	for _, ch := range mailDigestSend {
+		if err := sendMailDigest(townRoot, ch, digest, markdown); err != nil {
+			style.PrintWarning("could not send digest via %s: %v", ch, err)
+			continue
+		}
+		fmt.Printf("  Sent via %s\n", ch)
+	}
+	return nil
+}
+
+// mailDigestDue reports whether the period's digest should be produced now,
+// given when the last one was (zero if never). The daily digest is due once
+// a day; the weekly one once the last is 7 days old, whatever the weekday.
+func mailDigestDue(period string, last, now time.Time) bool {
+	if now.Hour() < mailDigestHour {
+		return false
+	}
+	if last.IsZero() {
+		return true
+	}
+	lastDay := last.Local().Format("2006-01-02")
+	if period == "weekly" {
+		return lastDay <= now.AddDate(0, 0, -7).Format("2006-01-02")
+	}
+	return lastDay != now.Format("2006-01-02")
+}
+
+// collectMailDigest gathers the digest for the period ending at end. Each
+// source is best-effort: a failure is noted in the digest, not fatal.
+func collectMailDigest(townRoot, period string, end time.Time) *mailDigest {
+	start := end.AddDate(0, 0, -1)
+	if period == "weekly" {
+		start = end.AddDate(0, 0, -7)
+	}
+	d := &mailDigest{Period: period, Start: start, End: end}
+
+	// Unread high-priority mail
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	if mailbox, err := router.GetMailbox("overseer"); err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("overseer mailbox: %v", err))
+	} else if unread, err := mailbox.ListUnread(); err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("overseer mailbox: %v", err))
+	} else {
+		d.UnreadTotal = len(unread)
+		for _, msg := range unread {
+			if msg.Priority == mail.PriorityHigh || msg.Priority == mail.PriorityUrgent {
+				d.Unread = append(d.Unread, msg)
+			}
+		}
+	}
+
+	// Open escalations
+	townBeads := filepath.Join(townRoot, ".beads")
+	if issues, err := beads.New(townBeads).ListEscalations(); err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("escalations: %v", err))
+	} else {
+		for _, issue := range issues {
+			fields := beads.ParseEscalationFields(issue.Description)
+			d.Escalations = append(d.Escalations, digestEscalation{
+				ID:          issue.ID,
+				Title:       issue.Title,
+				Severity:    fields.Severity,
+				EscalatedBy: fields.EscalatedBy,
+			})
+		}
+	}
+
+	// Convoy progress
+	convoys, err := collectDigestConvoys(townBeads)
+	if err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("convoys: %v", err))
+	}
+	d.Convoys = convoys
+
+	// Merges and deaths
+	merges, deaths, err := readDigestEvents(filepath.Join(townRoot, events.EventsFile), start, end)
+	if err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("events: %v", err))
+	}
+	d.Merges, d.Deaths = merges, deaths
+
+	return d
+}
+
+// collectDigestConvoys returns the progress of every open convoy.
+func collectDigestConvoys(townBeads string) ([]digestConvoy, error) {
+	listCmd := exec.Command("bd", "list", "--type=convoy", "--status=open", "--json")
+	listCmd.Dir = townBeads
+	out, err := listCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing convoys: %w", err)
+	}
+	var convoys []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(out, &convoys); err != nil {
+		return nil, fmt.Errorf("parsing convoy list: %w", err)
+	}
+
+	var result []digestConvoy
+	for _, c := range convoys {
+		tracked, err := getTrackedIssues(townBeads, c.ID)
+		if err != nil {
+			continue
+		}
+		dc := digestConvoy{ID: c.ID, Title: c.Title, Total: len(tracked)}
+		for _, t := range tracked {
+			if t.Status == "closed" {
+				dc.Done++
+			}
+		}
+		result = append(result, dc)
+	}
+	return result, nil
+}
+
+// readDigestEvents returns the merges and agent deaths logged between
+// start and end.
+func readDigestEvents(path string, start, end time.Time) (merges, deaths []digestEvent, err error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is the town events log
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		at, perr := time.Parse(time.RFC3339, e.Timestamp)
+		if perr != nil || at.Before(start) || at.After(end) {
+			continue
+		}
+		p := func(key string) string {
+			s, _ := e.Payload[key].(string)
+			return s
+		}
+
+		switch e.Type {
+		case events.TypeMerged:
+			summary := p("branch")
+			if worker := p("worker"); worker != "" {
+				summary += " by " + worker
+			}
+			if mr := p("mr"); mr != "" {
+				summary += " (" + mr + ")"
+			}
+			merges = append(merges, digestEvent{At: at, Actor: e.Actor, Summary: summary})
+		case events.TypeSessionDeath:
+			agent := p("agent")
+			if agent == "" {
+				agent = p("session")
+			}
+			summary := agent
+			if reason := p("reason"); reason != "" {
+				summary += ": " + reason
+			}
+			deaths = append(deaths, digestEvent{At: at, Actor: e.Actor, Summary: summary})
+		case events.TypeMassDeath:
+			count, _ := e.Payload["count"].(float64)
+			summary := fmt.Sprintf("%d sessions died within %s", int(count), p("window"))
+			if cause := p("possible_cause"); cause != "" {
+				summary += ": " + cause
+			}
+			deaths = append(deaths, digestEvent{At: at, Actor: e.Actor, Summary: summary})
+		}
+	}
+	return merges, deaths, scanner.Err()
+}
+
+// mailDigestTitle is the digest's subject line and bead title.
+func mailDigestTitle(d *mailDigest) string {
+	if d.Period == "weekly" {
+		return fmt.Sprintf("%s%s to %s", mailDigestTitlePrefix(d.Period), d.Start.Format("2006-01-02"), d.End.Format("2006-01-02"))
+	}
+	return mailDigestTitlePrefix(d.Period) + d.End.Format("2006-01-02")
+}
+
+// mailDigestTitlePrefix is the title shared by every digest of a period.
+func mailDigestTitlePrefix(period string) string {
+	return fmt.Sprintf("Gas Town %s digest ", period)
+}
+
+// renderMailDigestMarkdown renders the digest as Markdown.
+func renderMailDigestMarkdown(d *mailDigest) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s\n\n", mailDigestTitle(d)))
+	sb.WriteString(fmt.Sprintf("Covers %s to %s.\n", d.Start.Format("Jan 2 15:04"), d.End.Format("Jan 2 15:04")))
+
+	none := func(items int) {
+		if items == 0 {
+			sb.WriteString("_None._\n")
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n## Unread high-priority mail (%d of %d unread)\n\n", len(d.Unread), d.UnreadTotal))
+	for _, msg := range d.Unread {
+		sb.WriteString(fmt.Sprintf("- **%s** from %s, %s (`%s`)\n", msg.Subject, msg.From, msg.Timestamp.Format("Jan 2 15:04"), msg.ID))
+	}
+	none(len(d.Unread))
+
+	sb.WriteString(fmt.Sprintf("\n## Open escalations (%d)\n\n", len(d.Escalations)))
+	for _, e := range d.Escalations {
+		sb.WriteString(fmt.Sprintf("- [%s] %s", strings.ToUpper(e.Severity), e.Title))
+		if e.EscalatedBy != "" {
+			sb.WriteString(" by " + e.EscalatedBy)
+		}
+		sb.WriteString(fmt.Sprintf(" (`%s`)\n", e.ID))
+	}
+	none(len(d.Escalations))
+
+	sb.WriteString(fmt.Sprintf("\n## Convoy progress (%d open)\n\n", len(d.Convoys)))
+	for _, c := range d.Convoys {
+		sb.WriteString(fmt.Sprintf("- %s: %d/%d done (`%s`)\n", c.Title, c.Done, c.Total, c.ID))
+	}
+	none(len(d.Convoys))
+
+	sb.WriteString(fmt.Sprintf("\n## Merges landed (%d)\n\n", len(d.Merges)))
+	for _, e := range d.Merges {
+		sb.WriteString(fmt.Sprintf("- %s %s\n", e.At.Local().Format("Jan 2 15:04"), e.Summary))
+	}
+	none(len(d.Merges))
+
+	sb.WriteString(fmt.Sprintf("\n## Agent deaths (%d)\n\n", len(d.Deaths)))
+	for _, e := range d.Deaths {
+		sb.WriteString(fmt.Sprintf("- %s %s\n", e.At.Local().Format("Jan 2 15:04"), e.Summary))
+	}
+	none(len(d.Deaths))
+
+	if len(d.Errors) > 0 {
+		sb.WriteString("\n## Incomplete sections\n\n")
+		for _, e := range d.Errors {
+			sb.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+	}
+	return sb.String()
+}
+
+var mailDigestHTMLTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"when":  func(t time.Time) string { return t.Local().Format("Jan 2 15:04") },
+	"upper": strings.ToUpper,
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body style="font-family: sans-serif; max-width: 48em">
+<h1>{{.Title}}</h1>
+<p>Covers {{when .D.Start}} to {{when .D.End}}.</p>
+<h2>Unread high-priority mail ({{len .D.Unread}} of {{.D.UnreadTotal}} unread)</h2>
+{{if .D.Unread}}<ul>{{range .D.Unread}}
+<li><b>{{.Subject}}</b> from {{.From}}, {{when .Timestamp}} (<code>{{.ID}}</code>)</li>{{end}}
+</ul>{{else}}<p><i>None.</i></p>{{end}}
+<h2>Open escalations ({{len .D.Escalations}})</h2>
+{{if .D.Escalations}}<ul>{{range .D.Escalations}}
+<li>[{{upper .Severity}}] {{.Title}}{{if .EscalatedBy}} by {{.EscalatedBy}}{{end}} (<code>{{.ID}}</code>)</li>{{end}}
+</ul>{{else}}<p><i>None.</i></p>{{end}}
+<h2>Convoy progress ({{len .D.Convoys}} open)</h2>
+{{if .D.Convoys}}<ul>{{range .D.Convoys}}
+<li>{{.Title}}: {{.Done}}/{{.Total}} done (<code>{{.ID}}</code>)</li>{{end}}
+</ul>{{else}}<p><i>None.</i></p>{{end}}
+<h2>Merges landed ({{len .D.Merges}})</h2>
+{{if .D.Merges}}<ul>{{range .D.Merges}}
+<li>{{when .At}} {{.Summary}}</li>{{end}}
+</ul>{{else}}<p><i>None.</i></p>{{end}}
+<h2>Agent deaths ({{len .D.Deaths}})</h2>
+{{if .D.Deaths}}<ul>{{range .D.Deaths}}
+<li>{{when .At}} {{.Summary}}</li>{{end}}
+</ul>{{else}}<p><i>None.</i></p>{{end}}
+{{if .D.Errors}}<h2>Incomplete sections</h2>
+<ul>{{range .D.Errors}}
+<li>{{.}}</li>{{end}}
+</ul>{{end}}
+</body></html>
+`))
+
+// renderMailDigestHTML renders the digest as a standalone HTML page.
+func renderMailDigestHTML(d *mailDigest) (string, error) {
+	var buf bytes.Buffer
+	data := struct {
+		Title string
+		D     *mailDigest
+	}{mailDigestTitle(d), d}
+	if err := mailDigestHTMLTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering HTML digest: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// mailDigestPayload is the event payload of a digest bead.
+func mailDigestPayload(d *mailDigest) map[string]interface{} {
+	return map[string]interface{}{
+		"period":       d.Period,
+		"start":        d.Start.Format(time.RFC3339),
+		"end":          d.End.Format(time.RFC3339),
+		"unread":       d.UnreadTotal,
+		"unread_high":  len(d.Unread),
+		"escalations":  len(d.Escalations),
+		"convoys":      len(d.Convoys),
+		"merges":       len(d.Merges),
+		"agent_deaths": len(d.Deaths),
+	}
+}
+
+// sendMailDigest delivers the digest through one outbound channel.
+func sendMailDigest(townRoot, channel string, d *mailDigest, markdown string) error {
+	switch channel {
+	case "mail":
+		router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+		defer router.WaitPendingNotifications()
+		return router.Send(&mail.Message{
+			From:           "daemon",
+			To:             "overseer",
+			Subject:        mailDigestTitle(d),
+			Body:           markdown,
+			Type:           mail.TypeNotification,
+			Priority:       mail.PriorityNormal,
+			SuppressNotify: true,
+		})
+
+	case "slack":
+		cfg, err := config.LoadOrCreateEscalationConfig(config.EscalationConfigPath(townRoot))
+		if err != nil {
+			return fmt.Errorf("loading escalation config: %w", err)
+		}
+		if cfg.Contacts.SlackWebhook == "" {
+			return fmt.Errorf("contacts.slack_webhook not configured in settings/escalation.json")
+		}
+		body, err := json.Marshal(map[string]string{"text": markdown})
+		if err != nil {
+			return err
+		}
+		client := &http.Client{Timeout: 15 * time.Second}
+		resp, err := client.Post(cfg.Contacts.SlackWebhook, "application/json", bytes.NewReader(body)) //nolint:gosec // G107: webhook URL comes from town config
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("slack webhook returned %s", resp.Status)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown channel %q", channel)
+}
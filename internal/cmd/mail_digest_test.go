@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+)
+
+func TestReadDigestEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	lines := []string{
+		`{"ts":"2026-03-01T09:00:00Z","type":"merged","actor":"gastown/refinery","payload":{"mr":"gt-mr1","worker":"nux","branch":"polecat/nux"}}`,
+		`{"ts":"2026-03-01T10:00:00Z","type":"session_death","actor":"daemon","payload":{"session":"gt-gastown-toast","agent":"gastown/polecats/toast","reason":"zombie cleanup"}}`,
+		`{"ts":"2026-03-01T11:00:00Z","type":"mass_death","actor":"daemon","payload":{"count":3,"window":"5s","possible_cause":"tmux restart"}}`,
+		`{"ts":"2026-03-01T12:00:00Z","type":"sling","actor":"mayor","payload":{}}`,
+		`{"ts":"2026-02-20T09:00:00Z","type":"merged","actor":"gastown/refinery","payload":{"branch":"old"}}`,
+		`not json`,
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	merges, deaths, err := readDigestEvents(path, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("readDigestEvents: %v", err)
+	}
+	if len(merges) != 1 || merges[0].Summary != "polecat/nux by nux (gt-mr1)" {
+		t.Errorf("merges = %+v", merges)
+	}
+	if len(deaths) != 2 {
+		t.Fatalf("deaths = %+v", deaths)
+	}
+	if deaths[0].Summary != "gastown/polecats/toast: zombie cleanup" {
+		t.Errorf("deaths[0] = %q", deaths[0].Summary)
+	}
+	if deaths[1].Summary != "3 sessions died within 5s: tmux restart" {
+		t.Errorf("deaths[1] = %q", deaths[1].Summary)
+	}
+
+	if m, d, err := readDigestEvents(filepath.Join(t.TempDir(), "missing"), start, start); err != nil || m != nil || d != nil {
+		t.Errorf("missing log = %v, %v, %v", m, d, err)
+	}
+}
+
+func TestRenderMailDigest(t *testing.T) {
+	end := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	d := &mailDigest{
+		Period:      "daily",
+		Start:       end.AddDate(0, 0, -1),
+		End:         end,
+		UnreadTotal: 4,
+		Unread:      []*mail.Message{{ID: "hq-1", From: "mayor/", Subject: "Approve <deploy>", Priority: mail.PriorityUrgent, Timestamp: end}},
+		Escalations: []digestEscalation{{ID: "hq-e1", Title: "Refinery stuck", Severity: "high", EscalatedBy: "gastown/witness"}},
+		Convoys:     []digestConvoy{{ID: "hq-cv1", Title: "Auth rewrite", Done: 3, Total: 5}},
+	}
+
+	md := renderMailDigestMarkdown(d)
+	for _, want := range []string{
+		"# Gas Town daily digest 2026-03-02",
+		"## Unread high-priority mail (1 of 4 unread)",
+		"- **Approve <deploy>** from mayor/",
+		"- [HIGH] Refinery stuck by gastown/witness (`hq-e1`)",
+		"- Auth rewrite: 3/5 done (`hq-cv1`)",
+		"## Merges landed (0)\n\n_None._",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	html, err := renderMailDigestHTML(d)
+	if err != nil {
+		t.Fatalf("renderMailDigestHTML: %v", err)
+	}
+	if !strings.Contains(html, "Approve &lt;deploy&gt;") || strings.Contains(html, "<deploy>") {
+		t.Error("HTML digest does not escape message subjects")
+	}
+	if !strings.Contains(html, "<li>Auth rewrite: 3/5 done") {
+		t.Errorf("HTML missing convoy progress:\n%s", html)
+	}
+}
+
+func TestMailDigestDue(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		period string
+		last   time.Time
+		now    time.Time
+		want   bool
+	}{
+		{"daily before hour", "daily", time.Time{}, monday.Add(-2 * time.Hour), false},
+		{"daily never sent", "daily", time.Time{}, monday, true},
+		{"daily due", "daily", monday.AddDate(0, 0, -1), monday, true},
+		{"daily already sent", "daily", monday.Add(-time.Hour), monday, false},
+		{"weekly never sent", "weekly", time.Time{}, monday.AddDate(0, 0, 2), true},
+		{"weekly within week", "weekly", monday, monday.AddDate(0, 0, 6), false},
+		{"weekly a week old", "weekly", monday, monday.AddDate(0, 0, 7).Add(-time.Hour), true},
+		{"weekly missed monday", "weekly", monday, monday.AddDate(0, 0, 9), true},
+	}
+	for _, tt := range tests {
+		if got := mailDigestDue(tt.period, tt.last, tt.now); got != tt.want {
+			t.Errorf("%s: mailDigestDue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
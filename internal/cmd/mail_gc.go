@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Mail GC command flags
+var (
+	mailGCPurgeDays int
+	mailGCDryRun    bool
+)
+
+// mailGCGrace protects blobs stored by a send that is still in flight.
+const mailGCGrace = time.Hour
+
+var mailGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Purge old archived mail and unreferenced attachments",
+	Long: `Garbage-collect mail attachments.
+
+Attachment content lives in the town runtime dir, shared by every message
+that references it. A blob is kept while any open message, archived
+message or scheduled delivery references it, and removed once the last
+reference is deleted or purged from the archive. The daemon runs this
+every six hours; run it by hand to reclaim space sooner.
+
+--purge-archive removes archived messages older than N days first, so
+their attachments can be collected in the same run.
+
+Examples:
+  gt mail gc                       # Remove unreferenced attachments
+  gt mail gc --purge-archive 30    # Also purge archive entries older than 30 days
+  gt mail gc --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runMailGC,
+}
+
+func init() {
+	mailGCCmd.Flags().IntVar(&mailGCPurgeDays, "purge-archive", -1, "Purge archived messages older than N days (0 = all) before collecting")
+	mailGCCmd.Flags().BoolVarP(&mailGCDryRun, "dry-run", "n", false, "Show what would be removed without removing it")
+
+	mailCmd.AddCommand(mailGCCmd)
+}
+
+func runMailGC(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+
+	if mailGCPurgeDays >= 0 {
+		if mailGCDryRun {
+			fmt.Printf("%s would purge archived messages older than %d day(s)\n", style.Dim.Render("(dry run)"), mailGCPurgeDays)
+		} else {
+			mailbox, err := router.GetMailbox("overseer") // The archive is shared town-wide
+			if err != nil {
+				return err
+			}
+			purged, err := mailbox.PurgeArchive(mailGCPurgeDays)
+			if err != nil {
+				return fmt.Errorf("purging archive: %w", err)
+			}
+			fmt.Printf("%s Purged %d archived message(s)\n", style.Bold.Render("✓"), purged)
+		}
+	}
+
+	removed, freed, err := router.CollectAttachmentGarbage(mailGCGrace, mailGCDryRun)
+	if err != nil {
+		return fmt.Errorf("collecting attachments: %w", err)
+	}
+	verb := "Removed"
+	if mailGCDryRun {
+		verb = "Would remove"
+	}
+	fmt.Printf("%s %s %d unreferenced attachment(s), %s\n", style.Bold.Render("✓"), verb, removed, formatBytes(freed))
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
 )
 
 // getMailbox returns the mailbox for the given address.
@@ -175,6 +177,18 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		style.PrintWarning("could not mark message as read: %v", err)
 	}
 
+	// Attachment output instead of the message
+	if mailReadAttach != "" || mailReadExtract != "" {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		if mailReadExtract != "" {
+			return extractMailAttachments(townRoot, msg, mailReadExtract)
+		}
+		return printMailAttachment(townRoot, msg, mailReadAttach)
+	}
+
 	// JSON output
 	if mailReadJSON {
 		enc := json.NewEncoder(os.Stdout)
@@ -219,6 +233,14 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n%s\n", msg.Body)
 	}
 
+	if len(msg.Attachments) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Attachments:"))
+		for i, a := range msg.Attachments {
+			fmt.Printf("  %d. %s %s\n", i+1, a.Name, style.Dim.Render(fmt.Sprintf("(%s, %s)", a.Kind, formatBytes(a.Size))))
+		}
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("  View with: gt mail read %s --attachment <n>", msg.ID)))
+	}
+
 	// Ack after output (non-fatal).
 	if ackErr := mailbox.AcknowledgeDeliveries(address, []*mail.Message{msg}); ackErr != nil {
 		fmt.Fprintf(os.Stderr, "gt mail read: delivery ack failed: %v\n", ackErr)
@@ -582,3 +604,55 @@ func runMailClear(cmd *cobra.Command, args []string) error {
 		style.Bold.Render("✓"), deleted, address)
 	return nil
 }
+
+// findMailAttachment resolves an attachment by 1-based number or name.
+func findMailAttachment(msg *mail.Message, ref string) (mail.Attachment, error) {
+	if idx, err := strconv.Atoi(ref); err == nil {
+		if idx < 1 || idx > len(msg.Attachments) {
+			return mail.Attachment{}, fmt.Errorf("attachment %d out of range (message has %d)", idx, len(msg.Attachments))
+		}
+		return msg.Attachments[idx-1], nil
+	}
+	for _, a := range msg.Attachments {
+		if a.Name == ref {
+			return a, nil
+		}
+	}
+	return mail.Attachment{}, fmt.Errorf("message %s has no attachment %q", msg.ID, ref)
+}
+
+// printMailAttachment writes one attachment's content to stdout.
+func printMailAttachment(townRoot string, msg *mail.Message, ref string) error {
+	a, err := findMailAttachment(msg, ref)
+	if err != nil {
+		return err
+	}
+	content, err := mail.ReadAttachment(townRoot, a)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(content)
+	return err
+}
+
+// extractMailAttachments writes every attachment into dir.
+func extractMailAttachments(townRoot string, msg *mail.Message, dir string) error {
+	if len(msg.Attachments) == 0 {
+		return fmt.Errorf("message %s has no attachments", msg.ID)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, a := range msg.Attachments {
+		content, err := mail.ReadAttachment(townRoot, a)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.Base(a.Name))
+		if err := os.WriteFile(path, content, 0644); err != nil { //nolint:gosec // G306: extracted mail content is not sensitive
+			return err
+		}
+		fmt.Printf("%s %s\n", style.Bold.Render("✓"), path)
+	}
+	return nil
+}
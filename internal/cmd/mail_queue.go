@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,13 @@ func runMailClaim(cmd *cobra.Command, args []string) error {
 	beadsDir := beads.ResolveBeadsDir(townRoot)
 	bd := beads.NewWithBeadsDir(townRoot, beadsDir)
 
+	if mailClaimRenew {
+		if len(args) == 0 {
+			return fmt.Errorf("--renew requires a message ID")
+		}
+		return renewQueueClaim(bd, beadsDir, args[0], caller)
+	}
+
 	var queueName string
 	var queueFields *beads.QueueFields
 
@@ -39,22 +47,9 @@ func runMailClaim(cmd *cobra.Command, args []string) error {
 		// Specific queue requested
 		queueName = args[0]
 
-		// Look up the queue bead
-		queueID := beads.QueueBeadID(queueName, true) // Try town-level first
-		issue, fields, err := bd.GetQueueBead(queueID)
+		fields, err := lookupQueue(bd, queueName)
 		if err != nil {
-			return fmt.Errorf("looking up queue: %w", err)
-		}
-		if issue == nil {
-			// Try rig-level
-			queueID = beads.QueueBeadID(queueName, false)
-			issue, fields, err = bd.GetQueueBead(queueID)
-			if err != nil {
-				return fmt.Errorf("looking up queue: %w", err)
-			}
-			if issue == nil {
-				return fmt.Errorf("unknown queue: %s", queueName)
-			}
+			return err
 		}
 		queueFields = fields
 
@@ -154,10 +149,28 @@ func runMailClaim(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("  From: %s\n", claimed.From)
 	fmt.Printf("  Created: %s\n", claimed.Created.Format("2006-01-02 15:04"))
+	if ttl := queueFields.LeaseTTL(); ttl > 0 {
+		fmt.Printf("  Lease: %s %s\n", ttl,
+			style.Dim.Render("(renew with: gt mail claim --renew "+claimed.ID+")"))
+	}
 
 	return nil
 }
 
+// lookupQueue finds a queue bead by name, trying town level before rig level.
+func lookupQueue(bd *beads.Beads, queueName string) (*beads.QueueFields, error) {
+	for _, townLevel := range []bool{true, false} {
+		issue, fields, err := bd.GetQueueBead(beads.QueueBeadID(queueName, townLevel))
+		if err != nil {
+			return nil, fmt.Errorf("looking up queue: %w", err)
+		}
+		if issue != nil {
+			return fields, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown queue: %s", queueName)
+}
+
 // queueMessage represents a message in a queue.
 type queueMessage struct {
 	ID          string
@@ -168,11 +181,35 @@ type queueMessage struct {
 	Priority    int
 	ClaimedBy   string
 	ClaimedAt   *time.Time
+	// ClaimAttempts counts claims on this message that expired.
+	ClaimAttempts int
+	// claimLabels holds every claimed-by/claimed-at label, so a release
+	// also clears stale labels left by an interrupted renewal.
+	claimLabels []string
 }
 
 // listUnclaimedQueueMessages lists unclaimed messages in a queue.
 // Unclaimed messages have queue:<name> label but no claimed-by label.
 func listUnclaimedQueueMessages(beadsDir, queueName string) ([]queueMessage, error) {
+	all, err := listQueueMessages(beadsDir, queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only include unclaimed messages - check both ClaimedBy and ClaimedAt
+	// to handle orphaned claimed-at labels from interrupted releases
+	var messages []queueMessage
+	for _, msg := range all {
+		if msg.ClaimedBy == "" && msg.ClaimedAt == nil {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// listQueueMessages lists the open messages in a queue, claimed or not,
+// oldest first.
+func listQueueMessages(beadsDir, queueName string) ([]queueMessage, error) {
 	// Use bd list to find messages with queue:<name> label and status=open
 	args := []string{"list",
 		"--label", "queue:" + queueName,
@@ -215,7 +252,7 @@ func listUnclaimedQueueMessages(beadsDir, queueName string) ([]queueMessage, err
 		return nil, fmt.Errorf("parsing bd output: %w", err)
 	}
 
-	// Convert to queueMessage, filtering out already claimed messages
+	// Convert to queueMessage
 	var messages []queueMessage
 	for _, issue := range issues {
 		msg := queueMessage{
@@ -230,20 +267,10 @@ func listUnclaimedQueueMessages(beadsDir, queueName string) ([]queueMessage, err
 		for _, label := range issue.Labels {
 			if strings.HasPrefix(label, "from:") {
 				msg.From = strings.TrimPrefix(label, "from:")
-			} else if strings.HasPrefix(label, "claimed-by:") {
-				msg.ClaimedBy = strings.TrimPrefix(label, "claimed-by:")
-			} else if strings.HasPrefix(label, "claimed-at:") {
-				ts := strings.TrimPrefix(label, "claimed-at:")
-				if t, err := time.Parse(time.RFC3339, ts); err == nil {
-					msg.ClaimedAt = &t
-				}
 			}
 		}
-		// Only include unclaimed messages - check both ClaimedBy and ClaimedAt
-		// to handle orphaned claimed-at labels from interrupted releases
-		if msg.ClaimedBy == "" && msg.ClaimedAt == nil {
-			messages = append(messages, msg)
-		}
+		msg.ClaimedBy, msg.ClaimedAt, msg.ClaimAttempts, msg.claimLabels = parseClaimLabels(issue.Labels)
+		messages = append(messages, msg)
 	}
 
 	// Sort by created time (oldest first) for FIFO ordering
@@ -254,6 +281,29 @@ func listUnclaimedQueueMessages(beadsDir, queueName string) ([]queueMessage, err
 	return messages, nil
 }
 
+// parseClaimLabels extracts claim state from a queue message's labels.
+// A renewal adds the new claimed-at label before removing the old one, so
+// when several are present the latest wins.
+func parseClaimLabels(labels []string) (claimedBy string, claimedAt *time.Time, attempts int, claimLabels []string) {
+	for _, label := range labels {
+		if strings.HasPrefix(label, "claimed-by:") {
+			claimedBy = strings.TrimPrefix(label, "claimed-by:")
+			claimLabels = append(claimLabels, label)
+		} else if strings.HasPrefix(label, "claimed-at:") {
+			claimLabels = append(claimLabels, label)
+			ts := strings.TrimPrefix(label, "claimed-at:")
+			if t, err := time.Parse(time.RFC3339, ts); err == nil && (claimedAt == nil || t.After(*claimedAt)) {
+				claimedAt = &t
+			}
+		} else if strings.HasPrefix(label, mail.ClaimAttemptsLabelPrefix) {
+			if n, err := strconv.Atoi(strings.TrimPrefix(label, mail.ClaimAttemptsLabelPrefix)); err == nil {
+				attempts = n
+			}
+		}
+	}
+	return claimedBy, claimedAt, attempts, claimLabels
+}
+
 // claimQueueMessage claims a message by adding claimed-by and claimed-at labels.
 func claimQueueMessage(beadsDir, messageID, claimant string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
@@ -337,6 +387,9 @@ type queueMessageInfo struct {
 	ClaimedBy string
 	ClaimedAt *time.Time
 	Status    string
+
+	ClaimAttempts int
+	claimLabels   []string
 }
 
 // getQueueMessageInfo retrieves information about a queue message.
@@ -388,15 +441,9 @@ func getQueueMessageInfo(beadsDir, messageID string) (*queueMessageInfo, error)
 	for _, label := range issue.Labels {
 		if strings.HasPrefix(label, "queue:") {
 			info.QueueName = strings.TrimPrefix(label, "queue:")
-		} else if strings.HasPrefix(label, "claimed-by:") {
-			info.ClaimedBy = strings.TrimPrefix(label, "claimed-by:")
-		} else if strings.HasPrefix(label, "claimed-at:") {
-			ts := strings.TrimPrefix(label, "claimed-at:")
-			if t, err := time.Parse(time.RFC3339, ts); err == nil {
-				info.ClaimedAt = &t
-			}
 		}
 	}
+	info.ClaimedBy, info.ClaimedAt, info.ClaimAttempts, info.claimLabels = parseClaimLabels(issue.Labels)
 	return info, nil
 }
 
@@ -411,13 +458,7 @@ func releaseQueueMessage(beadsDir, messageID, actor string) error {
 	}
 
 	// Collect labels to remove in a single atomic operation
-	var labelsToRemove []string
-	if info.ClaimedBy != "" {
-		labelsToRemove = append(labelsToRemove, "claimed-by:"+info.ClaimedBy)
-	}
-	if info.ClaimedAt != nil {
-		labelsToRemove = append(labelsToRemove, "claimed-at:"+info.ClaimedAt.Format(time.RFC3339))
-	}
+	labelsToRemove := info.claimLabels
 
 	if len(labelsToRemove) == 0 {
 		return nil
@@ -447,8 +488,10 @@ func releaseQueueMessage(beadsDir, messageID, actor string) error {
 // Queue management commands (beads-native)
 
 var (
-	mailQueueClaimers string
-	mailQueueJSON     bool
+	mailQueueClaimers    string
+	mailQueueClaimTTL    string
+	mailQueueMaxAttempts int
+	mailQueueJSON        bool
 )
 
 var mailQueueCmd = &cobra.Command{
@@ -464,9 +507,11 @@ COMMANDS:
   show      Show queue details
   list      List all queues
   delete    Delete a queue
+  expire    Release expired claims (run by the daemon)
 
 Examples:
   gt mail queue create work --claimers 'gastown/polecats/*'
+  gt mail queue create scavenge --claimers '*' --claim-ttl 30m --max-attempts 5
   gt mail queue show work
   gt mail queue list
   gt mail queue delete work`,
@@ -481,10 +526,15 @@ var mailQueueCreateCmd = &cobra.Command{
 The --claimers flag specifies a pattern for who can claim messages from this queue.
 Patterns support wildcards: 'gastown/polecats/*' matches any polecat in gastown rig.
 
+Claims are leases. A claimant that does not renew within --claim-ttl
+(default 1h) loses the message back to the queue; after --max-attempts
+expired claims (default 3) the message goes to the deadletter mailbox.
+
 Examples:
   gt mail queue create work --claimers 'gastown/polecats/*'
   gt mail queue create dispatch --claimers 'gastown/crew/*'
-  gt mail queue create urgent --claimers '*'`,
+  gt mail queue create urgent --claimers '*'
+  gt mail queue create scavenge --claimers '*' --claim-ttl 30m --max-attempts 5`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMailQueueCreate,
 }
@@ -533,6 +583,8 @@ func init() {
 	// Queue create flags
 	mailQueueCreateCmd.Flags().StringVar(&mailQueueClaimers, "claimers", "", "Pattern for who can claim from this queue (required)")
 	_ = mailQueueCreateCmd.MarkFlagRequired("claimers")
+	mailQueueCreateCmd.Flags().StringVar(&mailQueueClaimTTL, "claim-ttl", "", "Claim lease duration before unrenewed claims expire (default: claims never expire)")
+	mailQueueCreateCmd.Flags().IntVar(&mailQueueMaxAttempts, "max-attempts", 0, "Expired claims before a message is dead-lettered (default 3)")
 
 	// Queue show/list flags
 	mailQueueShowCmd.Flags().BoolVar(&mailQueueJSON, "json", false, "Output as JSON")
@@ -558,6 +610,15 @@ func runMailQueueCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	if mailQueueClaimTTL != "" {
+		if d, err := time.ParseDuration(mailQueueClaimTTL); err != nil || d <= 0 {
+			return fmt.Errorf("invalid --claim-ttl %q: must be a positive duration like 30m", mailQueueClaimTTL)
+		}
+	}
+	if mailQueueMaxAttempts < 0 {
+		return fmt.Errorf("--max-attempts must not be negative")
+	}
+
 	// Get caller identity for created_by
 	caller := detectSender()
 
@@ -581,6 +642,8 @@ func runMailQueueCreate(cmd *cobra.Command, args []string) error {
 		Name:         queueName,
 		ClaimPattern: mailQueueClaimers,
 		Status:       beads.QueueStatusActive,
+		ClaimTTL:     mailQueueClaimTTL,
+		MaxAttempts:  mailQueueMaxAttempts,
 		CreatedBy:    caller,
 		CreatedAt:    time.Now().Format(time.RFC3339),
 	}
@@ -594,6 +657,7 @@ func runMailQueueCreate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%s Created queue %s\n", style.Bold.Render("✓"), queueName)
 	fmt.Printf("  ID: %s\n", queueID)
 	fmt.Printf("  Claimers: %s\n", mailQueueClaimers)
+	fmt.Printf("  Lease: %s, %d attempts\n", leaseString(fields.LeaseTTL()), fields.ClaimAttemptLimit())
 
 	return nil
 }
@@ -630,6 +694,8 @@ func runMailQueueShow(cmd *cobra.Command, args []string) error {
 			"processing_count": fields.ProcessingCount,
 			"completed_count":  fields.CompletedCount,
 			"failed_count":     fields.FailedCount,
+			"claim_ttl":        claimTTLJSON(fields.LeaseTTL()),
+			"max_attempts":     fields.ClaimAttemptLimit(),
 			"created_by":       fields.CreatedBy,
 			"created_at":       fields.CreatedAt,
 		}
@@ -646,6 +712,7 @@ func runMailQueueShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  ID: %s\n", issue.ID)
 	fmt.Printf("  Claimers: %s\n", fields.ClaimPattern)
 	fmt.Printf("  Status: %s\n", fields.Status)
+	fmt.Printf("  Lease: %s, %d attempts\n", leaseString(fields.LeaseTTL()), fields.ClaimAttemptLimit())
 	fmt.Printf("  Available: %d\n", fields.AvailableCount)
 	fmt.Printf("  Processing: %d\n", fields.ProcessingCount)
 	fmt.Printf("  Completed: %d\n", fields.CompletedCount)
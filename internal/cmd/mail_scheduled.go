@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Scheduled mail command flags
+var (
+	mailScheduledJSON    bool
+	mailScheduledCancel  []string
+	mailScheduledRelease bool
+)
+
+var mailScheduledCmd = &cobra.Command{
+	Use:   "scheduled",
+	Short: "List or cancel scheduled mail",
+	Long: `List mail waiting for delivery (sent with --at, --in or --cron).
+
+Scheduled mail is held outside the recipient's inbox until it is due. The
+daemon releases due messages on each heartbeat and notifies the recipient
+as if the mail had just been sent. Recurring (--cron) mail stays listed
+with its next delivery time until cancelled.
+
+Examples:
+  gt mail scheduled                          # List pending deliveries
+  gt mail scheduled --json
+  gt mail scheduled --cancel sched-1a2b3c4d  # Cancel a delivery
+  gt mail scheduled --release                # Send everything due now`,
+	Args: cobra.NoArgs,
+	RunE: runMailScheduled,
+}
+
+func init() {
+	mailScheduledCmd.Flags().BoolVar(&mailScheduledJSON, "json", false, "Output as JSON")
+	mailScheduledCmd.Flags().StringArrayVar(&mailScheduledCancel, "cancel", nil, "Cancel a scheduled delivery by ID (repeatable)")
+	mailScheduledCmd.Flags().BoolVar(&mailScheduledRelease, "release", false, "Send all due messages now (run by the daemon)")
+	mailScheduledCmd.MarkFlagsMutuallyExclusive("cancel", "release")
+
+	mailCmd.AddCommand(mailScheduledCmd)
+}
+
+func runMailScheduled(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if len(mailScheduledCancel) > 0 {
+		for _, id := range mailScheduledCancel {
+			if err := mail.CancelScheduled(townRoot, id); err != nil {
+				return err
+			}
+			fmt.Printf("%s Cancelled %s\n", style.Bold.Render("✓"), id)
+		}
+		return nil
+	}
+
+	if mailScheduledRelease {
+		router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+		defer router.WaitPendingNotifications()
+		released, err := router.ReleaseDue(time.Now())
+		for _, s := range released {
+			fmt.Printf("%s Delivered %s to %s: %s\n", style.Bold.Render("✓"), s.ID, s.Message.To, s.Message.Subject)
+		}
+		return err
+	}
+
+	pending, err := mail.ListScheduled(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if mailScheduledJSON {
+		if pending == nil {
+			pending = []*mail.ScheduledMessage{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(pending)
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("No scheduled mail")
+		return nil
+	}
+
+	fmt.Printf("%s (%d)\n\n", style.Bold.Render("Scheduled mail"), len(pending))
+	for _, s := range pending {
+		when := s.Message.DeliverAt.Local().Format("2006-01-02 15:04")
+		fmt.Printf("  %s  %s → %s\n", style.Bold.Render(s.ID), when, s.Message.To)
+		fmt.Printf("    %s\n", s.Message.Subject)
+		if s.Cron != "" {
+			fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("repeats %s (delivered %d)", s.Cron, s.Delivered)))
+		}
+		if s.LastError != "" {
+			fmt.Printf("    %s\n", style.Error.Render(fmt.Sprintf("failed %d time(s): %s", s.Attempts, s.LastError)))
+		}
+	}
+	return nil
+}
@@ -4,63 +4,100 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
 )
 
-// runMailSearch searches for messages matching a pattern.
+// runMailSearch searches the town-wide mail index.
 func runMailSearch(cmd *cobra.Command, args []string) error {
-	query := args[0]
+	query, err := mail.ParseSearchQuery(strings.Join(args, " "), time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Who is searching, and which mailbox
+	reader := detectSender()
+	query.Reader = reader
+	scope := reader
+	switch {
+	case mailSearchTown:
+		query.Mailbox = ""
+		scope = "all readable mailboxes"
+	case mailSearchIdentity != "":
+		if !mail.CanReadMailbox(reader, mailSearchIdentity) {
+			return fmt.Errorf("%s may not search %s's mail", reader, mailSearchIdentity)
+		}
+		query.Mailbox = mailSearchIdentity
+		scope = mailSearchIdentity
+	default:
+		query.Mailbox = reader
+	}
 
-	// Determine which inbox to search
-	address := detectSender()
+	// Flag filters
+	if mailSearchFrom != "" {
+		query.From = mailSearchFrom
+	}
+	query.SubjectOnly = mailSearchSubject
+	query.BodyOnly = mailSearchBody
+	if mailSearchRegex {
+		re, err := regexp.Compile("(?i)" + query.Text)
+		if err != nil {
+			return fmt.Errorf("invalid search pattern: %w", err)
+		}
+		query.Pattern = re
+	}
+	if query.Is == "" && !mailSearchArchive {
+		query.Is = "inbox"
+	}
 
-	// Get workspace for mail operations
-	workDir, err := findMailWorkDir()
+	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
-
-	// Get mailbox
-	router := mail.NewRouter(workDir)
-	mailbox, err := router.GetMailbox(address)
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	index, err := router.RefreshSearchIndex(mailSearchReindex)
 	if err != nil {
-		return fmt.Errorf("getting mailbox: %w", err)
+		return fmt.Errorf("updating search index: %w", err)
 	}
 
-	// Build search options
-	opts := mail.SearchOptions{
-		Query:       query,
-		FromFilter:  mailSearchFrom,
-		SubjectOnly: mailSearchSubject,
-		BodyOnly:    mailSearchBody,
+	results := index.Search(query)
+	if len(results) == 0 && query.Pattern == nil && query.Text != "" {
+		// No whole-word match: fall back to a literal substring match
+		query.Pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query.Text))
+		results = index.Search(query)
 	}
-
-	// Execute search
-	messages, err := mailbox.Search(opts)
-	if err != nil {
-		return fmt.Errorf("searching messages: %w", err)
+	total := len(results)
+	if mailSearchLimit > 0 && len(results) > mailSearchLimit {
+		results = results[:mailSearchLimit]
 	}
 
 	// JSON output
 	if mailSearchJSON {
+		if results == nil {
+			results = []mail.SearchResult{}
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(messages)
+		return enc.Encode(results)
 	}
 
 	// Human-readable output
 	fmt.Printf("%s Search results for %s: %d message(s)\n\n",
-		style.Bold.Render("🔍"), address, len(messages))
+		style.Bold.Render("🔍"), scope, total)
 
-	if len(messages) == 0 {
+	if len(results) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(no matches)"))
 		return nil
 	}
 
-	for _, msg := range messages {
+	for _, r := range results {
+		msg := r.Message
 		readMarker := "●"
 		if msg.Read {
 			readMarker = "○"
@@ -77,14 +114,34 @@ func runMailSearch(cmd *cobra.Command, args []string) error {
 		if msg.Wisp {
 			wispMarker = " " + style.Dim.Render("(wisp)")
 		}
+		if r.Archived {
+			wispMarker += " " + style.Dim.Render("(archived)")
+		}
 
 		fmt.Printf("  %s %s%s%s%s\n", readMarker, msg.Subject, typeMarker, priorityMarker, wispMarker)
-		fmt.Printf("    %s from %s\n",
+		fmt.Printf("    %s from %s to %s\n",
 			style.Dim.Render(msg.ID),
-			msg.From)
+			msg.From, searchRecipient(msg))
 		fmt.Printf("    %s\n",
 			style.Dim.Render(msg.Timestamp.Format("2006-01-02 15:04")))
 	}
 
+	if total > len(results) {
+		fmt.Printf("\n  %s\n", style.Dim.Render(fmt.Sprintf("... %d more (use --limit 0 for all)", total-len(results))))
+	}
+
 	return nil
 }
+
+// searchRecipient describes where a search hit was delivered.
+func searchRecipient(msg *mail.Message) string {
+	switch {
+	case msg.Queue != "":
+		return "queue:" + msg.Queue
+	case msg.Channel != "":
+		return "channel:" + msg.Channel
+	case msg.To != "":
+		return msg.To
+	}
+	return "(unknown)"
+}
@@ -7,11 +7,15 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/events"
+	"github.com/xcawolfe-amzn/gastown/internal/git"
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
 )
 
@@ -131,6 +135,17 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		msg.ThreadID = generateThreadID()
 	}
 
+	// Store attachments in the town blob store; the message references them
+	msg.Attachments, err = collectMailAttachments(workDir)
+	if err != nil {
+		return err
+	}
+
+	// Scheduled delivery: spool now, the daemon sends it when due
+	if mailSendAt != "" || mailSendIn != "" || mailSendCron != "" {
+		return scheduleMailSend(workDir, msg)
+	}
+
 	// Use address resolver for new address types
 	townRoot, _ := workspace.FindFromCwd()
 	b := beads.New(townRoot)
@@ -210,6 +225,9 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	if len(msg.CC) > 0 {
 		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
 	}
+	for _, a := range msg.Attachments {
+		fmt.Printf("  Attached: %s (%s, %s)\n", a.Name, a.Kind, formatBytes(a.Size))
+	}
 	if msg.Type != mail.TypeNotification {
 		fmt.Printf("  Type: %s\n", msg.Type)
 	}
@@ -223,3 +241,91 @@ func generateThreadID() string {
 	_, _ = rand.Read(b) // crypto/rand.Read only fails on broken system
 	return "thread-" + hex.EncodeToString(b)
 }
+
+// scheduleMailSend spools msg per --at/--in/--cron instead of sending it.
+func scheduleMailSend(workDir string, msg *mail.Message) error {
+	now := time.Now()
+	switch {
+	case mailSendAt != "":
+		at, err := mail.ParseDeliverAt(mailSendAt, now)
+		if err != nil {
+			return err
+		}
+		if !at.After(now) {
+			return fmt.Errorf("--at %s is in the past", mailSendAt)
+		}
+		msg.DeliverAt = &at
+	case mailSendIn != "":
+		d, err := mail.ParseDeliverIn(mailSendIn)
+		if err != nil {
+			return err
+		}
+		at := now.Add(d)
+		msg.DeliverAt = &at
+	}
+
+	router := mail.NewRouter(workDir)
+	entry, err := router.Schedule(msg, mailSendCron)
+	if err != nil {
+		return fmt.Errorf("scheduling message: %w", err)
+	}
+
+	fmt.Printf("%s Message to %s scheduled for %s\n", style.Bold.Render("✓"),
+		msg.To, entry.Message.DeliverAt.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("  Subject: %s\n", msg.Subject)
+	if entry.Cron != "" {
+		fmt.Printf("  Repeats: %s\n", entry.Cron)
+	}
+	fmt.Printf("  ID: %s %s\n", entry.ID, style.Dim.Render("(cancel with gt mail scheduled --cancel "+entry.ID+")"))
+	return nil
+}
+
+// collectMailAttachments stores the --attach, --attach-diff and
+// --attach-pane content and returns references to it.
+func collectMailAttachments(townRoot string) ([]mail.Attachment, error) {
+	var attachments []mail.Attachment
+	for _, path := range mailAttach {
+		a, err := mail.AttachFile(townRoot, path)
+		if err != nil {
+			return nil, fmt.Errorf("attaching %s: %w", path, err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	if len(mailAttachDiff) > 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("getting current directory: %w", err)
+		}
+		g := git.NewGit(cwd)
+		for _, spec := range mailAttachDiff {
+			diff, err := g.Diff(spec)
+			if err != nil {
+				return nil, fmt.Errorf("attaching diff %s: %w", spec, err)
+			}
+			if diff == "" {
+				return nil, fmt.Errorf("attaching diff %s: no changes", spec)
+			}
+			name := "diff-" + strings.NewReplacer("/", "_", ".", "_", "~", "_", "^", "_").Replace(spec) + ".patch"
+			a, err := mail.StoreAttachment(townRoot, mail.AttachmentDiff, name, []byte(diff))
+			if err != nil {
+				return nil, err
+			}
+			attachments = append(attachments, a)
+		}
+	}
+
+	t := tmux.NewTmux()
+	for _, session := range mailAttachPane {
+		capture, err := t.CapturePaneAll(session)
+		if err != nil {
+			return nil, fmt.Errorf("capturing pane %s: %w", session, err)
+		}
+		a, err := mail.StoreAttachment(townRoot, mail.AttachmentPane, session+".txt", []byte(capture))
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+)
+
+// moleculeRunKey is the description line on a molecule root that carries
+// its moleculeRun.
+const moleculeRunKey = "formula_run"
+
+// moleculeRun is the control-flow state of a molecule instantiated from a
+// formula whose steps use when/loop/retry/timeout. It is stored on the
+// molecule root so each 'gt mol step done' can apply the formula's
+// decisions to the step beads.
+type moleculeRun struct {
+	Formula string            `json:"formula"`
+	Steps   map[string]string `json:"steps"` // Formula step ID -> step bead ID
+	State   *formula.RunState `json:"state"`
+}
+
+// runUpdate is what advancing a moleculeRun asks of the step beads.
+type runUpdate struct {
+	Close   []string          // Step beads finished (done or skipped)
+	Failed  map[string]string // Step beads failed for good -> reason
+	Reopen  []string          // Closed step beads to run again (loop)
+	Ready   []string          // Step beads that may start now
+	Notes   []string          // Decisions, for the user
+	Status  string            // formula.RunStatus after the update
+	RetryAt time.Time         // Earliest pending retry
+}
+
+// loadControlFormula loads a formula the way bd cook resolves it. Returns
+// nil if it can't be loaded here; bd reports that on its own.
+func loadControlFormula(formulaName, workDir, townRoot string) *formula.Formula {
+	dirs := []string{filepath.Join(workDir, ".beads", "formulas"), filepath.Join(townRoot, ".beads", "formulas")}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".beads", "formulas"))
+	}
+	load := formula.DirLoader(dirs...)
+	data, err := load(formulaName)
+	if err != nil {
+		return nil
+	}
+	f, err := formula.ParseWithLoader(data, load)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// recordMoleculeRun stores a run state on a freshly created wisp when its
+// formula uses control flow. wispOut is bd mol wisp's JSON output, whose
+// id_mapping ties formula steps to step beads. Steps whose "when" is false
+// from the start are closed as skipped.
+func recordMoleculeRun(formulaName, workDir, townRoot string, wispOut []byte, vars []string) error {
+	f := loadControlFormula(formulaName, workDir, townRoot)
+	if f == nil || len(f.ControlSteps()) == 0 {
+		return nil
+	}
+	var created wispCreateJSON
+	if err := json.Unmarshal(wispOut, &created); err != nil {
+		return fmt.Errorf("parsing wisp JSON: %w", err)
+	}
+	rootID, err := parseWispIDFromJSON(wispOut)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]string)
+	for _, v := range vars {
+		if k, val, ok := strings.Cut(v, "="); ok {
+			values[k] = val
+		}
+	}
+	run := &moleculeRun{
+		Formula: formulaName,
+		Steps:   mapFormulaSteps(f, created.IDMapping),
+		State:   formula.NewRunState(values),
+	}
+	if len(run.Steps) == 0 {
+		return fmt.Errorf("formula %s uses control flow but bd did not report its step beads", formulaName)
+	}
+
+	b := beads.New(workDir)
+	root, err := b.Show(rootID)
+	if err != nil {
+		return fmt.Errorf("reading molecule %s: %w", rootID, err)
+	}
+	return applyRunUpdate(b, root, run, run.advance(f, "", "", "", time.Now()))
+}
+
+// mapFormulaSteps matches bd's proto-to-instance ID mapping to formula
+// steps. Proto step IDs end in ".<step-id>".
+func mapFormulaSteps(f *formula.Formula, idMapping map[string]string) map[string]string {
+	steps := make(map[string]string)
+	for proto, beadID := range idMapping {
+		for _, step := range f.Steps {
+			if proto == step.ID || strings.HasSuffix(proto, "."+step.ID) {
+				steps[step.ID] = beadID
+			}
+		}
+	}
+	return steps
+}
+
+// parseMoleculeRun returns the run state stored on a molecule root, or nil.
+func parseMoleculeRun(root *beads.Issue) *moleculeRun {
+	if root == nil {
+		return nil
+	}
+	for _, line := range strings.Split(root.Description, "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), moleculeRunKey+":")
+		if !ok {
+			continue
+		}
+		var run moleculeRun
+		if err := json.Unmarshal([]byte(strings.TrimSpace(value)), &run); err != nil || run.State == nil {
+			return nil
+		}
+		return &run
+	}
+	return nil
+}
+
+// setMoleculeRun returns description with its run state line replaced.
+func setMoleculeRun(description string, run *moleculeRun) (string, error) {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return "", fmt.Errorf("marshaling run state: %w", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(description, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), moleculeRunKey+":") {
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	lines = append(lines, moleculeRunKey+": "+string(data))
+	return strings.Join(lines, "\n"), nil
+}
+
+// stepFor returns the formula step a step bead was instantiated from.
+func (r *moleculeRun) stepFor(beadID string) string {
+	for id, b := range r.Steps {
+		if b == beadID {
+			return id
+		}
+	}
+	return ""
+}
+
+// advance applies a step bead's completion (or, with failure set, a failed
+// attempt) to the run, checks running steps against their timeouts and
+// works out which steps are skipped and which may start. An empty beadID
+// just re-evaluates the run. Beads are closed or reopened by comparing
+// each step's status before and after.
+func (r *moleculeRun) advance(f *formula.Formula, beadID, outcome, failure string, now time.Time) runUpdate {
+	u := runUpdate{Failed: make(map[string]string)}
+	before := make(map[string]string)
+	for _, step := range f.Steps {
+		before[step.ID] = r.State.Step(step.ID).Status
+	}
+
+	failReasons := make(map[string]string)
+	apply := func(t formula.Transition) {
+		switch t.Action {
+		case formula.ActionLoop:
+			u.Notes = append(u.Notes, fmt.Sprintf("%s loops back: %s", t.Step, t.Reason))
+		case formula.ActionRetry:
+			u.Notes = append(u.Notes, fmt.Sprintf("%s will be retried after %s: %s", t.Step, t.RetryAt.Format("15:04:05"), t.Reason))
+		case formula.ActionFail:
+			failReasons[t.Step] = t.Reason
+			u.Notes = append(u.Notes, fmt.Sprintf("%s failed: %s", t.Step, t.Reason))
+		case formula.ActionDone:
+			if t.Reason != "" {
+				u.Notes = append(u.Notes, fmt.Sprintf("%s: %s", t.Step, t.Reason))
+			}
+		}
+	}
+
+	timedOut := make(map[string]bool)
+	for _, id := range f.TimedOut(r.State, now) {
+		timedOut[id] = true
+	}
+	if step := r.stepFor(beadID); step != "" {
+		switch {
+		case failure != "":
+			apply(f.Fail(r.State, step, failure, now))
+		case timedOut[step]:
+			apply(f.Fail(r.State, step, "timed out after "+f.GetStep(step).Timeout, now))
+		default:
+			apply(f.Complete(r.State, step, outcome))
+		}
+		delete(timedOut, step)
+	}
+	for _, step := range f.Steps {
+		if timedOut[step.ID] {
+			apply(f.Fail(r.State, step.ID, "timed out after "+step.Timeout, now))
+		}
+	}
+	started := make(map[string]bool)
+	for _, id := range f.Ready(r.State, now) {
+		f.Start(r.State, id, now)
+		started[id] = true
+	}
+
+	closed := func(status string) bool {
+		return status == formula.StepDone || status == formula.StepSkipped || status == formula.StepFailed
+	}
+	for _, step := range f.Steps {
+		st := r.State.Step(step.ID)
+		bead := r.Steps[step.ID]
+		if bead == "" {
+			continue
+		}
+		switch {
+		case closed(st.Status) && !closed(before[step.ID]):
+			if st.Status == formula.StepFailed {
+				u.Failed[bead] = failReasons[step.ID]
+			} else {
+				u.Close = append(u.Close, bead)
+			}
+			if st.Status == formula.StepSkipped {
+				u.Notes = append(u.Notes, fmt.Sprintf("%s skipped: %s is false", step.ID, step.When))
+			}
+		case !closed(st.Status) && closed(before[step.ID]):
+			u.Reopen = append(u.Reopen, bead)
+		}
+		if started[step.ID] {
+			u.Ready = append(u.Ready, bead)
+		}
+		if st.Status == formula.StepPending && st.NotBefore.After(now) && (u.RetryAt.IsZero() || st.NotBefore.Before(u.RetryAt)) {
+			u.RetryAt = st.NotBefore
+		}
+	}
+	u.Status = f.RunStatus(r.State)
+	return u
+}
+
+// applyRunUpdate closes, fails and reopens step beads as the update says
+// and saves the run on the molecule root.
+func applyRunUpdate(b *beads.Beads, root *beads.Issue, run *moleculeRun, u runUpdate) error {
+	if err := b.Close(u.Close...); err != nil {
+		return fmt.Errorf("closing steps: %w", err)
+	}
+	for id, reason := range u.Failed {
+		if err := b.CloseWithReason("failed: "+reason, id); err != nil {
+			return fmt.Errorf("closing failed step %s: %w", id, err)
+		}
+	}
+	open := "open"
+	for _, id := range u.Reopen {
+		if err := b.Update(id, beads.UpdateOptions{Status: &open}); err != nil {
+			return fmt.Errorf("reopening step %s: %w", id, err)
+		}
+	}
+
+	desc, err := setMoleculeRun(root.Description, run)
+	if err != nil {
+		return err
+	}
+	if err := b.Update(root.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("saving run state on %s: %w", root.ID, err)
+	}
+	for _, note := range u.Notes {
+		fmt.Printf("%s %s\n", style.Dim.Render("→"), note)
+	}
+	return nil
+}
+
+// advanceMoleculeStep completes (or with failure set, fails) a step bead of
+// a molecule that carries a run state. It returns nil if the molecule has
+// none or the bead isn't one of its formula steps; the caller then closes
+// the bead and follows needs as usual.
+func advanceMoleculeStep(b *beads.Beads, moleculeID, stepID, workDir, townRoot, outcome, failure string, dryRun bool) (*runUpdate, error) {
+	root, err := b.Show(moleculeID)
+	if err != nil {
+		return nil, nil // Not every step's parent is readable here; fall back to needs
+	}
+	run := parseMoleculeRun(root)
+	if run == nil || run.stepFor(stepID) == "" {
+		return nil, nil
+	}
+	f := loadControlFormula(run.Formula, workDir, townRoot)
+	if f == nil {
+		return nil, fmt.Errorf("molecule %s follows formula %s, which can't be loaded", moleculeID, run.Formula)
+	}
+
+	u := run.advance(f, stepID, outcome, failure, time.Now())
+	if !dryRun {
+		return &u, applyRunUpdate(b, root, run, u)
+	}
+	for _, id := range u.Close {
+		fmt.Printf("[dry-run] Would close step: %s\n", id)
+	}
+	for id, reason := range u.Failed {
+		fmt.Printf("[dry-run] Would close step %s as failed: %s\n", id, reason)
+	}
+	for _, id := range u.Reopen {
+		fmt.Printf("[dry-run] Would reopen step: %s\n", id)
+	}
+	for _, note := range u.Notes {
+		fmt.Printf("[dry-run] %s\n", note)
+	}
+	return &u, nil
+}
+
+// closes reports whether the update closes a step bead, done or failed.
+func (u *runUpdate) closes(beadID string) bool {
+	_, failed := u.Failed[beadID]
+	return failed || slices.Contains(u.Close, beadID)
+}
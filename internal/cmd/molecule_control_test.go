@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
+)
+
+const controlTestFormula = `
+formula = "review-loop"
+type = "workflow"
+
+[vars.env]
+default = "dev"
+
+[[steps]]
+id = "implement"
+title = "Implement"
+timeout = "30m"
+retry = { max = 1, backoff = "1m" }
+
+[[steps]]
+id = "review"
+title = "Review"
+needs = ["implement"]
+
+[[steps]]
+id = "fix"
+title = "Fix review findings"
+needs = ["review"]
+when = "steps.review.outcome != approved"
+loop = { back = "review", until = "steps.review.outcome == approved", max = 3 }
+
+[[steps]]
+id = "deploy"
+title = "Deploy"
+needs = ["fix"]
+when = "vars.env == 'prod'"
+
+[[steps]]
+id = "submit"
+title = "Submit"
+needs = ["deploy"]
+`
+
+func newControlTestRun(t *testing.T) (*formula.Formula, *moleculeRun) {
+	t.Helper()
+	f, err := formula.Parse([]byte(controlTestFormula))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	run := &moleculeRun{
+		Formula: "review-loop",
+		Steps: mapFormulaSteps(f, map[string]string{
+			"mol-review-loop":           "gt-w",
+			"mol-review-loop.implement": "gt-w.1",
+			"mol-review-loop.review":    "gt-w.2",
+			"mol-review-loop.fix":       "gt-w.3",
+			"mol-review-loop.deploy":    "gt-w.4",
+			"mol-review-loop.submit":    "gt-w.5",
+		}),
+		State: formula.NewRunState(map[string]string{"env": "dev"}),
+	}
+	return f, run
+}
+
+func TestMapFormulaSteps(t *testing.T) {
+	_, run := newControlTestRun(t)
+	want := map[string]string{
+		"implement": "gt-w.1",
+		"review":    "gt-w.2",
+		"fix":       "gt-w.3",
+		"deploy":    "gt-w.4",
+		"submit":    "gt-w.5",
+	}
+	if !reflect.DeepEqual(run.Steps, want) {
+		t.Errorf("Steps = %v, want %v", run.Steps, want)
+	}
+	if got := run.stepFor("gt-w.3"); got != "fix" {
+		t.Errorf("stepFor(gt-w.3) = %q, want fix", got)
+	}
+}
+
+func TestMoleculeRunAdvance_ReviewLoop(t *testing.T) {
+	f, run := newControlTestRun(t)
+	now := time.Now()
+
+	steps := []struct {
+		bead, outcome string
+		close, reopen []string
+		ready         []string
+		status        string
+	}{
+		{"", "", nil, nil, []string{"gt-w.1"}, formula.StepRunning},
+		{"gt-w.1", "", []string{"gt-w.1"}, nil, []string{"gt-w.2"}, formula.StepRunning},
+		{"gt-w.2", "changes", []string{"gt-w.2"}, nil, []string{"gt-w.3"}, formula.StepRunning},
+		// Fix done, review not approved yet: loop back to review.
+		{"gt-w.3", "", nil, []string{"gt-w.2"}, []string{"gt-w.2"}, formula.StepRunning},
+		// Approved: fix and deploy (env is dev) are skipped.
+		{"gt-w.2", "approved", []string{"gt-w.2", "gt-w.3", "gt-w.4"}, nil, []string{"gt-w.5"}, formula.StepRunning},
+		{"gt-w.5", "", []string{"gt-w.5"}, nil, nil, formula.StepDone},
+	}
+	for i, s := range steps {
+		u := run.advance(f, s.bead, s.outcome, "", now)
+		if !reflect.DeepEqual(u.Close, s.close) || !reflect.DeepEqual(u.Reopen, s.reopen) ||
+			!reflect.DeepEqual(u.Ready, s.ready) || u.Status != s.status {
+			t.Fatalf("step %d (%s): close=%v reopen=%v ready=%v status=%s, want close=%v reopen=%v ready=%v status=%s",
+				i, s.bead, u.Close, u.Reopen, u.Ready, u.Status, s.close, s.reopen, s.ready, s.status)
+		}
+	}
+}
+
+func TestMoleculeRunAdvance_RetryAndTimeout(t *testing.T) {
+	f, run := newControlTestRun(t)
+	now := time.Now()
+	run.advance(f, "", "", "", now)
+
+	// A failed attempt is retried after the backoff, not closed.
+	u := run.advance(f, "gt-w.1", "", "tests red", now)
+	if len(u.Close) != 0 || len(u.Failed) != 0 || len(u.Ready) != 0 {
+		t.Fatalf("after failed attempt: %+v, want nothing closed or ready", u)
+	}
+	if want := now.Add(time.Minute); !u.RetryAt.Equal(want) {
+		t.Errorf("RetryAt = %v, want %v", u.RetryAt, want)
+	}
+
+	later := now.Add(2 * time.Minute)
+	u = run.advance(f, "", "", "", later)
+	if !reflect.DeepEqual(u.Ready, []string{"gt-w.1"}) {
+		t.Fatalf("after backoff: Ready = %v, want [gt-w.1]", u.Ready)
+	}
+
+	// The retry runs past its timeout: out of retries, the step fails.
+	u = run.advance(f, "", "", "", later.Add(31*time.Minute))
+	if reason, ok := u.Failed["gt-w.1"]; !ok || !strings.Contains(reason, "timed out") {
+		t.Errorf("Failed = %v, want gt-w.1 timed out", u.Failed)
+	}
+	if u.Status != formula.StepFailed {
+		t.Errorf("Status = %s, want %s", u.Status, formula.StepFailed)
+	}
+}
+
+func TestSetMoleculeRun_RoundTrip(t *testing.T) {
+	f, run := newControlTestRun(t)
+	run.advance(f, "", "", "", time.Now())
+
+	desc, err := setMoleculeRun("Work on gt-abc\n\nformula_run: {}\n", run)
+	if err != nil {
+		t.Fatalf("setMoleculeRun: %v", err)
+	}
+	if strings.Count(desc, moleculeRunKey+":") != 1 || !strings.HasPrefix(desc, "Work on gt-abc\n") {
+		t.Fatalf("description = %q, want one run line after the text", desc)
+	}
+
+	got := parseMoleculeRun(&beads.Issue{Description: desc})
+	if got == nil {
+		t.Fatal("parseMoleculeRun returned nil")
+	}
+	if got.Formula != run.Formula || !reflect.DeepEqual(got.Steps, run.Steps) ||
+		got.State.Step("implement").Status != formula.StepRunning {
+		t.Errorf("parsed run = %+v, want %+v", got, run)
+	}
+	if parseMoleculeRun(&beads.Issue{Description: "no run here"}) != nil {
+		t.Error("parseMoleculeRun found a run in a plain description")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
@@ -34,22 +35,36 @@ This command handles the step-to-step transition for polecats:
    - Sends POLECAT_DONE to witness
    - Exits the session
 
+Molecules cooked from a formula with when/loop/retry/timeout steps carry
+their run state on the molecule root, and the formula decides instead:
+steps whose "when" is false are closed as skipped, a loop whose "until"
+doesn't hold yet reopens its body, and a failed attempt (--failed) is
+retried after the step's backoff. Running steps past their timeout fail.
+--outcome records the step's result for conditions such as
+"review.outcome == 'approved'".
+
 IMPORTANT: This is the canonical way to complete molecule steps. Do NOT manually
 close steps with 'bd close' - it skips the auto-continuation logic.
 
-Example:
-  gt mol step done gt-abc.1    # Complete step 1 of molecule gt-abc`,
+Examples:
+  gt mol step done gt-abc.1                      # Complete step 1 of molecule gt-abc
+  gt mol step done gt-abc.3 --outcome approved   # Complete a review step
+  gt mol step done gt-abc.2 --failed "tests red" # Report a failed attempt`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMoleculeStepDone,
 }
 
 var (
-	moleculeStepDryRun bool
+	moleculeStepDryRun  bool
+	moleculeStepOutcome string
+	moleculeStepFailed  string
 )
 
 func init() {
 	moleculeStepDoneCmd.Flags().BoolVarP(&moleculeStepDryRun, "dry-run", "n", false, "Show what would be done without executing")
 	moleculeStepDoneCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
+	moleculeStepDoneCmd.Flags().StringVar(&moleculeStepOutcome, "outcome", "", "Outcome of the step, for formula loop/when conditions (e.g. approved)")
+	moleculeStepDoneCmd.Flags().StringVar(&moleculeStepFailed, "failed", "", "Report a failed attempt with this reason (retried per the formula)")
 }
 
 // StepDoneResult is the result of a step done operation.
@@ -106,11 +121,25 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		MoleculeID: moleculeID,
 	}
 
-	// Step 3: Close the step
-	if moleculeStepDryRun {
+	// Step 3: Close the step. Molecules from a formula with control flow
+	// let the formula decide: skip, loop back, retry or fail.
+	run, err := advanceMoleculeStep(b, moleculeID, stepID, workDir, townRoot,
+		moleculeStepOutcome, moleculeStepFailed, moleculeStepDryRun)
+	if err != nil {
+		return err
+	}
+	switch {
+	case run != nil:
+		result.StepClosed = run.closes(stepID)
+		if result.StepClosed && !moleculeStepDryRun {
+			fmt.Printf("%s Closed step %s: %s\n", style.Bold.Render("✓"), stepID, step.Title)
+		}
+	case moleculeStepFailed != "":
+		return fmt.Errorf("step %s has no retry policy; escalate the failure with 'gt escalate'", stepID)
+	case moleculeStepDryRun:
 		fmt.Printf("[dry-run] Would close step: %s\n", stepID)
 		result.StepClosed = true
-	} else {
+	default:
 		if err := b.Close(stepID); err != nil {
 			return fmt.Errorf("closing step: %w", err)
 		}
@@ -119,9 +148,25 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 4: Find all ready steps (supports fan-out pattern)
-	readySteps, allComplete, err := findAllReadySteps(b, moleculeID)
-	if err != nil {
-		return fmt.Errorf("finding next steps: %w", err)
+	var readySteps []*beads.Issue
+	var allComplete bool
+	if run != nil {
+		if run.Status == formula.StepFailed {
+			return fmt.Errorf("molecule %s failed; escalate with 'gt escalate'", moleculeID)
+		}
+		allComplete = run.Status == formula.StepDone
+		for _, id := range run.Ready {
+			issue, err := b.Show(id)
+			if err != nil {
+				return fmt.Errorf("reading step %s: %w", id, err)
+			}
+			readySteps = append(readySteps, issue)
+		}
+	} else {
+		readySteps, allComplete, err = findAllReadySteps(b, moleculeID)
+		if err != nil {
+			return fmt.Errorf("finding next steps: %w", err)
+		}
 	}
 
 	if allComplete {
@@ -162,6 +207,11 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		return handleMoleculeComplete(cwd, townRoot, moleculeID, moleculeStepDryRun)
 
 	case "no_more_ready":
+		if run != nil && !run.RetryAt.IsZero() {
+			fmt.Printf("\n%s Waiting to retry at %s - run 'gt mol step done' again after that\n",
+				style.Dim.Render("ℹ"), run.RetryAt.Format("15:04:05"))
+			return nil
+		}
 		fmt.Printf("\n%s All remaining steps are blocked - waiting on dependencies\n",
 			style.Dim.Render("ℹ"))
 		fmt.Printf("Run 'gt mol progress %s' to see blocked steps\n", moleculeID)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/git"
 	"github.com/xcawolfe-amzn/gastown/internal/refinery"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
@@ -73,6 +74,7 @@ func runMQList(cmd *cobra.Command, args []string) error {
 
 	// Apply additional filters and calculate scores
 	now := time.Now()
+	deadlines := convoy.LoadDeadlineStatuses(filepath.Dir(r.Path))
 	type scoredIssue struct {
 		issue          *beads.Issue
 		fields         *beads.MRFields
@@ -129,7 +131,7 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		branchMissing, branchVerifyErr := verifyBranch(mqListVerify, gitClient, fields)
 
 		// Calculate priority score
-		score := calculateMRScore(issue, fields, now)
+		score := calculateMRScore(issue, fields, deadlines, now)
 		scored = append(scored, scoredIssue{issue: issue, fields: fields, score: score, branchMissing: branchMissing, branchVerifyErr: branchVerifyErr})
 	}
 
@@ -352,7 +354,7 @@ func outputJSON(data interface{}) error {
 
 // calculateMRScore computes the priority score for an MR using the refinery scoring function.
 // Higher scores mean higher priority (process first).
-func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time) float64 {
+func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, deadlines map[string]convoy.DeadlineStatus, now time.Time) float64 {
 	// Parse MR creation time
 	mrCreatedAt, err := time.Parse(time.RFC3339, issue.CreatedAt)
 	if err != nil {
@@ -379,6 +381,12 @@ func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time)
 				input.ConvoyCreatedAt = &convoyTime
 			}
 		}
+		if fields.ConvoyDueAt != "" {
+			if dueTime, err := time.Parse(time.RFC3339, fields.ConvoyDueAt); err == nil {
+				input.ConvoyDueAt = &dueTime
+			}
+		}
+		input.ConvoyDeadline = deadlines[fields.ConvoyID]
 	}
 
 	return refinery.ScoreMRWithDefaults(input)
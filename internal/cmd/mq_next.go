@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 )
 
@@ -91,6 +93,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 	}
 
 	now := time.Now()
+	deadlines := convoy.LoadDeadlineStatuses(filepath.Dir(r.Path))
 
 	// Sort based on strategy
 	if mqNextStrategy == "fifo" {
@@ -109,7 +112,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 		scored := make([]scoredIssue, len(ready))
 		for i, issue := range ready {
 			fields := beads.ParseMRFields(issue)
-			score := calculateMRScore(issue, fields, now)
+			score := calculateMRScore(issue, fields, deadlines, now)
 			scored[i] = scoredIssue{issue: issue, score: score}
 		}
 
@@ -140,7 +143,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 	// Human-readable output
 	fmt.Printf("%s Next MR to process:\n\n", style.Bold.Render("🎯"))
 
-	score := calculateMRScore(next, fields, now)
+	score := calculateMRScore(next, fields, deadlines, now)
 
 	fmt.Printf("  ID:       %s\n", next.ID)
 	fmt.Printf("  Score:    %.1f\n", score)
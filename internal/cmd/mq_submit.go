@@ -163,6 +163,7 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 
 	// Determine target branch
 	target := defaultBranch
+	convoyInfo := getConvoyInfoForIssue(issueID)
 	if mqSubmitEpic != "" {
 		// Explicit --epic flag: read stored branch name, fall back to template
 		rigPath := filepath.Join(townRoot, rigName)
@@ -185,6 +186,10 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 				target = autoTarget
 			}
 		}
+		target, err = convoyLandingTarget(g, convoyInfo, defaultBranch, target)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Get source issue for priority inheritance
@@ -209,6 +214,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	if worker != "" {
 		description += fmt.Sprintf("\nworker: %s", worker)
 	}
+	if convoyInfo != nil {
+		description += fmt.Sprintf("\nconvoy_id: %s", convoyInfo.ID)
+	}
 
 	// Check if MR bead already exists for this branch (idempotency)
 	var mrIssue *beads.Issue
@@ -269,7 +277,7 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 func polecatCleanup(rigName, worker, townRoot string) error {
 	// Send lifecycle request to witness
 	manager := rigName + "/witness"
-	subject := fmt.Sprintf("LIFECYCLE: polecat-%s requesting shutdown", worker)
+	subject := fmt.Sprintf("LIFECYCLE:Shutdown %s", worker)
 	body := fmt.Sprintf(`Lifecycle request from polecat %s.
 
 Action: shutdown
@@ -26,6 +26,8 @@ var (
 	nudgeIfFreshFlag  bool
 	nudgeModeFlag     string
 	nudgePriorityFlag string
+	nudgeKindFlag     string
+	nudgeKeyFlag      string
 )
 
 // Nudge delivery modes.
@@ -49,6 +51,8 @@ func init() {
 	nudgeCmd.Flags().BoolVar(&nudgeIfFreshFlag, "if-fresh", false, "Only send if caller's tmux session is <60s old (suppresses compaction nudges)")
 	nudgeCmd.Flags().StringVar(&nudgeModeFlag, "mode", NudgeModeImmediate, "Delivery mode: immediate (default), queue, or wait-idle")
 	nudgeCmd.Flags().StringVar(&nudgePriorityFlag, "priority", nudge.PriorityNormal, "Queue priority: normal (default) or urgent")
+	nudgeCmd.Flags().StringVar(&nudgeKindFlag, "kind", "", "Queue kind: pending nudges with the same sender and kind collapse to the latest")
+	nudgeCmd.Flags().StringVar(&nudgeKeyFlag, "key", "", "Queue key: supersedes your pending nudges with the same key")
 }
 
 var nudgeCmd = &cobra.Command{
@@ -69,6 +73,12 @@ Delivery modes (--mode):
              directly. Falls back to queue on timeout. If both idle-wait and
              queue fail, falls back to immediate delivery as a last resort.
 
+Queued nudges are coalesced: urgent nudges are delivered first, pending
+nudges with the same sender and --kind collapse to the latest, and a nudge
+with a --key supersedes the sender's older ones with that key. Inspect a
+queue with
+'gt nudge queue <session>'.
+
 Queue and wait-idle modes require the target agent to support hooks
 (UserPromptSubmit) for drain. Agents without hook support should use immediate.
 
@@ -120,10 +130,11 @@ const ifFreshMaxAge = 60 * time.Second
 var waitIdleTimeout = 15 * time.Second
 
 // deliverNudge routes a nudge based on the --mode flag.
-// For "immediate" mode: sends directly via tmux (current behavior).
+// For "immediate" mode: sends directly via the session backend.
 // For "queue" mode: writes to the nudge queue for cooperative delivery.
 // For "wait-idle" mode: waits for idle, then delivers or falls back to queue.
-func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
+// Idle detection needs tmux; headless sessions always fall back to the queue.
+func deliverNudge(t session.SessionBackend, sessionName, message, sender string) error {
 	townRoot, _ := workspace.FindFromCwd()
 
 	// For direct tmux delivery, prefix with sender attribution.
@@ -140,6 +151,8 @@ func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
 			Sender:   sender,
 			Message:  message,
 			Priority: nudgePriorityFlag,
+			Kind:     nudgeKindFlag,
+			Key:      nudgeKeyFlag,
 		})
 
 	case NudgeModeWaitIdle:
@@ -149,7 +162,10 @@ func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
 			return fmt.Errorf("--mode=wait-idle requires a Gas Town workspace")
 		}
 		// Try to wait for idle
-		err := t.WaitForIdle(sessionName, waitIdleTimeout)
+		err := fmt.Errorf("no idle detection for headless session %s", sessionName)
+		if tm, ok := t.(*tmux.Tmux); ok {
+			err = tm.WaitForIdle(sessionName, waitIdleTimeout)
+		}
 		if err == nil {
 			// Agent is idle — safe to deliver directly
 			return t.NudgeSession(sessionName, prefixedMessage)
@@ -164,6 +180,8 @@ func deliverNudge(t *tmux.Tmux, sessionName, message, sender string) error {
 			Sender:   sender,
 			Message:  message,
 			Priority: nudgePriorityFlag,
+			Kind:     nudgeKindFlag,
+			Key:      nudgeKeyFlag,
 		}); qErr != nil {
 			// Queue failed — fall back to immediate as last resort.
 			// Better to interrupt than lose the message entirely.
@@ -278,7 +296,7 @@ func runNudge(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	t := tmux.NewTmux()
+	t := session.BackendForTown(townRoot, tmux.NewTmux())
 
 	// Expand role shortcuts to session names
 	// These shortcuts let users type "mayor" instead of "gt-mayor"
@@ -464,7 +482,7 @@ func runNudgeChannel(channelName, message, sender string) error {
 	}
 
 	// Send nudges via deliverNudge (respects --mode flag)
-	t := tmux.NewTmux()
+	t := session.BackendForTown(townRoot, tmux.NewTmux())
 	var succeeded, failed, skipped int
 	var failures []string
 
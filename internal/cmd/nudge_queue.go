@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/nudge"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+var nudgeQueueJSON bool
+
+var nudgeQueueCmd = &cobra.Command{
+	Use:   "queue <session>",
+	Short: "Show pending queued nudges for a session",
+	Long: `Show the nudges waiting in a session's queue, in the order the agent
+will receive them at its next turn boundary: urgent first, then FIFO, with
+superseded nudges already collapsed. Nothing is removed from the queue.
+
+The mayor and deacon shortcuts map to their session names.
+
+Examples:
+  gt nudge queue gt-gastown-witness
+  gt nudge queue mayor --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNudgeQueue,
+}
+
+func init() {
+	nudgeQueueCmd.Flags().BoolVar(&nudgeQueueJSON, "json", false, "Output as JSON")
+	nudgeCmd.AddCommand(nudgeQueueCmd)
+}
+
+func runNudgeQueue(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName := args[0]
+	switch sessionName {
+	case "mayor":
+		sessionName = session.MayorSessionName()
+	case "deacon":
+		sessionName = session.DeaconSessionName()
+	}
+
+	pending, err := nudge.List(townRoot, sessionName)
+	if err != nil {
+		return err
+	}
+
+	if nudgeQueueJSON {
+		if pending == nil {
+			pending = []nudge.QueuedNudge{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(pending)
+	}
+
+	fmt.Printf("%s Nudge queue for %s: %d pending\n", style.Bold.Render("📬"), sessionName, len(pending))
+	if len(pending) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(empty)"))
+		return nil
+	}
+	for i, n := range pending {
+		marker := " "
+		if n.Priority == nudge.PriorityUrgent {
+			marker = style.Bold.Render("!")
+		}
+		fmt.Printf("  %d. %s [from %s] %s\n", i+1, marker, n.Sender, n.Message)
+
+		details := formatAge(n.Timestamp)
+		if n.Kind != "" {
+			details += ", kind " + n.Kind
+		}
+		if n.Key != "" {
+			details += ", key " + n.Key
+		}
+		if n.Coalesced > 0 {
+			details += fmt.Sprintf(", replaces %d earlier", n.Coalesced)
+		}
+		fmt.Printf("     %s\n", style.Dim.Render(details))
+	}
+	return nil
+}
@@ -286,25 +286,32 @@ func createPatrolDigestBead(digest PatrolDigest) (string, error) {
 		desc.WriteString("\n")
 	}
 
-	// Build payload JSON with cycle details
-	payloadJSON, err := json.Marshal(digest)
+	title := fmt.Sprintf("Patrol Report %s", digest.Date)
+	return createDigestBead("", title, "patrol.digest", digest, desc.String(), "daily patrol digest")
+}
+
+// createDigestBead records a digest as a permanent event bead in dir (the
+// current directory if empty). Patrol reports and overseer mail digests
+// share it.
+func createDigestBead(dir, title, category string, payload interface{}, description, closeReason string) (string, error) {
+	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return "", fmt.Errorf("marshaling digest payload: %w", err)
 	}
 
 	// Create the digest bead (NOT ephemeral - this is permanent)
-	title := fmt.Sprintf("Patrol Report %s", digest.Date)
 	bdArgs := []string{
 		"create",
 		"--type=event",
 		"--title=" + title,
-		"--event-category=patrol.digest",
+		"--event-category=" + category,
 		"--event-payload=" + string(payloadJSON),
-		"--description=" + desc.String(),
+		"--description=" + description,
 		"--silent",
 	}
 
 	bdCmd := exec.Command("bd", bdArgs...)
+	bdCmd.Dir = dir
 	output, err := bdCmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("creating digest bead: %w\nOutput: %s", err, string(output))
@@ -313,7 +320,8 @@ func createPatrolDigestBead(digest PatrolDigest) (string, error) {
 	digestID := strings.TrimSpace(string(output))
 
 	// Auto-close the digest (it's an audit record, not work)
-	closeCmd := exec.Command("bd", "close", digestID, "--reason=daily patrol digest")
+	closeCmd := exec.Command("bd", "close", digestID, "--reason="+closeReason)
+	closeCmd.Dir = dir
 	_ = closeCmd.Run() // Best effort
 
 	return digestID, nil
@@ -322,35 +330,45 @@ func createPatrolDigestBead(digest PatrolDigest) (string, error) {
 // findExistingPatrolDigest checks if a patrol digest already exists for the given date.
 // Returns the bead ID if found, empty string if not found.
 func findExistingPatrolDigest(dateStr string) (string, error) {
-	expectedTitle := fmt.Sprintf("Patrol Report %s", dateStr)
+	id, _, err := findDigestBead("", fmt.Sprintf("Patrol Report %s", dateStr))
+	return id, err
+}
 
-	// Query event beads with patrol.digest category
+// findDigestBead returns the newest recent digest bead in dir whose title
+// starts with titlePrefix, and when it was created. The ID is empty if
+// there is none.
+func findDigestBead(dir, titlePrefix string) (string, time.Time, error) {
+	// Query recent event beads
 	listCmd := exec.Command("bd", "list",
 		"--type=event",
 		"--json",
 		"--limit=50", // Recent events only
 	)
+	listCmd.Dir = dir
 	listOutput, err := listCmd.Output()
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
 	var events []struct {
-		ID    string `json:"id"`
-		Title string `json:"title"`
+		ID        string    `json:"id"`
+		Title     string    `json:"title"`
+		CreatedAt time.Time `json:"created_at"`
 	}
 
 	if err := json.Unmarshal(listOutput, &events); err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
+	var id string
+	var created time.Time
 	for _, evt := range events {
-		if evt.Title == expectedTitle {
-			return evt.ID, nil
+		if strings.HasPrefix(evt.Title, titlePrefix) && (id == "" || evt.CreatedAt.After(created)) {
+			id, created = evt.ID, evt.CreatedAt
 		}
 	}
 
-	return "", nil
+	return id, created, nil
 }
 
 // deletePatrolDigests deletes ephemeral patrol digest beads for a target date.
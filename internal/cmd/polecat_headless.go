@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/git"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+	"github.com/xcawolfe-amzn/gastown/internal/polecat"
+	"github.com/xcawolfe-amzn/gastown/internal/rig"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
+)
+
+// headlessPane is the placeholder pane for polecats started with
+// gt sling --headless. There is no tmux pane to nudge.
+const headlessPane = "<headless>"
+
+// Run-headless flags
+var (
+	polecatRunHeadlessAgent      string
+	polecatRunHeadlessAccount    string
+	polecatRunHeadlessDoltBranch string
+	polecatRunHeadlessIssue      string
+)
+
+var polecatRunHeadlessCmd = &cobra.Command{
+	Use:    "run-headless <rig>/<polecat>",
+	Short:  "Run a polecat via its agent's non-interactive mode (internal)",
+	Hidden: true, // Started in the background by gt sling --headless
+	Long: `Run a polecat as a supervised child process instead of a tmux session.
+
+The agent is invoked in its non-interactive mode (claude -p, codex exec,
+gemini -p, ...) with the full startup beacon as its prompt. Output is
+captured to the run log under .runtime/headless/, where gt peek finds it.
+
+When the agent exits without having run 'gt done', the exit status is fed
+back into the done flow: exit 0 runs 'gt done', any other status runs
+'gt done --status ESCALATED'. The witness patrol treats a headless run
+like a session: while it runs the polecat is alive, after it exits the
+usual zombie detection applies.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPolecatRunHeadless,
+}
+
+func init() {
+	polecatRunHeadlessCmd.Flags().StringVar(&polecatRunHeadlessAgent, "agent", "", "Agent override (e.g., codex, gemini)")
+	polecatRunHeadlessCmd.Flags().StringVar(&polecatRunHeadlessAccount, "account", "", "Claude Code account handle to use")
+	polecatRunHeadlessCmd.Flags().StringVar(&polecatRunHeadlessDoltBranch, "dolt-branch", "", "Dolt branch for write isolation")
+	polecatRunHeadlessCmd.Flags().StringVar(&polecatRunHeadlessIssue, "issue", "", "Issue to hook before running")
+	polecatCmd.AddCommand(polecatRunHeadlessCmd)
+}
+
+func runPolecatRunHeadless(cmd *cobra.Command, args []string) error {
+	rigName, polecatName, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	claudeConfigDir, _, err := config.ResolveAccountConfigDir(constants.MayorAccountsPath(townRoot), polecatRunHeadlessAccount)
+	if err != nil {
+		return fmt.Errorf("resolving account: %w", err)
+	}
+
+	sessMgr, _, err := getSessionManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := polecat.SessionStartOptions{
+		Issue:            polecatRunHeadlessIssue,
+		RuntimeConfigDir: claudeConfigDir,
+		DoltBranch:       polecatRunHeadlessDoltBranch,
+		Agent:            polecatRunHeadlessAgent,
+	}
+	status, err := sessMgr.RunHeadless(ctx, polecatName, opts)
+	if err != nil {
+		return err
+	}
+
+	code := -1
+	if status.ExitCode != nil {
+		code = *status.ExitCode
+	}
+	fmt.Printf("Headless run %s exited with status %d\n", status.Session, code)
+
+	// Feed the exit status back into the done flow if the agent didn't.
+	polecatMgr, _, err := getPolecatManager(rigName)
+	if err != nil {
+		return err
+	}
+	p, err := polecatMgr.Get(polecatName)
+	if err != nil || !p.State.IsActive() || p.Issue == "" {
+		// Already ran gt done (worktree nuked, state done, or hook cleared).
+		return nil
+	}
+	if ctx.Err() != nil {
+		// Killed deliberately (nuke, shutdown) — leave recovery to the witness.
+		return nil
+	}
+
+	doneArgs := []string{"done", "--status", headlessDoneStatus(status)}
+	done := exec.Command(os.Args[0], doneArgs...) //nolint:gosec // G204: our own binary
+	done.Dir = p.ClonePath
+	done.Env = append(os.Environ(),
+		"GT_RIG="+r.Name,
+		"GT_POLECAT="+polecatName,
+		"GT_ROLE="+fmt.Sprintf("%s/polecats/%s", r.Name, polecatName),
+		"GT_POLECAT_PATH="+p.ClonePath,
+		"GT_TOWN_ROOT="+townRoot,
+	)
+	done.Stdout = os.Stdout
+	done.Stderr = os.Stderr
+	if err := done.Run(); err != nil {
+		return fmt.Errorf("running gt done after headless exit: %w", err)
+	}
+	return nil
+}
+
+// headlessDoneStatus maps a run's exit status to a gt done exit type.
+// A clean exit completes the work; anything else escalates for review.
+func headlessDoneStatus(status *headless.RunStatus) string {
+	if status.Succeeded() {
+		return ExitCompleted
+	}
+	return ExitEscalated
+}
+
+// startHeadlessSession is the --headless counterpart of StartSession's tmux
+// path: it launches the supervisor and records the polecat as working.
+func (s *SpawnedPolecatInfo) startHeadlessSession(townRoot string, r *rig.Rig, t *tmux.Tmux) (string, error) {
+	pane, err := s.startHeadless(townRoot, r.Path)
+	if err != nil {
+		return "", err
+	}
+
+	// Warn-only, as in StartSession: the run is already going.
+	polecatMgr := polecat.NewManager(r, git.NewGit(r.Path), t)
+	if err := polecatMgr.SetAgentStateWithRetry(s.PolecatName, "working"); err != nil {
+		style.PrintWarning("could not update agent state after retries: %v", err)
+	}
+	if err := polecatMgr.SetState(s.PolecatName, polecat.StateWorking); err != nil {
+		style.PrintWarning("could not update issue status to in_progress: %v", err)
+	}
+
+	s.Pane = pane
+	return pane, nil
+}
+
+// startHeadless launches the run-headless supervisor for a spawned polecat
+// in the background and waits briefly for the run to register.
+func (s *SpawnedPolecatInfo) startHeadless(townRoot, rigPath string) (string, error) {
+	// Fail fast if the agent has no non-interactive mode, rather than
+	// leaving a supervisor that can never start.
+	rc := config.ResolveRoleAgentConfig("polecat", townRoot, rigPath)
+	if s.agent != "" {
+		override, _, err := config.ResolveAgentConfigWithOverride(townRoot, rigPath, s.agent)
+		if err != nil {
+			return "", err
+		}
+		rc = override
+	}
+	if !config.SupportsNonInteractive(rc.Provider) {
+		return "", fmt.Errorf("agent %q has no non-interactive mode; cannot use --headless", rc.Provider)
+	}
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding executable: %w", err)
+	}
+
+	args := []string{"polecat", "run-headless", s.RigName + "/" + s.PolecatName}
+	if s.hookBead != "" {
+		args = append(args, "--issue", s.hookBead) // Names the work in the startup beacon
+	}
+	if s.agent != "" {
+		args = append(args, "--agent", s.agent)
+	}
+	if s.account != "" {
+		args = append(args, "--account", s.account)
+	}
+	if s.DoltBranch != "" {
+		args = append(args, "--dolt-branch", s.DoltBranch)
+	}
+
+	dir := session.HeadlessDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating headless dir: %w", err)
+	}
+	// Clear the previous run's status so its exit isn't mistaken for ours.
+	_ = os.Remove(headless.StatusPath(dir, s.SessionName))
+	supLog, err := os.OpenFile(filepath.Join(dir, s.SessionName+".supervisor.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return "", fmt.Errorf("opening supervisor log: %w", err)
+	}
+	defer supLog.Close()
+
+	sup := exec.Command(gtPath, args...) //nolint:gosec // G204: our own binary
+	sup.Dir = s.ClonePath
+	sup.Stdin = nil
+	sup.Stdout = supLog
+	sup.Stderr = supLog
+	detachProcess(sup) // Outlive gt sling and its terminal
+	if err := sup.Start(); err != nil {
+		return "", fmt.Errorf("starting headless supervisor: %w", err)
+	}
+	_ = sup.Process.Release()
+
+	fmt.Printf("Starting headless run for %s/%s...\n", s.RigName, s.PolecatName)
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if headless.RunAlive(dir, s.SessionName) {
+			return headlessPane, nil
+		}
+		if st, _ := headless.ReadRunStatus(dir, s.SessionName); st != nil && st.ExitedAt != nil {
+			return "", fmt.Errorf("headless run exited immediately (see %s)", headless.LogPath(dir, s.SessionName))
+		}
+		time.Sleep(constants.PollInterval)
+	}
+	style.PrintWarning("headless run for %s has not registered yet; see %s", s.SessionName, supLog.Name())
+	return headlessPane, nil
+}
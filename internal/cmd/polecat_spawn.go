@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,13 +10,17 @@ import (
 	"time"
 
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/budget"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/deps"
 	"github.com/xcawolfe-amzn/gastown/internal/doltserver"
 	"github.com/xcawolfe-amzn/gastown/internal/events"
 	"github.com/xcawolfe-amzn/gastown/internal/git"
 	"github.com/xcawolfe-amzn/gastown/internal/polecat"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
@@ -32,8 +37,10 @@ type SpawnedPolecatInfo struct {
 	BaseBranch  string // Effective base branch (e.g., "main", "integration/epic-id")
 
 	// Internal fields for deferred session start
-	account string
-	agent   string
+	account  string
+	agent    string
+	headless bool
+	hookBead string
 }
 
 // AgentID returns the agent identifier (e.g., "gastown/polecats/Toast")
@@ -54,6 +61,7 @@ type SlingSpawnOptions struct {
 	HookBead   string // Bead ID to set as hook_bead at spawn time (atomic assignment)
 	Agent      string // Agent override for this spawn (e.g., "gemini", "codex", "claude-haiku")
 	BaseBranch string // Override base branch for polecat worktree (e.g., "develop", "release/v2")
+	Headless   bool   // Run via the agent's non-interactive mode instead of a tmux session
 }
 
 // SpawnPolecatForSling creates a fresh polecat and optionally starts its session.
@@ -97,6 +105,23 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		return nil, fmt.Errorf("admission control: %w", err)
 	}
 
+	// Budget guardrail: no new polecats once a hard spend limit is hit.
+	convoysOf := func(issueID string) []string { return convoy.TrackingConvoys(townRoot, issueID) }
+	if err := budget.CheckSpawn(townRoot, rigName, opts.HookBead, convoysOf); err != nil {
+		return nil, err
+	}
+
+	// Agent version guardrail: refuse CLIs that failed gt agents probe.
+	spawnAgent := opts.Agent
+	if spawnAgent == "" {
+		spawnAgent, _ = config.ResolveRoleAgentName("polecat", townRoot, r.Path)
+	}
+	if preset := deps.ResolveAgentPresetName(townRoot, r.Path, spawnAgent); preset != "" {
+		if err := deps.CheckAgent(context.Background(), deps.ExecRunner, townRoot, preset); err != nil {
+			return nil, err
+		}
+	}
+
 	// Allocate a new polecat name
 	polecatName, err := polecatMgr.AllocateName()
 	if err != nil {
@@ -222,6 +247,8 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 		BaseBranch:  effectiveBranch,
 		account:     opts.Account,
 		agent:       opts.Agent,
+		headless:    opts.Headless,
+		hookBead:    opts.HookBead,
 	}, nil
 }
 
@@ -262,6 +289,9 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 
 	// Start session
 	t := tmux.NewTmux()
+	if s.headless {
+		return s.startHeadlessSession(townRoot, r, t)
+	}
 	polecatSessMgr := polecat.NewSessionManager(t, r)
 
 	fmt.Printf("Starting session for %s/%s...\n", s.RigName, s.PolecatName)
@@ -282,10 +312,13 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	}
 
 	// Wait for runtime to be fully ready before returning.
-	spawnTownRoot := filepath.Dir(r.Path)
-	runtimeConfig := config.ResolveRoleAgentConfig("polecat", spawnTownRoot, r.Path)
-	if err := t.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
-		style.PrintWarning("runtime may not be fully ready: %v", err)
+	sessions := session.BackendForTown(townRoot, t)
+	if _, onTmux := sessions.(*tmux.Tmux); onTmux {
+		spawnTownRoot := filepath.Dir(r.Path)
+		runtimeConfig := config.ResolveRoleAgentConfig("polecat", spawnTownRoot, r.Path)
+		if err := t.WaitForRuntimeReady(s.SessionName, runtimeConfig, 30*time.Second); err != nil {
+			style.PrintWarning("runtime may not be fully ready: %v", err)
+		}
 	}
 
 	// Update agent state with retry logic (gt-94llt7: fail-safe Dolt writes).
@@ -308,10 +341,10 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 
 	// Get pane — if this fails, the session may have died during startup.
 	// Kill the dead session to prevent "session already running" on next attempt (gt-jn40ft).
-	pane, err := getSessionPane(s.SessionName)
+	pane, err := sessionPane(sessions, s.SessionName)
 	if err != nil {
-		// Session likely died — clean up the tmux session so it doesn't block re-sling
-		_ = t.KillSession(s.SessionName)
+		// Session likely died — clean up the session so it doesn't block re-sling
+		_ = sessions.KillSessionWithProcesses(s.SessionName)
 		return "", fmt.Errorf("getting pane for %s (session likely died during startup): %w", s.SessionName, err)
 	}
 
@@ -2,7 +2,10 @@
 
 package cmd
 
-import "syscall"
+import (
+	"os/exec"
+	"syscall"
+)
 
 // isProcessRunning checks if a process with the given PID exists.
 func isProcessRunning(pid int) bool {
@@ -18,3 +21,9 @@ func isProcessRunning(pid int) bool {
 	// EPERM means process exists but we don't have permission to signal it.
 	return err == syscall.EPERM
 }
+
+// detachProcess starts cmd in its own session, so it survives the
+// terminal or process that launched it (and their signals).
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
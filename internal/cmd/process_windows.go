@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"math"
+	"os/exec"
 
 	"golang.org/x/sys/windows"
 )
@@ -31,3 +32,7 @@ func isProcessRunning(pid int) bool {
 
 	return exitCode == processStillActive
 }
+
+// detachProcess is a no-op on Windows: the child already runs
+// independently of its parent.
+func detachProcess(cmd *exec.Cmd) {}
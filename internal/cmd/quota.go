@@ -11,7 +11,9 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
 	"github.com/xcawolfe-amzn/gastown/internal/quota"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
 	ttmux "github.com/xcawolfe-amzn/gastown/internal/tmux"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
@@ -24,6 +26,42 @@ func (quotaLogger) Warn(format string, args ...interface{}) {
 	style.PrintWarning(format, args...)
 }
 
+// quotaSessions returns the town's session backend as the quota package
+// uses it: a client for scanning and an executor for restarts.
+func quotaSessions(townRoot string, t *ttmux.Tmux) (quota.TmuxClient, quota.TmuxExecutor) {
+	if c, ok := session.BackendForTown(townRoot, t).(*headless.Client); ok {
+		return c, headlessExecutor{c}
+	}
+	return t, t
+}
+
+// headlessExecutor adapts the respawn-pane lifecycle to hosted headless
+// sessions. A session's name stands in for its pane, and respawning
+// replaces the session's host, which stops the old agent and starts the
+// new one on a fresh log, so the steps before it have nothing to do.
+type headlessExecutor struct {
+	*headless.Client
+}
+
+func (e headlessExecutor) GetPaneID(session string) (string, error) {
+	if ok, _ := e.HasSession(session); !ok {
+		return "", fmt.Errorf("%w: %s", headless.ErrSessionNotFound, session)
+	}
+	return session, nil
+}
+
+func (headlessExecutor) SetRemainOnExit(string, bool) error { return nil }
+
+func (headlessExecutor) KillPaneProcesses(string) error { return nil }
+
+func (headlessExecutor) ClearHistory(string) error { return nil }
+
+func (e headlessExecutor) RespawnPane(pane, command string) error {
+	return e.RespawnSession(pane, command)
+}
+
+func (headlessExecutor) AcceptBypassPermissionsWarning(string) error { return nil }
+
 // Quota command flags
 var (
 	quotaJSON bool
@@ -43,7 +81,9 @@ Commands:
   gt quota status            Show account quota status
   gt quota scan              Detect rate-limited sessions
   gt quota rotate            Swap blocked sessions to available accounts
-  gt quota clear             Mark account(s) as available again`,
+  gt quota forecast          Project when each account will hit its limit
+  gt quota clear             Mark account(s) as available again
+  gt quota fallback          Move stalled sessions to a fallback agent`,
 }
 
 var quotaStatusCmd = &cobra.Command{
@@ -62,13 +102,14 @@ Examples:
 
 // QuotaStatusItem represents an account in status output.
 type QuotaStatusItem struct {
-	Handle    string `json:"handle"`
-	Email     string `json:"email"`
-	Status    string `json:"status"`
-	LimitedAt string `json:"limited_at,omitempty"`
-	ResetsAt  string `json:"resets_at,omitempty"`
-	LastUsed  string `json:"last_used,omitempty"`
-	IsDefault bool   `json:"is_default"`
+	Handle       string `json:"handle"`
+	Email        string `json:"email"`
+	Status       string `json:"status"`
+	LimitedAt    string `json:"limited_at,omitempty"`
+	ResetsAt     string `json:"resets_at,omitempty"`
+	ResetsAtTime string `json:"resets_at_time,omitempty"`
+	LastUsed     string `json:"last_used,omitempty"`
+	IsDefault    bool   `json:"is_default"`
 }
 
 func runQuotaStatus(cmd *cobra.Command, args []string) error {
@@ -118,13 +159,14 @@ func printQuotaStatusJSON(acctCfg *config.AccountsConfig, state *config.QuotaSta
 			status = string(config.QuotaStatusAvailable)
 		}
 		items = append(items, QuotaStatusItem{
-			Handle:    handle,
-			Email:     acct.Email,
-			Status:    status,
-			LimitedAt: qs.LimitedAt,
-			ResetsAt:  qs.ResetsAt,
-			LastUsed:  qs.LastUsed,
-			IsDefault: handle == acctCfg.Default,
+			Handle:       handle,
+			Email:        acct.Email,
+			Status:       status,
+			LimitedAt:    qs.LimitedAt,
+			ResetsAt:     qs.ResetsAt,
+			ResetsAtTime: qs.ResetsAtTime,
+			LastUsed:     qs.LastUsed,
+			IsDefault:    handle == acctCfg.Default,
 		})
 	}
 	enc := json.NewEncoder(os.Stdout)
@@ -162,7 +204,9 @@ func printQuotaStatusText(acctCfg *config.AccountsConfig, state *config.QuotaSta
 		case config.QuotaStatusLimited:
 			badge = style.Error.Render("limited")
 			limited++
-			if qs.ResetsAt != "" {
+			if reset, ok := quota.ResetTime(qs); ok {
+				badge += style.Dim.Render(" (resets in " + formatQuotaWait(time.Until(reset)) + ")")
+			} else if qs.ResetsAt != "" {
 				badge += style.Dim.Render(" (resets " + qs.ResetsAt + ")")
 			}
 		case config.QuotaStatusCooldown:
@@ -221,8 +265,8 @@ func runQuotaScan(cmd *cobra.Command, args []string) error {
 	// acctCfg can be nil if no accounts configured — scan still works
 
 	// Create scanner
-	t := ttmux.NewTmux()
-	scanner, err := quota.NewScanner(t, nil, acctCfg)
+	sessions, _ := quotaSessions(townRoot, ttmux.NewTmux())
+	scanner, err := quota.NewScanner(sessions, nil, acctCfg)
 	if err != nil {
 		return fmt.Errorf("creating scanner: %w", err)
 	}
@@ -254,16 +298,10 @@ func updateQuotaState(townRoot string, results []quota.ScanResult, acctCfg *conf
 		}
 		mgr.EnsureAccountsTracked(state, acctCfg.Accounts)
 
-		now := time.Now().UTC().Format(time.RFC3339)
+		now := time.Now()
 		for _, r := range results {
 			if r.RateLimited && r.AccountHandle != "" {
-				existing := state.Accounts[r.AccountHandle]
-				state.Accounts[r.AccountHandle] = config.AccountQuotaState{
-					Status:    config.QuotaStatusLimited,
-					LimitedAt: now,
-					ResetsAt:  r.ResetsAt,
-					LastUsed:  existing.LastUsed,
-				}
+				state.Accounts[r.AccountHandle] = quota.LimitedState(state.Accounts[r.AccountHandle], r.ResetsAt, now)
 			}
 		}
 
@@ -315,7 +353,8 @@ func printScanText(results []quota.ScanResult) error {
 
 // Rotate command flags
 var (
-	rotateDryRun bool
+	rotateDryRun     bool
+	rotatePreemptive bool
 )
 
 var quotaRotateCmd = &cobra.Command{
@@ -332,8 +371,13 @@ The rotation process:
   3. Updates tmux session environment with new CLAUDE_CONFIG_DIR
   4. Restarts blocked sessions via respawn-pane
 
+With --preemptive, sessions are rotated before they are blocked: any session
+on an account forecast to run out within 20 minutes (see gt quota forecast)
+moves to an account with headroom. The daemon runs this every heartbeat.
+
 Examples:
   gt quota rotate              # Rotate all blocked sessions
+  gt quota rotate --preemptive # Rotate sessions on nearly exhausted accounts
   gt quota rotate --dry-run    # Show plan without executing
   gt quota rotate --json       # JSON output`,
 	RunE: runQuotaRotate,
@@ -356,20 +400,29 @@ func runQuotaRotate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create scanner and plan rotation
-	t := ttmux.NewTmux()
-	scanner, err := quota.NewScanner(t, nil, acctCfg)
+	sessions, executor := quotaSessions(townRoot, ttmux.NewTmux())
+	scanner, err := quota.NewScanner(sessions, nil, acctCfg)
 	if err != nil {
 		return fmt.Errorf("creating scanner: %w", err)
 	}
 
 	mgr := quota.NewManager(townRoot)
-	plan, err := quota.PlanRotation(scanner, mgr, acctCfg)
+	var plan *quota.RotatePlan
+	if rotatePreemptive {
+		plan, err = planPreemptiveRotation(townRoot, scanner, mgr, acctCfg)
+	} else {
+		plan, err = quota.PlanRotation(scanner, mgr, acctCfg)
+	}
 	if err != nil {
 		return fmt.Errorf("planning rotation: %w", err)
 	}
 
 	if len(plan.LimitedSessions) == 0 {
-		fmt.Printf(" %s No rate-limited sessions detected\n", style.SuccessPrefix)
+		if rotatePreemptive {
+			fmt.Printf(" %s No sessions on accounts forecast to run out\n", style.SuccessPrefix)
+		} else {
+			fmt.Printf(" %s No rate-limited sessions detected\n", style.SuccessPrefix)
+		}
 		return nil
 	}
 
@@ -424,7 +477,7 @@ func runQuotaRotate(cmd *cobra.Command, args []string) error {
 	if !quotaJSON {
 		fmt.Println()
 	}
-	rotator := quota.NewRotator(t, t, mgr, acctCfg, buildRestartCommand, quotaLogger{},
+	rotator := quota.NewRotator(sessions, executor, mgr, acctCfg, buildRestartCommand, quotaLogger{},
 		townRoot, "" /* agentName: default "claude" */, symlinkSessionToConfigDir)
 	results := rotator.Execute(plan, sortedSessions)
 
@@ -514,6 +567,7 @@ func init() {
 	quotaScanCmd.Flags().BoolVar(&scanUpdate, "update", false, "Update quota state with detected limits")
 
 	quotaRotateCmd.Flags().BoolVar(&rotateDryRun, "dry-run", false, "Show plan without executing")
+	quotaRotateCmd.Flags().BoolVar(&rotatePreemptive, "preemptive", false, "Rotate sessions on accounts forecast to run out soon")
 	quotaRotateCmd.Flags().BoolVar(&quotaJSON, "json", false, "Output as JSON")
 
 	quotaCmd.AddCommand(quotaStatusCmd)
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/checkpoint"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/quota"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	ttmux "github.com/xcawolfe-amzn/gastown/internal/tmux"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+var fallbackDryRun bool
+
+var quotaFallbackCmd = &cobra.Command{
+	Use:   "fallback",
+	Short: "Move stalled sessions to their role's fallback agent",
+	Long: `Restart sessions on the next agent in their role's fallback chain.
+
+Fallback chains are configured per role in settings/config.json:
+
+  "agent_fallback": {
+    "polecat": ["claude", "codex", "gemini"],
+    "witness": ["claude", "gemini"]
+  }
+
+A session falls back when:
+  - it is rate-limited and no spare account is left to rotate to, or
+  - its provider is failing (API 5xx, overloaded, dropped streams)
+
+The session is checkpointed, GT_AGENT is switched to the next agent, and
+the pane is respawned. The hook stays on the bead, so the new agent picks
+the work up on prime along with the checkpoint.
+
+Sessions return to their primary agent once it has had time to recover
+(30 minutes, and for rate limits only when an account is available) and a
+scan shows it working: another session runs it without failing. Otherwise
+one session is moved back as a probe, and the rest follow once the probe
+scans clean; a probe that fails again falls back as before.
+
+The daemon runs this every heartbeat when any chain is configured.
+
+Examples:
+  gt quota fallback              # Fall back / restore as needed
+  gt quota fallback --dry-run    # Show plan without executing
+  gt quota fallback --json       # JSON output`,
+	RunE: runQuotaFallback,
+}
+
+func init() {
+	quotaFallbackCmd.Flags().BoolVar(&fallbackDryRun, "dry-run", false, "Show plan without executing")
+	quotaFallbackCmd.Flags().BoolVar(&quotaJSON, "json", false, "Output as JSON")
+	quotaCmd.AddCommand(quotaFallbackCmd)
+}
+
+func runQuotaFallback(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	if len(settings.AgentFallback) == 0 {
+		if !quotaJSON {
+			fmt.Printf(" %s No agent fallback chains configured\n", style.SuccessPrefix)
+		}
+		return nil
+	}
+
+	// This command restarts other sessions. buildRestartCommand prefers the
+	// process env over the target's session env, so drop our own agent identity.
+	_ = os.Unsetenv("GT_AGENT")
+	_ = os.Unsetenv("GT_PROCESS_NAMES")
+
+	// Accounts are optional: without a pool every rate limit is terminal.
+	acctCfg, _ := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	sessions, executor := quotaSessions(townRoot, ttmux.NewTmux())
+
+	limited, available, err := fallbackLimitedSessions(sessions, townRoot, acctCfg)
+	if err != nil {
+		return err
+	}
+	downScanner, err := quota.NewScanner(sessions, constants.DefaultProviderDownPatterns, acctCfg)
+	if err != nil {
+		return fmt.Errorf("creating scanner: %w", err)
+	}
+	downResults, err := downScanner.ScanAll()
+	if err != nil {
+		return fmt.Errorf("scanning sessions: %w", err)
+	}
+	var down []quota.ScanResult
+	for _, r := range downResults {
+		if r.RateLimited {
+			down = append(down, r)
+		}
+	}
+
+	agents, err := fallbackSessionAgents(sessions, townRoot)
+	if err != nil {
+		return err
+	}
+	state, err := quota.LoadFallbackState(townRoot)
+	if err != nil {
+		return err
+	}
+	state.Prune(agents)
+
+	hasPool := acctCfg != nil && len(acctCfg.Accounts) > 0
+	spare := !hasPool || len(available) > 0
+	actions := quota.PlanFallback(limited, down, agents, settings.AgentFallback, state, spare, time.Now())
+	for i := range actions {
+		if actions[i].Restore && actions[i].Reason == quota.FallbackLimited && hasPool {
+			actions[i].Account = available[0]
+		}
+	}
+
+	if !fallbackDryRun {
+		switcher := quota.NewAgentSwitcher(executor, acctCfg, buildRestartCommand,
+			func(sess, note string) error { return checkpointForFallback(townRoot, sess, note) },
+			quotaLogger{})
+		for i := range actions {
+			if err := switcher.Switch(actions[i]); err != nil {
+				actions[i].Error = err.Error()
+				continue
+			}
+			actions[i].Switched = true
+			state.Record(actions[i], time.Now())
+		}
+	}
+	if err := quota.SaveFallbackState(townRoot, state); err != nil {
+		return fmt.Errorf("saving fallback state: %w", err)
+	}
+
+	if quotaJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(actions)
+	}
+
+	if len(actions) == 0 {
+		fmt.Printf(" %s No sessions need an agent switch (%d on fallback)\n",
+			style.SuccessPrefix, len(state.Sessions))
+		return nil
+	}
+	for _, a := range actions {
+		verb := "fallback"
+		switch {
+		case a.Probe:
+			verb = "probe"
+		case a.Restore:
+			verb = "restore"
+		}
+		line := fmt.Sprintf("%-25s %s → %s %s", a.Session,
+			style.Dim.Render(a.From), style.Success.Render(a.To),
+			style.Dim.Render("("+verb+", "+string(a.Reason)+")"))
+		switch {
+		case fallbackDryRun:
+			fmt.Printf(" %s %s\n", style.ArrowPrefix, line)
+		case a.Error != "":
+			fmt.Printf(" %s %s: %s\n", style.ErrorPrefix, a.Session, a.Error)
+		default:
+			fmt.Printf(" %s %s\n", style.SuccessPrefix, line)
+		}
+	}
+	if fallbackDryRun {
+		fmt.Println()
+		fmt.Println(style.Dim.Render(" (dry run — no changes made)"))
+	}
+	return nil
+}
+
+// fallbackLimitedSessions returns rate-limited sessions that account
+// rotation cannot place, and the accounts still available.
+func fallbackLimitedSessions(t quota.TmuxClient, townRoot string, acctCfg *config.AccountsConfig) ([]quota.ScanResult, []string, error) {
+	scanner, err := quota.NewScanner(t, nil, acctCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating scanner: %w", err)
+	}
+
+	if acctCfg == nil || len(acctCfg.Accounts) == 0 {
+		results, err := scanner.ScanAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("scanning sessions: %w", err)
+		}
+		var limited []quota.ScanResult
+		for _, r := range results {
+			if r.RateLimited {
+				limited = append(limited, r)
+			}
+		}
+		return limited, nil, nil
+	}
+
+	plan, err := quota.PlanRotation(scanner, quota.NewManager(townRoot), acctCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("planning rotation: %w", err)
+	}
+	var limited []quota.ScanResult
+	for _, r := range plan.LimitedSessions {
+		if _, ok := plan.Assignments[r.Session]; !ok {
+			limited = append(limited, r)
+		}
+	}
+	return limited, plan.AvailableAccounts, nil
+}
+
+// fallbackSessionAgents maps each live agent session to its role and the
+// agent it runs (GT_AGENT, else the role's configured agent).
+func fallbackSessionAgents(t quota.TmuxClient, townRoot string) (map[string]quota.SessionAgent, error) {
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	agents := make(map[string]quota.SessionAgent)
+	for _, sess := range sessions {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			continue
+		}
+		role := string(identity.Role)
+		agent, _ := t.GetEnvironment(sess, "GT_AGENT")
+		if agent == "" {
+			rigPath := ""
+			if identity.Rig != "" {
+				rigPath = filepath.Join(townRoot, identity.Rig)
+			}
+			agent, _ = config.ResolveRoleAgentName(role, townRoot, rigPath)
+		}
+		agents[sess] = quota.SessionAgent{Role: role, Agent: agent}
+	}
+	return agents, nil
+}
+
+// checkpointForFallback writes a checkpoint for polecat and crew sessions so
+// the next agent can resume where the previous one stopped.
+func checkpointForFallback(townRoot, sess, note string) error {
+	identity, err := session.ParseSessionName(sess)
+	if err != nil {
+		return err
+	}
+	role := Role(identity.Role)
+	if role != RolePolecat && role != RoleCrew {
+		return nil
+	}
+	workDir, err := sessionWorkDir(sess, townRoot)
+	if err != nil {
+		return err
+	}
+	cp, err := checkpoint.Capture(workDir)
+	if err != nil {
+		return err
+	}
+	cp.WithNotes(note)
+	if prev, err := checkpoint.Read(workDir); err == nil && prev != nil {
+		cp.WithMolecule(prev.MoleculeID, prev.CurrentStep, prev.StepTitle)
+	}
+	if hooked := detectHookedBead(workDir, RoleInfo{Role: role, Rig: identity.Rig, Polecat: identity.Name}); hooked != "" {
+		cp.WithHookedBead(hooked)
+	}
+	cp.SessionID = sess
+	return checkpoint.Write(workDir, cp)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+	"github.com/xcawolfe-amzn/gastown/internal/quota"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+var quotaForecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Project when each account will hit its limit",
+	Long: `Forecast quota exhaustion for each registered account.
+
+Spend is attributed to accounts by 'gt costs record' (from the session's
+CLAUDE_CONFIG_DIR). For each account the forecast compares:
+
+  window   spend in the trailing 5h usage window
+  rate     spend over the last hour
+  capacity spend in the window before the account was last limited
+
+and projects when the remaining capacity runs out at the current rate.
+Accounts are learned as they hit limits; until then there is no forecast.
+
+Limited accounts show when they reset. Accounts whose reset time has passed
+are returned to available.
+
+Examples:
+  gt quota forecast          # Text output
+  gt quota forecast --json   # JSON output`,
+	RunE: runQuotaForecast,
+}
+
+func init() {
+	quotaForecastCmd.Flags().BoolVar(&quotaJSON, "json", false, "Output as JSON")
+	quotaCmd.AddCommand(quotaForecastCmd)
+}
+
+func runQuotaForecast(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+	acctCfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("no accounts configured (run 'gt account add' first): %w", err)
+	}
+
+	now := time.Now()
+	forecasts, _, err := loadQuotaForecasts(townRoot, acctCfg, now)
+	if err != nil {
+		return err
+	}
+
+	if quotaJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(forecasts)
+	}
+
+	fmt.Println(style.Bold.Render("Account Quota Forecast"))
+	fmt.Println()
+	fmt.Printf("   %-12s %10s %10s %10s  %s\n", "Account", "Window", "Rate/h", "Capacity", "Outlook")
+	for _, f := range forecasts {
+		capacity := "-"
+		if f.CapacityUSD > 0 {
+			capacity = fmt.Sprintf("$%.2f", f.CapacityUSD)
+		}
+		var outlook string
+		switch {
+		case f.Status != config.QuotaStatusAvailable && f.ResetsAt != nil:
+			outlook = style.Error.Render("limited") + style.Dim.Render(", resets in "+formatQuotaWait(f.ResetsAt.Sub(now)))
+		case f.Status != config.QuotaStatusAvailable:
+			outlook = style.Error.Render(string(f.Status))
+		case f.AtRisk(now, quota.DefaultPreemptLead):
+			outlook = style.Warning.Render("runs out in " + formatQuotaWait(f.ExhaustsAt.Sub(now)))
+		case f.ExhaustsAt != nil:
+			outlook = style.Success.Render("ok") + style.Dim.Render(", runs out in "+formatQuotaWait(f.ExhaustsAt.Sub(now)))
+		default:
+			outlook = style.Success.Render("ok")
+		}
+		fmt.Printf("   %-12s %10s %10s %10s  %s\n", f.Handle,
+			fmt.Sprintf("$%.2f", f.WindowSpendUSD),
+			fmt.Sprintf("$%.2f", f.RateUSDPerHour),
+			capacity, outlook)
+	}
+	return nil
+}
+
+// loadQuotaForecasts refreshes quota state from the cost log (learning
+// capacities, releasing reset accounts) and forecasts every account.
+func loadQuotaForecasts(townRoot string, acctCfg *config.AccountsConfig, now time.Time) ([]quota.Forecast, *config.QuotaState, error) {
+	// Capacity learning looks back a full window before the limit.
+	entries, err := costs.ReadSpendSince(costs.LogPath(), now.Add(-3*quota.DefaultLimitWindow))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mgr := quota.NewManager(townRoot)
+	if _, err := mgr.Refresh(entries, now); err != nil {
+		return nil, nil, fmt.Errorf("refreshing quota state: %w", err)
+	}
+	state, err := mgr.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading quota state: %w", err)
+	}
+	mgr.EnsureAccountsTracked(state, acctCfg.Accounts)
+	return quota.ForecastAccounts(state, entries, now), state, nil
+}
+
+// planPreemptiveRotation plans rotating sessions off accounts forecast to
+// run out within quota.DefaultPreemptLead.
+func planPreemptiveRotation(townRoot string, scanner *quota.Scanner, mgr *quota.Manager, acctCfg *config.AccountsConfig) (*quota.RotatePlan, error) {
+	now := time.Now()
+	forecasts, state, err := loadQuotaForecasts(townRoot, acctCfg, now)
+	if err != nil {
+		return nil, err
+	}
+	results, err := scanner.ScanAll()
+	if err != nil {
+		return nil, fmt.Errorf("scanning sessions: %w", err)
+	}
+	return quota.PlanPreemptiveRotation(results, state, forecasts, mgr, now, quota.DefaultPreemptLead), nil
+}
+
+// formatQuotaWait renders a duration as "2h05m" or "12m".
+func formatQuotaWait(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
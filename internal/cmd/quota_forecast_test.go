@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatQuotaWait(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "<1m"},
+		{12 * time.Minute, "12m"},
+		{2*time.Hour + 5*time.Minute, "2h05m"},
+	}
+	for _, tt := range tests {
+		if got := formatQuotaWait(tt.d); got != tt.want {
+			t.Errorf("formatQuotaWait(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
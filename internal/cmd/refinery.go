@@ -800,6 +800,9 @@ func runRefineryReadyAll(eng *refinery.Engineer, rigName string) error {
 		if mr.BlockedBy != "" {
 			flags = append(flags, fmt.Sprintf("blocked-by:%s", mr.BlockedBy))
 		}
+		if mr.HeldBy != "" {
+			flags = append(flags, "held:convoy-landing")
+		}
 		if !mr.BranchExistsLocal && !mr.BranchExistsRemote {
 			flags = append(flags, "no-branch")
 		}
@@ -853,6 +856,9 @@ func runRefineryBlocked(cmd *cobra.Command, args []string) error {
 		if mr.BlockedBy != "" {
 			fmt.Printf("     Blocked by: %s\n", mr.BlockedBy)
 		}
+		if mr.HeldBy != "" {
+			fmt.Printf("     Held: %s\n", mr.HeldBy)
+		}
 	}
 
 	return nil
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+)
+
+// Session host flags
+var (
+	sessionHostDir     string
+	sessionHostWorkDir string
+)
+
+var sessionHostCmd = &cobra.Command{
+	Use:    "host <session> <command>",
+	Short:  "Serve a headless session (internal)",
+	Hidden: true, // Started in the background by the headless session backend
+	Long: `Run a command as a headless session and serve it until it exits.
+
+The command runs on a pseudo-terminal. Its scrollback is mirrored to
+<dir>/<session>.log, its status (agent and host PIDs, exit code) is kept in
+<dir>/<session>.json, and input appended to <dir>/<session>.in is typed
+into it. That is how gt nudge, gt sling and the daemon reach headless
+agents from their own processes.
+
+The host runs detached from whoever started it, so headless agents
+survive a daemon restart. Stopping the host (SIGTERM) stops the agent.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSessionHost,
+}
+
+func init() {
+	sessionHostCmd.Flags().StringVar(&sessionHostDir, "dir", "", "Directory for the session's files")
+	sessionHostCmd.Flags().StringVar(&sessionHostWorkDir, "workdir", "", "Working directory for the command")
+	_ = sessionHostCmd.MarkFlagRequired("dir")
+	sessionCmd.AddCommand(sessionHostCmd)
+}
+
+func runSessionHost(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	_, err := headless.Host(ctx, headless.HostOptions{
+		Dir:     sessionHostDir,
+		Session: args[0],
+		WorkDir: sessionHostWorkDir,
+		Command: args[1],
+	})
+	return err
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/xcawolfe-amzn/gastown/internal/formula"
+	"github.com/xcawolfe-amzn/gastown/internal/sim"
+	"github.com/xcawolfe-amzn/gastown/internal/style"
+	"github.com/xcawolfe-amzn/gastown/internal/workspace"
+)
+
+// Sim command flags
+var (
+	simScript  string
+	simRig     string
+	simOn      string
+	simVars    []string
+	simLogJSON bool
+)
+
+var simCmd = &cobra.Command{
+	Use:     "sim",
+	GroupID: GroupDiag,
+	Short:   "Run molecules against a scripted fake agent",
+	Long: `Simulate polecat work without an LLM.
+
+A simulation script tells a fake agent what to do on its real worktree:
+commit, run gt done, ask the witness for help, crash or hang. Polecats
+spawned with the "sim" agent follow the script, so witness patrol, done,
+merge queue and convoy-close flows can be regression-tested end to end on
+a laptop. gt sim run registers the agent in the town's settings/agents.json;
+it runs as gt-sim, a link to gt under .runtime/sim/bin/.
+
+Script format (TOML); the first [[agent]] whose match fits the polecat
+name or hooked issue ID is used:
+
+  [[agent]]
+  match = "nux"                      # glob; omit to match every polecat
+  actions = [{ help = "tests fail on CI" }, { hang = true }]
+
+  [[agent]]
+  actions = [
+    { commit = "Implement {{issue}}" },  # file defaults to .sim/<issue>.txt
+    { sleep = "5s" },
+    { done = "COMPLETED" },              # or ESCALATED, DEFERRED
+  ]
+
+Other actions: { crash = 1 } exits with that code, { run = "make test" }
+runs a shell command in the worktree. Text may use {{issue}},
+{{polecat}} and {{rig}}. An agent that runs out of actions idles until
+its session is killed.
+
+Every action and every nudge the agent receives is logged to
+.runtime/sim/log.jsonl; view it with 'gt sim log'.`,
+	RunE: requireSubcommand,
+}
+
+var simRunCmd = &cobra.Command{
+	Use:   "run <formula>",
+	Short: "Dispatch a formula to polecats running a sim script",
+	Long: `Dispatch a formula with polecats that follow a simulation script.
+
+The script is validated and installed as the town's active script, then
+the formula is dispatched with --agent sim: convoy formulas through
+'gt formula run', other formulas through 'gt sling'.
+
+Examples:
+  gt sim run mol-polecat-work --script happy.toml --rig gastown --on gt-abc
+  gt sim run code-review --script reviewers.toml --rig gastown
+  gt sim run towers-of-hanoi --script crashy.toml --rig gastown --var disks=3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSimRun,
+}
+
+var simAgentCmd = &cobra.Command{
+	Use:    "agent",
+	Short:  "Run as a scripted fake agent (started by the sim preset)",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runSimAgent,
+}
+
+var simLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the simulation event log",
+	Args:  cobra.NoArgs,
+	RunE:  runSimLog,
+}
+
+func init() {
+	simRunCmd.Flags().StringVar(&simScript, "script", "", "Simulation script (required)")
+	simRunCmd.Flags().StringVar(&simRig, "rig", "", "Target rig (default: current rig)")
+	simRunCmd.Flags().StringVar(&simOn, "on", "", "Apply the formula to an existing bead")
+	simRunCmd.Flags().StringArrayVar(&simVars, "var", nil, "Formula variable (key=value), can be repeated")
+	_ = simRunCmd.MarkFlagRequired("script")
+
+	simAgentCmd.Flags().StringVar(&simScript, "script", "", "Simulation script (default: the town's active script)")
+
+	simLogCmd.Flags().BoolVar(&simLogJSON, "json", false, "Output as JSON lines")
+
+	simCmd.AddCommand(simRunCmd)
+	simCmd.AddCommand(simAgentCmd)
+	simCmd.AddCommand(simLogCmd)
+	rootCmd.AddCommand(simCmd)
+}
+
+func runSimRun(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	formulaPath, err := findFormulaFile(args[0])
+	if err != nil {
+		return fmt.Errorf("finding formula: %w", err)
+	}
+	f, err := parseFormulaFile(formulaPath)
+	if err != nil {
+		return fmt.Errorf("parsing formula: %w", err)
+	}
+
+	targetRig := simRig
+	if targetRig == "" {
+		if name, _, err := findCurrentRig(townRoot); err == nil {
+			targetRig = name
+		}
+	}
+	if targetRig == "" {
+		return fmt.Errorf("no rig: use --rig or run from inside a rig")
+	}
+
+	script, err := sim.Install(townRoot, simScript)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Installed sim script %s (%d agent entries)\n",
+		style.Bold.Render("✓"), simScript, len(script.Agents))
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating gt binary: %w", err)
+	}
+	if err := sim.RegisterAgent(townRoot, gtPath); err != nil {
+		return err
+	}
+	agent := sim.AgentName
+	var gtArgs []string
+	if f.Type == formula.TypeConvoy {
+		gtArgs = []string{"formula", "run", args[0], "--rig", targetRig, "--agent", agent}
+	} else {
+		gtArgs = []string{"sling", args[0]}
+		if simOn != "" {
+			gtArgs = append(gtArgs, "--on", simOn)
+		}
+		gtArgs = append(gtArgs, targetRig, "--agent", agent)
+		for _, v := range simVars {
+			gtArgs = append(gtArgs, "--var", v)
+		}
+	}
+	fmt.Printf("%s gt %s\n\n", style.Bold.Render("→"), strings.Join(gtArgs, " "))
+
+	c := exec.Command("gt", gtArgs...)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("dispatching %s: %w", args[0], err)
+	}
+
+	fmt.Printf("\nFollow the simulation with %s\n", style.Bold.Render("gt sim log"))
+	return nil
+}
+
+func runSimAgent(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	role, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return err
+	}
+
+	scriptPath := simScript
+	if scriptPath == "" {
+		scriptPath = sim.ScriptPath(townRoot)
+	}
+	script, err := sim.LoadScript(scriptPath)
+	if err != nil {
+		return err
+	}
+
+	// The bead is usually hooked before the session starts; allow for a
+	// short race with sling.
+	var issue string
+	for i := 0; i < 10; i++ {
+		if issue = detectHookedBead(cwd, role); issue != "" {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	runner := &sim.Runner{
+		Identity: sim.Identity{Rig: role.Rig, Polecat: role.Polecat, Issue: issue, WorkDir: cwd},
+		LogPath:  sim.LogPath(townRoot),
+	}
+	runner.Log("start", scriptPath)
+	fmt.Printf("sim agent %s/%s on %s\n", role.Rig, role.Polecat, valueOr(issue, "(no hooked issue)"))
+
+	// Nudges arrive as typed input; record them for the log.
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				runner.Log("nudge", line)
+			}
+		}
+	}()
+
+	actions := script.ActionsFor(role.Polecat, issue)
+	if len(actions) == 0 {
+		fmt.Println("no script entry matches; idling")
+	}
+	err = runner.Run(actions)
+	var exit *sim.ExitError
+	switch {
+	case errors.As(err, &exit):
+		fmt.Printf("sim agent crashing with exit code %d\n", exit.Code)
+		return NewSilentExit(exit.Code)
+	case errors.Is(err, sim.ErrHang):
+		fmt.Println("sim agent hanging")
+	case err != nil:
+		// A real agent would be stuck after a failed command; stay up so
+		// the witness can find it.
+		fmt.Printf("sim agent action failed: %v\n", err)
+	default:
+		runner.Log("idle", "")
+		fmt.Println("sim script finished; idling")
+	}
+	select {}
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func runSimLog(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	events, err := sim.ReadLog(sim.LogPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("reading sim log: %w", err)
+	}
+	if simLogJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(events) == 0 {
+		fmt.Printf("No simulation events in %s\n", filepath.Join(".runtime", "sim", "log.jsonl"))
+		return nil
+	}
+	for _, e := range events {
+		line := fmt.Sprintf("%s  %-20s %-12s %-8s %s",
+			e.Time.Local().Format("15:04:05"), e.Rig+"/"+e.Polecat, e.Issue, e.Action, e.Detail)
+		if e.Error != "" {
+			line += "  " + style.Error.Render(e.Error)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
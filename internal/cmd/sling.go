@@ -57,6 +57,14 @@ Spawning Options (when target is a rig):
   gt sling gp-abc greenplace --create               # Create polecat if missing
   gt sling gp-abc greenplace --force                # Ignore unread mail
   gt sling gp-abc greenplace --account work         # Use specific Claude account
+  gt sling gp-abc greenplace --headless             # Non-interactive run, no tmux
+
+Headless Mode (--headless):
+  Runs the polecat through its agent's non-interactive mode (claude -p,
+  codex exec, gemini -p) as a supervised background process. Output goes
+  to .runtime/headless/<session>.log (readable with gt peek), and the exit
+  status is fed into gt done if the agent didn't call it. Witness patrol
+  rules apply unchanged. Intended for CI-style batch work.
 
 Natural Language Args:
   gt sling gt-abc --args "patch release"
@@ -124,6 +132,7 @@ var (
 	slingNoBoot        bool   // --no-boot: skip wakeRigAgents (avoid witness/refinery boot and lock contention)
 	slingMaxConcurrent int    // --max-concurrent: limit concurrent spawns in batch mode
 	slingBaseBranch    string // --base-branch: override base branch for polecat worktree
+	slingHeadless      bool   // --headless: run polecat via agent's non-interactive mode, no tmux
 )
 
 func init() {
@@ -148,6 +157,7 @@ func init() {
 	slingCmd.Flags().BoolVar(&slingNoBoot, "no-boot", false, "Skip rig boot after polecat spawn (avoids witness/refinery lock contention)")
 	slingCmd.Flags().IntVar(&slingMaxConcurrent, "max-concurrent", 0, "Limit concurrent polecat spawns in batch mode (0 = no limit)")
 	slingCmd.Flags().StringVar(&slingBaseBranch, "base-branch", "", "Override base branch for polecat worktree (e.g., 'develop', 'release/v2')")
+	slingCmd.Flags().BoolVar(&slingHeadless, "headless", false, "Run spawned polecat via the agent's non-interactive mode (no tmux pane)")
 
 	rootCmd.AddCommand(slingCmd)
 }
@@ -382,6 +392,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 		BeadID:     beadID,
 		TownRoot:   townRoot,
 		BaseBranch: slingBaseBranch,
+		Headless:   slingHeadless,
 	})
 	if err != nil {
 		return err
@@ -140,6 +140,7 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			HookBead:   beadID, // Set atomically at spawn time
 			Agent:      slingAgent,
 			BaseBranch: slingBaseBranch,
+			Headless:   slingHeadless,
 		}
 		spawnInfo, err := spawnPolecatForSling(rigName, spawnOpts)
 		if err != nil {
@@ -10,8 +10,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
 )
 
@@ -142,6 +144,9 @@ type ConvoyInfo struct {
 	ID            string // Convoy bead ID (e.g., "hq-cv-abc")
 	Owned         bool   // true if convoy has gt:owned label
 	MergeStrategy string // "direct", "mr", "local", or "" (default = mr)
+	CreatedAt     string // Convoy creation time (RFC 3339), for MR starvation scoring
+	DueAt         string // Convoy deadline (RFC 3339), empty if none
+	Landing       string // Landing policy: "independent", "atomic" or "ordered"
 }
 
 // IsOwnedDirect returns true if the convoy is owned with direct merge strategy.
@@ -177,12 +182,19 @@ func getConvoyInfoForIssue(issueID string) *ConvoyInfo {
 	var convoys []struct {
 		Labels      []string `json:"labels"`
 		Description string   `json:"description"`
+		CreatedAt   string   `json:"created_at"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil || len(convoys) == 0 {
 		return &ConvoyInfo{ID: convoyID}
 	}
 
 	info := &ConvoyInfo{ID: convoyID}
+	if t, err := time.Parse(time.RFC3339, convoys[0].CreatedAt); err == nil {
+		info.CreatedAt = t.UTC().Format(time.RFC3339)
+	}
+	if due, _, ok := convoy.ParseDeadline(convoys[0].Description); ok {
+		info.DueAt = due.UTC().Format(time.RFC3339)
+	}
 
 	// Check for gt:owned label
 	for _, label := range convoys[0].Labels {
@@ -192,8 +204,9 @@ func getConvoyInfoForIssue(issueID string) *ConvoyInfo {
 		}
 	}
 
-	// Parse merge strategy from description
+	// Parse merge strategy and landing policy from description
 	info.MergeStrategy = parseConvoyMergeStrategy(convoys[0].Description)
+	info.Landing, _ = convoy.ParseLanding(convoys[0].Description)
 
 	return info
 }
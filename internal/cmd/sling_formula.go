@@ -12,14 +12,14 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/events"
 	"github.com/xcawolfe-amzn/gastown/internal/style"
-	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 	"github.com/xcawolfe-amzn/gastown/internal/workspace"
 )
 
 type wispCreateJSON struct {
-	NewEpicID string `json:"new_epic_id"`
-	RootID    string `json:"root_id"`
-	ResultID  string `json:"result_id"`
+	NewEpicID string            `json:"new_epic_id"`
+	RootID    string            `json:"root_id"`
+	ResultID  string            `json:"result_id"`
+	IDMapping map[string]string `json:"id_mapping"` // Proto bead ID -> instance bead ID
 }
 
 func parseWispIDFromJSON(jsonOutput []byte) (string, error) {
@@ -96,6 +96,7 @@ func runSlingFormula(args []string) error {
 		NoBoot:   slingNoBoot,
 		WorkDesc: formulaName,
 		TownRoot: townRoot,
+		Headless: slingHeadless,
 	})
 	if err != nil {
 		return err
@@ -169,6 +170,13 @@ func runSlingFormula(args []string) error {
 
 	fmt.Printf("%s Wisp created: %s\n", style.Bold.Render("✓"), wispRootID)
 
+	// Formulas with when/loop/retry/timeout steps carry their run state on
+	// the wisp for 'gt mol step done' to advance.
+	if err := recordMoleculeRun(formulaName, formulaWorkDir, townRoot, wispOut, slingVars); err != nil {
+		rollbackSpawned("")
+		return fmt.Errorf("recording run state: %w", err)
+	}
+
 	// Step 3: Hook the wisp bead with retry and verification.
 	// See: https://github.com/xcawolfe-amzn/gastown/issues/148
 	hookDir := beads.ResolveHookDir(townRoot, wispRootID, "")
@@ -246,6 +254,10 @@ func runSlingFormula(args []string) error {
 		fmt.Printf("%s No pane to nudge (agent will discover work via gt prime)\n", style.Dim.Render("○"))
 		return nil
 	}
+	if targetPane == headlessPane {
+		fmt.Printf("%s Headless run: startup prompt already includes work instructions\n", style.Dim.Render("○"))
+		return nil
+	}
 
 	// Skip nudge during tests to prevent agent self-interruption
 	if os.Getenv("GT_TEST_NO_NUDGE") != "" {
@@ -258,8 +270,7 @@ func runSlingFormula(args []string) error {
 	} else {
 		prompt = fmt.Sprintf("Formula %s slung. Run `"+cli.Name()+" hook` to see your hook, then execute the steps.", formulaName)
 	}
-	t := tmux.NewTmux()
-	if err := t.NudgePane(targetPane, prompt); err != nil {
+	if err := nudgePane(targetPane, prompt); err != nil {
 		// Graceful fallback for no-tmux mode
 		fmt.Printf("%s Could not nudge (no tmux?): %v\n", style.Dim.Render("○"), err)
 		fmt.Printf("  Agent will discover work via gt prime / bd show\n")
@@ -307,8 +307,36 @@ func injectStartPrompt(pane, beadID, subject, args string) error {
 	}
 
 	// Use the reliable nudge pattern (same as gt nudge / tmux.NudgeSession)
-	t := tmux.NewTmux()
-	return t.NudgePane(pane, prompt)
+	return nudgePane(pane, prompt)
+}
+
+// townSessions returns the session backend selected in the current town's
+// settings: tmux, or the headless backend, whose sessions any gt process
+// can reach.
+func townSessions() session.SessionBackend {
+	townRoot, _ := workspace.FindFromCwd()
+	return session.BackendForTown(townRoot, tmux.NewTmux())
+}
+
+// sessionPane returns the pane to nudge for a session. Headless sessions
+// have no tmux pane; the session name stands in for it.
+func sessionPane(sessions session.SessionBackend, sessionName string) (string, error) {
+	if _, ok := sessions.(*tmux.Tmux); ok {
+		return getSessionPane(sessionName)
+	}
+	if alive, _ := sessions.HasSession(sessionName); !alive {
+		return "", fmt.Errorf("session %s is not running", sessionName)
+	}
+	return sessionName, nil
+}
+
+// nudgePane delivers a prompt to a sling target's pane (see sessionPane).
+func nudgePane(pane, prompt string) error {
+	sessions := townSessions()
+	if t, ok := sessions.(*tmux.Tmux); ok {
+		return t.NudgePane(pane, prompt)
+	}
+	return sessions.NudgeSession(getSessionFromPane(pane), prompt)
 }
 
 // getSessionFromPane extracts session name from a pane target.
@@ -336,7 +364,15 @@ func getSessionFromPane(pane string) string {
 // Uses a pragmatic approach: wait for the pane to leave a shell, then (Claude-only)
 // accept the bypass permissions warning and give it a moment to finish initializing.
 func ensureAgentReady(sessionName string) error {
-	t := tmux.NewTmux()
+	sessions := townSessions()
+	t, ok := sessions.(*tmux.Tmux)
+	if !ok {
+		// Headless agents own their process; there is no pane to watch for a prompt.
+		if !sessions.IsAgentAlive(sessionName) {
+			return fmt.Errorf("agent in %s is not running", sessionName)
+		}
+		return nil
+	}
 
 	if t.IsAgentRunning(sessionName) {
 		// Agent process is detected, but it may have just started (fresh spawn).
@@ -544,13 +580,13 @@ func wakeRigAgents(rigName string) {
 		if err := nudge.Enqueue(townRoot, witnessSession, nudge.QueuedNudge{
 			Sender:  "sling",
 			Message: "Polecat dispatched - check for work",
+			Kind:    nudge.KindWork,
 		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to queue nudge for %s: %v\n", witnessSession, err)
 		}
 	} else {
 		// Fallback to direct nudge if town root unavailable
-		t := tmux.NewTmux()
-		_ = t.NudgeSession(witnessSession, "Polecat dispatched - check for work")
+		_ = townSessions().NudgeSession(witnessSession, "Polecat dispatched - check for work")
 	}
 }
 
@@ -577,13 +613,13 @@ func nudgeRefinery(rigName, message string) {
 		if err := nudge.Enqueue(townRoot, refinerySession, nudge.QueuedNudge{
 			Sender:  "sling",
 			Message: message,
+			Kind:    nudge.KindMerge,
 		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to queue nudge for %s: %v\n", refinerySession, err)
 		}
 	} else {
 		// Fallback to direct nudge if town root unavailable
-		t := tmux.NewTmux()
-		_ = t.NudgeSession(refinerySession, message)
+		_ = townSessions().NudgeSession(refinerySession, message)
 	}
 }
 
@@ -652,6 +688,10 @@ func InstantiateFormulaOnBead(formulaName, beadID, title, hookWorkDir, townRoot
 	if err != nil {
 		return nil, fmt.Errorf("parsing wisp output: %w", err)
 	}
+	vars := append([]string{featureVar, issueVar}, extraVars...)
+	if err := recordMoleculeRun(formulaName, formulaWorkDir, townRoot, wispOut, vars); err != nil {
+		return nil, fmt.Errorf("recording run state for formula %s: %w", formulaName, err)
+	}
 
 	// Step 3: Bond wisp to original bead (creates compound)
 	bondArgs := []string{"mol", "bond", wispRootID, beadID, "--json"}
@@ -691,7 +731,7 @@ func CookFormula(formulaName, workDir, townRoot string) error {
 // isHookedAgentDeadFn is a seam for tests. Production uses isHookedAgentDead.
 var isHookedAgentDeadFn = isHookedAgentDead
 
-// isHookedAgentDead checks if the tmux session for a hooked assignee is dead.
+// isHookedAgentDead checks if the session for a hooked assignee is dead.
 // Used by sling to auto-force re-sling when the previous agent has no active session (gt-pqf9x).
 // Returns true if the session is confirmed dead. Returns false if alive or if we
 // can't determine liveness (conservative: don't auto-force on uncertainty).
@@ -700,8 +740,7 @@ func isHookedAgentDead(assignee string) bool {
 	if sessionName == "" {
 		return false // Unknown format, can't determine
 	}
-	t := tmux.NewTmux()
-	alive, err := t.HasSession(sessionName)
+	alive, err := townSessions().HasSession(sessionName)
 	if err != nil {
 		return false // tmux not available or error, be conservative
 	}
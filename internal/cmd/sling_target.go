@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 )
@@ -24,14 +25,18 @@ func resolveTargetAgent(target string) (agentID string, pane string, hookRoot st
 	agentID = sessionToAgentID(sessionName)
 
 	// Get the pane for that session
-	pane, err = getSessionPane(sessionName)
+	sessions := townSessions()
+	pane, err = sessionPane(sessions, sessionName)
 	if err != nil {
 		return "", "", "", fmt.Errorf("getting pane for %s: %w", sessionName, err)
 	}
 
 	// Get the target's working directory for hook storage
-	t := tmux.NewTmux()
-	hookRoot, err = t.GetPaneWorkDir(sessionName)
+	if hc, ok := sessions.(*headless.Client); ok {
+		hookRoot, err = hc.GetPaneWorkDir(sessionName)
+	} else {
+		hookRoot, err = tmux.NewTmux().GetPaneWorkDir(sessionName)
+	}
 	if err != nil {
 		return "", "", "", fmt.Errorf("getting working dir for %s: %w", sessionName, err)
 	}
@@ -104,6 +109,7 @@ type ResolveTargetOptions struct {
 	TownRoot   string
 	WorkDesc   string // Description for dog dispatch (defaults to HookBead if empty)
 	BaseBranch string // Override base branch for polecat worktree
+	Headless   bool   // Run spawned polecats non-interactively (no tmux)
 }
 
 // ResolvedTarget holds the results of target resolution.
@@ -193,6 +199,7 @@ func resolveTarget(target string, opts ResolveTargetOptions) (*ResolvedTarget, e
 			HookBead:   opts.HookBead,
 			Agent:      opts.Agent,
 			BaseBranch: opts.BaseBranch,
+			Headless:   opts.Headless,
 		}
 		spawnInfo, err := spawnPolecatForSling(rigName, spawnOpts)
 		if err != nil {
@@ -228,6 +235,7 @@ func resolveTarget(target string, opts ResolveTargetOptions) (*ResolvedTarget, e
 					HookBead:   opts.HookBead,
 					Agent:      opts.Agent,
 					BaseBranch: opts.BaseBranch,
+					Headless:   opts.Headless,
 				}
 				spawnInfo, spawnErr := spawnPolecatForSling(rigName, spawnOpts)
 				if spawnErr != nil {
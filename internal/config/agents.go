@@ -3,6 +3,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -129,6 +130,12 @@ type AgentPresetInfo struct {
 	// EmitsPermissionWarning indicates the agent shows a bypass-permissions warning on startup
 	// that needs to be acknowledged via tmux.
 	EmitsPermissionWarning bool `json:"emits_permission_warning,omitempty"`
+
+	// MinVersion and MaxVersion pin the CLI versions this preset is known to
+	// work with (inclusive, dotted numeric). Empty means unbounded.
+	// Checked by gt agents probe and before spawning.
+	MinVersion string `json:"min_version,omitempty"`
+	MaxVersion string `json:"max_version,omitempty"`
 }
 
 // NonInteractiveConfig contains settings for running agents non-interactively.
@@ -568,6 +575,51 @@ func SupportsSessionResume(agentName string) bool {
 	return info != nil && info.ResumeFlag != ""
 }
 
+// nonInteractiveFor returns the non-interactive settings for an agent.
+// Claude has no NonInteractive block because "-p" is its native print mode.
+func nonInteractiveFor(agentName string) *NonInteractiveConfig {
+	info := GetAgentPresetByName(agentName)
+	if info == nil {
+		return nil
+	}
+	if info.NonInteractive != nil {
+		return info.NonInteractive
+	}
+	if info.Name == AgentClaude {
+		return &NonInteractiveConfig{PromptFlag: "-p"}
+	}
+	return nil
+}
+
+// SupportsNonInteractive checks if an agent can run a single prompt to
+// completion without a TUI (e.g., "codex exec", "gemini -p").
+func SupportsNonInteractive(agentName string) bool {
+	return nonInteractiveFor(agentName) != nil
+}
+
+// BuildNonInteractiveArgv builds the argv for running rc's agent in
+// non-interactive mode with the given prompt. The agent is identified by
+// rc.Provider; rc.Command and rc.Args carry any user overrides.
+// Returns an error if the agent has no non-interactive mode.
+func BuildNonInteractiveArgv(rc *RuntimeConfig, prompt string) ([]string, error) {
+	if rc == nil {
+		rc = DefaultRuntimeConfig()
+	}
+	ni := nonInteractiveFor(rc.Provider)
+	if ni == nil {
+		return nil, fmt.Errorf("agent %q does not support non-interactive mode", rc.Provider)
+	}
+
+	argv := []string{rc.Command}
+	argv = append(argv, strings.Fields(ni.Subcommand)...)
+	argv = append(argv, rc.Args...)
+	argv = append(argv, strings.Fields(ni.OutputFlag)...)
+	if ni.PromptFlag != "" {
+		argv = append(argv, ni.PromptFlag)
+	}
+	return append(argv, prompt), nil
+}
+
 // GetSessionIDEnvVar returns the environment variable name for storing session IDs
 // for a given agent. Returns empty string if the agent doesn't use env vars for this.
 func GetSessionIDEnvVar(agentName string) string {
@@ -979,3 +979,51 @@ func TestKiroResumeCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildNonInteractiveArgv(t *testing.T) {
+	tests := []struct {
+		name string
+		rc   *RuntimeConfig
+		want []string
+	}{
+		{
+			name: "claude uses native print mode",
+			rc:   &RuntimeConfig{Provider: "claude", Command: "claude", Args: []string{"--dangerously-skip-permissions"}},
+			want: []string{"claude", "--dangerously-skip-permissions", "-p", "do the thing"},
+		},
+		{
+			name: "codex uses exec subcommand",
+			rc:   &RuntimeConfig{Provider: "codex", Command: "codex", Args: []string{"--dangerously-bypass-approvals-and-sandbox"}},
+			want: []string{"codex", "exec", "--dangerously-bypass-approvals-and-sandbox", "--json", "do the thing"},
+		},
+		{
+			name: "gemini uses prompt flag and output flag",
+			rc:   &RuntimeConfig{Provider: "gemini", Command: "gemini", Args: []string{"--approval-mode", "yolo"}},
+			want: []string{"gemini", "--approval-mode", "yolo", "--output-format", "json", "-p", "do the thing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildNonInteractiveArgv(tt.rc, "do the thing")
+			if err != nil {
+				t.Fatalf("BuildNonInteractiveArgv() error = %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("BuildNonInteractiveArgv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildNonInteractiveArgvUnsupported(t *testing.T) {
+	rc := &RuntimeConfig{Provider: "auggie", Command: "auggie"}
+	if _, err := BuildNonInteractiveArgv(rc, "x"); err == nil {
+		t.Error("expected error for agent without non-interactive mode")
+	}
+	if SupportsNonInteractive("auggie") {
+		t.Error("SupportsNonInteractive(auggie) = true, want false")
+	}
+	if !SupportsNonInteractive("claude") {
+		t.Error("SupportsNonInteractive(claude) = false, want true")
+	}
+}
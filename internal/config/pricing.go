@@ -0,0 +1,95 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ModelPrice is the USD price per million tokens for a model.
+type ModelPrice struct {
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	CacheRead  float64 `json:"cache_read,omitempty"`
+	CacheWrite float64 `json:"cache_write,omitempty"`
+}
+
+// DefaultModelPricing returns the built-in pricing table used by gt costs.
+// Keys are model IDs; dated snapshots ("claude-sonnet-4-20250514") match
+// their undated ID. Entries in TownSettings.ModelPricing override or extend
+// it, and TownSettings.ModelAliases maps further IDs onto these entries.
+func DefaultModelPricing() map[string]ModelPrice {
+	return map[string]ModelPrice{
+		// Anthropic (https://www.anthropic.com/pricing)
+		"claude-opus-4-5":   {Input: 5.0, Output: 25.0, CacheRead: 0.5, CacheWrite: 6.25},
+		"claude-opus-4-1":   {Input: 15.0, Output: 75.0, CacheRead: 1.5, CacheWrite: 18.75},
+		"claude-opus-4":     {Input: 15.0, Output: 75.0, CacheRead: 1.5, CacheWrite: 18.75},
+		"claude-sonnet-4-5": {Input: 3.0, Output: 15.0, CacheRead: 0.3, CacheWrite: 3.75},
+		"claude-sonnet-4":   {Input: 3.0, Output: 15.0, CacheRead: 0.3, CacheWrite: 3.75},
+		"claude-haiku-4-5":  {Input: 1.0, Output: 5.0, CacheRead: 0.1, CacheWrite: 1.25},
+		"claude-3-5-haiku":  {Input: 0.8, Output: 4.0, CacheRead: 0.08, CacheWrite: 1.0},
+
+		// OpenAI (https://openai.com/api/pricing)
+		"gpt-5":       {Input: 1.25, Output: 10.0, CacheRead: 0.125},
+		"gpt-5-codex": {Input: 1.25, Output: 10.0, CacheRead: 0.125},
+		"gpt-5-mini":  {Input: 0.25, Output: 2.0, CacheRead: 0.025},
+		"gpt-5-nano":  {Input: 0.05, Output: 0.4, CacheRead: 0.005},
+		"gpt-4.1":     {Input: 2.0, Output: 8.0, CacheRead: 0.5},
+		"o3":          {Input: 2.0, Output: 8.0, CacheRead: 0.5},
+		"o4-mini":     {Input: 1.1, Output: 4.4, CacheRead: 0.275},
+
+		// Google (https://ai.google.dev/pricing)
+		"gemini-2.5-pro":        {Input: 1.25, Output: 10.0, CacheRead: 0.31},
+		"gemini-2.5-flash":      {Input: 0.3, Output: 2.5, CacheRead: 0.075},
+		"gemini-2.5-flash-lite": {Input: 0.1, Output: 0.4, CacheRead: 0.025},
+	}
+}
+
+// ResolveModelPricing returns the effective pricing table: the built-in
+// defaults overlaid with any entries from town settings, plus an entry for
+// each configured alias whose target is priced.
+func ResolveModelPricing(settings *TownSettings) map[string]ModelPrice {
+	table := DefaultModelPricing()
+	if settings == nil {
+		return table
+	}
+	for model, price := range settings.ModelPricing {
+		table[model] = price
+	}
+	for alias, target := range settings.ModelAliases {
+		if price, ok := LookupModelPrice(table, target); ok {
+			table[alias] = price
+		}
+	}
+	return table
+}
+
+// snapshotSuffix matches the date stamp on a model snapshot ID.
+var snapshotSuffix = regexp.MustCompile(`-(\d{8}|\d{4}-\d{2}-\d{2})$`)
+
+// LookupModelPrice finds the price for a model. The ID must match a table
+// key exactly, after stripping a provider qualifier ("anthropic/") or a
+// snapshot date. Unknown models are reported as unpriced rather than
+// priced at a similarly named model's rate.
+func LookupModelPrice(table map[string]ModelPrice, model string) (ModelPrice, bool) {
+	if model == "" {
+		return ModelPrice{}, false
+	}
+	if p, ok := table[model]; ok {
+		return p, true
+	}
+
+	bare := model
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		bare = model[i+1:]
+		if p, ok := table[bare]; ok {
+			return p, true
+		}
+	}
+
+	if undated := snapshotSuffix.ReplaceAllString(bare, ""); undated != bare {
+		if p, ok := table[undated]; ok {
+			return p, true
+		}
+	}
+	return ModelPrice{}, false
+}
@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestLookupModelPrice(t *testing.T) {
+	table := DefaultModelPricing()
+
+	tests := []struct {
+		model     string
+		wantInput float64
+		wantOK    bool
+	}{
+		{"claude-sonnet-4-20250514", 3.0, true},
+		{"claude-opus-4-5-20251101", 5.0, true},
+		{"claude-opus-4-1-20250805", 15.0, true},
+		{"anthropic/claude-sonnet-4-5", 3.0, true},
+		{"gpt-5-codex", 1.25, true},
+		{"gpt-5-mini-2025-08-07", 0.25, true},
+		{"gemini-2.5-flash-lite", 0.1, true},
+		{"claude-opus-5", 0, false}, // new model is not priced as a sibling
+		{"gpt-5-pro", 0, false},
+		{"llama-3-70b", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		p, ok := LookupModelPrice(table, tt.model)
+		if ok != tt.wantOK || p.Input != tt.wantInput {
+			t.Errorf("LookupModelPrice(%q) = (%v, %v), want input %v ok %v", tt.model, p.Input, ok, tt.wantInput, tt.wantOK)
+		}
+	}
+}
+
+func TestResolveModelPricingOverrides(t *testing.T) {
+	settings := NewTownSettings()
+	settings.ModelPricing = map[string]ModelPrice{
+		"claude-sonnet-4-5": {Input: 2.5, Output: 12},
+		"llama-3-70b":       {Input: 0.5, Output: 0.5},
+	}
+	settings.ModelAliases = map[string]string{
+		"sonnet":       "claude-sonnet-4-5",
+		"mystery-beta": "no-such-model",
+	}
+	table := ResolveModelPricing(settings)
+
+	if p, _ := LookupModelPrice(table, "claude-sonnet-4-5-20250929"); p.Input != 2.5 {
+		t.Errorf("override not applied: input = %v", p.Input)
+	}
+	if _, ok := LookupModelPrice(table, "llama-3-70b"); !ok {
+		t.Error("added model not found")
+	}
+	if p, ok := LookupModelPrice(table, "sonnet"); !ok || p.Input != 2.5 {
+		t.Errorf("alias = (%v, %v), want overridden sonnet price", p.Input, ok)
+	}
+	if _, ok := LookupModelPrice(table, "mystery-beta"); ok {
+		t.Error("alias to an unpriced model should stay unpriced")
+	}
+	if _, ok := LookupModelPrice(table, "gpt-5"); !ok {
+		t.Error("defaults dropped when overriding")
+	}
+}
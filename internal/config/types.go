@@ -86,6 +86,32 @@ type TownSettings struct {
 	// Actual model assignments live in RoleAgents and Agents.
 	// Values: "standard", "economy", "budget", or empty for custom configs.
 	CostTier string `json:"cost_tier,omitempty"`
+
+	// SessionBackend selects how agent sessions are hosted.
+	// Values: "tmux" (default), "headless" (PTY processes, each served by a
+	// detached 'gt session host', for CI boxes and containers without tmux).
+	SessionBackend string `json:"session_backend,omitempty"`
+
+	// ModelPricing overrides or extends the built-in per-model token prices
+	// used by gt costs. Keys are exact model IDs; values are USD per
+	// million tokens.
+	// Example: {"gpt-5-codex": {"input": 1.25, "output": 10, "cache_read": 0.125}}
+	ModelPricing map[string]ModelPrice `json:"model_pricing,omitempty"`
+
+	// ModelAliases prices additional model IDs as an existing entry.
+	// Models that match neither a pricing entry nor an alias are unpriced.
+	// Example: {"claude-opus-4-6": "claude-opus-4-5"}
+	ModelAliases map[string]string `json:"model_aliases,omitempty"`
+
+	// Budget sets daily spend limits enforced by the daemon and gt sling.
+	Budget *BudgetConfig `json:"budget,omitempty"`
+
+	// AgentFallback maps role names to an ordered chain of agents to fall
+	// back through when a session's provider is rate-limited with no spare
+	// account, or down. The first entry is the primary; the daemon switches
+	// sessions back to it once it recovers.
+	// Example: {"polecat": ["claude", "codex", "gemini"]}
+	AgentFallback map[string][]string `json:"agent_fallback,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -180,6 +206,35 @@ type ConvoyConfig struct {
 	// NotifyOnComplete controls whether convoy completion pushes a notification
 	// into the active Mayor session (in addition to mail). Opt-in; default false.
 	NotifyOnComplete bool `json:"notify_on_complete,omitempty"`
+
+	// MaxParallel is the default number of a convoy's issues dispatched at
+	// once when the convoy does not set its own limit. Default: 1.
+	MaxParallel int `json:"max_parallel,omitempty"`
+}
+
+// BudgetConfig configures daily spend limits. Spend is the sum of today's
+// entries in the gt costs log; each limit applies per key per calendar day.
+type BudgetConfig struct {
+	// Rigs limits spend per rig name.
+	Rigs map[string]BudgetLimit `json:"rigs,omitempty"`
+	// Roles limits spend per role (mayor, deacon, witness, refinery, polecat, crew).
+	Roles map[string]BudgetLimit `json:"roles,omitempty"`
+	// Convoy limits spend of each convoy (work items tracked by it).
+	Convoy *BudgetLimit `json:"convoy,omitempty"`
+	// Issue limits spend of each single work item.
+	Issue *BudgetLimit `json:"issue,omitempty"`
+
+	// SoftTier is the cost tier applied to affected roles at a soft limit.
+	// Default: "budget".
+	SoftTier string `json:"soft_tier,omitempty"`
+}
+
+// BudgetLimit is a pair of USD thresholds. Zero disables a threshold.
+// Crossing Soft escalates and downgrades models; crossing Hard blocks new
+// polecats and pauses the patrols in its scope until the next day.
+type BudgetLimit struct {
+	Soft float64 `json:"soft_usd,omitempty"`
+	Hard float64 `json:"hard_usd,omitempty"`
 }
 
 // ParseDurationOrDefault parses a Go duration string, returning fallback on error or empty input.
@@ -1031,6 +1086,14 @@ type AccountQuotaState struct {
 	LimitedAt string             `json:"limited_at,omitempty"` // RFC3339 when limit was detected
 	ResetsAt  string             `json:"resets_at,omitempty"`  // Human-readable reset time from provider (e.g. "7pm (America/Los_Angeles)")
 	LastUsed  string             `json:"last_used,omitempty"`  // RFC3339 when account was last assigned to a session
+
+	// ResetsAtTime is ResetsAt resolved to an instant (RFC3339). Empty when
+	// the provider text could not be parsed; the default limit window applies.
+	ResetsAtTime string `json:"resets_at_time,omitempty"`
+
+	// CapacityUSD is the spend observed on the account in the usage window
+	// leading up to its last limit. Used to forecast the next exhaustion.
+	CapacityUSD float64 `json:"capacity_usd,omitempty"`
 }
 
 // CurrentQuotaVersion is the current schema version for QuotaState.
@@ -297,3 +297,12 @@ var DefaultRateLimitPatterns = []string{
 	`Stop and wait for limit to reset`,        // /rate-limit-options TUI prompt option 1
 	`Add funds to continue with extra usage`,  // /rate-limit-options TUI prompt option 2
 }
+
+// DefaultProviderDownPatterns indicate the agent's provider is unavailable
+// (outage or persistent overload) rather than the account being limited.
+// Compiled with (?i) like DefaultRateLimitPatterns.
+var DefaultProviderDownPatterns = []string{
+	`API Error: 5\d\d\b`,                    // Claude Code: "API Error: 529 {...overloaded...}"
+	`overloaded_error`,                      // Anthropic error type
+	`stream disconnected before completion`, // Codex retry exhaustion
+}
@@ -0,0 +1,339 @@
+package convoy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// DeadlineStatus classifies an open convoy against its deadline.
+type DeadlineStatus string
+
+const (
+	// DeadlineNone means the convoy has no deadline.
+	DeadlineNone DeadlineStatus = ""
+	// DeadlineOnTrack means the convoy is projected to land before its deadline.
+	DeadlineOnTrack DeadlineStatus = "on_track"
+	// DeadlineAtRisk means the convoy is projected to land after its deadline.
+	DeadlineAtRisk DeadlineStatus = "at_risk"
+	// DeadlineOverdue means the deadline has passed with work still open.
+	DeadlineOverdue DeadlineStatus = "overdue"
+)
+
+// Rank orders statuses by urgency: overdue > at risk > on track > none.
+func (s DeadlineStatus) Rank() int {
+	switch s {
+	case DeadlineOverdue:
+		return 3
+	case DeadlineAtRisk:
+		return 2
+	case DeadlineOnTrack:
+		return 1
+	}
+	return 0
+}
+
+// DefaultAtRiskLead is how close to its deadline a convoy is considered at
+// risk when there is no cycle-time history to project from.
+const DefaultAtRiskLead = 4 * time.Hour
+
+// LabelSLABreached marks a convoy whose SLA breach has been escalated, so
+// the escalation fires once.
+const LabelSLABreached = "gt:sla-breached"
+
+// Description lines carrying a convoy's deadline, alongside "Owner:" and
+// "Merge:".
+const (
+	dueLinePrefix = "Due: "
+	slaLinePrefix = "SLA: "
+)
+
+// ParseDue parses a --due value: RFC 3339, "2006-01-02 15:04" or a bare
+// date, which means the end of that day in now's location.
+func ParseDue(text string, now time.Time) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if t, err := time.Parse(time.RFC3339, text); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", text, now.Location()); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q (want YYYY-MM-DD, \"YYYY-MM-DD HH:MM\" or RFC 3339)", text)
+}
+
+// ParseSLA parses an --sla value: a Go duration ("36h") or a whole number
+// of days or weeks ("3d", "2w").
+func ParseSLA(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	var d time.Duration
+	if n := len(text); n > 1 && (text[n-1] == 'd' || text[n-1] == 'w') {
+		count, err := strconv.Atoi(text[:n-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid SLA %q", text)
+		}
+		d = time.Duration(count) * 24 * time.Hour
+		if text[n-1] == 'w' {
+			d *= 7
+		}
+	} else {
+		parsed, err := time.ParseDuration(text)
+		if err != nil {
+			return 0, fmt.Errorf("invalid SLA %q (want e.g. 36h, 3d, 2w)", text)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("SLA must be positive, got %q", text)
+	}
+	return d, nil
+}
+
+// DeadlineLines returns the description lines recording a deadline. sla is
+// the original --sla text, kept for display; it may be empty.
+func DeadlineLines(due time.Time, sla string) string {
+	lines := dueLinePrefix + due.UTC().Format(time.RFC3339)
+	if sla != "" {
+		lines += "\n" + slaLinePrefix + sla
+	}
+	return lines
+}
+
+// ParseDeadline extracts the deadline and SLA text from a convoy
+// description. ok is false when the convoy has no deadline.
+func ParseDeadline(description string) (due time.Time, sla string, ok bool) {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, dueLinePrefix):
+			if t, err := time.Parse(time.RFC3339, strings.TrimPrefix(line, dueLinePrefix)); err == nil {
+				due, ok = t, true
+			}
+		case strings.HasPrefix(line, slaLinePrefix):
+			sla = strings.TrimPrefix(line, slaLinePrefix)
+		}
+	}
+	return due, sla, ok
+}
+
+// Assessment is a convoy's standing against its deadline.
+type Assessment struct {
+	Status      DeadlineStatus `json:"status,omitempty"`
+	DueAt       *time.Time     `json:"due_at,omitempty"`
+	ProjectedAt *time.Time     `json:"projected_at,omitempty"` // nil without cycle-time data
+}
+
+// Assess projects when a convoy's remaining issues will close and compares
+// that with its deadline. Remaining issues are worked inFlight at a time
+// (at least one), each taking cycleTime. A zero cycleTime skips the
+// projection; the convoy is then at risk within DefaultAtRiskLead of its
+// deadline.
+func Assess(due time.Time, now time.Time, remaining, inFlight int, cycleTime time.Duration) Assessment {
+	if due.IsZero() {
+		return Assessment{}
+	}
+	a := Assessment{DueAt: &due}
+	if remaining <= 0 {
+		a.Status = DeadlineOnTrack
+		return a
+	}
+	if cycleTime > 0 {
+		lanes := inFlight
+		if lanes < 1 {
+			lanes = 1
+		}
+		rounds := int(math.Ceil(float64(remaining) / float64(lanes)))
+		projected := now.Add(time.Duration(rounds) * cycleTime)
+		a.ProjectedAt = &projected
+	}
+
+	switch {
+	case now.After(due):
+		a.Status = DeadlineOverdue
+	case a.ProjectedAt != nil && a.ProjectedAt.After(due):
+		a.Status = DeadlineAtRisk
+	case a.ProjectedAt == nil && due.Sub(now) < DefaultAtRiskLead:
+		a.Status = DeadlineAtRisk
+	default:
+		a.Status = DeadlineOnTrack
+	}
+	return a
+}
+
+// DeadlineSnapshot is the deadline standing of every open convoy with a
+// deadline, recorded by gt convoy overdue (run by the daemon each
+// heartbeat) so the refinery and the convoy feed can favor at-risk work
+// without re-projecting each convoy.
+type DeadlineSnapshot struct {
+	CheckedAt time.Time                 `json:"checked_at"`
+	Convoys   map[string]DeadlineStatus `json:"convoys"`
+}
+
+// DeadlineSnapshotPath returns the path of the town's deadline snapshot.
+func DeadlineSnapshotPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "convoy-deadlines.json")
+}
+
+// SaveDeadlineSnapshot writes the deadline snapshot.
+func SaveDeadlineSnapshot(townRoot string, s *DeadlineSnapshot) error {
+	if err := os.MkdirAll(filepath.Dir(DeadlineSnapshotPath(townRoot)), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(DeadlineSnapshotPath(townRoot), s)
+}
+
+// LoadDeadlineStatuses returns the last recorded deadline status per
+// convoy. A missing or unreadable snapshot yields an empty map: deadline
+// boosts are best-effort.
+func LoadDeadlineStatuses(townRoot string) map[string]DeadlineStatus {
+	data, err := os.ReadFile(DeadlineSnapshotPath(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		return map[string]DeadlineStatus{}
+	}
+	var s DeadlineSnapshot
+	if err := json.Unmarshal(data, &s); err != nil || s.Convoys == nil {
+		return map[string]DeadlineStatus{}
+	}
+	return s.Convoys
+}
+
+// maxCycleSamples caps the cycle-time history.
+const maxCycleSamples = 200
+
+// minOwnSamples is how many of a convoy's own closed issues are enough to
+// estimate its cycle time without falling back to history.
+const minOwnSamples = 3
+
+// CycleSample is the time one issue took from creation to close.
+type CycleSample struct {
+	Issue   string    `json:"issue"`
+	Seconds float64   `json:"seconds"`
+	Closed  time.Time `json:"closed_at"`
+}
+
+// Duration returns the sample's cycle time.
+func (s CycleSample) Duration() time.Duration {
+	return time.Duration(s.Seconds * float64(time.Second))
+}
+
+// CycleHistory holds cycle times of issues from landed convoys, newest last.
+type CycleHistory struct {
+	Samples []CycleSample `json:"samples"`
+}
+
+// CycleHistoryPath returns the path of the town's cycle-time history.
+func CycleHistoryPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "convoy-cycle-times.json")
+}
+
+// LoadCycleHistory reads the cycle-time history. A missing file yields an
+// empty history.
+func LoadCycleHistory(townRoot string) (*CycleHistory, error) {
+	data, err := os.ReadFile(CycleHistoryPath(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &CycleHistory{}, nil
+		}
+		return nil, err
+	}
+	var h CycleHistory
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("parsing cycle-time history: %w", err)
+	}
+	return &h, nil
+}
+
+// RecordCycleTimes adds samples to the town's history, replacing earlier
+// samples for the same issue and keeping the newest maxCycleSamples.
+func RecordCycleTimes(townRoot string, samples []CycleSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	h, err := LoadCycleHistory(townRoot)
+	if err != nil {
+		h = &CycleHistory{} // a corrupt history is rebuilt
+	}
+	replaced := make(map[string]bool, len(samples))
+	for _, s := range samples {
+		replaced[s.Issue] = true
+	}
+	kept := h.Samples[:0]
+	for _, s := range h.Samples {
+		if !replaced[s.Issue] {
+			kept = append(kept, s)
+		}
+	}
+	kept = append(kept, samples...)
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].Closed.Before(kept[j].Closed) })
+	if len(kept) > maxCycleSamples {
+		kept = kept[len(kept)-maxCycleSamples:]
+	}
+	h.Samples = kept
+
+	if err := os.MkdirAll(filepath.Dir(CycleHistoryPath(townRoot)), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(CycleHistoryPath(townRoot), h)
+}
+
+// Durations returns the history's cycle times.
+func (h *CycleHistory) Durations() []time.Duration {
+	out := make([]time.Duration, 0, len(h.Samples))
+	for _, s := range h.Samples {
+		out = append(out, s.Duration())
+	}
+	return out
+}
+
+// IssueCycleSample returns the cycle sample for a closed issue with
+// parseable created_at/closed_at timestamps.
+func IssueCycleSample(issueID, status, createdAt, closedAt string) (CycleSample, bool) {
+	if status != "closed" {
+		return CycleSample{}, false
+	}
+	created, err1 := parseBeadTime(createdAt)
+	closed, err2 := parseBeadTime(closedAt)
+	if err1 != nil || err2 != nil || !closed.After(created) {
+		return CycleSample{}, false
+	}
+	return CycleSample{Issue: issueID, Seconds: closed.Sub(created).Seconds(), Closed: closed}, true
+}
+
+// parseBeadTime parses the timestamp formats bd emits.
+func parseBeadTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04", s)
+}
+
+// EstimateCycleTime returns the median cycle time of a convoy's own closed
+// issues, topped up from town history when it has fewer than a few.
+// Returns 0 when there is no data.
+func EstimateCycleTime(own []CycleSample, history []time.Duration) time.Duration {
+	durations := make([]time.Duration, 0, len(own)+len(history))
+	for _, s := range own {
+		durations = append(durations, s.Duration())
+	}
+	if len(durations) < minOwnSamples {
+		durations = append(durations, history...)
+	}
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[len(durations)/2]
+}
@@ -0,0 +1,179 @@
+package convoy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDue(t *testing.T) {
+	loc := time.FixedZone("test", -8*3600)
+	now := time.Date(2026, 1, 10, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-01-15", time.Date(2026, 1, 15, 23, 59, 59, 0, loc)},
+		{"2026-01-15 14:30", time.Date(2026, 1, 15, 14, 30, 0, 0, loc)},
+		{"2026-01-15T14:30:00Z", time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseDue(tt.in, now)
+		if err != nil {
+			t.Errorf("ParseDue(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDue(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := ParseDue("next tuesday", now); err == nil {
+		t.Error("ParseDue should reject free text")
+	}
+}
+
+func TestParseSLA(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"36h", 36 * time.Hour, false},
+		{"3d", 72 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"0h", 0, true},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSLA(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSLA(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSLA(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDeadlineRoundTrip(t *testing.T) {
+	due := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	desc := "Convoy tracking 2 issues\nOwner: mayor/\n" + DeadlineLines(due, "3d") + "\nMerge: mr"
+
+	got, sla, ok := ParseDeadline(desc)
+	if !ok || !got.Equal(due) || sla != "3d" {
+		t.Errorf("ParseDeadline = %v, %q, %v; want %v, \"3d\", true", got, sla, ok, due)
+	}
+	if _, _, ok := ParseDeadline("Convoy tracking 2 issues"); ok {
+		t.Error("ParseDeadline should report no deadline")
+	}
+}
+
+func TestAssess(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	due := now.Add(10 * time.Hour)
+
+	tests := []struct {
+		name      string
+		due       time.Time
+		remaining int
+		inFlight  int
+		cycle     time.Duration
+		want      DeadlineStatus
+		projected time.Duration // 0 = no projection
+	}{
+		{"no deadline", time.Time{}, 3, 1, time.Hour, DeadlineNone, 0},
+		{"done", due, 0, 0, time.Hour, DeadlineOnTrack, 0},
+		{"on track", due, 4, 2, 3 * time.Hour, DeadlineOnTrack, 6 * time.Hour},
+		{"at risk", due, 4, 1, 3 * time.Hour, DeadlineAtRisk, 12 * time.Hour},
+		{"overdue", now.Add(-time.Minute), 1, 1, time.Hour, DeadlineOverdue, time.Hour},
+		{"no history, far", due, 4, 1, 0, DeadlineOnTrack, 0},
+		{"no history, close", now.Add(time.Hour), 4, 1, 0, DeadlineAtRisk, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Assess(tt.due, now, tt.remaining, tt.inFlight, tt.cycle)
+			if a.Status != tt.want {
+				t.Errorf("Status = %q, want %q", a.Status, tt.want)
+			}
+			switch {
+			case tt.projected == 0 && a.ProjectedAt != nil:
+				t.Errorf("ProjectedAt = %v, want nil", a.ProjectedAt)
+			case tt.projected != 0 && (a.ProjectedAt == nil || !a.ProjectedAt.Equal(now.Add(tt.projected))):
+				t.Errorf("ProjectedAt = %v, want %v", a.ProjectedAt, now.Add(tt.projected))
+			}
+		})
+	}
+}
+
+func TestEstimateCycleTime(t *testing.T) {
+	sample := func(d time.Duration) CycleSample { return CycleSample{Seconds: d.Seconds()} }
+	history := []time.Duration{10 * time.Hour, 10 * time.Hour, 10 * time.Hour}
+
+	own := []CycleSample{sample(time.Hour), sample(2 * time.Hour), sample(3 * time.Hour)}
+	if got := EstimateCycleTime(own, history); got != 2*time.Hour {
+		t.Errorf("with enough own samples = %v, want 2h", got)
+	}
+	if got := EstimateCycleTime(own[:1], history); got != 10*time.Hour {
+		t.Errorf("topped up from history = %v, want 10h", got)
+	}
+	if got := EstimateCycleTime(nil, nil); got != 0 {
+		t.Errorf("no data = %v, want 0", got)
+	}
+}
+
+func TestRecordCycleTimes(t *testing.T) {
+	townRoot := t.TempDir()
+	closed := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	s1, ok := IssueCycleSample("gt-a", "closed", "2026-01-10T10:00:00Z", closed.Format(time.RFC3339))
+	if !ok || s1.Duration() != 2*time.Hour {
+		t.Fatalf("IssueCycleSample = %+v, %v", s1, ok)
+	}
+	if _, ok := IssueCycleSample("gt-b", "open", "2026-01-10T10:00:00Z", ""); ok {
+		t.Error("open issues have no cycle sample")
+	}
+
+	if err := RecordCycleTimes(townRoot, []CycleSample{s1}); err != nil {
+		t.Fatal(err)
+	}
+	s1.Seconds = time.Hour.Seconds()
+	if err := RecordCycleTimes(townRoot, []CycleSample{s1}); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := LoadCycleHistory(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Durations(); len(got) != 1 || got[0] != time.Hour {
+		t.Errorf("history = %v, want one replaced 1h sample", got)
+	}
+}
+
+func TestDeadlineSnapshot(t *testing.T) {
+	townRoot := t.TempDir()
+	if got := LoadDeadlineStatuses(townRoot); len(got) != 0 {
+		t.Errorf("missing snapshot = %v, want empty", got)
+	}
+
+	snap := &DeadlineSnapshot{CheckedAt: time.Now(), Convoys: map[string]DeadlineStatus{
+		"hq-cv-late": DeadlineAtRisk,
+		"hq-cv-fine": DeadlineOnTrack,
+	}}
+	if err := SaveDeadlineSnapshot(townRoot, snap); err != nil {
+		t.Fatal(err)
+	}
+	got := LoadDeadlineStatuses(townRoot)
+	if got["hq-cv-late"] != DeadlineAtRisk || got["hq-cv-fine"] != DeadlineOnTrack {
+		t.Errorf("statuses = %v", got)
+	}
+
+	if n := DeadlineParallel(2, got["hq-cv-late"]); n != 4 {
+		t.Errorf("at-risk parallel = %d, want 4", n)
+	}
+	if n := DeadlineParallel(2, got["hq-cv-fine"]); n != 2 {
+		t.Errorf("on-track parallel = %d, want 2", n)
+	}
+}
@@ -0,0 +1,269 @@
+package convoy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/flock"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/rig"
+)
+
+// DefaultMaxParallel is the number of a convoy's issues worked at once when
+// neither the convoy nor the town sets a limit.
+const DefaultMaxParallel = 1
+
+// MaxParallelLine formats the convoy description line that carries the
+// convoy's concurrency limit. Returns "" for n < 1 (use the town default).
+func MaxParallelLine(n int) string {
+	if n < 1 {
+		return ""
+	}
+	return fmt.Sprintf("MaxParallel: %d", n)
+}
+
+// ParseMaxParallel extracts the concurrency limit from a convoy description.
+// Returns 0 when the description does not set one.
+func ParseMaxParallel(description string) int {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+		if !strings.HasPrefix(lower, "maxparallel:") && !strings.HasPrefix(lower, "max_parallel:") {
+			continue
+		}
+		value := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// ResolveMaxParallel returns the effective concurrency limit for a convoy:
+// its own MaxParallel line, else the town's convoy.max_parallel, else
+// DefaultMaxParallel.
+func ResolveMaxParallel(townRoot, description string) int {
+	if n := ParseMaxParallel(description); n > 0 {
+		return n
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err == nil && settings.Convoy != nil && settings.Convoy.MaxParallel > 0 {
+		return settings.Convoy.MaxParallel
+	}
+	return DefaultMaxParallel
+}
+
+// DeadlineParallel returns the concurrency limit for a convoy with the given
+// deadline standing: a convoy at risk of missing its deadline, or past it,
+// may work twice its MaxParallel issues at once, so free polecat slots pull
+// its work forward.
+func DeadlineParallel(maxParallel int, status DeadlineStatus) int {
+	if status == DeadlineAtRisk || status == DeadlineOverdue {
+		return maxParallel * 2
+	}
+	return maxParallel
+}
+
+// feedPick is an issue chosen for dispatch and the rig it goes to.
+type feedPick struct {
+	IssueID string
+	Rig     string
+}
+
+// isReady reports whether an issue can be dispatched: open, unassigned and
+// not waiting on an unfinished blocker.
+func (t trackedIssue) isReady() bool {
+	return t.Status == "open" && t.Assignee == "" && !t.Blocked
+}
+
+// isInFlight reports whether an issue is being worked and so occupies one
+// of the convoy's parallel slots.
+func (t trackedIssue) isInFlight() bool {
+	switch t.Status {
+	case "in_progress", "hooked":
+		return true
+	case "open":
+		return t.Assignee != ""
+	}
+	return false
+}
+
+// planFeed picks the ready issues to dispatch, in tracked (dependency)
+// order, so that no more than maxParallel issues are in flight and no rig
+// is given more issues than it has free polecat slots. rigOf maps an issue
+// to its rig ("" = unknown); rigFree returns a rig's free slots (< 0 =
+// unlimited) and is called at most once per rig.
+func planFeed(tracked []trackedIssue, maxParallel int, rigOf func(string) string, rigFree func(string) int, logger func(format string, args ...interface{})) []feedPick {
+	slots := maxParallel
+	for _, issue := range tracked {
+		if issue.isInFlight() {
+			slots--
+		}
+	}
+
+	free := make(map[string]int)
+	var picks []feedPick
+	for _, issue := range tracked {
+		if slots <= 0 {
+			break
+		}
+		if !issue.isReady() {
+			continue
+		}
+
+		r := rigOf(issue.ID)
+		if r == "" {
+			logger("cannot determine rig for issue %s, skipping", issue.ID)
+			continue
+		}
+		n, ok := free[r]
+		if !ok {
+			n = rigFree(r)
+		}
+		if n == 0 {
+			if !ok {
+				logger("rig %s is at polecat capacity, holding %s", r, issue.ID)
+			}
+			free[r] = 0
+			continue
+		}
+		if n > 0 {
+			n--
+		}
+		free[r] = n
+
+		picks = append(picks, feedPick{IssueID: issue.ID, Rig: r})
+		slots--
+	}
+	return picks
+}
+
+// FeedReadyIssues dispatches up to the convoy's MaxParallel ready issues via
+// gt sling, counting issues already in flight against the limit and skipping
+// rigs with no free polecat slots. Convoys the last deadline snapshot found
+// at risk or overdue get a raised limit (see DeadlineParallel). Returns the
+// number of issues dispatched.
+//
+// The daemon, witness, refinery and gt convoy import all feed convoys, so
+// planning and dispatch run under a per-convoy file lock: a second feeder
+// waits and then sees the first one's issues as in flight.
+func FeedReadyIssues(townRoot, convoyID, observer string, logger func(format string, args ...interface{})) int {
+	if logger == nil {
+		logger = func(format string, args ...interface{}) {} // no-op
+	}
+
+	fl, err := lockFeed(townRoot, convoyID)
+	if err != nil {
+		logger("%s: convoy %s: %v", observer, convoyID, err)
+		return 0
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	tracked := getConvoyTrackedIssues(townRoot, convoyID)
+	if len(tracked) == 0 {
+		return 0
+	}
+
+	maxParallel := ResolveMaxParallel(townRoot, getConvoyDescription(townRoot, convoyID))
+	prefixed := func(format string, args ...interface{}) {
+		logger("%s: convoy %s: "+format, append([]interface{}{observer, convoyID}, args...)...)
+	}
+	if status := LoadDeadlineStatuses(townRoot)[convoyID]; DeadlineParallel(maxParallel, status) != maxParallel {
+		maxParallel = DeadlineParallel(maxParallel, status)
+		prefixed("deadline %s, raising max parallel to %d", status, maxParallel)
+	}
+	picks := planFeed(tracked, maxParallel,
+		func(issueID string) string { return rigForIssue(townRoot, issueID) },
+		func(rigName string) int { return rigFreeSlots(townRoot, rigName) },
+		prefixed)
+	if len(picks) == 0 {
+		prefixed("no ready issues to feed (max parallel %d)", maxParallel)
+		return 0
+	}
+
+	dispatched := 0
+	for _, p := range picks {
+		prefixed("feeding ready issue %s to %s", p.IssueID, p.Rig)
+		if err := dispatchIssue(townRoot, p.IssueID, p.Rig); err != nil {
+			prefixed("failed to dispatch %s: %v", p.IssueID, err)
+			continue
+		}
+		dispatched++
+	}
+	return dispatched
+}
+
+// lockFeed acquires the exclusive feed lock for a convoy.
+// Caller must defer fl.Unlock().
+func lockFeed(townRoot, convoyID string) (*flock.Flock, error) {
+	lockDir := filepath.Join(townRoot, constants.DirRuntime, "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating lock dir: %w", err)
+	}
+	fl := flock.New(filepath.Join(lockDir, fmt.Sprintf("convoy-feed-%s.lock", convoyID)))
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring feed lock: %w", err)
+	}
+	return fl, nil
+}
+
+// getConvoyDescription returns a convoy's description, or "" on error.
+func getConvoyDescription(townRoot, convoyID string) string {
+	cmd := exec.Command("bd", "show", convoyID, "--json")
+	cmd.Dir = townRoot
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return ""
+	}
+
+	var results []struct {
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil || len(results) == 0 {
+		return ""
+	}
+	return results[0].Description
+}
+
+// rigFreeSlots returns how many more polecats a rig may run: its
+// max_polecats setting minus the polecats it already has. Returns -1 when
+// the rig sets no limit.
+func rigFreeSlots(townRoot, rigName string) int {
+	r := &rig.Rig{
+		Name: rigName,
+		Path: filepath.Join(townRoot, rigName),
+	}
+	limit := r.GetIntConfig("max_polecats")
+	if limit <= 0 {
+		return -1
+	}
+	free := limit - countPolecats(r.Path)
+	if free < 0 {
+		return 0
+	}
+	return free
+}
+
+// countPolecats counts the polecat worktrees in a rig.
+func countPolecats(rigPath string) int {
+	entries, err := os.ReadDir(filepath.Join(rigPath, "polecats"))
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			n++
+		}
+	}
+	return n
+}
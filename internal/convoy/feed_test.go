@@ -0,0 +1,132 @@
+package convoy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gofrs/flock"
+)
+
+func TestParseMaxParallel(t *testing.T) {
+	tests := []struct {
+		desc string
+		want int
+	}{
+		{"Convoy tracking 3 issues\nMaxParallel: 4", 4},
+		{"max_parallel: 2\nOwner: mayor/", 2},
+		{"MaxParallel: 0", 0},
+		{"MaxParallel: lots", 0},
+		{"Convoy tracking 3 issues", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseMaxParallel(tt.desc); got != tt.want {
+			t.Errorf("ParseMaxParallel(%q) = %d, want %d", tt.desc, got, tt.want)
+		}
+	}
+	if got := ParseMaxParallel(MaxParallelLine(7)); got != 7 {
+		t.Errorf("round trip = %d, want 7", got)
+	}
+	if MaxParallelLine(0) != "" {
+		t.Error("MaxParallelLine(0) should be empty")
+	}
+}
+
+func TestResolveMaxParallel_Default(t *testing.T) {
+	townRoot := t.TempDir()
+	if got := ResolveMaxParallel(townRoot, ""); got != DefaultMaxParallel {
+		t.Errorf("ResolveMaxParallel = %d, want %d", got, DefaultMaxParallel)
+	}
+	if got := ResolveMaxParallel(townRoot, "MaxParallel: 3"); got != 3 {
+		t.Errorf("ResolveMaxParallel = %d, want 3", got)
+	}
+}
+
+func TestPlanFeed(t *testing.T) {
+	nolog := func(string, ...interface{}) {}
+	rigOf := func(id string) string {
+		if id == "xx-orphan" {
+			return ""
+		}
+		return "gastown"
+	}
+	unlimited := func(string) int { return -1 }
+
+	tracked := []trackedIssue{
+		{ID: "gt-done", Status: "closed"},
+		{ID: "gt-working", Status: "in_progress", Assignee: "gastown/polecats/alpha"},
+		{ID: "gt-blocked", Status: "open", Blocked: true},
+		{ID: "xx-orphan", Status: "open"},
+		{ID: "gt-a", Status: "open"},
+		{ID: "gt-b", Status: "open"},
+		{ID: "gt-c", Status: "open"},
+	}
+
+	ids := func(picks []feedPick) []string {
+		var out []string
+		for _, p := range picks {
+			out = append(out, p.IssueID)
+		}
+		return out
+	}
+
+	// One issue already in flight leaves two of three slots.
+	if got := ids(planFeed(tracked, 3, rigOf, unlimited, nolog)); !reflect.DeepEqual(got, []string{"gt-a", "gt-b"}) {
+		t.Errorf("max 3 = %v, want [gt-a gt-b]", got)
+	}
+	// Serial convoys with work in flight dispatch nothing.
+	if got := planFeed(tracked, 1, rigOf, unlimited, nolog); len(got) != 0 {
+		t.Errorf("max 1 = %v, want none", ids(got))
+	}
+	// Rig capacity caps the batch below the convoy limit.
+	calls := 0
+	oneFree := func(string) int { calls++; return 1 }
+	if got := ids(planFeed(tracked, 10, rigOf, oneFree, nolog)); !reflect.DeepEqual(got, []string{"gt-a"}) {
+		t.Errorf("rig with 1 free slot = %v, want [gt-a]", got)
+	}
+	if calls != 1 {
+		t.Errorf("rigFree called %d times, want 1", calls)
+	}
+	// A full rig gets nothing.
+	if got := planFeed(tracked, 10, rigOf, func(string) int { return 0 }, nolog); len(got) != 0 {
+		t.Errorf("full rig = %v, want none", ids(got))
+	}
+}
+
+func TestCountPolecats(t *testing.T) {
+	rigPath := t.TempDir()
+	if n := countPolecats(rigPath); n != 0 {
+		t.Errorf("no polecats dir = %d, want 0", n)
+	}
+	for _, name := range []string{"alpha", "beta", ".pending"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, "polecats", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(rigPath, "polecats", "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if n := countPolecats(rigPath); n != 2 {
+		t.Errorf("countPolecats = %d, want 2", n)
+	}
+}
+
+func TestLockFeed(t *testing.T) {
+	town := t.TempDir()
+	fl, err := lockFeed(town, "hq-cv-1")
+	if err != nil {
+		t.Fatalf("lockFeed: %v", err)
+	}
+	defer fl.Unlock() //nolint:errcheck
+
+	other := flock.New(fl.Path())
+	if ok, err := other.TryLock(); err != nil || ok {
+		t.Errorf("second feeder got the lock (ok=%v, err=%v)", ok, err)
+	}
+	next, err := lockFeed(town, "hq-cv-2")
+	if err != nil {
+		t.Fatalf("lockFeed for another convoy: %v", err)
+	}
+	_ = next.Unlock()
+}
@@ -0,0 +1,385 @@
+package convoy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultImportPriority is the priority given to imported issues that do not
+// set one.
+const DefaultImportPriority = 2
+
+// ImportSpec describes a convoy and the issues it tracks, as read by
+// gt convoy import. The spec is applied idempotently: each issue's bead ID is
+// derived from the spec key and the issue key, so re-applying an edited spec
+// updates the beads it created earlier instead of duplicating them.
+type ImportSpec struct {
+	Key         string        `yaml:"key" toml:"key"`   // Stable identity (default: file name)
+	Name        string        `yaml:"name" toml:"name"` // Convoy title
+	Rig         string        `yaml:"rig" toml:"rig"`   // Default target rig for issues
+	Owner       string        `yaml:"owner" toml:"owner"`
+	Notify      string        `yaml:"notify" toml:"notify"`
+	Merge       string        `yaml:"merge" toml:"merge"`
+	Due         string        `yaml:"due" toml:"due"`
+	MaxParallel int           `yaml:"max_parallel" toml:"max_parallel"`
+	Issues      []ImportIssue `yaml:"issues" toml:"issues"`
+}
+
+// ImportIssue is one issue in an import spec.
+type ImportIssue struct {
+	Key         string   `yaml:"key" toml:"key"` // Stable identity (default: slug of the title)
+	Title       string   `yaml:"title" toml:"title"`
+	Description string   `yaml:"description" toml:"description"`
+	Type        string   `yaml:"type" toml:"type"`         // task (default), bug, feature, ...
+	Priority    *int     `yaml:"priority" toml:"priority"` // 0-4 (default 2)
+	Rig         string   `yaml:"rig" toml:"rig"`           // Target rig ("" = spec rig, else town)
+	DependsOn   []string `yaml:"depends_on" toml:"depends_on"`
+	Labels      []string `yaml:"labels" toml:"labels"`
+	Done        bool     `yaml:"done" toml:"done"` // Close the issue if it is open
+
+	keyLine int // Line of a Markdown item without a #key (see PinMarkdownKeys)
+}
+
+// ParseImportSpec parses an import spec, choosing the format from the file
+// extension: .yaml/.yml, .toml or .md/.markdown (a checklist). The spec key
+// defaults to the file name without its extension.
+func ParseImportSpec(path string, data []byte) (*ImportSpec, error) {
+	var spec ImportSpec
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("parsing YAML: %w", err)
+		}
+	case ".toml":
+		if _, err := toml.Decode(string(data), &spec); err != nil {
+			return nil, fmt.Errorf("parsing TOML: %w", err)
+		}
+	case ".md", ".markdown":
+		s, err := parseMarkdownSpec(data)
+		if err != nil {
+			return nil, err
+		}
+		spec = *s
+	default:
+		return nil, fmt.Errorf("unsupported spec format %q (want .yaml, .toml or .md)", ext)
+	}
+
+	if spec.Key == "" {
+		spec.Key = slugify(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+	if err := spec.normalize(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// normalize fills in defaults and validates keys, priorities and
+// dependencies.
+func (s *ImportSpec) normalize() error {
+	if s.Key == "" {
+		return fmt.Errorf("spec has no key")
+	}
+	if s.Name == "" {
+		s.Name = s.Key
+	}
+	switch s.Merge {
+	case "", "direct", "mr", "local":
+	default:
+		return fmt.Errorf("invalid merge %q: must be direct, mr, or local", s.Merge)
+	}
+	if s.MaxParallel < 0 {
+		return fmt.Errorf("invalid max_parallel %d: must be positive", s.MaxParallel)
+	}
+	if len(s.Issues) == 0 {
+		return fmt.Errorf("spec has no issues")
+	}
+
+	seen := make(map[string]bool)
+	for i := range s.Issues {
+		issue := &s.Issues[i]
+		issue.Title = strings.TrimSpace(issue.Title)
+		if issue.Title == "" {
+			return fmt.Errorf("issue %d has no title", i+1)
+		}
+		if issue.Key == "" {
+			issue.Key = slugify(issue.Title)
+		}
+		issue.Key = strings.TrimPrefix(issue.Key, "#")
+		if seen[issue.Key] {
+			return fmt.Errorf("duplicate issue key %q", issue.Key)
+		}
+		seen[issue.Key] = true
+		if issue.Rig == "" {
+			issue.Rig = s.Rig
+		}
+		if issue.Type == "" {
+			issue.Type = "task"
+		}
+		if issue.Priority == nil {
+			p := DefaultImportPriority
+			issue.Priority = &p
+		} else if *issue.Priority < 0 || *issue.Priority > 4 {
+			return fmt.Errorf("issue %q: invalid priority %d (want 0-4)", issue.Key, *issue.Priority)
+		}
+		for j, dep := range issue.DependsOn {
+			issue.DependsOn[j] = strings.TrimPrefix(strings.TrimSpace(dep), "#")
+		}
+	}
+
+	for _, issue := range s.Issues {
+		for _, dep := range issue.DependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("issue %q depends on unknown issue %q", issue.Key, dep)
+			}
+		}
+	}
+	_, err := s.Ordered()
+	return err
+}
+
+// Ordered returns the spec's issues with every issue after the issues it
+// depends on, otherwise keeping file order. Returns an error on a
+// dependency cycle.
+func (s *ImportSpec) Ordered() ([]ImportIssue, error) {
+	byKey := make(map[string]ImportIssue, len(s.Issues))
+	for _, issue := range s.Issues {
+		byKey[issue.Key] = issue
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(s.Issues))
+	var ordered []ImportIssue
+	var visit func(key string, path []string) error
+	visit = func(key string, path []string) error {
+		switch state[key] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, key), " → "))
+		}
+		state[key] = visiting
+		issue := byKey[key]
+		for _, dep := range issue.DependsOn {
+			if err := visit(dep, append(path, key)); err != nil {
+				return err
+			}
+		}
+		state[key] = done
+		ordered = append(ordered, issue)
+		return nil
+	}
+	for _, issue := range s.Issues {
+		if err := visit(issue.Key, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// ImportIssueID returns the bead ID for an imported issue: the rig's prefix
+// and a hash of the spec and issue keys.
+func ImportIssueID(prefix, specKey, issueKey string) string {
+	return prefix + "-" + importHash(specKey+"/"+issueKey)
+}
+
+// ImportConvoyID returns the convoy ID for an import spec.
+func ImportConvoyID(specKey string) string {
+	return "hq-cv-" + importHash(specKey)
+}
+
+// importHash returns a short, stable, lowercase hash of s.
+func importHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return strings.ToLower(base32.StdEncoding.EncodeToString(sum[:])[:7])
+}
+
+var slugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a title into a key: lowercase words joined by dashes.
+func slugify(s string) string {
+	return strings.Trim(slugInvalid.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+var (
+	mdHeading  = regexp.MustCompile(`^#\s+(.+)$`)
+	mdItem     = regexp.MustCompile(`^[-*+]\s+\[( |x|X)\]\s+(.+)$`)
+	mdSetting  = regexp.MustCompile(`^([A-Za-z_]+):\s*(.*)$`)
+	mdPriority = regexp.MustCompile(`^[Pp]([0-4])$`)
+)
+
+// parseMarkdownSpec reads a Markdown checklist spec:
+//
+//	# Sprint 12
+//	rig: gastown
+//	max_parallel: 2
+//
+//	- [ ] Design token schema #schema P1
+//	- [ ] Add OAuth login #oauth @gastown after:schema
+//	  Indented lines become the description.
+//	- [x] Already done
+//
+// The first heading names the convoy; "key: value" lines before the first
+// item set spec fields. Trailing tokens on an item set its key (#key), rig
+// (@rig), priority (P0-P4), type (type:bug) and dependencies (after:a,b).
+// Checked items are imported as done.
+func parseMarkdownSpec(data []byte) (*ImportSpec, error) {
+	spec := &ImportSpec{}
+	var current *ImportIssue
+	var desc []string
+
+	flush := func() {
+		if current != nil {
+			current.Description = strings.TrimSpace(strings.Join(desc, "\n"))
+			spec.Issues = append(spec.Issues, *current)
+		}
+		current, desc = nil, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		if m := mdItem.FindStringSubmatch(raw); m != nil {
+			flush()
+			issue, err := parseMarkdownItem(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			issue.Done = m[1] != " "
+			if issue.Key == "" {
+				issue.keyLine = lineNo
+			}
+			current = &issue
+			continue
+		}
+
+		if current != nil {
+			if line != "" && raw != line { // indented: part of the item
+				desc = append(desc, line)
+				continue
+			}
+			flush()
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			if spec.Name == "" {
+				spec.Name = strings.TrimSpace(m[1])
+			}
+			continue
+		}
+		if len(spec.Issues) > 0 {
+			continue // prose after the checklist starts
+		}
+		if m := mdSetting.FindStringSubmatch(line); m != nil {
+			if err := setMarkdownSetting(spec, strings.ToLower(m[1]), strings.TrimSpace(m[2])); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+	return spec, nil
+}
+
+// PinMarkdownKeys returns a Markdown spec with a #key token appended to
+// every checklist item that had none, and whether anything changed. A
+// derived key is a slug of the title, so without pinning it, editing the
+// title would import the item as a new issue. spec must be the result of
+// parsing data.
+func PinMarkdownKeys(data []byte, spec *ImportSpec) ([]byte, bool) {
+	lines := strings.Split(string(data), "\n")
+	changed := false
+	for _, issue := range spec.Issues {
+		i := issue.keyLine - 1
+		if i < 0 || i >= len(lines) || issue.Key == "" {
+			continue
+		}
+		line := strings.TrimRight(lines[i], "\r")
+		lines[i] = line + " #" + issue.Key + lines[i][len(line):]
+		changed = true
+	}
+	if !changed {
+		return data, false
+	}
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// setMarkdownSetting applies a "key: value" line from a Markdown spec.
+// Unknown keys are treated as prose and ignored.
+func setMarkdownSetting(spec *ImportSpec, key, value string) error {
+	switch key {
+	case "key":
+		spec.Key = value
+	case "rig":
+		spec.Rig = value
+	case "owner":
+		spec.Owner = value
+	case "notify":
+		spec.Notify = value
+	case "merge":
+		spec.Merge = value
+	case "due":
+		spec.Due = value
+	case "max_parallel", "maxparallel":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid max_parallel %q", value)
+		}
+		spec.MaxParallel = n
+	}
+	return nil
+}
+
+// parseMarkdownItem parses a checklist item's text, peeling attribute
+// tokens off the end of the title.
+func parseMarkdownItem(text string) (ImportIssue, error) {
+	var issue ImportIssue
+	words := strings.Fields(text)
+	for len(words) > 0 {
+		tok := words[len(words)-1]
+		switch {
+		case strings.HasPrefix(tok, "#") && len(tok) > 1:
+			issue.Key = tok[1:]
+		case strings.HasPrefix(tok, "@") && len(tok) > 1:
+			issue.Rig = tok[1:]
+		case mdPriority.MatchString(tok):
+			p, _ := strconv.Atoi(tok[1:])
+			issue.Priority = &p
+		case strings.HasPrefix(tok, "type:") && len(tok) > len("type:"):
+			issue.Type = strings.TrimPrefix(tok, "type:")
+		case strings.HasPrefix(tok, "after:") && len(tok) > len("after:"):
+			var deps []string
+			for _, dep := range strings.Split(strings.TrimPrefix(tok, "after:"), ",") {
+				if dep = strings.TrimSpace(dep); dep != "" {
+					deps = append(deps, dep)
+				}
+			}
+			issue.DependsOn = append(deps, issue.DependsOn...)
+		default:
+			issue.Title = strings.Join(words, " ")
+			return issue, nil
+		}
+		words = words[:len(words)-1]
+	}
+	return issue, fmt.Errorf("checklist item %q has no title", text)
+}
@@ -0,0 +1,192 @@
+package convoy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func issueKeys(issues []ImportIssue) []string {
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	return keys
+}
+
+func TestParseImportSpec_YAML(t *testing.T) {
+	data := `
+name: Sprint 12
+rig: gastown
+max_parallel: 2
+due: 2026-01-20
+issues:
+  - key: oauth
+    title: Add OAuth login
+    priority: 1
+    depends_on: [schema]
+  - key: schema
+    title: Design token schema
+    rig: beads
+    type: feature
+`
+	spec, err := ParseImportSpec("specs/sprint-12.yaml", []byte(data))
+	if err != nil {
+		t.Fatalf("ParseImportSpec: %v", err)
+	}
+	if spec.Key != "sprint-12" || spec.Name != "Sprint 12" || spec.MaxParallel != 2 || spec.Due != "2026-01-20" {
+		t.Errorf("spec = %+v", spec)
+	}
+	oauth, schema := spec.Issues[0], spec.Issues[1]
+	if oauth.Rig != "gastown" || *oauth.Priority != 1 || oauth.Type != "task" {
+		t.Errorf("oauth = %+v", oauth)
+	}
+	if schema.Rig != "beads" || *schema.Priority != DefaultImportPriority || schema.Type != "feature" {
+		t.Errorf("schema = %+v", schema)
+	}
+
+	ordered, err := spec.Ordered()
+	if err != nil {
+		t.Fatalf("Ordered: %v", err)
+	}
+	if got, want := issueKeys(ordered), []string{"schema", "oauth"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Ordered = %v, want %v", got, want)
+	}
+}
+
+func TestParseImportSpec_TOML(t *testing.T) {
+	data := `
+key = "q3-cleanup"
+name = "Q3 cleanup"
+
+[[issues]]
+title = "Remove dead flags"
+
+[[issues]]
+title = "Drop legacy config"
+depends_on = ["remove-dead-flags"]
+done = true
+`
+	spec, err := ParseImportSpec("cleanup.toml", []byte(data))
+	if err != nil {
+		t.Fatalf("ParseImportSpec: %v", err)
+	}
+	if spec.Key != "q3-cleanup" {
+		t.Errorf("Key = %q, want q3-cleanup", spec.Key)
+	}
+	if got, want := issueKeys(spec.Issues), []string{"remove-dead-flags", "drop-legacy-config"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+	if !spec.Issues[1].Done {
+		t.Error("expected second issue to be done")
+	}
+}
+
+func TestParseImportSpec_Markdown(t *testing.T) {
+	data := `# Sprint 12
+
+Planning notes for the sprint.
+rig: gastown
+max_parallel: 3
+
+- [ ] Design token schema #schema P1 @beads
+- [ ] Add OAuth login #oauth type:feature after:schema,refresh
+  Support Google and GitHub.
+  Keep the old login behind a flag.
+- [x] Token refresh #refresh
+
+## Notes
+- [ ] Write release notes
+`
+	spec, err := ParseImportSpec("sprint.md", []byte(data))
+	if err != nil {
+		t.Fatalf("ParseImportSpec: %v", err)
+	}
+	if spec.Name != "Sprint 12" || spec.Rig != "gastown" || spec.MaxParallel != 3 {
+		t.Errorf("spec = %+v", spec)
+	}
+	if got, want := issueKeys(spec.Issues), []string{"schema", "oauth", "refresh", "write-release-notes"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+
+	schema, oauth, refresh := spec.Issues[0], spec.Issues[1], spec.Issues[2]
+	if schema.Title != "Design token schema" || schema.Rig != "beads" || *schema.Priority != 1 {
+		t.Errorf("schema = %+v", schema)
+	}
+	if oauth.Type != "feature" || oauth.Rig != "gastown" || !reflect.DeepEqual(oauth.DependsOn, []string{"schema", "refresh"}) {
+		t.Errorf("oauth = %+v", oauth)
+	}
+	if oauth.Description != "Support Google and GitHub.\nKeep the old login behind a flag." {
+		t.Errorf("oauth description = %q", oauth.Description)
+	}
+	if !refresh.Done || schema.Done {
+		t.Error("expected only the checked item to be done")
+	}
+}
+
+func TestParseImportSpec_Errors(t *testing.T) {
+	tests := []struct {
+		name, path, data, want string
+	}{
+		{"format", "spec.json", `{}`, "unsupported spec format"},
+		{"no issues", "spec.yaml", "name: x\n", "no issues"},
+		{"duplicate", "spec.md", "- [ ] A #a\n- [ ] B #a\n", "duplicate issue key"},
+		{"unknown dep", "spec.md", "- [ ] A after:b\n", "unknown issue"},
+		{"cycle", "spec.md", "- [ ] A #a after:b\n- [ ] B #b after:a\n", "dependency cycle"},
+		{"priority", "spec.yaml", "issues:\n  - title: A\n    priority: 9\n", "invalid priority"},
+		{"merge", "spec.yaml", "merge: yolo\nissues:\n  - title: A\n", "invalid merge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseImportSpec(tt.path, []byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportIDsAreStable(t *testing.T) {
+	a := ImportIssueID("gt", "sprint-12", "oauth")
+	if a != ImportIssueID("gt", "sprint-12", "oauth") {
+		t.Error("ImportIssueID is not deterministic")
+	}
+	if a == ImportIssueID("gt", "sprint-12", "schema") || a == ImportIssueID("gt", "sprint-13", "oauth") {
+		t.Error("ImportIssueID collides across keys")
+	}
+	if !strings.HasPrefix(a, "gt-") || len(a) != len("gt-")+7 {
+		t.Errorf("ImportIssueID = %q", a)
+	}
+	if id := ImportConvoyID("sprint-12"); !strings.HasPrefix(id, "hq-cv-") {
+		t.Errorf("ImportConvoyID = %q", id)
+	}
+}
+
+func TestPinMarkdownKeys(t *testing.T) {
+	data := "# Sprint\n\n- [ ] Design schema #schema\n- [ ] Add OAuth login P1\r\n  Details.\n"
+	spec, err := ParseImportSpec("sprint.md", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned, ok := PinMarkdownKeys([]byte(data), spec)
+	if !ok {
+		t.Fatal("PinMarkdownKeys reported no change")
+	}
+	want := "# Sprint\n\n- [ ] Design schema #schema\n- [ ] Add OAuth login P1 #add-oauth-login\r\n  Details.\n"
+	if string(pinned) != want {
+		t.Errorf("pinned =\n%q\nwant\n%q", pinned, want)
+	}
+
+	// The pinned key survives a retitle.
+	retitled := strings.Replace(string(pinned), "Add OAuth login", "Add SSO login", 1)
+	spec, err = ParseImportSpec("sprint.md", []byte(retitled))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Issues[1].Key != "add-oauth-login" || *spec.Issues[1].Priority != 1 {
+		t.Errorf("retitled issue = %+v", spec.Issues[1])
+	}
+	if _, ok := PinMarkdownKeys([]byte(retitled), spec); ok {
+		t.Error("fully keyed spec was rewritten")
+	}
+}
@@ -0,0 +1,150 @@
+package convoy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Convoy landing policies. A convoy spanning several rigs can require its
+// rigs' work to land together (atomic) or one rig after another (ordered).
+const (
+	LandingIndependent = "independent" // default: each MR lands on its own
+	LandingAtomic      = "atomic"      // MRs merge to an integration branch per rig; all rigs land together
+	LandingOrdered     = "ordered"     // a rig's MRs are held until earlier rigs have landed
+)
+
+// LabelLandingFailed marks an atomic convoy whose automatic landing failed.
+// Observers stop retrying until 'gt convoy land' succeeds.
+const LabelLandingFailed = "gt:landing-failed"
+
+// landingLinePrefix starts the convoy description line carrying the policy,
+// e.g. "Landing: ordered gastown, beads".
+const landingLinePrefix = "Landing: "
+
+// ValidateLanding checks that policy is a known landing policy.
+func ValidateLanding(policy string) error {
+	switch policy {
+	case LandingIndependent, LandingAtomic, LandingOrdered:
+		return nil
+	}
+	return fmt.Errorf("invalid landing policy %q: must be independent, atomic, or ordered", policy)
+}
+
+// LandingLine formats the description line for a landing policy. order
+// lists rigs in landing order (ordered policy only). Returns "" for the
+// independent policy.
+func LandingLine(policy string, order []string) string {
+	switch policy {
+	case "", LandingIndependent:
+		return ""
+	case LandingOrdered:
+		if len(order) > 0 {
+			return landingLinePrefix + policy + " " + strings.Join(order, ", ")
+		}
+	}
+	return landingLinePrefix + policy
+}
+
+// ParseLanding extracts the landing policy and rig order from a convoy
+// description. Returns LandingIndependent when none is set.
+func ParseLanding(description string) (policy string, order []string) {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, landingLinePrefix) {
+			continue
+		}
+		fields := strings.FieldsFunc(strings.TrimPrefix(line, landingLinePrefix), func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		if len(fields) == 0 || ValidateLanding(fields[0]) != nil {
+			continue
+		}
+		return fields[0], fields[1:]
+	}
+	return LandingIndependent, nil
+}
+
+// IntegrationBranch returns the branch an atomic convoy's MRs merge into in
+// each of its rigs. It lives under integration/ so the pre-push guardrail
+// keeps it off the default branch until the convoy lands.
+func IntegrationBranch(convoyID string) string {
+	return "integration/" + convoyID
+}
+
+// LandingOrder returns the rigs of a convoy in landing order: the explicit
+// order first, then any other rigs in the order their issues are tracked.
+func LandingOrder(order, trackedRigs []string) []string {
+	var rigs []string
+	seen := make(map[string]bool)
+	for _, r := range append(append([]string{}, order...), trackedRigs...) {
+		if r != "" && !seen[r] {
+			seen[r] = true
+			rigs = append(rigs, r)
+		}
+	}
+	return rigs
+}
+
+// LandingState is a convoy's landing policy and where its tracked work
+// stands, used by the refinery to decide whether to hold a convoy's MRs.
+type LandingState struct {
+	ConvoyID string
+	Policy   string
+	Order    []string       // Rigs in landing order
+	Pending  map[string]int // Rig -> tracked issues not yet closed
+}
+
+// LoadLandingState reads a convoy's landing policy and the state of its
+// tracked issues. Returns nil for independent convoys or when the convoy
+// cannot be read (MRs are then not held).
+func LoadLandingState(townRoot, convoyID string) *LandingState {
+	policy, order := ParseLanding(getConvoyDescription(townRoot, convoyID))
+	if policy == LandingIndependent {
+		return nil
+	}
+
+	state := &LandingState{
+		ConvoyID: convoyID,
+		Policy:   policy,
+		Pending:  make(map[string]int),
+	}
+	var trackedRigs []string
+	for _, issue := range getConvoyTrackedIssues(townRoot, convoyID) {
+		r := rigForIssue(townRoot, issue.ID)
+		trackedRigs = append(trackedRigs, r)
+		if issue.Status != "closed" && issue.Status != "tombstone" && r != "" {
+			state.Pending[r]++
+		}
+	}
+	state.Order = LandingOrder(order, trackedRigs)
+	return state
+}
+
+// Hold returns why an MR from rig, targeting target, must wait before the
+// refinery merges it, or "" when it may proceed.
+//
+//   - atomic: MRs must target the convoy's integration branch; the rigs land
+//     together via 'gt convoy land' once all of them are merged and green.
+//   - ordered: MRs wait until every earlier rig in the order has no open
+//     tracked issues.
+func (s *LandingState) Hold(rig, target string) string {
+	if s == nil {
+		return ""
+	}
+	switch s.Policy {
+	case LandingAtomic:
+		if branch := IntegrationBranch(s.ConvoyID); target != branch {
+			return fmt.Sprintf("atomic convoy %s lands via %s, but MR targets %s", s.ConvoyID, branch, target)
+		}
+	case LandingOrdered:
+		for _, r := range s.Order {
+			if r == rig {
+				break
+			}
+			if n := s.Pending[r]; n > 0 {
+				return fmt.Sprintf("ordered convoy %s lands %s first (%d issue(s) open)", s.ConvoyID, r, n)
+			}
+		}
+	}
+	return ""
+}
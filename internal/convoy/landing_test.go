@@ -0,0 +1,83 @@
+package convoy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLandingLineRoundTrip(t *testing.T) {
+	tests := []struct {
+		policy    string
+		order     []string
+		line      string
+		wantOrder []string
+	}{
+		{LandingIndependent, nil, "", nil},
+		{LandingAtomic, nil, "Landing: atomic", []string{}},
+		{LandingOrdered, nil, "Landing: ordered", []string{}},
+		{LandingOrdered, []string{"api", "client"}, "Landing: ordered api, client", []string{"api", "client"}},
+	}
+	for _, tt := range tests {
+		line := LandingLine(tt.policy, tt.order)
+		if line != tt.line {
+			t.Errorf("LandingLine(%q, %v) = %q, want %q", tt.policy, tt.order, line, tt.line)
+		}
+		policy, order := ParseLanding("Convoy tracking 2 issues\n" + line + "\nMerge: mr")
+		if policy != tt.policy {
+			t.Errorf("ParseLanding(%q) policy = %q, want %q", line, policy, tt.policy)
+		}
+		if len(order) != len(tt.wantOrder) || (len(order) > 0 && !reflect.DeepEqual(order, tt.wantOrder)) {
+			t.Errorf("ParseLanding(%q) order = %v, want %v", line, order, tt.wantOrder)
+		}
+	}
+
+	if policy, _ := ParseLanding("Landing: sideways"); policy != LandingIndependent {
+		t.Errorf("unknown policy parsed as %q", policy)
+	}
+	if err := ValidateLanding("sideways"); err == nil {
+		t.Error("ValidateLanding accepted an unknown policy")
+	}
+}
+
+func TestLandingOrder(t *testing.T) {
+	got := LandingOrder([]string{"api"}, []string{"client", "api", "", "docs", "client"})
+	if want := []string{"api", "client", "docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LandingOrder = %v, want %v", got, want)
+	}
+}
+
+func TestLandingStateHold(t *testing.T) {
+	var none *LandingState
+	if reason := none.Hold("api", "main"); reason != "" {
+		t.Errorf("nil state held MR: %s", reason)
+	}
+
+	atomic := &LandingState{ConvoyID: "hq-cv-abc", Policy: LandingAtomic}
+	if reason := atomic.Hold("api", "main"); !strings.Contains(reason, "integration/hq-cv-abc") {
+		t.Errorf("atomic MR to main not held: %q", reason)
+	}
+	if reason := atomic.Hold("api", IntegrationBranch("hq-cv-abc")); reason != "" {
+		t.Errorf("atomic MR to integration branch held: %s", reason)
+	}
+
+	ordered := &LandingState{
+		ConvoyID: "hq-cv-abc",
+		Policy:   LandingOrdered,
+		Order:    []string{"api", "client", "docs"},
+		Pending:  map[string]int{"api": 1, "client": 2},
+	}
+	if reason := ordered.Hold("api", "main"); reason != "" {
+		t.Errorf("first rig held: %s", reason)
+	}
+	if reason := ordered.Hold("client", "main"); !strings.Contains(reason, "lands api first") {
+		t.Errorf("second rig not held for api: %q", reason)
+	}
+	ordered.Pending["api"] = 0
+	if reason := ordered.Hold("client", "main"); reason != "" {
+		t.Errorf("second rig held after api landed: %s", reason)
+	}
+	if reason := ordered.Hold("docs", "main"); !strings.Contains(reason, "lands client first") {
+		t.Errorf("third rig not held for client: %q", reason)
+	}
+}
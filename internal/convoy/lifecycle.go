@@ -0,0 +1,83 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Convoy lifecycle states. Beads only knows open and closed, so an
+// abandoned convoy is a closed bead carrying LabelAbandoned.
+const (
+	StatusOpen      = "open"
+	StatusClosed    = "closed" // completed: all tracked work landed
+	StatusAbandoned = "abandoned"
+)
+
+// LabelAbandoned marks a closed convoy as abandoned rather than completed.
+const LabelAbandoned = "gt:abandoned"
+
+// AbandonReasonPrefix starts the close reason recorded for abandoned convoys.
+const AbandonReasonPrefix = "Abandoned: "
+
+// ErrInvalidTransition is returned when a convoy state transition is not allowed.
+var ErrInvalidTransition = errors.New("invalid convoy state transition")
+
+// Lifecycle returns the lifecycle state of a convoy from its beads status and
+// labels: open, closed (completed) or abandoned.
+func Lifecycle(status string, labels []string) string {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != StatusClosed {
+		return status
+	}
+	for _, l := range labels {
+		if l == LabelAbandoned {
+			return StatusAbandoned
+		}
+	}
+	return StatusClosed
+}
+
+// IsKnownState reports whether s is a convoy lifecycle state.
+func IsKnownState(s string) bool {
+	switch s {
+	case StatusOpen, StatusClosed, StatusAbandoned:
+		return true
+	}
+	return false
+}
+
+// ValidateTransition checks if a convoy state transition from -> to is valid.
+//
+// Valid transitions:
+//   - open → closed (all tracked issues landed, or gt convoy close)
+//   - open → abandoned (gt convoy abandon)
+//   - closed → open (gt convoy reopen, or adding issues)
+//   - abandoned → open (gt convoy reopen only)
+//
+// Invalid:
+//   - closed ↔ abandoned (a finished convoy keeps its outcome; reopen first)
+func ValidateTransition(from, to string) error {
+	if !IsKnownState(from) {
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, from)
+	}
+	if !IsKnownState(to) {
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidTransition, to)
+	}
+
+	// Same state is always valid (no-op)
+	if from == to {
+		return nil
+	}
+
+	switch from {
+	case StatusOpen:
+		return nil // open → closed | abandoned
+	case StatusClosed, StatusAbandoned:
+		if to == StatusOpen {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, from, to)
+}
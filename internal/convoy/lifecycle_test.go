@@ -0,0 +1,50 @@
+package convoy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLifecycle(t *testing.T) {
+	tests := []struct {
+		status string
+		labels []string
+		want   string
+	}{
+		{"open", nil, StatusOpen},
+		{" Closed ", nil, StatusClosed},
+		{"closed", []string{"gt:owned", LabelAbandoned}, StatusAbandoned},
+		{"open", []string{LabelAbandoned}, StatusOpen}, // stale label on a reopened convoy
+	}
+	for _, tt := range tests {
+		if got := Lifecycle(tt.status, tt.labels); got != tt.want {
+			t.Errorf("Lifecycle(%q, %v) = %q, want %q", tt.status, tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{StatusOpen, StatusClosed, true},
+		{StatusOpen, StatusAbandoned, true},
+		{StatusClosed, StatusOpen, true},
+		{StatusAbandoned, StatusOpen, true},
+		{StatusAbandoned, StatusAbandoned, true},
+		{StatusClosed, StatusAbandoned, false},
+		{StatusAbandoned, StatusClosed, false},
+		{"in_progress", StatusClosed, false},
+		{StatusOpen, "archived", false},
+	}
+	for _, tt := range tests {
+		err := ValidateTransition(tt.from, tt.to)
+		if tt.ok && err != nil {
+			t.Errorf("%s → %s: unexpected error %v", tt.from, tt.to, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("%s → %s: got %v, want ErrInvalidTransition", tt.from, tt.to, err)
+		}
+	}
+}
@@ -13,8 +13,8 @@ import (
 
 // CheckConvoysForIssue finds any convoys tracking the given issue and triggers
 // convoy completion checks. If the convoy is not complete, it reactively feeds
-// the next ready issue to keep the convoy progressing without waiting for
-// polling-based patrol cycles.
+// ready issues, up to the convoy's MaxParallel, to keep the convoy progressing
+// without waiting for polling-based patrol cycles.
 //
 // This enables redundant convoy observation from multiple agents (Witness,
 // Refinery, Daemon).
@@ -56,16 +56,21 @@ func CheckConvoysForIssue(townRoot, issueID, observer string, logger func(format
 		}
 
 		// Continuation feed: if convoy is still open after the completion check,
-		// reactively dispatch the next ready issue. This makes convoy feeding
+		// reactively dispatch ready issues. This makes convoy feeding
 		// event-driven instead of relying on polling-based patrol cycles.
 		if !isConvoyClosed(townRoot, convoyID) {
-			feedNextReadyIssue(townRoot, convoyID, observer, logger)
+			FeedReadyIssues(townRoot, convoyID, observer, logger)
 		}
 	}
 
 	return convoyIDs
 }
 
+// TrackingConvoys returns the IDs of convoys that track the given issue.
+func TrackingConvoys(townRoot, issueID string) []string {
+	return getTrackingConvoys(townRoot, issueID)
+}
+
 // getTrackingConvoys returns convoy IDs that track the given issue.
 // Uses bd dep list to query the dependency graph.
 func getTrackingConvoys(townRoot, issueID string) []string {
@@ -135,44 +140,7 @@ type trackedIssue struct {
 	Status   string `json:"status"`
 	Assignee string `json:"assignee"`
 	Priority int    `json:"priority"`
-}
-
-// feedNextReadyIssue finds the next ready issue in a convoy and dispatches it
-// via gt sling. A ready issue is one that is open with no assignee. This
-// provides reactive (event-driven) convoy feeding instead of waiting for
-// polling-based patrol cycles.
-//
-// Only one issue is dispatched per call. When that issue completes, the
-// observer fires again and feeds the next one.
-func feedNextReadyIssue(townRoot, convoyID, observer string, logger func(format string, args ...interface{})) {
-	tracked := getConvoyTrackedIssues(townRoot, convoyID)
-	if len(tracked) == 0 {
-		return
-	}
-
-	// Find the first ready issue (open, no assignee).
-	// Issues are returned by bd dep list in dependency order, so we pick
-	// the first match which is typically the highest priority.
-	for _, issue := range tracked {
-		if issue.Status != "open" || issue.Assignee != "" {
-			continue
-		}
-
-		// Determine target rig from issue prefix
-		rig := rigForIssue(townRoot, issue.ID)
-		if rig == "" {
-			logger("%s: convoy %s: cannot determine rig for issue %s, skipping", observer, convoyID, issue.ID)
-			continue
-		}
-
-		logger("%s: convoy %s: feeding next ready issue %s to %s", observer, convoyID, issue.ID, rig)
-		if err := dispatchIssue(townRoot, issue.ID, rig); err != nil {
-			logger("%s: convoy %s: failed to dispatch %s: %v", observer, convoyID, issue.ID, err)
-		}
-		return // Feed one at a time
-	}
-
-	logger("%s: convoy %s: no ready issues to feed", observer, convoyID)
+	Blocked  bool   `json:"-"` // waiting on an unfinished blocker
 }
 
 // getConvoyTrackedIssues returns issues tracked by a convoy with fresh status.
@@ -227,6 +195,7 @@ func getConvoyTrackedIssues(townRoot, convoyID string) []trackedIssue {
 		if fresh, ok := freshStatus[d.ID]; ok {
 			t.Status = fresh.Status
 			t.Assignee = fresh.Assignee
+			t.Blocked = fresh.Blocked
 		}
 		result[i] = t
 	}
@@ -254,19 +223,33 @@ func batchShowIssues(townRoot string, issueIDs []string) map[string]trackedIssue
 	}
 
 	var issues []struct {
-		ID       string `json:"id"`
-		Status   string `json:"status"`
-		Assignee string `json:"assignee"`
+		ID             string   `json:"id"`
+		Status         string   `json:"status"`
+		Assignee       string   `json:"assignee"`
+		BlockedBy      []string `json:"blocked_by"`
+		BlockedByCount int      `json:"blocked_by_count"`
+		Dependencies   []struct {
+			Status         string `json:"status"`
+			DependencyType string `json:"dependency_type"`
+		} `json:"dependencies"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
 		return result
 	}
 
 	for _, issue := range issues {
+		blocked := issue.BlockedByCount > 0 || len(issue.BlockedBy) > 0
+		// bd show can omit blocked_by_count; fall back to live dependency edges.
+		for _, dep := range issue.Dependencies {
+			if dep.DependencyType == "blocks" && dep.Status != "closed" && dep.Status != "tombstone" {
+				blocked = true
+			}
+		}
 		result[issue.ID] = trackedIssue{
 			ID:       issue.ID,
 			Status:   issue.Status,
 			Assignee: issue.Assignee,
+			Blocked:  blocked,
 		}
 	}
 
@@ -124,14 +124,16 @@ func TestBatchShowIssues_Empty(t *testing.T) {
 	}
 }
 
-func TestFeedNextReadyIssue_EmptyTracked(t *testing.T) {
-	// feedNextReadyIssue should handle empty tracked issues without panic.
+func TestFeedReadyIssues_EmptyTracked(t *testing.T) {
+	// FeedReadyIssues should handle empty tracked issues without panic.
 	var logged []string
 	logger := func(format string, args ...interface{}) {
 		logged = append(logged, format)
 	}
 
-	feedNextReadyIssue("/nonexistent/path", "convoy-1", "test", logger)
+	if n := FeedReadyIssues("/nonexistent/path", "convoy-1", "test", logger); n != 0 {
+		t.Errorf("expected 0 dispatched, got %d", n)
+	}
 	if len(logged) != 0 {
 		t.Errorf("expected no logs for empty tracked issues, got %d", len(logged))
 	}
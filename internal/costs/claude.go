@@ -0,0 +1,82 @@
+package costs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ClaudeExtractor reads Claude Code transcripts from
+// <config>/projects/<workdir-with-dashes>/*.jsonl.
+type ClaudeExtractor struct {
+	// ConfigDir overrides the Claude config directory. Defaults to
+	// $CLAUDE_CONFIG_DIR, then ~/.claude.
+	ConfigDir string
+}
+
+// claudeMessage is a line of a Claude Code transcript.
+type claudeMessage struct {
+	Type    string `json:"type"`
+	Message *struct {
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+		} `json:"usage,omitempty"`
+	} `json:"message,omitempty"`
+}
+
+// ProjectDir returns the transcript directory for a working directory.
+// Claude Code replaces path separators with dashes, keeping the leading one.
+func (e ClaudeExtractor) ProjectDir(workDir string) (string, error) {
+	dir := e.ConfigDir
+	if dir == "" {
+		dir = os.Getenv("CLAUDE_CONFIG_DIR")
+	}
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".claude")
+	}
+	return filepath.Join(dir, "projects", strings.ReplaceAll(workDir, "/", "-")), nil
+}
+
+// Extract sums usage from assistant messages in the latest transcript.
+func (e ClaudeExtractor) Extract(workDir string) (*Usage, error) {
+	projectDir, err := e.ProjectDir(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("getting project dir: %w", err)
+	}
+	path, err := latestFile(projectDir, ".jsonl")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := NewUsage("claude")
+	err = scanJSONL(path, func(line []byte) {
+		var msg claudeMessage
+		if json.Unmarshal(line, &msg) != nil {
+			return // Skip malformed lines
+		}
+		if msg.Type != "assistant" || msg.Message == nil || msg.Message.Usage == nil {
+			return
+		}
+		u := msg.Message.Usage
+		usage.Add(msg.Message.Model, Tokens{
+			Input:      u.InputTokens,
+			CacheRead:  u.CacheReadInputTokens,
+			CacheWrite: u.CacheCreationInputTokens,
+			Output:     u.OutputTokens,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing transcript: %w", err)
+	}
+	return usage, nil
+}
@@ -0,0 +1,134 @@
+package costs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// codexScanLimit bounds how many recent rollout files are opened when
+// looking for a working directory's session.
+const codexScanLimit = 200
+
+// CodexExtractor reads Codex CLI rollouts from
+// <codex home>/sessions/YYYY/MM/DD/rollout-*.jsonl.
+type CodexExtractor struct {
+	// Home overrides the Codex home directory. Defaults to $CODEX_HOME,
+	// then ~/.codex.
+	Home string
+}
+
+// codexLine is a line of a Codex rollout file.
+type codexLine struct {
+	Type    string `json:"type"`
+	Payload struct {
+		Type  string `json:"type"`
+		CWD   string `json:"cwd"`
+		Model string `json:"model"`
+		Info  *struct {
+			TotalTokenUsage *struct {
+				InputTokens       int64 `json:"input_tokens"`
+				CachedInputTokens int64 `json:"cached_input_tokens"`
+				OutputTokens      int64 `json:"output_tokens"`
+			} `json:"total_token_usage"`
+		} `json:"info"`
+	} `json:"payload"`
+}
+
+func (e CodexExtractor) home() (string, error) {
+	if e.Home != "" {
+		return e.Home, nil
+	}
+	if h := os.Getenv("CODEX_HOME"); h != "" {
+		return h, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".codex"), nil
+}
+
+// Extract finds the newest rollout whose session started in workDir.
+// Codex reports cumulative totals, so the last token_count event wins.
+func (e CodexExtractor) Extract(workDir string) (*Usage, error) {
+	home, err := e.home()
+	if err != nil {
+		return nil, err
+	}
+	sessionsDir := filepath.Join(home, "sessions")
+	files, err := filesByModTime(sessionsDir, ".jsonl")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > codexScanLimit {
+		files = files[:codexScanLimit]
+	}
+
+	want := filepath.Clean(workDir)
+	for _, path := range files {
+		if codexSessionCWD(path) != want {
+			continue
+		}
+		return parseCodexRollout(path)
+	}
+	return nil, fmt.Errorf("%w for %s in %s", ErrNoTranscript, workDir, sessionsDir)
+}
+
+// codexSessionCWD returns the cwd from a rollout's session_meta line.
+func codexSessionCWD(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	// session_meta is the first line; tolerate a few leading extras.
+	for i := 0; i < 5 && scanner.Scan(); i++ {
+		var line codexLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+		if line.Type == "session_meta" {
+			return filepath.Clean(line.Payload.CWD)
+		}
+	}
+	return ""
+}
+
+func parseCodexRollout(path string) (*Usage, error) {
+	var model string
+	var total Tokens
+	err := scanJSONL(path, func(raw []byte) {
+		var line codexLine
+		if json.Unmarshal(raw, &line) != nil {
+			return
+		}
+		switch {
+		case line.Type == "turn_context" && line.Payload.Model != "":
+			model = line.Payload.Model
+		case line.Type == "event_msg" && line.Payload.Type == "token_count":
+			if line.Payload.Info == nil || line.Payload.Info.TotalTokenUsage == nil {
+				return
+			}
+			u := line.Payload.Info.TotalTokenUsage
+			// input_tokens includes the cached portion.
+			total = Tokens{
+				Input:     u.InputTokens - u.CachedInputTokens,
+				CacheRead: u.CachedInputTokens,
+				Output:    u.OutputTokens,
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing rollout: %w", err)
+	}
+
+	usage := NewUsage("codex")
+	usage.Add(model, total)
+	return usage, nil
+}
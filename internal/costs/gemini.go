@@ -0,0 +1,82 @@
+package costs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// GeminiExtractor reads Gemini CLI chat logs from
+// <gemini dir>/tmp/<sha256(workdir)>/chats/session-*.json.
+type GeminiExtractor struct {
+	// Dir overrides the Gemini config directory. Defaults to ~/.gemini.
+	Dir string
+}
+
+// geminiChat is a saved Gemini CLI conversation.
+type geminiChat struct {
+	Messages []struct {
+		Type   string `json:"type"`
+		Model  string `json:"model"`
+		Tokens *struct {
+			Input    int64 `json:"input"`
+			Output   int64 `json:"output"`
+			Cached   int64 `json:"cached"`
+			Thoughts int64 `json:"thoughts"`
+		} `json:"tokens"`
+	} `json:"messages"`
+}
+
+// ChatsDir returns the chat log directory for a working directory.
+// Gemini CLI keys project data by the SHA-256 of the project root.
+func (e GeminiExtractor) ChatsDir(workDir string) (string, error) {
+	dir := e.Dir
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".gemini")
+	}
+	sum := sha256.Sum256([]byte(workDir))
+	return filepath.Join(dir, "tmp", hex.EncodeToString(sum[:]), "chats"), nil
+}
+
+// Extract sums usage from model responses in the latest chat log.
+func (e GeminiExtractor) Extract(workDir string) (*Usage, error) {
+	chatsDir, err := e.ChatsDir(workDir)
+	if err != nil {
+		return nil, err
+	}
+	path, err := latestFile(chatsDir, ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var chat geminiChat
+	if err := json.Unmarshal(data, &chat); err != nil {
+		return nil, fmt.Errorf("parsing chat log: %w", err)
+	}
+
+	usage := NewUsage("gemini")
+	for _, msg := range chat.Messages {
+		if msg.Type != "gemini" || msg.Tokens == nil {
+			continue
+		}
+		t := msg.Tokens
+		// input counts the cached prompt tokens; thoughts bill as output.
+		usage.Add(msg.Model, Tokens{
+			Input:     t.Input - t.Cached,
+			CacheRead: t.Cached,
+			Output:    t.Output + t.Thoughts,
+		})
+	}
+	return usage, nil
+}
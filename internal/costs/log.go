@@ -0,0 +1,139 @@
+package costs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogEntry is a line of the session cost log written by gt costs record.
+type LogEntry struct {
+	SessionID string    `json:"session_id"`
+	Role      string    `json:"role"`
+	Rig       string    `json:"rig,omitempty"`
+	Worker    string    `json:"worker,omitempty"`
+	CostUSD   float64   `json:"cost_usd"`
+	EndedAt   time.Time `json:"ended_at"`
+	WorkItem  string    `json:"work_item,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Models    []string  `json:"models,omitempty"`
+	Unpriced  []string  `json:"unpriced_models,omitempty"`
+	Account   string    `json:"account,omitempty"` // quota account handle the session ran on
+}
+
+// LogPath returns the path to the session cost log (~/.gt/costs.jsonl).
+func LogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "/tmp/gt-costs.jsonl" // Fallback
+	}
+	return filepath.Join(home, ".gt", "costs.jsonl")
+}
+
+// ReadLog returns the entries in the cost log at path that ended on the
+// same calendar day as day. Malformed lines are skipped. A missing log
+// yields no entries.
+func ReadLog(path string, day time.Time) ([]LogEntry, error) {
+	targetDay := day.Format("2006-01-02")
+	return readLog(path, func(e LogEntry) bool {
+		return e.EndedAt.Format("2006-01-02") == targetDay
+	})
+}
+
+// ReadLogSince returns the entries in the cost log at path that ended at or
+// after since.
+func ReadLogSince(path string, since time.Time) ([]LogEntry, error) {
+	return readLog(path, func(e LogEntry) bool {
+		return !e.EndedAt.Before(since)
+	})
+}
+
+// ReadSpendSince returns the spend recorded in the cost log at path at or
+// after since. gt costs record runs on every turn and logs the session's
+// cumulative cost, so each returned entry's CostUSD is the increment since
+// the session's previous entry rather than the running total. Summing the
+// result therefore counts each dollar once.
+func ReadSpendSince(path string, since time.Time) ([]LogEntry, error) {
+	entries, err := readLog(path, func(LogEntry) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	var out []LogEntry
+	for _, e := range Increments(entries) {
+		if !e.EndedAt.Before(since) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Increments converts cumulative cost entries into per-entry spend. Each
+// entry's CostUSD becomes its cost minus the previous entry of the same
+// session; a drop means the session name was reused by a new session, so
+// the full cost counts. The first entry of a session and entries without a
+// session ID count in full, so entries must include each session's history.
+// Order is preserved; entries adding nothing are dropped.
+func Increments(entries []LogEntry) []LogEntry {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return entries[order[a]].EndedAt.Before(entries[order[b]].EndedAt)
+	})
+
+	delta := make([]float64, len(entries))
+	last := make(map[string]float64)
+	for _, i := range order {
+		e := entries[i]
+		delta[i] = e.CostUSD
+		if e.SessionID == "" {
+			continue
+		}
+		if prev, ok := last[e.SessionID]; ok && e.CostUSD >= prev {
+			delta[i] = e.CostUSD - prev
+		}
+		last[e.SessionID] = e.CostUSD
+	}
+
+	var out []LogEntry
+	for i, e := range entries {
+		if delta[i] <= 0 {
+			continue
+		}
+		e.CostUSD = delta[i]
+		out = append(out, e)
+	}
+	return out
+}
+
+func readLog(path string, keep func(LogEntry) bool) ([]LogEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the gt cost log
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No log file yet
+		}
+		return nil, fmt.Errorf("reading costs log: %w", err)
+	}
+
+	var entries []LogEntry
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var e LogEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue
+		}
+		if !keep(e) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,94 @@
+package costs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.jsonl")
+	day := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	writeFile(t, path, strings.Join([]string{
+		`{"session_id":"a","role":"polecat","cost_usd":1.5,"ended_at":"2026-03-04T09:00:00Z","work_item":"gt-1"}`,
+		`{"session_id":"b","role":"mayor","cost_usd":2,"ended_at":"2026-03-03T23:00:00Z"}`,
+		`garbage`,
+		``,
+		`{"session_id":"c","role":"witness","cost_usd":0.5,"ended_at":"2026-03-04T20:00:00Z"}`,
+	}, "\n"))
+
+	entries, err := ReadLog(path, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].SessionID != "a" || entries[1].SessionID != "c" {
+		t.Errorf("ReadLog = %+v, want sessions a and c", entries)
+	}
+	if entries[0].WorkItem != "gt-1" {
+		t.Errorf("WorkItem = %q", entries[0].WorkItem)
+	}
+
+	if entries, err := ReadLog(filepath.Join(t.TempDir(), "missing.jsonl"), day); err != nil || entries != nil {
+		t.Errorf("ReadLog(missing) = %v, %v", entries, err)
+	}
+}
+
+func TestReadLogSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.jsonl")
+	writeFile(t, path, strings.Join([]string{
+		`{"session_id":"a","cost_usd":1,"ended_at":"2026-03-04T09:00:00Z","account":"work"}`,
+		`{"session_id":"b","cost_usd":2,"ended_at":"2026-03-04T11:00:00Z"}`,
+	}, "\n"))
+
+	entries, err := ReadLogSince(path, time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].SessionID != "b" {
+		t.Errorf("ReadLogSince = %+v, want session b", entries)
+	}
+}
+
+func TestIncrements(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 3, 4, h, 0, 0, 0, time.UTC) }
+	got := Increments([]LogEntry{
+		{SessionID: "a", CostUSD: 1, EndedAt: at(1)},
+		{SessionID: "b", CostUSD: 2, EndedAt: at(2)},
+		{SessionID: "a", CostUSD: 1.5, EndedAt: at(3)},
+		{SessionID: "a", CostUSD: 1.5, EndedAt: at(4)},  // idle turn
+		{SessionID: "a", CostUSD: 0.25, EndedAt: at(5)}, // session name reused
+		{CostUSD: 3, EndedAt: at(6)},
+	})
+
+	want := []float64{1, 2, 0.5, 0.25, 3}
+	if len(got) != len(want) {
+		t.Fatalf("Increments = %+v, want %d entries", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].CostUSD != w {
+			t.Errorf("entry %d CostUSD = %v, want %v", i, got[i].CostUSD, w)
+		}
+	}
+}
+
+func TestReadSpendSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.jsonl")
+	writeFile(t, path, strings.Join([]string{
+		`{"session_id":"a","cost_usd":4,"ended_at":"2026-03-03T23:00:00Z"}`,
+		`{"session_id":"a","cost_usd":5,"ended_at":"2026-03-04T01:00:00Z"}`,
+		`{"session_id":"a","cost_usd":7,"ended_at":"2026-03-04T02:00:00Z"}`,
+	}, "\n"))
+
+	entries, err := ReadSpendSince(path, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var total float64
+	for _, e := range entries {
+		total += e.CostUSD
+	}
+	if total != 3 {
+		t.Errorf("spend since midnight = %v, want 3 (cumulative 7 minus 4 before)", total)
+	}
+}
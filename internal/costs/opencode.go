@@ -0,0 +1,110 @@
+package costs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OpenCodeExtractor reads OpenCode's storage from
+// <data dir>/storage/{session,message}/.
+type OpenCodeExtractor struct {
+	// DataDir overrides the OpenCode data directory. Defaults to
+	// $XDG_DATA_HOME/opencode, then ~/.local/share/opencode.
+	DataDir string
+}
+
+// openCodeSession is storage/session/<project>/<id>.json.
+type openCodeSession struct {
+	ID        string `json:"id"`
+	Directory string `json:"directory"`
+}
+
+// openCodeMessage is storage/message/<session>/<id>.json.
+type openCodeMessage struct {
+	Role    string `json:"role"`
+	ModelID string `json:"modelID"`
+	Tokens  *struct {
+		Input     int64 `json:"input"`
+		Output    int64 `json:"output"`
+		Reasoning int64 `json:"reasoning"`
+		Cache     struct {
+			Read  int64 `json:"read"`
+			Write int64 `json:"write"`
+		} `json:"cache"`
+	} `json:"tokens"`
+}
+
+func (e OpenCodeExtractor) storageDir() (string, error) {
+	dir := e.DataDir
+	if dir == "" {
+		if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "opencode")
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			dir = filepath.Join(home, ".local", "share", "opencode")
+		}
+	}
+	return filepath.Join(dir, "storage"), nil
+}
+
+// Extract finds the newest session for workDir and sums its assistant
+// messages.
+func (e OpenCodeExtractor) Extract(workDir string) (*Usage, error) {
+	storage, err := e.storageDir()
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := filesByModTime(filepath.Join(storage, "session"), ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	want := filepath.Clean(workDir)
+	for _, path := range sessions {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var s openCodeSession
+		if json.Unmarshal(data, &s) != nil || s.ID == "" || filepath.Clean(s.Directory) != want {
+			continue
+		}
+		return parseOpenCodeMessages(filepath.Join(storage, "message", s.ID))
+	}
+	return nil, fmt.Errorf("%w for %s in %s", ErrNoTranscript, workDir, storage)
+}
+
+func parseOpenCodeMessages(dir string) (*Usage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	usage := NewUsage("opencode")
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var msg openCodeMessage
+		if json.Unmarshal(data, &msg) != nil || msg.Role != "assistant" || msg.Tokens == nil {
+			continue
+		}
+		t := msg.Tokens
+		usage.Add(msg.ModelID, Tokens{
+			Input:      t.Input,
+			CacheRead:  t.Cache.Read,
+			CacheWrite: t.Cache.Write,
+			Output:     t.Output + t.Reasoning,
+		})
+	}
+	return usage, nil
+}
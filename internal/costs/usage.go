@@ -0,0 +1,133 @@
+// Package costs extracts token usage from agent session transcripts and
+// prices it. Each agent runtime (Claude Code, Codex, Gemini CLI, OpenCode)
+// stores transcripts in its own location and format; an Extractor per
+// provider hides those differences from gt costs.
+package costs
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+// ErrNoTranscript is returned when no transcript exists for a working directory.
+var ErrNoTranscript = errors.New("no transcript found")
+
+// Tokens counts tokens by billing category. Input excludes cached input.
+type Tokens struct {
+	Input      int64 `json:"input"`
+	CacheRead  int64 `json:"cache_read,omitempty"`
+	CacheWrite int64 `json:"cache_write,omitempty"`
+	Output     int64 `json:"output"`
+}
+
+// Total returns the sum of all token categories.
+func (t Tokens) Total() int64 {
+	return t.Input + t.CacheRead + t.CacheWrite + t.Output
+}
+
+// Usage is the token usage of one agent session, broken down by model.
+// Sessions can switch models mid-way (e.g., OpenCode, /model in Claude Code),
+// so each model is priced separately.
+type Usage struct {
+	Provider string             `json:"provider"`
+	ByModel  map[string]*Tokens `json:"by_model"`
+}
+
+// NewUsage returns an empty Usage for a provider.
+func NewUsage(provider string) *Usage {
+	return &Usage{Provider: provider, ByModel: make(map[string]*Tokens)}
+}
+
+// Add accumulates tokens for a model.
+func (u *Usage) Add(model string, t Tokens) {
+	cur, ok := u.ByModel[model]
+	if !ok {
+		cur = &Tokens{}
+		u.ByModel[model] = cur
+	}
+	cur.Input += t.Input
+	cur.CacheRead += t.CacheRead
+	cur.CacheWrite += t.CacheWrite
+	cur.Output += t.Output
+}
+
+// Models returns the models with non-zero usage, sorted.
+func (u *Usage) Models() []string {
+	var models []string
+	for m, t := range u.ByModel {
+		if t.Total() > 0 {
+			models = append(models, m)
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
+// Cost prices usage against a pricing table. Models missing from the table
+// contribute nothing to the total and are returned in unpriced, so callers
+// can flag them instead of guessing.
+func Cost(u *Usage, table map[string]config.ModelPrice) (usd float64, unpriced []string) {
+	if u == nil {
+		return 0, nil
+	}
+	for _, model := range u.Models() {
+		t := u.ByModel[model]
+		price, ok := config.LookupModelPrice(table, model)
+		if !ok {
+			name := model
+			if name == "" {
+				name = "(unknown)"
+			}
+			unpriced = append(unpriced, name)
+			continue
+		}
+		usd += float64(t.Input) / 1_000_000 * price.Input
+		usd += float64(t.CacheRead) / 1_000_000 * price.CacheRead
+		usd += float64(t.CacheWrite) / 1_000_000 * price.CacheWrite
+		usd += float64(t.Output) / 1_000_000 * price.Output
+	}
+	return usd, unpriced
+}
+
+// Extractor reads token usage for an agent runtime.
+type Extractor interface {
+	// Extract returns usage for the most recent session of this runtime
+	// in workDir. Returns ErrNoTranscript if there is none.
+	Extract(workDir string) (*Usage, error)
+}
+
+// extractors maps agent provider names to their extractor.
+var extractors = map[string]Extractor{
+	"claude":   ClaudeExtractor{},
+	"codex":    CodexExtractor{},
+	"gemini":   GeminiExtractor{},
+	"opencode": OpenCodeExtractor{},
+}
+
+// ForProvider returns the extractor for an agent provider.
+func ForProvider(provider string) (Extractor, bool) {
+	e, ok := extractors[provider]
+	return e, ok
+}
+
+// Providers returns the providers with usage extractors, sorted.
+func Providers() []string {
+	names := make([]string, 0, len(extractors))
+	for name := range extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Extract reads usage for provider's most recent session in workDir.
+func Extract(provider, workDir string) (*Usage, error) {
+	e, ok := ForProvider(provider)
+	if !ok {
+		return nil, fmt.Errorf("no usage extractor for agent %q", provider)
+	}
+	return e.Extract(workDir)
+}
@@ -0,0 +1,166 @@
+package costs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClaudeExtractor(t *testing.T) {
+	configDir := t.TempDir()
+	e := ClaudeExtractor{ConfigDir: configDir}
+	workDir := "/town/gastown/polecats/toast"
+
+	projectDir, err := e.ProjectDir(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(configDir, "projects", "-town-gastown-polecats-toast"); projectDir != want {
+		t.Errorf("ProjectDir = %q, want %q", projectDir, want)
+	}
+
+	writeFile(t, filepath.Join(projectDir, "old.jsonl"),
+		`{"type":"assistant","message":{"model":"claude-opus-4-1","usage":{"input_tokens":999}}}`+"\n")
+	old := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(filepath.Join(projectDir, "old.jsonl"), old, old)
+	writeFile(t, filepath.Join(projectDir, "new.jsonl"), strings.Join([]string{
+		`{"type":"user","message":{"role":"user"}}`,
+		`{"type":"assistant","message":{"model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":100,"cache_creation_input_tokens":10,"cache_read_input_tokens":1000,"output_tokens":50}}}`,
+		`not json`,
+		`{"type":"assistant","message":{"model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":100,"output_tokens":50}}}`,
+	}, "\n"))
+
+	u, err := e.Extract(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := u.ByModel["claude-sonnet-4-5-20250929"]
+	want := Tokens{Input: 200, CacheRead: 1000, CacheWrite: 10, Output: 100}
+	if got == nil || *got != want {
+		t.Errorf("tokens = %+v, want %+v", got, want)
+	}
+	if len(u.ByModel) != 1 {
+		t.Errorf("read models from stale transcript: %v", u.Models())
+	}
+}
+
+func TestCodexExtractor(t *testing.T) {
+	home := t.TempDir()
+	workDir := "/town/gastown/polecats/nux"
+
+	writeFile(t, filepath.Join(home, "sessions", "2026", "01", "02", "rollout-other.jsonl"),
+		`{"type":"session_meta","payload":{"cwd":"/elsewhere"}}`+"\n")
+	writeFile(t, filepath.Join(home, "sessions", "2026", "01", "02", "rollout-mine.jsonl"), strings.Join([]string{
+		`{"type":"session_meta","payload":{"id":"abc","cwd":"/town/gastown/polecats/nux"}}`,
+		`{"type":"turn_context","payload":{"cwd":"/town/gastown/polecats/nux","model":"gpt-5-codex"}}`,
+		`{"type":"event_msg","payload":{"type":"token_count","info":null}}`,
+		`{"type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":500,"cached_input_tokens":200,"output_tokens":40}}}}`,
+		`{"type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":900,"cached_input_tokens":300,"output_tokens":80}}}}`,
+	}, "\n"))
+
+	u, err := CodexExtractor{Home: home}.Extract(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := u.ByModel["gpt-5-codex"]
+	want := Tokens{Input: 600, CacheRead: 300, Output: 80}
+	if got == nil || *got != want {
+		t.Errorf("tokens = %+v, want %+v (cumulative totals, last wins)", got, want)
+	}
+
+	if _, err := (CodexExtractor{Home: home}).Extract("/nowhere"); !errors.Is(err, ErrNoTranscript) {
+		t.Errorf("Extract(unknown dir) err = %v, want ErrNoTranscript", err)
+	}
+}
+
+func TestGeminiExtractor(t *testing.T) {
+	dir := t.TempDir()
+	workDir := "/town/gastown/crew/max"
+	sum := sha256.Sum256([]byte(workDir))
+
+	writeFile(t, filepath.Join(dir, "tmp", hex.EncodeToString(sum[:]), "chats", "session-1.json"), `{
+  "sessionId": "s1",
+  "messages": [
+    {"type": "user", "content": "hi"},
+    {"type": "gemini", "model": "gemini-2.5-pro", "tokens": {"input": 1000, "output": 100, "cached": 400, "thoughts": 20, "tool": 0, "total": 1120}}
+  ]
+}`)
+
+	u, err := GeminiExtractor{Dir: dir}.Extract(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := u.ByModel["gemini-2.5-pro"]
+	want := Tokens{Input: 600, CacheRead: 400, Output: 120}
+	if got == nil || *got != want {
+		t.Errorf("tokens = %+v, want %+v", got, want)
+	}
+}
+
+func TestOpenCodeExtractor(t *testing.T) {
+	dataDir := t.TempDir()
+	storage := filepath.Join(dataDir, "storage")
+	workDir := "/town/gastown/polecats/slit"
+
+	writeFile(t, filepath.Join(storage, "session", "proj1", "ses_a.json"),
+		`{"id":"ses_a","directory":"/town/gastown/polecats/slit"}`)
+	writeFile(t, filepath.Join(storage, "message", "ses_a", "msg_1.json"),
+		`{"role":"user"}`)
+	writeFile(t, filepath.Join(storage, "message", "ses_a", "msg_2.json"),
+		`{"role":"assistant","modelID":"claude-sonnet-4-5","tokens":{"input":10,"output":5,"reasoning":1,"cache":{"read":100,"write":20}}}`)
+	writeFile(t, filepath.Join(storage, "message", "ses_a", "msg_3.json"),
+		`{"role":"assistant","modelID":"gpt-5","tokens":{"input":7,"output":3,"reasoning":0,"cache":{"read":0,"write":0}}}`)
+
+	u, err := OpenCodeExtractor{DataDir: dataDir}.Extract(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Models(); strings.Join(got, ",") != "claude-sonnet-4-5,gpt-5" {
+		t.Errorf("Models() = %v", got)
+	}
+	if got, want := *u.ByModel["claude-sonnet-4-5"], (Tokens{Input: 10, CacheRead: 100, CacheWrite: 20, Output: 6}); got != want {
+		t.Errorf("tokens = %+v, want %+v", got, want)
+	}
+}
+
+func TestCost(t *testing.T) {
+	table := map[string]config.ModelPrice{
+		"claude-sonnet-4": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+	}
+	u := NewUsage("claude")
+	u.Add("claude-sonnet-4-20250514", Tokens{Input: 1_000_000, CacheRead: 1_000_000, CacheWrite: 1_000_000, Output: 1_000_000})
+	u.Add("mystery-model", Tokens{Input: 5})
+	u.Add("<synthetic>", Tokens{}) // zero usage is not flagged
+
+	usd, unpriced := Cost(u, table)
+	if math.Abs(usd-22.05) > 1e-9 {
+		t.Errorf("Cost = %v, want 22.05", usd)
+	}
+	if len(unpriced) != 1 || unpriced[0] != "mystery-model" {
+		t.Errorf("unpriced = %v, want [mystery-model]", unpriced)
+	}
+}
+
+func TestExtractUnknownProvider(t *testing.T) {
+	if _, err := Extract("cursor", t.TempDir()); err == nil {
+		t.Error("Extract(cursor) should fail: no extractor")
+	}
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+// checkAgentFallback moves sessions along their role's agent fallback chain
+// when their provider is rate-limited with no spare account or down, and
+// returns them to the primary once it recovers. The work itself is done by
+// gt quota fallback, which owns the restart-command construction.
+func (d *Daemon) checkAgentFallback() {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot))
+	if err != nil {
+		d.logger.Printf("Agent fallback: failed to load town settings: %v", err)
+		return
+	}
+	if len(settings.AgentFallback) == 0 {
+		return
+	}
+
+	// Respawning kills pane processes with a grace period per session.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "quota", "fallback") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		d.logger.Printf("Agent fallback: gt quota fallback failed: %v: %s", err, strings.TrimSpace(string(out)))
+		return
+	}
+	if s := strings.TrimSpace(string(out)); s != "" && !strings.Contains(s, "No sessions need") {
+		d.logger.Printf("Agent fallback:\n%s", s)
+	}
+}
@@ -0,0 +1,206 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/budget"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+	"github.com/xcawolfe-amzn/gastown/internal/deacon"
+)
+
+// checkBudgets enforces daily spend limits from town settings.
+//
+// Soft limit: escalate and move the affected roles to the configured cheaper
+// cost tier. Hard limit: escalate and pause only the scope that is over:
+// a rig limit parks that rig and a deacon role limit pauses the Deacon's
+// patrols. Other limits rely on gt sling refusing new polecats they cover
+// (see budget.CheckSpawn). Each limit is acted on once per level per day.
+// At the first heartbeat of a new day the downgrades are undone and budget
+// pauses are lifted.
+func (d *Daemon) checkBudgets() {
+	townRoot := d.config.TownRoot
+	settingsPath := config.TownSettingsPath(townRoot)
+	settings, err := config.LoadOrCreateTownSettings(settingsPath)
+	if err != nil {
+		d.logger.Printf("Budget: failed to load town settings: %v", err)
+		return
+	}
+
+	state, err := budget.LoadState(townRoot)
+	if err != nil {
+		d.logger.Printf("Budget: failed to load state, starting fresh: %v", err)
+		state = &budget.State{}
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if state.Date != today {
+		d.resetBudgetDay(settings, settingsPath, state)
+		state = &budget.State{Date: today}
+	}
+
+	if settings.Budget == nil {
+		if err := budget.SaveState(townRoot, state); err != nil {
+			d.logger.Printf("Budget: failed to save state: %v", err)
+		}
+		return
+	}
+
+	convoysOf := func(issueID string) []string { return convoy.TrackingConvoys(townRoot, issueID) }
+	_, statuses, err := budget.Check(townRoot, convoysOf)
+	if err != nil {
+		d.logger.Printf("Budget: failed to evaluate spend: %v", err)
+		return
+	}
+	state.Statuses = statuses
+	state.CheckedAt = time.Now()
+	if state.Escalated == nil {
+		state.Escalated = make(map[string]budget.Level)
+	}
+
+	settingsChanged := false
+	for _, s := range statuses {
+		if s.Level == budget.LevelOK {
+			continue
+		}
+		prev := state.Escalated[s.ID()]
+		if prev == s.Level || prev == budget.LevelHard {
+			continue
+		}
+		state.Escalated[s.ID()] = s.Level
+		d.logger.Printf("Budget: %s", s)
+
+		// Both levels downgrade; a limit can jump straight to hard.
+		changed, err := budget.Downgrade(settings, state, budget.SoftTier(settings.Budget), budget.AffectedRoles(s))
+		if err != nil {
+			d.logger.Printf("Budget: downgrade failed: %v", err)
+		}
+		settingsChanged = settingsChanged || changed
+
+		severity := "high"
+		if s.Level == budget.LevelHard {
+			severity = "critical"
+			d.pauseForBudget(state, s)
+		}
+		d.escalateBudget(s, severity)
+	}
+
+	if settingsChanged {
+		if err := config.SaveTownSettings(settingsPath, settings); err != nil {
+			d.logger.Printf("Budget: failed to save downgraded settings: %v", err)
+		} else {
+			d.logger.Printf("Budget: downgraded roles to %s tier", budget.SoftTier(settings.Budget))
+		}
+	}
+	if err := budget.SaveState(townRoot, state); err != nil {
+		d.logger.Printf("Budget: failed to save state: %v", err)
+	}
+}
+
+// resetBudgetDay undoes the previous day's enforcement actions.
+func (d *Daemon) resetBudgetDay(settings *config.TownSettings, settingsPath string, state *budget.State) {
+	if state.Downgraded {
+		budget.Restore(settings, state)
+		if err := config.SaveTownSettings(settingsPath, settings); err != nil {
+			d.logger.Printf("Budget: failed to restore role agents: %v", err)
+		} else {
+			d.logger.Printf("Budget: new day, restored role agents")
+		}
+	}
+	for _, rigName := range state.ParkedRigs {
+		// A rig unparked by hand since stays as it is.
+		if ok, _ := d.isRigOperational(rigName); ok {
+			continue
+		}
+		if err := d.runGT("rig", "unpark", rigName); err != nil {
+			d.logger.Printf("Budget: failed to unpark rig %s: %v", rigName, err)
+		} else {
+			d.logger.Printf("Budget: new day, unparked rig %s", rigName)
+		}
+	}
+	if state.PausedPatrols {
+		// Only lift a pause we created; a human pause stays.
+		if paused, ps, _ := deacon.IsPaused(d.config.TownRoot); paused && ps != nil && ps.PausedBy == budget.PausedBy {
+			if err := deacon.Resume(d.config.TownRoot); err != nil {
+				d.logger.Printf("Budget: failed to resume patrols: %v", err)
+			} else {
+				d.logger.Printf("Budget: new day, resumed patrols")
+			}
+		}
+	}
+}
+
+// pauseForBudget pauses the patrols covered by a hard limit: a rig limit
+// parks the rig, a deacon role limit pauses the Deacon. Rigs already parked
+// and a Deacon already paused are left alone, so a human pause is never
+// lifted by the daily reset.
+func (d *Daemon) pauseForBudget(state *budget.State, s budget.Status) {
+	switch {
+	case s.Scope == budget.ScopeRig:
+		if ok, _ := d.isRigOperational(s.Key); !ok {
+			return
+		}
+		if err := d.runGT("rig", "park", s.Key); err != nil {
+			d.logger.Printf("Budget: failed to park rig %s: %v", s.Key, err)
+			return
+		}
+		state.ParkedRigs = append(state.ParkedRigs, s.Key)
+		d.logger.Printf("Budget: parked rig %s", s.Key)
+	case s.Scope == budget.ScopeRole && s.Key == "deacon":
+		d.pauseDeaconForBudget(state, s)
+	}
+}
+
+func (d *Daemon) pauseDeaconForBudget(state *budget.State, s budget.Status) {
+	if paused, _, _ := deacon.IsPaused(d.config.TownRoot); paused {
+		return
+	}
+	if err := deacon.Pause(d.config.TownRoot, "hard budget limit: "+s.String(), budget.PausedBy); err != nil {
+		d.logger.Printf("Budget: failed to pause patrols: %v", err)
+		return
+	}
+	state.PausedPatrols = true
+	d.logger.Printf("Budget: paused Deacon patrols")
+}
+
+// runGT runs a gt subcommand in the town root.
+func (d *Daemon) runGT(args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, args...) //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// escalateBudget files an escalation for a crossed limit.
+func (d *Daemon) escalateBudget(s budget.Status, severity string) {
+	action := "Affected roles moved to a cheaper cost tier."
+	if s.Level == budget.LevelHard {
+		switch {
+		case s.Scope == budget.ScopeRig:
+			action = "New polecats in the rig are blocked and the rig is parked until tomorrow."
+		case s.Scope == budget.ScopeRole && s.Key == "deacon":
+			action = "Deacon patrols are paused until tomorrow."
+		default:
+			action = "New polecats covered by the limit are blocked until tomorrow."
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "escalate", //nolint:gosec // G204: args are constructed internally
+		"Budget "+string(s.Level)+" limit reached: "+string(s.Scope)+" "+s.Key,
+		"--severity", severity,
+		"--source", "daemon:budget",
+		"--reason", s.String()+". "+action+" See gt costs budget.")
+	cmd.Dir = d.config.TownRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("Budget: escalation failed: %v: %s", err, string(out))
+	}
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// checkConvoyDeadlines escalates convoys that have blown their deadline
+// (gt convoy create --due/--sla). gt convoy overdue --escalate labels each
+// escalated convoy, so a breach is escalated once; it also refreshes the
+// deadline snapshot behind the refinery's and the convoy feed's at-risk
+// boosts. Only new escalations are logged.
+func (d *Daemon) checkConvoyDeadlines() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "convoy", "overdue", "--escalate", "--json") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.Output()
+	if err != nil {
+		d.logger.Printf("Convoy deadlines: check failed: %v: %s", err, strings.TrimSpace(string(out)))
+		return
+	}
+
+	// Warnings are printed ahead of the JSON array; skip them.
+	if i := bytes.Index(out, []byte("\n[")); i >= 0 && !bytes.HasPrefix(out, []byte("[")) {
+		out = out[i+1:]
+	}
+	var convoys []struct {
+		ID           string `json:"id"`
+		Title        string `json:"title"`
+		EscalatedNow bool   `json:"escalated_now"`
+	}
+	if err := json.Unmarshal(out, &convoys); err != nil {
+		d.logger.Printf("Convoy deadlines: parsing output: %v", err)
+		return
+	}
+	for _, c := range convoys {
+		if c.EscalatedNow {
+			d.logger.Printf("Convoy deadlines: %s (%s) is overdue, SLA breach escalated", c.ID, c.Title)
+		}
+	}
+}
@@ -11,11 +11,13 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 )
 
 // ConvoyWatcher monitors bd activity for issue closes and triggers convoy completion checks.
-// When an issue closes, it checks if the issue is tracked by any convoy and runs the
-// completion check if all tracked issues are now closed.
+// When an issue closes, it checks if the issue is tracked by any convoy, runs the
+// completion check, and feeds ready issues to convoys that remain open.
 type ConvoyWatcher struct {
 	townRoot string
 	ctx      context.Context
@@ -192,28 +194,13 @@ func (w *ConvoyWatcher) getTrackingConvoys(issueID string) []string {
 }
 
 // checkConvoyCompletion checks if all issues tracked by a convoy are closed.
-// If so, runs gt convoy check to close the convoy.
+// If so, runs gt convoy check to close the convoy. If the convoy stays open,
+// feeds ready issues up to its MaxParallel so independent work proceeds in
+// parallel.
 func (w *ConvoyWatcher) checkConvoyCompletion(convoyID string) {
 	// First check if the convoy is still open
-	showCmd := exec.Command(w.bdPath, "show", convoyID, "--json")
-	showCmd.Dir = w.townRoot
-	showCmd.Env = os.Environ()
-	var stdout bytes.Buffer
-	showCmd.Stdout = &stdout
-
-	if err := showCmd.Run(); err != nil {
-		return
-	}
-
-	var convoyStatus []struct {
-		Status string `json:"status"`
-	}
-	if err := json.Unmarshal(stdout.Bytes(), &convoyStatus); err != nil || len(convoyStatus) == 0 {
-		return
-	}
-
-	if convoyStatus[0].Status == "closed" {
-		return // Already closed
+	if status := w.convoyStatus(convoyID); status == "" || status == "closed" {
+		return // Unknown or already closed
 	}
 
 	// Run gt convoy check with specific convoy ID for targeted check
@@ -235,4 +222,29 @@ func (w *ConvoyWatcher) checkConvoyCompletion(convoyID string) {
 	if output := checkStdout.String(); output != "" && !strings.Contains(output, "No convoys ready") {
 		w.logger("convoy watcher: %s", strings.TrimSpace(output))
 	}
+
+	if w.convoyStatus(convoyID) == "open" {
+		convoy.FeedReadyIssues(w.townRoot, convoyID, "convoy watcher", w.logger)
+	}
+}
+
+// convoyStatus returns the convoy's status, or "" if it cannot be read.
+func (w *ConvoyWatcher) convoyStatus(convoyID string) string {
+	showCmd := exec.Command(w.bdPath, "show", convoyID, "--json")
+	showCmd.Dir = w.townRoot
+	showCmd.Env = os.Environ()
+	var stdout bytes.Buffer
+	showCmd.Stdout = &stdout
+
+	if err := showCmd.Run(); err != nil {
+		return ""
+	}
+
+	var convoyStatus []struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &convoyStatus); err != nil || len(convoyStatus) == 0 {
+		return ""
+	}
+	return convoyStatus[0].Status
 }
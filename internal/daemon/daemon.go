@@ -25,6 +25,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/events"
 	"github.com/xcawolfe-amzn/gastown/internal/feed"
 	gitpkg "github.com/xcawolfe-amzn/gastown/internal/git"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
 	"github.com/xcawolfe-amzn/gastown/internal/mayor"
 	"github.com/xcawolfe-amzn/gastown/internal/polecat"
 	"github.com/xcawolfe-amzn/gastown/internal/refinery"
@@ -44,6 +45,7 @@ type Daemon struct {
 	config        *Config
 	patrolConfig  *DaemonPatrolConfig
 	tmux          *tmux.Tmux
+	sessions      session.SessionBackend
 	logger        *log.Logger
 	ctx           context.Context
 	cancel        context.CancelFunc
@@ -73,6 +75,10 @@ type Daemon struct {
 
 	// Restart tracking with exponential backoff to prevent crash loops
 	restartTracker *RestartTracker
+
+	// lastMailGC throttles attachment collection to mailGCInterval.
+	// Only accessed from heartbeat loop goroutine - no sync needed.
+	lastMailGC time.Time
 }
 
 // sessionDeath records a detected session death for mass death analysis.
@@ -140,10 +146,14 @@ func New(config *Config) (*Daemon, error) {
 		logger.Printf("Warning: failed to load restart state: %v", err)
 	}
 
+	t := tmux.NewTmux()
+	sessions := resolveSessionBackend(config.TownRoot, t, logger)
+
 	return &Daemon{
 		config:         config,
 		patrolConfig:   patrolConfig,
-		tmux:           tmux.NewTmux(),
+		tmux:           t,
+		sessions:       sessions,
 		logger:         logger,
 		ctx:            ctx,
 		cancel:         cancel,
@@ -154,6 +164,68 @@ func New(config *Config) (*Daemon, error) {
 	}, nil
 }
 
+// resolveSessionBackend returns the session backend configured in town
+// settings. tmux remains the default; headless sessions run in detached
+// host processes, so like tmux sessions they survive a daemon restart.
+func resolveSessionBackend(townRoot string, t *tmux.Tmux, logger *log.Logger) session.SessionBackend {
+	ts, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || ts.SessionBackend == "" || ts.SessionBackend == session.BackendTmux {
+		return t
+	}
+	b, err := session.NewBackend(ts.SessionBackend, townRoot)
+	if err != nil {
+		logger.Printf("Warning: %v; falling back to tmux", err)
+		return t
+	}
+	logger.Printf("Session backend: %s", ts.SessionBackend)
+	return b
+}
+
+// backend returns the session backend that hosts agent sessions. Liveness
+// checks, kills and nudges go through it so a headless town is supervised
+// the same way as a tmux one.
+func (d *Daemon) backend() session.SessionBackend {
+	if d.sessions != nil {
+		return d.sessions
+	}
+	return d.tmux
+}
+
+// isHeadless reports whether agent sessions are hosted by the headless
+// backend rather than tmux.
+func (d *Daemon) isHeadless() bool {
+	switch d.sessions.(type) {
+	case *headless.Client, *headless.Backend:
+		return true
+	}
+	return false
+}
+
+// startHeadlessSession runs startCmd as a headless session, replacing any
+// dead session of the same name. The patrol role managers drive tmux
+// directly, so the daemon starts headless patrol agents itself.
+func (d *Daemon) startHeadlessSession(sessionName, workDir, startCmd string) error {
+	if _, err := session.KillExistingSession(d.sessions, sessionName, true); err != nil {
+		return err
+	}
+	if err := d.sessions.NewSessionWithCommand(sessionName, workDir, startCmd); err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	return nil
+}
+
+// ensureHeadlessAgent starts a patrol agent on the headless backend if its
+// session is not running. Returns true if a session was started.
+func (d *Daemon) ensureHeadlessAgent(identity, sessionName string) (bool, error) {
+	if d.sessions.IsAgentAlive(sessionName) {
+		return false, nil
+	}
+	if err := d.restartSession(sessionName, identity); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Run starts the daemon main loop.
 func (d *Daemon) Run() error {
 	d.logger.Printf("Daemon starting (PID %d)", os.Getpid())
@@ -425,6 +497,33 @@ func (d *Daemon) heartbeat(state *State) {
 	// branches persist indefinitely. This cleans them up periodically.
 	d.pruneStaleBranches()
 
+	// 14. Enforce daily spend limits (soft: downgrade + escalate,
+	// hard: pause the over-limit scope; sling blocks new polecats itself).
+	d.checkBudgets()
+
+	// 15. Return reset accounts to the pool and rotate sessions off accounts
+	// forecast to hit their limit soon.
+	d.checkQuota()
+
+	// 16. Fall back to the next agent in the role's chain for sessions whose
+	// provider is down or out of accounts; switch back once it recovers.
+	d.checkAgentFallback()
+
+	// 17. Escalate convoys that missed their deadline (once per convoy).
+	d.checkConvoyDeadlines()
+
+	// 18. Deliver scheduled mail that has come due.
+	d.releaseScheduledMail()
+
+	// 19. Send the overseer's daily/weekly mail digest when due.
+	d.sendMailDigests()
+
+	// 20. Release queue claims whose lease expired.
+	d.expireQueueClaims()
+
+	// 21. Remove mail attachments no message references any more.
+	d.collectMailGarbage()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -518,7 +617,7 @@ func (d *Daemon) ensureBootRunning() {
 
 	// Check for degraded mode
 	degraded := os.Getenv("GT_DEGRADED") == "true"
-	if degraded || !d.tmux.IsAvailable() {
+	if degraded || d.isHeadless() || !d.tmux.IsAvailable() {
 		// In degraded mode, run mechanical triage directly
 		d.logger.Println("Degraded mode: running mechanical Boot triage")
 		d.runDegradedBootTriage(b)
@@ -547,7 +646,7 @@ func (d *Daemon) runDegradedBootTriage(b *boot.Boot) {
 	}
 
 	// Simple check: is Deacon session alive?
-	hasDeacon, err := d.tmux.HasSession(d.getDeaconSessionName())
+	hasDeacon, err := d.backend().HasSession(d.getDeaconSessionName())
 	if err != nil {
 		d.logger.Printf("Error checking Deacon session: %v", err)
 		status.LastAction = "error"
@@ -587,9 +686,19 @@ func (d *Daemon) ensureDeaconRunning() {
 		}
 	}
 
-	mgr := deacon.NewManager(d.config.TownRoot)
-
-	if err := mgr.Start(""); err != nil {
+	if d.isHeadless() {
+		started, err := d.ensureHeadlessAgent("deacon", d.getDeaconSessionName())
+		if err != nil {
+			d.logger.Printf("Error starting Deacon: %v", err)
+			return
+		}
+		if !started {
+			if d.restartTracker != nil {
+				d.restartTracker.RecordSuccess(agentID)
+			}
+			return
+		}
+	} else if err := deacon.NewManager(d.config.TownRoot).Start(""); err != nil {
 		if err == deacon.ErrAlreadyRunning {
 			// Deacon is running - record success to reset backoff
 			if d.restartTracker != nil {
@@ -688,7 +797,7 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	d.logger.Printf("Deacon heartbeat is stale (%s old), checking session...", age.Round(time.Minute))
 
 	// Check if session exists
-	hasSession, err := d.tmux.HasSession(sessionName)
+	hasSession, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		d.logger.Printf("Error checking Deacon session: %v", err)
 		return
@@ -708,7 +817,7 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	} else {
 		// Stuck but not critically - nudge to wake up
 		d.logger.Printf("Deacon stuck for %s - nudging session", age.Round(time.Minute))
-		if err := d.tmux.NudgeSession(sessionName, "HEALTH_CHECK: heartbeat stale, respond to confirm responsiveness"); err != nil {
+		if err := d.backend().NudgeSession(sessionName, "HEALTH_CHECK: heartbeat stale, respond to confirm responsiveness"); err != nil {
 			d.logger.Printf("Error nudging stuck Deacon: %v", err)
 		}
 	}
@@ -718,10 +827,10 @@ func (d *Daemon) checkDeaconHeartbeat() {
 // Extracted for reuse by PATCH-005 grace period logic.
 func (d *Daemon) restartStuckDeacon(sessionName string) {
 	// Check if session exists before trying to kill
-	hasSession, _ := d.tmux.HasSession(sessionName)
+	hasSession, _ := d.backend().HasSession(sessionName)
 	if hasSession {
 		d.logger.Printf("Killing stuck Deacon session %s", sessionName)
-		if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+		if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 			d.logger.Printf("Error killing stuck Deacon: %v", err)
 		}
 	}
@@ -748,6 +857,15 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		return
 	}
 
+	if d.isHeadless() {
+		if started, err := d.ensureHeadlessAgent(rigName+"-witness", session.WitnessSessionName(session.PrefixFor(rigName))); err != nil {
+			d.logger.Printf("Error starting witness for %s: %v", rigName, err)
+		} else if started {
+			d.logger.Printf("Witness session for %s started successfully", rigName)
+		}
+		return
+	}
+
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// startup readiness waits, and crucially - startup/propulsion nudges (GUPP).
 	// It returns ErrAlreadyRunning if Claude is already running in tmux.
@@ -789,6 +907,15 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		return
 	}
 
+	if d.isHeadless() {
+		if started, err := d.ensureHeadlessAgent(rigName+"-refinery", session.RefinerySessionName(session.PrefixFor(rigName))); err != nil {
+			d.logger.Printf("Error starting refinery for %s: %v", rigName, err)
+		} else if started {
+			d.logger.Printf("Refinery session for %s started successfully", rigName)
+		}
+		return
+	}
+
 	// Manager.Start() handles: zombie detection, session creation, env vars, theming,
 	// WaitForClaudeReady, and crucially - startup/propulsion nudges (GUPP).
 	// It returns ErrAlreadyRunning if Claude is already running in tmux.
@@ -814,6 +941,15 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 // ensureMayorRunning ensures the Mayor is running.
 // Uses mayor.Manager for consistent startup behavior (zombie detection, GUPP, etc.).
 func (d *Daemon) ensureMayorRunning() {
+	if d.isHeadless() {
+		if started, err := d.ensureHeadlessAgent("mayor", session.MayorSessionName()); err != nil {
+			d.logger.Printf("Error starting Mayor: %v", err)
+		} else if started {
+			d.logger.Println("Mayor started successfully")
+		}
+		return
+	}
+
 	mgr := mayor.NewManager(d.config.TownRoot)
 
 	if err := mgr.Start(""); err != nil {
@@ -833,10 +969,10 @@ func (d *Daemon) ensureMayorRunning() {
 // running their own patrol loops and spawning agents. (hq-2mstj)
 func (d *Daemon) killDeaconSessions() {
 	for _, name := range []string{session.DeaconSessionName(), session.BootSessionName()} {
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -848,10 +984,10 @@ func (d *Daemon) killDeaconSessions() {
 func (d *Daemon) killWitnessSessions() {
 	for _, rigName := range d.getKnownRigs() {
 		name := session.WitnessSessionName(session.PrefixFor(rigName))
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -863,10 +999,10 @@ func (d *Daemon) killWitnessSessions() {
 func (d *Daemon) killRefinerySessions() {
 	for _, rigName := range d.getKnownRigs() {
 		name := session.RefinerySessionName(session.PrefixFor(rigName))
-		exists, _ := d.tmux.HasSession(name)
+		exists, _ := d.backend().HasSession(name)
 		if exists {
 			d.logger.Printf("Killing leftover %s session (patrol disabled)", name)
-			if err := d.tmux.KillSessionWithProcesses(name); err != nil {
+			if err := d.backend().KillSessionWithProcesses(name); err != nil {
 				d.logger.Printf("Error killing %s session: %v", name, err)
 			}
 		}
@@ -1352,7 +1488,7 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 	// Check if tmux session exists
-	sessionAlive, err := d.tmux.HasSession(sessionName)
+	sessionAlive, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		d.logger.Printf("Error checking session %s: %v", sessionName, err)
 		return
@@ -1384,7 +1520,7 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	// TOCTOU guard: re-verify session is still dead before restarting.
 	// Between the initial check and now, the session may have been restarted
 	// by another heartbeat cycle, witness, or the polecat itself.
-	sessionRevived, err := d.tmux.HasSession(sessionName)
+	sessionRevived, err := d.backend().HasSession(sessionName)
 	if err == nil && sessionRevived {
 		return // Session came back - no restart needed
 	}
@@ -1483,12 +1619,6 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	// Pre-sync workspace (ensure beads are current)
 	d.syncWorkspace(workDir)
 
-	// Create new tmux session
-	// Use EnsureSessionFresh to handle zombie sessions that exist but have dead Claude
-	if err := d.tmux.EnsureSessionFresh(sessionName, workDir); err != nil {
-		return fmt.Errorf("creating session: %w", err)
-	}
-
 	// Set environment variables using centralized AgentEnv
 	envVars := config.AgentEnv(config.AgentEnvConfig{
 		Role:      "polecat",
@@ -1497,6 +1627,19 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 		TownRoot:  d.config.TownRoot,
 	})
 
+	// Launch Claude with environment exported inline
+	// Pass rigPath so rig agent settings are honored (not town-level defaults)
+	startCmd := config.BuildStartupCommand(envVars, rigPath, "")
+	if d.isHeadless() {
+		return d.startHeadlessSession(sessionName, workDir, startCmd)
+	}
+
+	// Create new tmux session
+	// Use EnsureSessionFresh to handle zombie sessions that exist but have dead Claude
+	if err := d.tmux.EnsureSessionFresh(sessionName, workDir); err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+
 	// Set all env vars in tmux session (for debugging) and they'll also be exported to Claude
 	for k, v := range envVars {
 		_ = d.tmux.SetEnvironment(sessionName, k, v)
@@ -1510,9 +1653,6 @@ func (d *Daemon) restartPolecatSession(rigName, polecatName, sessionName string)
 	agentID := fmt.Sprintf("%s/%s", rigName, polecatName)
 	_ = d.tmux.SetPaneDiedHook(sessionName, agentID)
 
-	// Launch Claude with environment exported inline
-	// Pass rigPath so rig agent settings are honored (not town-level defaults)
-	startCmd := config.BuildStartupCommand(envVars, rigPath, "")
 	if err := d.tmux.SendKeys(sessionName, startCmd); err != nil {
 		return fmt.Errorf("sending startup command: %w", err)
 	}
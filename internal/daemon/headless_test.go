@@ -0,0 +1,43 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
+)
+
+func TestDaemonBackendSelection(t *testing.T) {
+	d := &Daemon{tmux: tmux.NewTmux()}
+	if d.isHeadless() {
+		t.Error("daemon without a session backend should not be headless")
+	}
+	if _, ok := d.backend().(*tmux.Tmux); !ok {
+		t.Errorf("backend() = %T, want *tmux.Tmux", d.backend())
+	}
+
+	hb := headless.New("")
+	defer hb.Close()
+	d.sessions = hb
+	if !d.isHeadless() || d.backend() != hb {
+		t.Error("headless session backend should be used for supervision")
+	}
+}
+
+func TestEnsureHeadlessAgentSkipsLiveSession(t *testing.T) {
+	hb := headless.New("")
+	defer hb.Close()
+	if err := hb.NewSessionWithCommand("hq-deacon", t.TempDir(), "sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+
+	d := &Daemon{sessions: hb, logger: log.New(io.Discard, "", 0)}
+	started, err := d.ensureHeadlessAgent("deacon", "hq-deacon")
+	if err != nil || started {
+		t.Errorf("ensureHeadlessAgent(live) = %v, %v; want false, nil", started, err)
+	}
+}
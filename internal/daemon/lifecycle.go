@@ -13,6 +13,8 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/protocol"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
@@ -99,51 +101,33 @@ func (d *Daemon) ProcessLifecycleRequests() {
 	}
 }
 
-// LifecycleBody is the structured body format for lifecycle requests.
-// Claude should send mail with JSON body: {"action": "cycle"} or {"action": "shutdown"}
-type LifecycleBody struct {
-	Action string `json:"action"`
-}
-
 // parseLifecycleRequest extracts a lifecycle request from a message.
-// Uses structured body parsing instead of keyword matching on subject.
+// The action is decoded by the protocol registry: a structured block,
+// a JSON body ({"action": "cycle"}), a plain action word, or the subject
+// form "LIFECYCLE:Shutdown <name>".
 func (d *Daemon) parseLifecycleRequest(msg *BeadsMessage) *LifecycleRequest {
-	// Gate: subject must start with "LIFECYCLE:"
-	subject := strings.ToLower(msg.Subject)
-	if !strings.HasPrefix(subject, "lifecycle:") {
+	// Gate: subject must start with "LIFECYCLE:" (any case)
+	subject := msg.Subject
+	if !strings.HasPrefix(strings.ToLower(subject), "lifecycle:") {
 		return nil
 	}
+	subject = string(protocol.TypeLifecycle) + subject[len("lifecycle"):]
 
-	// Parse structured body for action
-	var body LifecycleBody
-	if err := json.Unmarshal([]byte(msg.Body), &body); err != nil {
-		// Fallback: check for simple action strings in body
-		bodyLower := strings.ToLower(strings.TrimSpace(msg.Body))
-		switch {
-		case bodyLower == "restart" || bodyLower == "action: restart":
-			body.Action = "restart"
-		case bodyLower == "shutdown" || bodyLower == "action: shutdown" || bodyLower == "stop":
-			body.Action = "shutdown"
-		case bodyLower == "cycle" || bodyLower == "action: cycle":
-			body.Action = "cycle"
-		default:
-			d.logger.Printf("Lifecycle request with unparseable body: %q", msg.Body)
-			return nil
-		}
+	_, payload, err := protocol.Decode(&mail.Message{Subject: subject, Body: msg.Body})
+	if err != nil {
+		d.logger.Printf("Lifecycle request with unparseable body: %q: %v", msg.Body, err)
+		return nil
 	}
 
 	// Map action string to enum
 	var action LifecycleAction
-	switch strings.ToLower(body.Action) {
-	case "restart":
+	switch payload.(*protocol.LifecyclePayload).Action {
+	case protocol.LifecycleRestart:
 		action = ActionRestart
-	case "shutdown", "stop":
+	case protocol.LifecycleShutdown:
 		action = ActionShutdown
-	case "cycle":
+	case protocol.LifecycleCycle:
 		action = ActionCycle
-	default:
-		d.logger.Printf("Unknown lifecycle action: %q", body.Action)
-		return nil
 	}
 
 	return &LifecycleRequest{
@@ -172,7 +156,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 	}
 
 	// Check if session exists (tmux detection still needed for lifecycle actions)
-	running, err := d.tmux.HasSession(sessionName)
+	running, err := d.backend().HasSession(sessionName)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -182,7 +166,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 		if running {
 			// Use KillSessionWithProcesses to ensure all descendant processes are killed.
 			// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-			if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+			if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 				return fmt.Errorf("killing session: %w", err)
 			}
 			d.logger.Printf("Killed session %s", sessionName)
@@ -192,7 +176,7 @@ func (d *Daemon) executeLifecycleAction(request *LifecycleRequest) error {
 	case ActionCycle, ActionRestart:
 		if running {
 			// Kill the session first - use KillSessionWithProcesses to prevent orphan processes.
-			if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+			if err := d.backend().KillSessionWithProcesses(sessionName); err != nil {
 				return fmt.Errorf("killing session: %w", err)
 			}
 			d.logger.Printf("Killed session %s for restart", sessionName)
@@ -371,6 +355,12 @@ func (d *Daemon) restartSession(sessionName, identity string) error {
 		d.syncWorkspace(workDir)
 	}
 
+	// Headless sessions get their environment on the command line, since
+	// there is no shell to receive per-session variables before launch.
+	if d.isHeadless() {
+		return d.startHeadlessSession(sessionName, workDir, d.headlessStartCommand(config, parsed))
+	}
+
 	// Create session
 	// Use EnsureSessionFresh to handle zombie sessions that exist but have dead Claude
 	if err := d.tmux.EnsureSessionFresh(sessionName, workDir); err != nil {
@@ -527,24 +517,32 @@ func (d *Daemon) getStartCommand(roleConfig *beads.RoleConfig, parsed *ParsedIde
 // setSessionEnvironment sets environment variables for the tmux session.
 // Uses centralized AgentEnv for consistency, plus custom env vars from role config if available.
 func (d *Daemon) setSessionEnvironment(sessionName string, roleConfig *beads.RoleConfig, parsed *ParsedIdentity) {
-	// Use centralized AgentEnv for base environment variables
+	for k, v := range d.sessionEnvironment(roleConfig, parsed) {
+		_ = d.tmux.SetEnvironment(sessionName, k, v)
+	}
+}
+
+// headlessStartCommand returns the start command with the agent's
+// environment exported inline.
+func (d *Daemon) headlessStartCommand(roleConfig *beads.RoleConfig, parsed *ParsedIdentity) string {
+	return config.PrependEnv(d.getStartCommand(roleConfig, parsed), d.sessionEnvironment(roleConfig, parsed))
+}
+
+// sessionEnvironment returns the centralized AgentEnv for an agent plus any
+// custom env vars from its role config.
+func (d *Daemon) sessionEnvironment(roleConfig *beads.RoleConfig, parsed *ParsedIdentity) map[string]string {
 	envVars := config.AgentEnv(config.AgentEnvConfig{
 		Role:      parsed.RoleType,
 		Rig:       parsed.RigName,
 		AgentName: parsed.AgentName,
 		TownRoot:  d.config.TownRoot,
 	})
-	for k, v := range envVars {
-		_ = d.tmux.SetEnvironment(sessionName, k, v)
-	}
-
-	// Set any custom env vars from role config
 	if roleConfig != nil {
 		for k, v := range roleConfig.EnvVars {
-			expanded := beads.ExpandRolePattern(v, d.config.TownRoot, parsed.RigName, parsed.AgentName, parsed.RoleType)
-			_ = d.tmux.SetEnvironment(sessionName, k, expanded)
+			envVars[k] = beads.ExpandRolePattern(v, d.config.TownRoot, parsed.RigName, parsed.AgentName, parsed.RoleType)
 		}
 	}
+	return envVars
 }
 
 // applySessionTheme applies tmux theming to the session.
@@ -958,7 +956,7 @@ func (d *Daemon) checkRigGUPPViolations(rigName string) {
 		sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 		// Check if tmux session exists and agent is running
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			// Session is alive - check if it's been stuck too long
 			updatedAt, err := time.Parse(time.RFC3339, agent.UpdatedAt)
 			if err != nil {
@@ -1052,14 +1050,14 @@ func (d *Daemon) checkRigOrphanedWork(rigName string) {
 		sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
 		// Session running = not orphaned (work is being processed)
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			continue
 		}
 
 		// TOCTOU guard: re-verify agent state before taking action.
 		// Between the bd list above and now, the agent may have been
 		// restarted or its hook_bead cleared. Re-check both conditions.
-		if d.tmux.IsAgentAlive(sessionName) {
+		if d.backend().IsAgentAlive(sessionName) {
 			continue
 		}
 		currentHookBead := d.getAgentHookBead(agent.ID)
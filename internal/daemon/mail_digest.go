@@ -0,0 +1,30 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// sendMailDigests produces the overseer's daily and weekly mail digests
+// once they are due. gt mail digest --if-due checks the last digest bead,
+// so this is a no-op on most heartbeats.
+func (d *Daemon) sendMailDigests() {
+	for _, period := range [][]string{nil, {"--weekly"}} {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		args := append([]string{"mail", "digest", "--if-due", "--send", "mail"}, period...)
+		cmd := exec.CommandContext(ctx, d.gtPath, args...) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		out, err := cmd.CombinedOutput()
+		cancel()
+		s := strings.TrimSpace(string(out))
+		if err != nil {
+			d.logger.Printf("Mail digest: %v: %s", err, s)
+			continue
+		}
+		if s != "" {
+			d.logger.Printf("Mail digest:\n%s", s)
+		}
+	}
+}
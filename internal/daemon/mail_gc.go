@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// mailGCInterval is how often the daemon collects unreferenced mail
+// attachments. Collection lists every message, so it is not run on every
+// heartbeat.
+const mailGCInterval = 6 * time.Hour
+
+// collectMailGarbage removes attachment blobs whose last reference was
+// deleted or purged from the archive.
+func (d *Daemon) collectMailGarbage() {
+	if time.Since(d.lastMailGC) < mailGCInterval {
+		return
+	}
+	d.lastMailGC = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "mail", "gc") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.CombinedOutput()
+	s := strings.TrimSpace(string(out))
+	if err != nil {
+		d.logger.Printf("Mail GC: %v: %s", err, s)
+		return
+	}
+	if s != "" {
+		d.logger.Printf("Mail GC: %s", s)
+	}
+}
@@ -0,0 +1,28 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// expireQueueClaims releases queue claims whose lease ran out, so work
+// claimed by an agent that died returns to its queue (or the dead-letter
+// mailbox once it has failed too often).
+func (d *Daemon) expireQueueClaims() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, d.gtPath, "mail", "queue", "expire") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.CombinedOutput()
+	s := strings.TrimSpace(string(out))
+	if err != nil {
+		d.logger.Printf("Queue claim expiry: %v: %s", err, s)
+		return
+	}
+	if s != "" {
+		d.logger.Printf("Queue claim expiry:\n%s", s)
+	}
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+	"github.com/xcawolfe-amzn/gastown/internal/quota"
+)
+
+// checkQuota keeps the account pool current: accounts whose reset time has
+// passed go back to available, capacities are learned from the cost log,
+// and sessions on accounts forecast to run out soon are rotated before they
+// stall mid-task (gt quota rotate --preemptive).
+func (d *Daemon) checkQuota() {
+	townRoot := d.config.TownRoot
+	acctCfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil || len(acctCfg.Accounts) == 0 {
+		return // No account pool
+	}
+
+	now := time.Now()
+	entries, err := costs.ReadSpendSince(costs.LogPath(), now.Add(-3*quota.DefaultLimitWindow))
+	if err != nil {
+		d.logger.Printf("Quota: failed to read cost log: %v", err)
+	}
+	released, err := quota.NewManager(townRoot).Refresh(entries, now)
+	if err != nil {
+		d.logger.Printf("Quota: failed to refresh state: %v", err)
+		return
+	}
+	for _, handle := range released {
+		d.logger.Printf("Quota: account %s reset, marked available", handle)
+	}
+
+	if len(acctCfg.Accounts) < 2 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "quota", "rotate", "--preemptive") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = townRoot
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		d.logger.Printf("Quota: preemptive rotation failed: %v: %s", err, strings.TrimSpace(string(out)))
+		return
+	}
+	if s := strings.TrimSpace(string(out)); strings.Contains(s, "→") {
+		d.logger.Printf("Quota: preemptive rotation:\n%s", s)
+	}
+}
@@ -0,0 +1,26 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// releaseScheduledMail sends scheduled mail (gt mail send --at/--in/--cron)
+// that has come due. Delivery granularity is the heartbeat interval.
+func (d *Daemon) releaseScheduledMail() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, "mail", "scheduled", "--release") //nolint:gosec // G204: args are constructed internally
+	cmd.Dir = d.config.TownRoot
+	out, err := cmd.CombinedOutput()
+	s := strings.TrimSpace(string(out))
+	if err != nil {
+		d.logger.Printf("Scheduled mail: release failed: %v: %s", err, s)
+		return
+	}
+	if s != "" {
+		d.logger.Printf("Scheduled mail:\n%s", s)
+	}
+}
@@ -0,0 +1,297 @@
+package deps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// ErrAgentIncompatible is returned when an agent CLI's installed version is
+// outside its pin or no longer accepts the flags its preset relies on.
+var ErrAgentIncompatible = errors.New("agent CLI incompatible with its preset")
+
+// probeTimeout bounds each --version/--help invocation.
+const probeTimeout = 10 * time.Second
+
+// AgentProbe is the recorded result of probing one agent CLI.
+type AgentProbe struct {
+	Agent      string    `json:"agent"`
+	Command    string    `json:"command"`
+	Version    string    `json:"version,omitempty"`
+	ProbedAt   time.Time `json:"probed_at"`
+	MinVersion string    `json:"min_version,omitempty"` // inclusive pin
+	MaxVersion string    `json:"max_version,omitempty"` // inclusive pin
+	Flags      []string  `json:"flags,omitempty"`       // flags the preset relies on that --help lists
+	Missing    []string  `json:"missing,omitempty"`     // flags the preset relies on that --help lacks
+	Problems   []string  `json:"problems,omitempty"`
+}
+
+// Compatible reports whether the probe found no problems.
+func (p *AgentProbe) Compatible() bool {
+	return len(p.Problems) == 0
+}
+
+// AgentProbeRegistry is the set of recorded probes (settings/agent-probes.json).
+type AgentProbeRegistry struct {
+	Version int                    `json:"version"`
+	Agents  map[string]*AgentProbe `json:"agents"`
+}
+
+// CurrentAgentProbeVersion is the schema version of AgentProbeRegistry.
+const CurrentAgentProbeVersion = 1
+
+// AgentProbesPath returns the path of the probe registry, next to the agent
+// registry in settings/.
+func AgentProbesPath(townRoot string) string {
+	return filepath.Join(townRoot, "settings", "agent-probes.json")
+}
+
+// LoadAgentProbes reads the probe registry. A missing file yields an empty one.
+func LoadAgentProbes(townRoot string) (*AgentProbeRegistry, error) {
+	reg := &AgentProbeRegistry{Version: CurrentAgentProbeVersion, Agents: make(map[string]*AgentProbe)}
+	data, err := os.ReadFile(AgentProbesPath(townRoot)) //nolint:gosec // G304: path is constructed internally
+	if os.IsNotExist(err) {
+		return reg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading agent probes: %w", err)
+	}
+	if err := json.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("parsing agent probes: %w", err)
+	}
+	if reg.Agents == nil {
+		reg.Agents = make(map[string]*AgentProbe)
+	}
+	return reg, nil
+}
+
+// SaveAgentProbes writes the probe registry.
+func SaveAgentProbes(townRoot string, reg *AgentProbeRegistry) error {
+	reg.Version = CurrentAgentProbeVersion
+	return util.EnsureDirAndWriteJSON(AgentProbesPath(townRoot), reg)
+}
+
+// CommandRunner runs an agent CLI and returns its combined output.
+// Injected so probing can be tested without real binaries.
+type CommandRunner func(ctx context.Context, command string, args ...string) (string, error)
+
+// ExecRunner runs commands with os/exec.
+func ExecRunner(ctx context.Context, command string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, command, args...).CombinedOutput() //nolint:gosec // G204: command comes from the agent preset
+	return string(out), err
+}
+
+// ExpectedAgentFlags lists the flags and subcommands a preset relies on:
+// its default args, resume/continue/fork flags and non-interactive options.
+func ExpectedAgentFlags(preset *config.AgentPresetInfo) []string {
+	seen := make(map[string]bool)
+	var flags []string
+	add := func(tokens ...string) {
+		for _, tok := range tokens {
+			tok = strings.TrimSpace(tok)
+			if i := strings.Index(tok, "="); i > 0 {
+				tok = tok[:i]
+			}
+			if tok == "" || seen[tok] {
+				continue
+			}
+			seen[tok] = true
+			flags = append(flags, tok)
+		}
+	}
+	for _, arg := range preset.Args {
+		if strings.HasPrefix(arg, "-") {
+			add(arg)
+		}
+	}
+	add(preset.ResumeFlag, preset.ContinueFlag)
+	if preset.SupportsForkSession {
+		add("--fork-session")
+	}
+	if ni := preset.NonInteractive; ni != nil {
+		add(ni.Subcommand, ni.PromptFlag)
+		if fields := strings.Fields(ni.OutputFlag); len(fields) > 0 {
+			add(fields[0])
+		}
+	}
+	return flags
+}
+
+var versionPattern = regexp.MustCompile(`\b(\d+\.\d+(?:\.\d+)?)\b`)
+
+// parseAgentVersion extracts the first dotted version number from output.
+func parseAgentVersion(output string) string {
+	if m := versionPattern.FindStringSubmatch(output); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// helpMentions reports whether help text lists flag as a whole token.
+func helpMentions(help, flag string) bool {
+	re := regexp.MustCompile(`(^|[\s,\[(|])` + regexp.QuoteMeta(flag) + `($|[\s,=\])|<])`)
+	return re.MatchString(help)
+}
+
+// ProbeAgent runs the preset's CLI with --version and --help, checks the
+// flags the preset relies on, and evaluates the version against minVersion
+// and maxVersion (empty = unbounded).
+func ProbeAgent(ctx context.Context, run CommandRunner, name string, preset *config.AgentPresetInfo, minVersion, maxVersion string) *AgentProbe {
+	p := &AgentProbe{
+		Agent:      name,
+		Command:    preset.Command,
+		ProbedAt:   time.Now().UTC(),
+		MinVersion: minVersion,
+		MaxVersion: maxVersion,
+	}
+
+	vctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	out, err := run(vctx, preset.Command, "--version")
+	cancel()
+	if err != nil && strings.TrimSpace(out) == "" {
+		p.Problems = append(p.Problems, fmt.Sprintf("%s --version failed: %v", preset.Command, err))
+		return p
+	}
+	p.Version = parseAgentVersion(out)
+	p.Problems = append(p.Problems, versionProblems(p.Version, minVersion, maxVersion)...)
+
+	hctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	help, _ := run(hctx, preset.Command, "--help")
+	cancel()
+	if strings.TrimSpace(help) == "" {
+		p.Problems = append(p.Problems, fmt.Sprintf("%s --help produced no output", preset.Command))
+		return p
+	}
+	for _, flag := range ExpectedAgentFlags(preset) {
+		if helpMentions(help, flag) {
+			p.Flags = append(p.Flags, flag)
+		} else {
+			p.Missing = append(p.Missing, flag)
+		}
+	}
+	if len(p.Missing) > 0 {
+		p.Problems = append(p.Problems, "--help no longer lists "+strings.Join(p.Missing, ", "))
+	}
+	return p
+}
+
+// versionProblems checks version against an inclusive pin.
+func versionProblems(version, minVersion, maxVersion string) []string {
+	if minVersion == "" && maxVersion == "" {
+		return nil
+	}
+	if version == "" {
+		return []string{"could not determine version to check against pin"}
+	}
+	var problems []string
+	if minVersion != "" && compareVersions(version, minVersion) < 0 {
+		problems = append(problems, fmt.Sprintf("version %s is below pinned minimum %s", version, minVersion))
+	}
+	if maxVersion != "" && compareVersions(version, maxVersion) > 0 {
+		problems = append(problems, fmt.Sprintf("version %s is above pinned maximum %s", version, maxVersion))
+	}
+	return problems
+}
+
+// AgentPins returns the version pin for an agent: the recorded probe's pin
+// if set, else the preset's.
+func AgentPins(preset *config.AgentPresetInfo, recorded *AgentProbe) (minVersion, maxVersion string) {
+	minVersion, maxVersion = preset.MinVersion, preset.MaxVersion
+	if recorded != nil {
+		if recorded.MinVersion != "" {
+			minVersion = recorded.MinVersion
+		}
+		if recorded.MaxVersion != "" {
+			maxVersion = recorded.MaxVersion
+		}
+	}
+	return minVersion, maxVersion
+}
+
+// CheckAgent verifies an agent CLI against its recorded probe before a
+// session is spawned on it. Unprobed agents pass (probing is opt-in via gt
+// agents probe). When the installed version differs from the recorded one,
+// the agent is re-probed and the record updated. Returns an error wrapping
+// ErrAgentIncompatible when the agent should not be used.
+func CheckAgent(ctx context.Context, run CommandRunner, townRoot, name string) error {
+	return checkAgent(ctx, run, townRoot, name, true)
+}
+
+// VerifyAgent is CheckAgent without updating the recorded probe, for
+// read-only callers such as gt doctor.
+func VerifyAgent(ctx context.Context, run CommandRunner, townRoot, name string) error {
+	return checkAgent(ctx, run, townRoot, name, false)
+}
+
+func checkAgent(ctx context.Context, run CommandRunner, townRoot, name string, persist bool) error {
+	reg, err := LoadAgentProbes(townRoot)
+	if err != nil {
+		return nil // fail open: a corrupt record shouldn't stop work
+	}
+	recorded := reg.Agents[name]
+	if recorded == nil {
+		return nil
+	}
+	preset := config.GetAgentPresetByName(name)
+	if preset == nil {
+		return nil
+	}
+
+	vctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	out, _ := run(vctx, preset.Command, "--version")
+	cancel()
+	probe := recorded
+	if v := parseAgentVersion(out); v != recorded.Version || recorded.Command != preset.Command {
+		minVersion, maxVersion := AgentPins(preset, recorded)
+		probe = ProbeAgent(ctx, run, name, preset, minVersion, maxVersion)
+		if persist {
+			reg.Agents[name] = probe
+			_ = SaveAgentProbes(townRoot, reg)
+		}
+	}
+	if probe.Compatible() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s: %s (run 'gt agents probe %s')",
+		ErrAgentIncompatible, name, probe.Version, strings.Join(probe.Problems, "; "), name)
+}
+
+// ProbedAgents returns the names in the registry, sorted.
+func (r *AgentProbeRegistry) ProbedAgents() []string {
+	names := make([]string, 0, len(r.Agents))
+	for name := range r.Agents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveAgentPresetName maps an agent name or alias (e.g. "claude-sonnet")
+// to the registry preset whose CLI it runs. Returns "" if none applies.
+func ResolveAgentPresetName(townRoot, rigPath, agent string) string {
+	if config.GetAgentPresetByName(agent) != nil {
+		return agent
+	}
+	rc, _, err := config.ResolveAgentConfigWithOverride(townRoot, rigPath, agent)
+	if err != nil || rc == nil {
+		return ""
+	}
+	if rc.Provider != "" && config.GetAgentPresetByName(rc.Provider) != nil {
+		return rc.Provider
+	}
+	if base := filepath.Base(rc.Command); config.GetAgentPresetByName(base) != nil {
+		return base
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package deps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+// fakeAgentCLI answers --version and --help like an agent binary.
+func fakeAgentCLI(version, help string) CommandRunner {
+	return func(_ context.Context, _ string, args ...string) (string, error) {
+		switch args[0] {
+		case "--version":
+			return version, nil
+		case "--help":
+			return help, nil
+		}
+		return "", errors.New("unexpected args")
+	}
+}
+
+const fakeClaudeHelp = `Usage: claude [options] [command] [prompt]
+
+Options:
+  --dangerously-skip-permissions  Bypass all permission checks
+  -c, --continue                  Continue the most recent conversation
+  -r, --resume [sessionId]        Resume a conversation
+  --fork-session                  Create a new session ID when resuming
+`
+
+func TestExpectedAgentFlags(t *testing.T) {
+	got := ExpectedAgentFlags(config.GetAgentPresetByName("claude"))
+	want := []string{"--dangerously-skip-permissions", "--resume", "--continue", "--fork-session"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("claude flags = %v, want %v", got, want)
+	}
+
+	got = ExpectedAgentFlags(config.GetAgentPresetByName("codex"))
+	want = []string{"--dangerously-bypass-approvals-and-sandbox", "resume", "exec", "--json"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("codex flags = %v, want %v", got, want)
+	}
+}
+
+func TestProbeAgent(t *testing.T) {
+	preset := config.GetAgentPresetByName("claude")
+	ctx := context.Background()
+
+	p := ProbeAgent(ctx, fakeAgentCLI("2.1.3 (Claude Code)", fakeClaudeHelp), "claude", preset, "2.0.0", "")
+	if !p.Compatible() {
+		t.Fatalf("expected compatible, problems: %v", p.Problems)
+	}
+	if p.Version != "2.1.3" || len(p.Flags) != 4 {
+		t.Errorf("probe = %+v", p)
+	}
+
+	// A CLI upgrade that dropped --fork-session.
+	help := strings.Replace(fakeClaudeHelp, "--fork-session", "--fork", 1)
+	p = ProbeAgent(ctx, fakeAgentCLI("2.1.3", help), "claude", preset, "", "")
+	if p.Compatible() || len(p.Missing) != 1 || p.Missing[0] != "--fork-session" {
+		t.Errorf("probe = %+v, want --fork-session missing", p)
+	}
+
+	p = ProbeAgent(ctx, fakeAgentCLI("3.0.0", fakeClaudeHelp), "claude", preset, "2.0.0", "2.9")
+	if p.Compatible() || !strings.Contains(p.Problems[0], "above pinned maximum") {
+		t.Errorf("problems = %v, want above maximum", p.Problems)
+	}
+}
+
+func TestCheckAgent(t *testing.T) {
+	townRoot := t.TempDir()
+	ctx := context.Background()
+	run := fakeAgentCLI("2.1.3", fakeClaudeHelp)
+
+	// Unprobed agents are not blocked.
+	if err := CheckAgent(ctx, run, townRoot, "claude"); err != nil {
+		t.Fatalf("unprobed CheckAgent = %v", err)
+	}
+
+	reg, _ := LoadAgentProbes(townRoot)
+	reg.Agents["claude"] = ProbeAgent(ctx, run, "claude", config.GetAgentPresetByName("claude"), "", "2.5")
+	if err := SaveAgentProbes(townRoot, reg); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckAgent(ctx, run, townRoot, "claude"); err != nil {
+		t.Fatalf("CheckAgent = %v", err)
+	}
+
+	// VerifyAgent reports the upgrade without touching the record.
+	if err := VerifyAgent(ctx, fakeAgentCLI("2.6.0", fakeClaudeHelp), townRoot, "claude"); !errors.Is(err, ErrAgentIncompatible) {
+		t.Errorf("VerifyAgent after upgrade = %v, want ErrAgentIncompatible", err)
+	}
+	if reg, _ := LoadAgentProbes(townRoot); reg.Agents["claude"].Version != "2.1.3" {
+		t.Errorf("VerifyAgent updated the record to %s", reg.Agents["claude"].Version)
+	}
+
+	// Upgrading past the pin blocks and updates the record.
+	if err := CheckAgent(ctx, fakeAgentCLI("2.6.0", fakeClaudeHelp), townRoot, "claude"); !errors.Is(err, ErrAgentIncompatible) {
+		t.Errorf("CheckAgent after upgrade = %v, want ErrAgentIncompatible", err)
+	}
+	reg, _ = LoadAgentProbes(townRoot)
+	if got := reg.Agents["claude"]; got.Version != "2.6.0" || got.MaxVersion != "2.5" {
+		t.Errorf("record = %+v, want re-probed 2.6.0 keeping pin", got)
+	}
+}
@@ -0,0 +1,71 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xcawolfe-amzn/gastown/internal/deps"
+)
+
+// AgentVersionCheck re-verifies agent CLIs recorded by 'gt agents probe'
+// against their version pins and required flags. Agents that were never
+// probed are not checked.
+type AgentVersionCheck struct {
+	BaseCheck
+}
+
+// NewAgentVersionCheck creates a new agent CLI compatibility check.
+func NewAgentVersionCheck() *AgentVersionCheck {
+	return &AgentVersionCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "agent-version",
+			CheckDescription: "Check probed agent CLIs match their version pins and flags",
+			CheckCategory:    CategoryInfrastructure,
+		},
+	}
+}
+
+// Run re-checks every probed agent, re-probing any whose version changed.
+// The probe record is left as is; sessions update it when they spawn.
+func (c *AgentVersionCheck) Run(ctx *CheckContext) *CheckResult {
+	reg, err := deps.LoadAgentProbes(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: err.Error(),
+			FixHint: "Run 'gt agents probe' to rewrite the probe record",
+		}
+	}
+	names := reg.ProbedAgents()
+	if len(names) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No agents probed (run 'gt agents probe' to enable version checks)",
+		}
+	}
+
+	var details []string
+	for _, name := range names {
+		if err := deps.VerifyAgent(context.Background(), deps.ExecRunner, ctx.TownRoot, name); errors.Is(err, deps.ErrAgentIncompatible) {
+			details = append(details, strings.TrimPrefix(err.Error(), deps.ErrAgentIncompatible.Error()+": "))
+		}
+	}
+	if len(details) > 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("%d agent CLI(s) incompatible; sessions will not spawn on them", len(details)),
+			Details: details,
+			FixHint: "Install a compatible version, or re-pin with 'gt agents probe <agent> --pin'",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("%d probed agent(s) compatible", len(names)),
+	}
+}
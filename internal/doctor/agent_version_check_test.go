@@ -0,0 +1,27 @@
+package doctor
+
+import (
+	"testing"
+)
+
+func TestAgentVersionCheck_Metadata(t *testing.T) {
+	check := NewAgentVersionCheck()
+
+	if check.Name() != "agent-version" {
+		t.Errorf("Name() = %q, want %q", check.Name(), "agent-version")
+	}
+	if check.Category() != CategoryInfrastructure {
+		t.Errorf("Category() = %q, want %q", check.Category(), CategoryInfrastructure)
+	}
+	if check.CanFix() {
+		t.Error("CanFix() should return false")
+	}
+}
+
+func TestAgentVersionCheck_NoProbes(t *testing.T) {
+	check := NewAgentVersionCheck()
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK with no probes, got %v: %s", result.Status, result.Message)
+	}
+}
@@ -49,7 +49,7 @@ func (c *FormulaCheck) Run(ctx *CheckContext) *CheckResult {
 
 	// Build details
 	var details []string
-	var needsFix bool
+	var needsFix, needsWarn bool
 
 	for _, f := range report.Formulas {
 		switch f.Status {
@@ -57,6 +57,12 @@ func (c *FormulaCheck) Run(ctx *CheckContext) *CheckResult {
 			details = append(details, fmt.Sprintf("  %s: update available", f.Name))
 			needsFix = true
 		case "missing":
+			if f.Registry {
+				// Fix only restores embedded formulas
+				details = append(details, fmt.Sprintf("  %s: missing (reinstall with 'gt formula install %s')", f.Name, strings.TrimSuffix(f.Name, ".formula.toml")))
+				needsWarn = true
+				continue
+			}
 			details = append(details, fmt.Sprintf("  %s: missing (will reinstall)", f.Name))
 			needsFix = true
 		case "modified":
@@ -72,7 +78,7 @@ func (c *FormulaCheck) Run(ctx *CheckContext) *CheckResult {
 
 	// Determine status
 	status := StatusOK
-	if needsFix {
+	if needsFix || needsWarn {
 		status = StatusWarning
 	}
 
@@ -23,16 +23,19 @@ var (
 // SessionManager handles dog session lifecycle.
 type SessionManager struct {
 	tmux     *tmux.Tmux
+	sessions session.SessionBackend // The town's session backend; tmux unless headless
 	mgr      *Manager
 	townRoot string
 }
 
 // NewSessionManager creates a new dog session manager.
 // The Manager parameter is used to sync persistent dog state (idle/working)
-// when sessions start and stop.
+// when sessions start and stop. Sessions are hosted by the backend selected
+// in town settings; t is used for tmux-only queries (attach state, panes).
 func NewSessionManager(t *tmux.Tmux, townRoot string, mgr *Manager) *SessionManager {
 	return &SessionManager{
 		tmux:     t,
+		sessions: session.BackendForTown(townRoot, t),
 		mgr:      mgr,
 		townRoot: townRoot,
 	}
@@ -90,7 +93,7 @@ func (m *SessionManager) Start(dogName string, opts SessionStartOptions) error {
 	sessionID := m.SessionName(dogName)
 
 	// Kill any existing zombie session (tmux alive but agent dead).
-	_, err := session.KillExistingSession(m.tmux, sessionID, true)
+	_, err := session.KillExistingSession(m.sessions, sessionID, true)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrSessionRunning, sessionID)
 	}
@@ -104,7 +107,7 @@ func (m *SessionManager) Start(dogName string, opts SessionStartOptions) error {
 
 	// Use unified session lifecycle.
 	theme := tmux.DogTheme()
-	_, err = session.StartSession(m.tmux, session.SessionConfig{
+	_, err = session.StartSession(m.sessions, session.SessionConfig{
 		SessionID: sessionID,
 		WorkDir:   kennelDir,
 		Role:      "dog",
@@ -144,7 +147,7 @@ func (m *SessionManager) Start(dogName string, opts SessionStartOptions) error {
 func (m *SessionManager) Stop(dogName string, force bool) error {
 	sessionID := m.SessionName(dogName)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.sessions.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
 	}
@@ -154,11 +157,11 @@ func (m *SessionManager) Stop(dogName string, force bool) error {
 
 	// Try graceful shutdown first
 	if !force {
-		_ = m.tmux.SendKeysRaw(sessionID, "C-c")
-		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
+		_ = m.sessions.SendKeysRaw(sessionID, "C-c")
+		session.WaitForSessionExit(m.sessions, sessionID, constants.GracefulShutdownTimeout)
 	}
 
-	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
+	if err := m.sessions.KillSessionWithProcesses(sessionID); err != nil {
 		return fmt.Errorf("killing session: %w", err)
 	}
 
@@ -175,14 +178,14 @@ func (m *SessionManager) Stop(dogName string, force bool) error {
 // IsRunning checks if a dog session is active.
 func (m *SessionManager) IsRunning(dogName string) (bool, error) {
 	sessionID := m.SessionName(dogName)
-	return m.tmux.HasSession(sessionID)
+	return m.sessions.HasSession(sessionID)
 }
 
 // Status returns detailed status for a dog session.
 func (m *SessionManager) Status(dogName string) (*SessionInfo, error) {
 	sessionID := m.SessionName(dogName)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.sessions.HasSession(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("checking session: %w", err)
 	}
@@ -193,7 +196,7 @@ func (m *SessionManager) Status(dogName string) (*SessionInfo, error) {
 		Running:   running,
 	}
 
-	if !running {
+	if _, onTmux := m.sessions.(*tmux.Tmux); !running || !onTmux {
 		return info, nil
 	}
 
@@ -207,11 +210,12 @@ func (m *SessionManager) Status(dogName string) (*SessionInfo, error) {
 	return info, nil
 }
 
-// GetPane returns the pane ID for a dog session.
+// GetPane returns the pane ID for a dog session, or for a headless session
+// its name.
 func (m *SessionManager) GetPane(dogName string) (string, error) {
 	sessionID := m.SessionName(dogName)
 
-	running, err := m.tmux.HasSession(sessionID)
+	running, err := m.sessions.HasSession(sessionID)
 	if err != nil {
 		return "", fmt.Errorf("checking session: %w", err)
 	}
@@ -219,6 +223,11 @@ func (m *SessionManager) GetPane(dogName string) (string, error) {
 		return "", ErrSessionNotFound
 	}
 
+	// Headless sessions have no pane; the session name stands in for it.
+	if _, onTmux := m.sessions.(*tmux.Tmux); !onTmux {
+		return sessionID, nil
+	}
+
 	// Get pane ID from session
 	pane, err := m.tmux.GetPaneID(sessionID)
 	if err != nil {
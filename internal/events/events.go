@@ -68,6 +68,12 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Flood protection events (mail and nudge rate limits, reply loops)
+	TypeRateLimited = "rate_limited"
+
+	// Queue claim leases (released or dead-lettered on expiry)
+	TypeClaimExpired = "claim_expired"
 )
 
 // EventsFile is the name of the raw events log.
@@ -259,6 +265,28 @@ func EscalationPayload(rig, target, to, reason string) map[string]interface{} {
 	}
 }
 
+// RateLimitPayload creates a payload for rate limit events.
+func RateLimitPayload(channel, sender, key, reason string, count int) map[string]interface{} {
+	return map[string]interface{}{
+		"channel": channel,
+		"sender":  sender,
+		"key":     key,
+		"reason":  reason,
+		"count":   count,
+	}
+}
+
+// ClaimExpiredPayload creates a payload for an expired queue claim.
+func ClaimExpiredPayload(queue, messageID, claimant string, attempts int, deadLettered bool) map[string]interface{} {
+	return map[string]interface{}{
+		"queue":         queue,
+		"message_id":    messageID,
+		"claimant":      claimant,
+		"attempts":      attempts,
+		"dead_lettered": deadLettered,
+	}
+}
+
 // UnhookPayload creates a payload for unhook events.
 func UnhookPayload(beadID string) map[string]interface{} {
 	return map[string]interface{}{
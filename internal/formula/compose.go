@@ -0,0 +1,537 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Loader returns the source of a formula by name. Parsing uses it to load
+// the formulas a formula extends, includes or composes.
+type Loader func(name string) ([]byte, error)
+
+// ErrFormulaNotFound is returned by loaders when no formula has the name.
+var ErrFormulaNotFound = errors.New("formula not found")
+
+// EmbeddedLoader loads the formulas shipped with gt.
+func EmbeddedLoader(name string) ([]byte, error) {
+	data, err := formulasFS.ReadFile("formulas/" + name + ".formula.toml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrFormulaNotFound, name)
+	}
+	return data, err
+}
+
+// DirLoader loads formulas from <dir>/<name>.formula.toml, trying dirs in
+// order and falling back to the embedded formulas.
+func DirLoader(dirs ...string) Loader {
+	return func(name string) ([]byte, error) {
+		for _, dir := range dirs {
+			data, err := os.ReadFile(filepath.Join(dir, name+".formula.toml")) //nolint:gosec // G304: path is from trusted formula directory
+			if err == nil {
+				return data, nil
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("reading formula %s: %w", name, err)
+			}
+		}
+		return EmbeddedLoader(name)
+	}
+}
+
+// resolve flattens the formula's composition: steps from extended formulas,
+// namespaced steps from included formulas, local overrides and insertions,
+// expansions and aspect advice. Afterwards Extends, Include and Compose are
+// cleared and the formula stands alone. stack holds the names of formulas
+// being resolved, for cycle detection across files.
+func (f *Formula) resolve(load Loader, stack []string) error {
+	if len(f.Extends) == 0 && len(f.Include) == 0 && f.Compose == nil {
+		return nil
+	}
+	stack = append(stack, f.Name)
+
+	own := f.Steps
+	f.Steps = nil
+
+	for _, name := range f.Extends {
+		base, err := loadComposed(load, name, stack)
+		if err != nil {
+			return fmt.Errorf("extends %s: %w", name, err)
+		}
+		f.inherit(base)
+	}
+
+	var namespaces []namespace
+	for _, inc := range f.Include {
+		ns, err := f.include(load, inc, stack)
+		if err != nil {
+			return err
+		}
+		namespaces = append(namespaces, ns)
+	}
+
+	for _, step := range own {
+		if existing := f.GetStep(step.ID); existing != nil {
+			existing.override(step)
+			continue
+		}
+		f.Steps = append(f.Steps, step)
+	}
+	f.insertBefore(namespaces)
+
+	if f.Compose != nil {
+		for _, rule := range f.Compose.Expand {
+			exp, err := loadComposed(load, rule.With, stack)
+			if err != nil {
+				return fmt.Errorf("expand %s with %s: %w", rule.Target, rule.With, err)
+			}
+			if err := f.expand(rule.Target, exp); err != nil {
+				return err
+			}
+		}
+		for _, name := range f.Compose.Aspects {
+			aspect, err := loadComposed(load, name, stack)
+			if err != nil {
+				return fmt.Errorf("aspect %s: %w", name, err)
+			}
+			f.applyAdvice(aspect)
+		}
+	}
+
+	f.Extends = nil
+	f.Include = nil
+	f.Compose = nil
+	return nil
+}
+
+// loadComposed loads and resolves a formula referenced by another.
+func loadComposed(load Loader, name string, stack []string) (*Formula, error) {
+	for _, s := range stack {
+		if s == name {
+			return nil, fmt.Errorf("composition cycle: %s -> %s", strings.Join(stack, " -> "), name)
+		}
+	}
+	if load == nil {
+		return nil, fmt.Errorf("%w: %s (no loader)", ErrFormulaNotFound, name)
+	}
+	data, err := load(name)
+	if err != nil {
+		return nil, err
+	}
+	g, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("formula %s: %w", name, err)
+	}
+	if g.Name == "" {
+		g.Name = name
+	}
+	if err := g.resolve(load, stack); err != nil {
+		return nil, err
+	}
+	g.inferType()
+	return g, nil
+}
+
+// inherit merges a resolved base formula into f. Base steps, legs,
+// templates and aspects come first; f's own settings win.
+func (f *Formula) inherit(base *Formula) {
+	if f.Description == "" {
+		f.Description = base.Description
+	}
+	if f.Type == "" {
+		f.Type = base.Type
+	}
+	f.Steps = append(f.Steps, base.Steps...)
+	f.Legs = mergeByID(base.Legs, f.Legs, func(l Leg) string { return l.ID })
+	f.Template = mergeByID(base.Template, f.Template, func(t Template) string { return t.ID })
+	f.Aspects = mergeByID(base.Aspects, f.Aspects, func(a Aspect) string { return a.ID })
+	if f.Synthesis == nil {
+		f.Synthesis = base.Synthesis
+	}
+	if f.Output == nil {
+		f.Output = base.Output
+	}
+	f.Vars = mergeMap(base.Vars, f.Vars)
+	f.Inputs = mergeMap(base.Inputs, f.Inputs)
+	f.Prompts = mergeMap(base.Prompts, f.Prompts)
+}
+
+// include adds the steps of another formula under the namespace inc.As
+// (default: the formula name). Step IDs become "<ns>.<id>". The included
+// formula's entry steps wait on inc.Needs, and a step in f that needs the
+// namespace itself waits on all of the included formula's final steps.
+// inc.Vars bind the included formula's variables; unbound variables flow
+// up into f's vars.
+func (f *Formula) include(load Loader, inc Include, stack []string) (namespace, error) {
+	if inc.Formula == "" {
+		return namespace{}, fmt.Errorf("include missing required formula field")
+	}
+	g, err := loadComposed(load, inc.Formula, stack)
+	if err != nil {
+		return namespace{}, fmt.Errorf("include %s: %w", inc.Formula, err)
+	}
+	if len(g.Steps) == 0 {
+		return namespace{}, fmt.Errorf("include %s: formula has no steps", inc.Formula)
+	}
+	ns := inc.As
+	if ns == "" {
+		ns = inc.Formula
+	}
+
+	bind := func(text string) string {
+		return variablePattern.ReplaceAllStringFunc(text, func(m string) string {
+			if v, ok := inc.Vars[variablePattern.FindStringSubmatch(m)[1]]; ok {
+				return v
+			}
+			return m
+		})
+	}
+	ids := make(map[string]bool)
+	needed := make(map[string]bool)
+	for _, step := range g.Steps {
+		ids[step.ID] = true
+		for _, need := range step.Needs {
+			needed[need] = true
+		}
+	}
+	// Conditions refer to steps and variables directly rather than through
+	// {{templates}}, so namespace the step references and substitute the
+	// bound variables.
+	bindCondition := func(expr string) string {
+		expr = conditionStepRef.ReplaceAllStringFunc(expr, func(m string) string {
+			sub := conditionStepRef.FindStringSubmatch(m)
+			if !ids[sub[1]] {
+				return m
+			}
+			return "steps." + ns + "." + sub[1] + "." + sub[2]
+		})
+		return conditionVarRef.ReplaceAllStringFunc(expr, func(m string) string {
+			v, ok := inc.Vars[conditionVarRef.FindStringSubmatch(m)[1]]
+			if !ok {
+				return m
+			}
+			if sub := variablePattern.FindStringSubmatch(v); sub != nil && sub[0] == v {
+				return "vars." + sub[1]
+			}
+			return "'" + v + "'"
+		})
+	}
+
+	var sinks []string
+	for _, step := range g.Steps {
+		s := Step{
+			ID:          ns + "." + step.ID,
+			Title:       bind(step.Title),
+			Description: bind(step.Description),
+			Parallel:    step.Parallel,
+			Retry:       step.Retry,
+			Timeout:     step.Timeout,
+		}
+		if step.When != "" {
+			s.When = bindCondition(step.When)
+		}
+		if step.Loop != nil {
+			loop := *step.Loop
+			if loop.Back != "" {
+				loop.Back = ns + "." + loop.Back
+			}
+			loop.Until = bindCondition(loop.Until)
+			s.Loop = &loop
+		}
+		for _, need := range step.Needs {
+			s.Needs = append(s.Needs, ns+"."+need)
+		}
+		if len(step.Needs) == 0 {
+			s.Needs = append(s.Needs, inc.Needs...)
+		}
+		if !needed[step.ID] {
+			sinks = append(sinks, s.ID)
+		}
+		f.Steps = append(f.Steps, s)
+	}
+
+	for name, v := range g.Vars {
+		if _, bound := inc.Vars[name]; bound {
+			continue
+		}
+		if _, ok := f.Vars[name]; !ok {
+			if f.Vars == nil {
+				f.Vars = make(map[string]Var)
+			}
+			f.Vars[name] = v
+		}
+	}
+	return namespace{name: ns, sinks: sinks}, nil
+}
+
+// conditionStepRef and conditionVarRef match step and variable references
+// in conditions.
+var (
+	conditionStepRef = regexp.MustCompile(`steps\.(\S+?)\.(outcome|status)\b`)
+	conditionVarRef  = regexp.MustCompile(`vars\.([a-zA-Z_][a-zA-Z0-9_]*)`)
+)
+
+// namespace is an included formula's namespace and its final steps.
+type namespace struct {
+	name  string
+	sinks []string
+}
+
+// override replaces the fields o sets on an inherited step.
+func (s *Step) override(o Step) {
+	if o.Title != "" {
+		s.Title = o.Title
+	}
+	if o.Description != "" {
+		s.Description = o.Description
+	}
+	if o.Needs != nil {
+		s.Needs = o.Needs
+	}
+	if o.Parallel {
+		s.Parallel = true
+	}
+	s.Before = append(s.Before, o.Before...)
+	if o.When != "" {
+		s.When = o.When
+	}
+	if o.Loop != nil {
+		s.Loop = o.Loop
+	}
+	if o.Retry != nil {
+		s.Retry = o.Retry
+	}
+	if o.Timeout != "" {
+		s.Timeout = o.Timeout
+	}
+}
+
+// insertBefore turns each step's "before" list into needs on the named
+// steps, and each need on an include namespace into needs on that
+// include's final steps.
+func (f *Formula) insertBefore(namespaces []namespace) {
+	for _, step := range f.Steps {
+		for _, target := range step.Before {
+			if t := f.GetStep(target); t != nil {
+				t.Needs = append(t.Needs, step.ID)
+			}
+		}
+	}
+	for i := range f.Steps {
+		f.Steps[i].Before = nil
+		var needs []string
+		for _, need := range f.Steps[i].Needs {
+			needs = append(needs, f.expandNamespace(namespaces, need)...)
+		}
+		f.Steps[i].Needs = needs
+	}
+}
+
+// expandNamespace returns the final steps of the include named id, or id.
+func (f *Formula) expandNamespace(namespaces []namespace, id string) []string {
+	if f.GetStep(id) != nil {
+		return []string{id}
+	}
+	for _, ns := range namespaces {
+		if ns.name == id {
+			return ns.sinks
+		}
+	}
+	return []string{id}
+}
+
+// expand replaces the target step with an expansion formula's templates.
+// "{target}", "{target.title}" and "{target.description}" in the templates
+// refer to the replaced step. Entry templates inherit the target's needs,
+// and steps that needed the target wait on the final templates instead.
+func (f *Formula) expand(target string, exp *Formula) error {
+	idx := -1
+	for i, step := range f.Steps {
+		if step.ID == target {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("expand: unknown target step %q", target)
+	}
+	if len(exp.Template) == 0 {
+		return fmt.Errorf("expand %s: formula %s has no templates", target, exp.Name)
+	}
+	t := f.Steps[idx]
+	sub := strings.NewReplacer(
+		"{target.title}", t.Title,
+		"{target.description}", t.Description,
+		"{target}", t.ID,
+	)
+
+	needed := make(map[string]bool)
+	for _, tmpl := range exp.Template {
+		for _, need := range tmpl.Needs {
+			needed[sub.Replace(need)] = true
+		}
+	}
+	var steps []Step
+	var sinks []string
+	for _, tmpl := range exp.Template {
+		s := Step{
+			ID:          sub.Replace(tmpl.ID),
+			Title:       sub.Replace(tmpl.Title),
+			Description: sub.Replace(tmpl.Description),
+		}
+		for _, need := range tmpl.Needs {
+			s.Needs = append(s.Needs, sub.Replace(need))
+		}
+		if len(tmpl.Needs) == 0 {
+			s.Needs = append(s.Needs, t.Needs...)
+		}
+		if !needed[s.ID] {
+			sinks = append(sinks, s.ID)
+		}
+		steps = append(steps, s)
+	}
+
+	f.Steps = append(f.Steps[:idx], append(steps, f.Steps[idx+1:]...)...)
+	f.replaceNeed(target, sinks)
+	return nil
+}
+
+// applyAdvice wraps the steps matched by an aspect formula's advice with
+// its before and after steps. "{step.id}" in advice steps refers to the
+// wrapped step. Before steps share the wrapped step's needs and the step
+// waits on them; after steps wait on the step, and its dependents wait on
+// the after steps.
+func (f *Formula) applyAdvice(aspect *Formula) {
+	var targets []string
+	for _, step := range f.Steps {
+		targets = append(targets, step.ID)
+	}
+	for _, adv := range aspect.Advice {
+		if adv.Around == nil {
+			continue
+		}
+		for _, id := range targets {
+			if !globMatch(adv.Target, id) || !aspect.inPointcut(id) {
+				continue
+			}
+			sub := strings.NewReplacer("{step.id}", id)
+			step := f.GetStep(id)
+			var befores []Step
+			var beforeIDs []string
+			for _, b := range adv.Around.Before {
+				s := Step{ID: sub.Replace(b.ID), Title: sub.Replace(b.Title), Description: sub.Replace(b.Description)}
+				s.Needs = append(s.Needs, step.Needs...)
+				befores = append(befores, s)
+				beforeIDs = append(beforeIDs, s.ID)
+			}
+			var afters []Step
+			var afterIDs []string
+			for _, a := range adv.Around.After {
+				s := Step{ID: sub.Replace(a.ID), Title: sub.Replace(a.Title), Description: sub.Replace(a.Description), Needs: []string{id}}
+				afters = append(afters, s)
+				afterIDs = append(afterIDs, s.ID)
+			}
+			if len(afterIDs) > 0 {
+				f.replaceNeed(id, afterIDs)
+			}
+			if len(beforeIDs) > 0 {
+				f.GetStep(id).Needs = beforeIDs
+			}
+
+			idx := 0
+			for i := range f.Steps {
+				if f.Steps[i].ID == id {
+					idx = i
+					break
+				}
+			}
+			rest := append([]Step{f.Steps[idx]}, afters...)
+			rest = append(rest, f.Steps[idx+1:]...)
+			f.Steps = append(append(f.Steps[:idx:idx], befores...), rest...)
+		}
+	}
+}
+
+// inPointcut reports whether an aspect's pointcuts select the step.
+// An aspect without pointcuts selects every step its advice targets.
+func (f *Formula) inPointcut(id string) bool {
+	if len(f.Pointcuts) == 0 {
+		return true
+	}
+	for _, pc := range f.Pointcuts {
+		if globMatch(pc.Glob, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceNeed points every need on id at ids instead, skipping the steps
+// in ids themselves.
+func (f *Formula) replaceNeed(id string, ids []string) {
+	skip := make(map[string]bool)
+	for _, r := range ids {
+		skip[r] = true
+	}
+	for i := range f.Steps {
+		if skip[f.Steps[i].ID] {
+			continue
+		}
+		var needs []string
+		for _, need := range f.Steps[i].Needs {
+			if need == id {
+				needs = append(needs, ids...)
+			} else {
+				needs = append(needs, need)
+			}
+		}
+		f.Steps[i].Needs = needs
+	}
+}
+
+// globMatch reports whether a step ID matches a shell glob pattern.
+func globMatch(pattern, id string) bool {
+	ok, err := path.Match(pattern, id)
+	return err == nil && ok
+}
+
+// mergeByID returns base with items of the same ID replaced by own's, then
+// own's remaining items.
+func mergeByID[T any](base, own []T, id func(T) string) []T {
+	if len(base) == 0 {
+		return own
+	}
+	index := make(map[string]int)
+	merged := append([]T{}, base...)
+	for i, item := range merged {
+		index[id(item)] = i
+	}
+	for _, item := range own {
+		if i, ok := index[id(item)]; ok {
+			merged[i] = item
+			continue
+		}
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// mergeMap returns base overlaid with own.
+func mergeMap[V any](base, own map[string]V) map[string]V {
+	if len(base) == 0 {
+		return own
+	}
+	merged := make(map[string]V, len(base)+len(own))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range own {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,206 @@
+package formula
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mapLoader serves formula sources from a map, for composition tests.
+func mapLoader(sources map[string]string) Loader {
+	return func(name string) ([]byte, error) {
+		src, ok := sources[name]
+		if !ok {
+			return nil, ErrFormulaNotFound
+		}
+		return []byte(src), nil
+	}
+}
+
+const composeBase = `
+formula = "base"
+type = "workflow"
+
+[vars.feature]
+required = true
+
+[[steps]]
+id = "design"
+title = "Design {{feature}}"
+
+[[steps]]
+id = "implement"
+title = "Implement {{feature}}"
+needs = ["design"]
+
+[[steps]]
+id = "submit"
+title = "Submit"
+needs = ["implement"]
+`
+
+func stepNeeds(f *Formula) map[string][]string {
+	needs := make(map[string][]string)
+	for _, s := range f.Steps {
+		needs[s.ID] = s.Needs
+	}
+	return needs
+}
+
+func TestResolveExtendsOverrideInsert(t *testing.T) {
+	load := mapLoader(map[string]string{"base": composeBase})
+	f, err := ParseWithLoader([]byte(`
+formula = "child"
+extends = ["base"]
+
+[[steps]]
+id = "implement"
+description = "Implement it carefully."
+
+[[steps]]
+id = "lint"
+title = "Lint"
+needs = ["implement"]
+before = ["submit"]
+`), load)
+	if err != nil {
+		t.Fatalf("ParseWithLoader: %v", err)
+	}
+
+	if f.Type != TypeWorkflow {
+		t.Errorf("Type = %q, want inherited workflow", f.Type)
+	}
+	if len(f.Extends) != 0 || f.Compose != nil {
+		t.Error("composition fields not cleared after resolving")
+	}
+	impl := f.GetStep("implement")
+	if impl.Title != "Implement {{feature}}" || impl.Description != "Implement it carefully." {
+		t.Errorf("override = %+v", impl)
+	}
+	if got := f.GetStep("submit").Needs; !reflect.DeepEqual(got, []string{"implement", "lint"}) {
+		t.Errorf("submit needs = %v, want [implement lint]", got)
+	}
+	if _, ok := f.Vars["feature"]; !ok {
+		t.Error("base vars not inherited")
+	}
+	order, err := f.TopologicalSort()
+	if err != nil || order[len(order)-1] != "submit" {
+		t.Errorf("TopologicalSort = %v, %v", order, err)
+	}
+}
+
+func TestResolveInclude(t *testing.T) {
+	load := mapLoader(map[string]string{
+		"base": composeBase,
+		"release": `
+formula = "release"
+
+[vars.version]
+required = true
+[vars.channel]
+default = "stable"
+
+[[steps]]
+id = "tag"
+title = "Tag {{version}} for {{channel}}"
+
+[[steps]]
+id = "publish"
+title = "Publish {{version}}"
+needs = ["tag"]
+`,
+	})
+	f, err := ParseWithLoader([]byte(`
+formula = "ship"
+extends = ["base"]
+
+[[include]]
+formula = "release"
+as = "rel"
+needs = ["submit"]
+vars = { version = "{{feature}}-1" }
+
+[[steps]]
+id = "announce"
+title = "Announce"
+needs = ["rel"]
+`), load)
+	if err != nil {
+		t.Fatalf("ParseWithLoader: %v", err)
+	}
+
+	needs := stepNeeds(f)
+	if !reflect.DeepEqual(needs["rel.tag"], []string{"submit"}) {
+		t.Errorf("rel.tag needs = %v, want [submit]", needs["rel.tag"])
+	}
+	if !reflect.DeepEqual(needs["rel.publish"], []string{"rel.tag"}) {
+		t.Errorf("rel.publish needs = %v, want [rel.tag]", needs["rel.publish"])
+	}
+	if !reflect.DeepEqual(needs["announce"], []string{"rel.publish"}) {
+		t.Errorf("announce needs = %v, want [rel.publish]", needs["announce"])
+	}
+	if got := f.GetStep("rel.tag").Title; got != "Tag {{feature}}-1 for {{channel}}" {
+		t.Errorf("rel.tag title = %q", got)
+	}
+	if _, ok := f.Vars["version"]; ok {
+		t.Error("bound variable leaked into parent vars")
+	}
+	if v, ok := f.Vars["channel"]; !ok || v.Default != "stable" {
+		t.Errorf("unbound variable did not flow into parent vars: %+v", f.Vars)
+	}
+	if err := f.ValidateTemplateVariables(); err != nil {
+		t.Errorf("ValidateTemplateVariables: %v", err)
+	}
+}
+
+func TestResolveCycle(t *testing.T) {
+	load := mapLoader(map[string]string{
+		"a": "formula = \"a\"\nextends = [\"b\"]\n",
+		"b": "formula = \"b\"\n[[include]]\nformula = \"a\"\n",
+	})
+	_, err := ParseWithLoader([]byte("formula = \"a\"\nextends = [\"b\"]\n"), load)
+	if err == nil || !strings.Contains(err.Error(), "composition cycle: a -> b -> a") {
+		t.Errorf("err = %v, want composition cycle", err)
+	}
+}
+
+func TestResolveMissingFormula(t *testing.T) {
+	_, err := ParseWithLoader([]byte("formula = \"x\"\nextends = [\"nope\"]\n"), mapLoader(nil))
+	if !errors.Is(err, ErrFormulaNotFound) {
+		t.Errorf("err = %v, want ErrFormulaNotFound", err)
+	}
+}
+
+func TestResolveEmbeddedComposition(t *testing.T) {
+	data, err := EmbeddedLoader("shiny-secure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse(shiny-secure): %v", err)
+	}
+	needs := stepNeeds(f)
+	if !reflect.DeepEqual(needs["implement"], []string{"implement-security-prescan"}) {
+		t.Errorf("implement needs = %v", needs["implement"])
+	}
+	if !reflect.DeepEqual(needs["review"], []string{"implement-security-postscan"}) {
+		t.Errorf("review needs = %v", needs["review"])
+	}
+
+	data, err = EmbeddedLoader("shiny-enterprise")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err = Parse(data)
+	if err != nil {
+		t.Fatalf("Parse(shiny-enterprise): %v", err)
+	}
+	if f.GetStep("implement") != nil || f.GetStep("implement.draft") == nil {
+		t.Error("implement was not expanded with rule-of-five")
+	}
+	if got := f.GetStep("implement.draft").Title; got != "Draft: Implement {{feature}}" {
+		t.Errorf("draft title = %q", got)
+	}
+}
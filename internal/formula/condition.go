@@ -0,0 +1,193 @@
+package formula
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition is a parsed step condition, used by "when" and loop "until".
+//
+// Grammar:
+//
+//	expr    = and { "||" and }
+//	and     = clause { "&&" clause }
+//	clause  = [ "!" ] operand [ ( "==" | "!=" ) operand ]
+//	operand = "vars.<name>" | "steps.<id>.outcome" | "steps.<id>.status"
+//	        | quoted string | bare word
+//
+// A lone operand is true when its value is non-empty and not "false", "0"
+// or "no". Examples:
+//
+//	when = "vars.env == 'prod'"
+//	until = "steps.review.outcome == approved"
+//	when = "!vars.skip_tests && steps.build.status != skipped"
+type Condition struct {
+	any [][]clause // Disjunction of conjunctions
+}
+
+type clause struct {
+	negate bool
+	left   operand
+	op     string // "", "==" or "!="
+	right  operand
+}
+
+type operand struct {
+	ref     string // "vars" or "steps"; "" for a literal
+	name    string // Variable name or step ID
+	field   string // Step field: "outcome" or "status"
+	literal string
+}
+
+// ParseCondition parses a condition expression.
+func ParseCondition(expr string) (*Condition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+	c := &Condition{}
+	for _, or := range strings.Split(expr, "||") {
+		var all []clause
+		for _, and := range strings.Split(or, "&&") {
+			cl, err := parseClause(strings.TrimSpace(and))
+			if err != nil {
+				return nil, fmt.Errorf("condition %q: %w", expr, err)
+			}
+			all = append(all, cl)
+		}
+		c.any = append(c.any, all)
+	}
+	return c, nil
+}
+
+func parseClause(s string) (clause, error) {
+	var cl clause
+	if s == "" {
+		return cl, fmt.Errorf("empty clause")
+	}
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(s, op); i >= 0 {
+			left, err := parseOperand(strings.TrimSpace(s[:i]))
+			if err != nil {
+				return cl, err
+			}
+			right, err := parseOperand(strings.TrimSpace(s[i+len(op):]))
+			if err != nil {
+				return cl, err
+			}
+			return clause{left: left, op: op, right: right}, nil
+		}
+	}
+	if strings.HasPrefix(s, "!") {
+		cl.negate = true
+		s = strings.TrimSpace(s[1:])
+	}
+	left, err := parseOperand(s)
+	if err != nil {
+		return cl, err
+	}
+	cl.left = left
+	return cl, nil
+}
+
+func parseOperand(s string) (operand, error) {
+	if s == "" {
+		return operand{}, fmt.Errorf("missing operand")
+	}
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return operand{literal: s[1 : len(s)-1]}, nil
+	}
+	if strings.ContainsAny(s, " \t!=") {
+		return operand{}, fmt.Errorf("invalid operand %q", s)
+	}
+	switch {
+	case strings.HasPrefix(s, "vars."):
+		name := strings.TrimPrefix(s, "vars.")
+		if name == "" || strings.Contains(name, ".") {
+			return operand{}, fmt.Errorf("invalid variable reference %q", s)
+		}
+		return operand{ref: "vars", name: name}, nil
+	case strings.HasPrefix(s, "steps."):
+		rest := strings.TrimPrefix(s, "steps.")
+		i := strings.LastIndex(rest, ".")
+		if i <= 0 {
+			return operand{}, fmt.Errorf("invalid step reference %q (want steps.<id>.outcome or steps.<id>.status)", s)
+		}
+		field := rest[i+1:]
+		if field != "outcome" && field != "status" {
+			return operand{}, fmt.Errorf("invalid step field %q in %q (want outcome or status)", field, s)
+		}
+		return operand{ref: "steps", name: rest[:i], field: field}, nil
+	}
+	return operand{literal: s}, nil
+}
+
+// Vars returns the variable names the condition references.
+func (c *Condition) Vars() []string {
+	return c.refs("vars")
+}
+
+// Steps returns the step IDs the condition references.
+func (c *Condition) Steps() []string {
+	return c.refs("steps")
+}
+
+func (c *Condition) refs(kind string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, all := range c.any {
+		for _, cl := range all {
+			for _, o := range []operand{cl.left, cl.right} {
+				if o.ref == kind && !seen[o.name] {
+					seen[o.name] = true
+					names = append(names, o.name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// Eval evaluates the condition. lookup resolves variable and step
+// references to their current values.
+func (c *Condition) Eval(lookup func(ref, name, field string) string) bool {
+	value := func(o operand) string {
+		if o.ref == "" {
+			return o.literal
+		}
+		return lookup(o.ref, o.name, o.field)
+	}
+	for _, all := range c.any {
+		ok := true
+		for _, cl := range all {
+			var v bool
+			switch cl.op {
+			case "==":
+				v = value(cl.left) == value(cl.right)
+			case "!=":
+				v = value(cl.left) != value(cl.right)
+			default:
+				v = truthy(value(cl.left))
+			}
+			if cl.negate {
+				v = !v
+			}
+			if !v {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// truthy reports whether a condition value counts as true.
+func truthy(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "false", "0", "no":
+		return false
+	}
+	return true
+}
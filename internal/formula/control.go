@@ -0,0 +1,385 @@
+package formula
+
+import (
+	"fmt"
+	"time"
+)
+
+// Step statuses in a RunState.
+const (
+	StepPending = "pending"
+	StepRunning = "running"
+	StepDone    = "done"
+	StepSkipped = "skipped" // "when" condition was false
+	StepFailed  = "failed"  // Retries or loop iterations exhausted
+)
+
+// Loop exhaustion policies.
+const (
+	LoopFail     = "fail"
+	LoopContinue = "continue"
+)
+
+// RunState is the execution state of a workflow: variable values and the
+// state of each step. The control-flow methods on Formula (Ready, Start,
+// Complete, Fail, TimedOut) read and update it deterministically, so the
+// formula test harness, the simulator and 'gt mol step done' (which keeps
+// it on the molecule) can persist it between steps and replay decisions.
+type RunState struct {
+	Vars  map[string]string     `json:"vars,omitempty"`
+	Steps map[string]*StepState `json:"steps,omitempty"`
+}
+
+// StepState is one step's progress within a RunState.
+type StepState struct {
+	Status     string    `json:"status"`
+	Outcome    string    `json:"outcome,omitempty"`    // Result reported on completion, e.g. "approved"
+	Attempts   int       `json:"attempts,omitempty"`   // Failed attempts so far
+	Iterations int       `json:"iterations,omitempty"` // Loop iterations completed (on a looping step)
+	StartedAt  time.Time `json:"started_at"`           // Start of the current attempt
+	NotBefore  time.Time `json:"not_before"`           // Earliest retry of a failed attempt
+}
+
+// NewRunState returns an empty run state with the given variable values.
+func NewRunState(vars map[string]string) *RunState {
+	return &RunState{Vars: vars, Steps: make(map[string]*StepState)}
+}
+
+// Step returns the state of a step, creating it as pending.
+func (s *RunState) Step(id string) *StepState {
+	if s.Steps == nil {
+		s.Steps = make(map[string]*StepState)
+	}
+	st, ok := s.Steps[id]
+	if !ok {
+		st = &StepState{Status: StepPending}
+		s.Steps[id] = st
+	}
+	return st
+}
+
+// status returns a step's status without creating its state.
+func (s *RunState) status(id string) string {
+	if st, ok := s.Steps[id]; ok {
+		return st.Status
+	}
+	return StepPending
+}
+
+// Transition actions returned by Complete and Fail.
+const (
+	ActionDone  = "done"  // Step finished; dependents may proceed
+	ActionLoop  = "loop"  // Loop body reset to pending for another iteration
+	ActionRetry = "retry" // Failed attempt will be retried at RetryAt
+	ActionFail  = "fail"  // Step failed for good; the workflow cannot finish
+)
+
+// Transition describes what Complete or Fail decided for a step.
+type Transition struct {
+	Step    string
+	Action  string
+	Reset   []string  // Steps reset to pending (ActionLoop)
+	RetryAt time.Time // Earliest next attempt (ActionRetry)
+	Reason  string
+}
+
+// Ready returns the workflow steps that may start now: pending, past any
+// retry backoff, with every need done or skipped. Steps whose "when"
+// condition is false are marked skipped on the way, so their dependents
+// become ready in the same call.
+func (f *Formula) Ready(state *RunState, now time.Time) []string {
+	for {
+		var ready []string
+		skipped := false
+		for i := range f.Steps {
+			step := &f.Steps[i]
+			if state.status(step.ID) != StepPending || !f.needsMet(state, step) {
+				continue
+			}
+			if step.When != "" && !f.evalCondition(state, step.When) {
+				state.Step(step.ID).Status = StepSkipped
+				skipped = true
+				continue
+			}
+			if st, ok := state.Steps[step.ID]; ok && now.Before(st.NotBefore) {
+				continue
+			}
+			ready = append(ready, step.ID)
+		}
+		if !skipped {
+			return ready
+		}
+	}
+}
+
+// needsMet reports whether every need of step is done or skipped.
+func (f *Formula) needsMet(state *RunState, step *Step) bool {
+	for _, need := range step.Needs {
+		switch state.status(need) {
+		case StepDone, StepSkipped:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Start marks a step running.
+func (f *Formula) Start(state *RunState, id string, now time.Time) {
+	st := state.Step(id)
+	st.Status = StepRunning
+	st.StartedAt = now
+}
+
+// Complete records a step's outcome. For a looping step whose "until"
+// condition does not hold yet, the loop body is reset to pending for
+// another iteration; once Loop.Max iterations are used up the step fails,
+// or with on_exhausted = "continue" completes anyway.
+func (f *Formula) Complete(state *RunState, id, outcome string) Transition {
+	st := state.Step(id)
+	st.Status = StepDone
+	st.Outcome = outcome
+	t := Transition{Step: id, Action: ActionDone}
+
+	step := f.GetStep(id)
+	if step == nil || step.Loop == nil {
+		return t
+	}
+	st.Iterations++
+	if f.evalCondition(state, step.Loop.Until) {
+		return t
+	}
+	if st.Iterations >= step.Loop.Max {
+		t.Reason = fmt.Sprintf("loop exhausted after %d iteration(s)", st.Iterations)
+		if step.Loop.OnExhausted != LoopContinue {
+			st.Status = StepFailed
+			t.Action = ActionFail
+		}
+		return t
+	}
+
+	t.Action = ActionLoop
+	t.Reason = fmt.Sprintf("iteration %d of %d: %s not met", st.Iterations, step.Loop.Max, step.Loop.Until)
+	for _, bodyID := range f.loopBody(step) {
+		b := state.Step(bodyID)
+		b.Status = StepPending
+		b.Attempts = 0
+		b.NotBefore = time.Time{}
+		t.Reset = append(t.Reset, bodyID)
+	}
+	return t
+}
+
+// Fail records a failed attempt. Steps with retries left go back to
+// pending with a backoff; otherwise the step fails.
+func (f *Formula) Fail(state *RunState, id, reason string, now time.Time) Transition {
+	st := state.Step(id)
+	st.Attempts++
+	t := Transition{Step: id, Action: ActionFail, Reason: reason}
+
+	if step := f.GetStep(id); step != nil && step.Retry != nil && st.Attempts <= step.Retry.Max {
+		backoff, _ := parseDuration(step.Retry.Backoff)
+		st.Status = StepPending
+		st.NotBefore = now.Add(backoff)
+		t.Action = ActionRetry
+		t.RetryAt = st.NotBefore
+		return t
+	}
+	st.Status = StepFailed
+	return t
+}
+
+// TimedOut returns the running steps whose current attempt has exceeded
+// the step's timeout. The caller reports each with Fail.
+func (f *Formula) TimedOut(state *RunState, now time.Time) []string {
+	var timedOut []string
+	for _, step := range f.Steps {
+		st, ok := state.Steps[step.ID]
+		if !ok || st.Status != StepRunning || step.Timeout == "" {
+			continue
+		}
+		if d, err := parseDuration(step.Timeout); err == nil && now.Sub(st.StartedAt) > d {
+			timedOut = append(timedOut, step.ID)
+		}
+	}
+	return timedOut
+}
+
+// RunStatus summarizes a run: StepFailed if any step failed, StepDone when
+// every step is done or skipped, otherwise StepRunning.
+func (f *Formula) RunStatus(state *RunState) string {
+	done := true
+	for _, step := range f.Steps {
+		switch state.status(step.ID) {
+		case StepFailed:
+			return StepFailed
+		case StepDone, StepSkipped:
+		default:
+			done = false
+		}
+	}
+	if done {
+		return StepDone
+	}
+	return StepRunning
+}
+
+// evalCondition evaluates a condition against the run state. Variables
+// without a value fall back to their [vars] default.
+func (f *Formula) evalCondition(state *RunState, expr string) bool {
+	c, err := ParseCondition(expr)
+	if err != nil {
+		return false // Rejected by Validate
+	}
+	return c.Eval(func(ref, name, field string) string {
+		if ref == "vars" {
+			if v, ok := state.Vars[name]; ok {
+				return v
+			}
+			return f.Vars[name].Default
+		}
+		st, ok := state.Steps[name]
+		if !ok {
+			if field == "status" {
+				return StepPending
+			}
+			return ""
+		}
+		if field == "status" {
+			return st.Status
+		}
+		return st.Outcome
+	})
+}
+
+// loopBody returns the steps repeated by a looping step: those that depend
+// (transitively) on Loop.Back and that the looping step depends on, plus
+// both ends, in formula order.
+func (f *Formula) loopBody(step *Step) []string {
+	back := step.Loop.Back
+	if back == "" || back == step.ID {
+		return []string{step.ID}
+	}
+	ancestors := f.ancestors(step.ID)
+	var body []string
+	for _, s := range f.Steps {
+		if s.ID == step.ID || s.ID == back || (ancestors[s.ID] && f.ancestors(s.ID)[back]) {
+			body = append(body, s.ID)
+		}
+	}
+	return body
+}
+
+// ancestors returns the steps id depends on, transitively.
+func (f *Formula) ancestors(id string) map[string]bool {
+	seen := make(map[string]bool)
+	var visit func(string)
+	visit = func(id string) {
+		step := f.GetStep(id)
+		if step == nil {
+			return
+		}
+		for _, need := range step.Needs {
+			if !seen[need] {
+				seen[need] = true
+				visit(need)
+			}
+		}
+	}
+	visit(id)
+	return seen
+}
+
+// ControlSteps returns the IDs of steps that use control flow (when, loop,
+// retry or timeout). Molecules cooked from a formula with control steps
+// carry a RunState that 'gt mol step done' advances; others follow needs.
+func (f *Formula) ControlSteps() []string {
+	var ids []string
+	for _, step := range f.Steps {
+		if step.When != "" || step.Loop != nil || step.Retry != nil || step.Timeout != "" {
+			ids = append(ids, step.ID)
+		}
+	}
+	return ids
+}
+
+// validateControl checks the control-flow fields of workflow steps.
+// Conditions may only reference declared variables and steps the step
+// depends on, so every decision is made from settled state.
+func (f *Formula) validateControl() error {
+	for _, step := range f.Steps {
+		ancestors := f.ancestors(step.ID)
+		if step.When != "" {
+			if err := f.validateCondition(step.ID, "when", step.When, ancestors); err != nil {
+				return err
+			}
+		}
+		if step.Loop != nil {
+			if step.Loop.Max < 1 {
+				return fmt.Errorf("step %q loop requires max >= 1", step.ID)
+			}
+			if step.Loop.Until == "" {
+				return fmt.Errorf("step %q loop requires an until condition", step.ID)
+			}
+			if b := step.Loop.Back; b != "" && b != step.ID && !ancestors[b] {
+				return fmt.Errorf("step %q loop back %q is not a step it depends on", step.ID, b)
+			}
+			switch step.Loop.OnExhausted {
+			case "", LoopFail, LoopContinue:
+			default:
+				return fmt.Errorf("step %q loop on_exhausted %q must be fail or continue", step.ID, step.Loop.OnExhausted)
+			}
+			self := map[string]bool{step.ID: true}
+			for a := range ancestors {
+				self[a] = true
+			}
+			if err := f.validateCondition(step.ID, "loop until", step.Loop.Until, self); err != nil {
+				return err
+			}
+		}
+		if step.Retry != nil {
+			if step.Retry.Max < 0 {
+				return fmt.Errorf("step %q retry max must not be negative", step.ID)
+			}
+			if _, err := parseDuration(step.Retry.Backoff); err != nil {
+				return fmt.Errorf("step %q retry backoff: %w", step.ID, err)
+			}
+		}
+		if step.Timeout != "" {
+			if d, err := parseDuration(step.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("step %q has invalid timeout %q", step.ID, step.Timeout)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCondition parses a step condition and checks its references.
+func (f *Formula) validateCondition(stepID, what, expr string, visible map[string]bool) error {
+	c, err := ParseCondition(expr)
+	if err != nil {
+		return fmt.Errorf("step %q %s: %w", stepID, what, err)
+	}
+	for _, name := range c.Vars() {
+		if _, ok := f.Vars[name]; !ok {
+			return fmt.Errorf("step %q %s references undefined variable %q", stepID, what, name)
+		}
+	}
+	for _, id := range c.Steps() {
+		if f.GetStep(id) == nil {
+			return fmt.Errorf("step %q %s references unknown step %q", stepID, what, id)
+		}
+		if !visible[id] {
+			return fmt.Errorf("step %q %s references step %q, which it does not depend on", stepID, what, id)
+		}
+	}
+	return nil
+}
+
+// parseDuration parses an optional duration; "" is zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,205 @@
+package formula
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const reviewLoopFormula = `
+formula = "review-loop"
+type = "workflow"
+
+[vars.env]
+default = "dev"
+
+[[steps]]
+id = "implement"
+title = "Implement"
+timeout = "30m"
+retry = { max = 2, backoff = "1m" }
+
+[[steps]]
+id = "review"
+title = "Review"
+needs = ["implement"]
+
+[[steps]]
+id = "fix"
+title = "Fix review findings"
+needs = ["review"]
+when = "steps.review.outcome != approved"
+loop = { back = "review", until = "steps.review.outcome == approved", max = 3 }
+
+[[steps]]
+id = "deploy"
+title = "Deploy"
+needs = ["fix"]
+when = "vars.env == 'prod'"
+
+[[steps]]
+id = "submit"
+title = "Submit"
+needs = ["deploy"]
+`
+
+func TestConditionEval(t *testing.T) {
+	values := map[string]string{"vars.env": "prod", "vars.skip": "false", "steps.review.outcome": "approved"}
+	lookup := func(ref, name, field string) string {
+		key := ref + "." + name
+		if field != "" {
+			key += "." + field
+		}
+		return values[key]
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"vars.env == prod", true},
+		{"vars.env == 'dev'", false},
+		{`vars.env != "dev"`, true},
+		{"vars.skip", false},
+		{"!vars.skip", true},
+		{"vars.missing || steps.review.outcome == approved", true},
+		{"vars.env == prod && vars.skip", false},
+	}
+	for _, tt := range tests {
+		c, err := ParseCondition(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCondition(%q): %v", tt.expr, err)
+		}
+		if got := c.Eval(lookup); got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "vars.", "steps.review", "steps.review.title == x", "a == b c"} {
+		if _, err := ParseCondition(bad); err == nil {
+			t.Errorf("ParseCondition(%q) accepted", bad)
+		}
+	}
+}
+
+func TestValidateControl(t *testing.T) {
+	base := "formula = \"x\"\n[vars.env]\n[[steps]]\nid = \"a\"\n[[steps]]\nid = \"b\"\n"
+	tests := []struct {
+		name, extra, wantErr string
+	}{
+		{"undefined var", `when = "vars.nope"`, "undefined variable"},
+		{"unknown step", `when = "steps.zz.outcome == ok"`, "unknown step"},
+		{"not a dependency", `when = "steps.a.outcome == ok"`, "does not depend on"},
+		{"loop without max", `loop = { until = "vars.env" }`, "max >= 1"},
+		{"loop back not a dependency", `loop = { back = "a", until = "vars.env", max = 2 }`, "loop back"},
+		{"bad timeout", `timeout = "soon"`, "invalid timeout"},
+		{"bad backoff", `retry = { max = 1, backoff = "later" }`, "retry backoff"},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(base + tt.extra + "\n"))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, err := Parse([]byte(reviewLoopFormula)); err != nil {
+		t.Errorf("valid control flow rejected: %v", err)
+	}
+}
+
+func TestReviewLoop(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopFormula))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := NewRunState(nil)
+
+	// Implement fails once and is retried after the backoff.
+	f.Start(state, "implement", now)
+	if tr := f.Fail(state, "implement", "tests failed", now); tr.Action != ActionRetry || !tr.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Fail = %+v, want retry in 1m", tr)
+	}
+	if ready := f.Ready(state, now); len(ready) != 0 {
+		t.Errorf("Ready during backoff = %v", ready)
+	}
+	if ready := f.Ready(state, now.Add(time.Minute)); !reflect.DeepEqual(ready, []string{"implement"}) {
+		t.Fatalf("Ready after backoff = %v", ready)
+	}
+	f.Complete(state, "implement", "")
+
+	// Review rejects twice, then approves: fix loops back to review.
+	for i, outcome := range []string{"changes-requested", "changes-requested"} {
+		f.Complete(state, "review", outcome)
+		if ready := f.Ready(state, now); !reflect.DeepEqual(ready, []string{"fix"}) {
+			t.Fatalf("iteration %d: Ready = %v, want [fix]", i, ready)
+		}
+		tr := f.Complete(state, "fix", "")
+		if tr.Action != ActionLoop || !reflect.DeepEqual(tr.Reset, []string{"review", "fix"}) {
+			t.Fatalf("iteration %d: Complete(fix) = %+v", i, tr)
+		}
+	}
+	f.Complete(state, "review", "approved")
+
+	// Approved: fix is skipped, deploy is skipped (env=dev), submit is ready.
+	if ready := f.Ready(state, now); !reflect.DeepEqual(ready, []string{"submit"}) {
+		t.Fatalf("Ready after approval = %v, want [submit]", ready)
+	}
+	if st := state.Steps["fix"].Status; st != StepSkipped {
+		t.Errorf("fix status = %s, want skipped", st)
+	}
+	f.Complete(state, "submit", "")
+	if got := f.RunStatus(state); got != StepDone {
+		t.Errorf("RunStatus = %s, want done", got)
+	}
+}
+
+func TestLoopExhaustedAndTimeout(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopFormula))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := NewRunState(map[string]string{"env": "prod"})
+
+	f.Start(state, "implement", now)
+	if got := f.TimedOut(state, now.Add(31*time.Minute)); !reflect.DeepEqual(got, []string{"implement"}) {
+		t.Errorf("TimedOut = %v, want [implement]", got)
+	}
+	f.Complete(state, "implement", "")
+
+	var tr Transition
+	for i := 0; i < 3; i++ {
+		f.Complete(state, "review", "changes-requested")
+		tr = f.Complete(state, "fix", "")
+	}
+	if tr.Action != ActionFail || state.Steps["fix"].Status != StepFailed {
+		t.Errorf("third rejection: %+v, status %s; want fail", tr, state.Steps["fix"].Status)
+	}
+	if got := f.RunStatus(state); got != StepFailed {
+		t.Errorf("RunStatus = %s, want failed", got)
+	}
+}
+
+func TestReadyStepsSkipsFalseWhen(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopFormula))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// With the default env=dev, deploy is skipped and submit follows fix.
+	got := f.ReadySteps(map[string]bool{"implement": true, "review": true, "fix": true})
+	if !reflect.DeepEqual(got, []string{"submit"}) {
+		t.Errorf("ReadySteps = %v, want [submit]", got)
+	}
+}
+
+func TestControlSteps(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopFormula))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"implement", "fix", "deploy"}
+	if got := f.ControlSteps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ControlSteps = %v, want %v", got, want)
+	}
+}
@@ -77,6 +77,22 @@
 //	title = "Publish"
 //	needs = ["build"]
 //
+// # Composition
+//
+// A formula can extend base formulas, include another formula's steps under
+// a namespace, override or insert steps, and apply expansion and aspect
+// formulas. Parse resolves composition into a standalone formula, loading
+// referenced formulas through a Loader (ParseFile looks next to the file,
+// then in the embedded formulas):
+//
+//	formula = "shiny-secure"
+//	extends = ["shiny"]
+//
+//	[compose]
+//	aspects = ["security-audit"]
+//
+// Cycles across files are reported as "composition cycle: a -> b -> a".
+//
 // # Validation
 //
 // The package performs comprehensive validation:
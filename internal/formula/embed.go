@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // Generate formulas directory from canonical source at .beads/formulas/
@@ -29,6 +31,7 @@ type FormulaStatus struct {
 	EmbeddedHash  string // hash computed from embedded content
 	InstalledHash string // hash we installed (from .installed.json)
 	CurrentHash   string // hash of current file on disk
+	Registry      bool   // installed from a registry; InstalledHash is from .lock.json
 }
 
 // HealthReport contains the results of checking formula health.
@@ -190,6 +193,11 @@ func CheckFormulaHealth(beadsPath string) (*HealthReport, error) {
 		return nil, err
 	}
 
+	lock, err := LoadLockFile(beadsPath)
+	if err != nil {
+		return nil, err
+	}
+
 	report := &HealthReport{}
 
 	for filename, embeddedHash := range embedded {
@@ -198,6 +206,12 @@ func CheckFormulaHealth(beadsPath string) (*HealthReport, error) {
 			EmbeddedHash: embeddedHash,
 		}
 
+		// Formulas installed from a registry are checked against the lockfile
+		if entry, ok := lock.Formulas[strings.TrimSuffix(filename, ".formula.toml")]; ok {
+			report.Formulas = append(report.Formulas, lockedStatus(report, status, entry, formulasDir))
+			continue
+		}
+
 		installedHash, wasInstalled := installed.Formulas[filename]
 		status.InstalledHash = installedHash
 
@@ -246,9 +260,46 @@ func CheckFormulaHealth(beadsPath string) (*HealthReport, error) {
 		report.Formulas = append(report.Formulas, status)
 	}
 
+	// Registry formulas with no embedded counterpart
+	names := make([]string, 0, len(lock.Formulas))
+	for name := range lock.Formulas {
+		if _, ok := embedded[name+".formula.toml"]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		status := FormulaStatus{Name: name + ".formula.toml"}
+		report.Formulas = append(report.Formulas, lockedStatus(report, status, lock.Formulas[name], formulasDir))
+	}
+
 	return report, nil
 }
 
+// lockedStatus checks a registry-installed formula against its lockfile
+// checksum and counts the result in report.
+func lockedStatus(report *HealthReport, status FormulaStatus, entry LockEntry, formulasDir string) FormulaStatus {
+	status.Registry = true
+	status.InstalledHash = strings.TrimPrefix(entry.Checksum, "sha256:")
+	currentHash, err := computeFileHash(filepath.Join(formulasDir, status.Name))
+	status.CurrentHash = currentHash
+	switch {
+	case os.IsNotExist(err):
+		status.Status = "missing"
+		report.Missing++
+	case err != nil:
+		status.Status = "error"
+		report.Error++
+	case currentHash == status.InstalledHash:
+		status.Status = "ok"
+		report.OK++
+	default:
+		status.Status = "modified"
+		report.Modified++
+	}
+	return status
+}
+
 // UpdateFormulas updates formulas that are safe to update (outdated, missing, or untracked).
 // Skips user-modified formulas (tracked files that user changed).
 // Returns counts of updated, skipped (modified), and reinstalled (missing).
@@ -267,12 +318,31 @@ func UpdateFormulas(beadsPath string) (updated, skipped, reinstalled int, err er
 	if err != nil {
 		return 0, 0, 0, err
 	}
+	lock, err := LoadLockFile(beadsPath)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	lockChanged := false
 
 	for filename, embeddedHash := range embedded {
 		installedHash, wasInstalled := installed.Formulas[filename]
 		destPath := filepath.Join(formulasDir, filename)
 		currentHash, fileErr := computeFileHash(destPath)
 
+		// Registry-installed formulas are left alone unless deleted, in which
+		// case the embedded version is restored and the lock entry dropped.
+		if name := strings.TrimSuffix(filename, ".formula.toml"); lock.Formulas[name].Checksum != "" {
+			if !os.IsNotExist(fileErr) {
+				if fileErr == nil && currentHash != strings.TrimPrefix(lock.Formulas[name].Checksum, "sha256:") {
+					skipped++
+				}
+				continue
+			}
+			delete(lock.Formulas, name)
+			lockChanged = true
+			wasInstalled = true
+		}
+
 		shouldInstall := false
 		isMissing := false
 		isModified := false
@@ -330,6 +400,11 @@ func UpdateFormulas(beadsPath string) (updated, skipped, reinstalled int, err er
 	if err := saveInstalledRecord(formulasDir, installed); err != nil {
 		return updated, skipped, reinstalled, fmt.Errorf("saving installed record: %w", err)
 	}
+	if lockChanged {
+		if err := lock.save(beadsPath); err != nil {
+			return updated, skipped, reinstalled, err
+		}
+	}
 
 	return updated, skipped, reinstalled, nil
 }
@@ -0,0 +1,300 @@
+package formula
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TestFileSuffix names the test file declared next to a formula:
+// shiny.formula.toml is tested by shiny.formula.test.toml.
+const TestFileSuffix = ".formula.test.toml"
+
+// TestFilePath returns the test file path for a formula file.
+func TestFilePath(formulaPath string) string {
+	return strings.TrimSuffix(formulaPath, ".formula.toml") + TestFileSuffix
+}
+
+// TestSuite is the set of test cases for a formula.
+//
+//	[[case]]
+//	name = "prod deploy after one review round"
+//	vars = { feature = "login", env = "prod" }
+//	steps = ["design", "implement", "review", "fix", "deploy"]
+//	order = ["design", "implement", "review", "fix", "deploy"]
+//	outcomes = { review = ["changes-requested", "approved"] }
+//	path = ["design", "implement", "review", "fix", "review", "fix", "deploy"]
+//	skipped = ["fix"]
+//	status = "done"
+//
+//	[case.prompts.implement]
+//	title = "Implement login"
+//	contains = ["login"]
+type TestSuite struct {
+	Cases []TestCase `toml:"case"`
+}
+
+// TestCase is one formula test: inputs and the expected results. Empty
+// expectations are not checked.
+type TestCase struct {
+	Name string            `toml:"name"`
+	Vars map[string]string `toml:"vars"`
+
+	// Error expects parsing, validation or variable resolution to fail
+	// with a message containing it; nothing else is checked.
+	Error string `toml:"error"`
+
+	Steps   []string                  `toml:"steps"` // Resolved step IDs, in formula order
+	Order   []string                  `toml:"order"` // TopologicalSort result
+	Prompts map[string]ExpectedPrompt `toml:"prompts"`
+
+	// Simulated run with mocked step outcomes. A step's outcomes are used
+	// one per completion (the last repeats); failures counts failed
+	// attempts before it succeeds.
+	Outcomes map[string]any `toml:"outcomes"`
+	Failures map[string]int `toml:"failures"`
+	Path     []string       `toml:"path"`    // Steps in completion order
+	Skipped  []string       `toml:"skipped"` // Steps skipped at the end of the run
+	Status   string         `toml:"status"`  // Final RunStatus: done or failed
+}
+
+// ExpectedPrompt is the expected rendering of a step.
+type ExpectedPrompt struct {
+	Title    string   `toml:"title"`    // Exact rendered title
+	Contains []string `toml:"contains"` // Substrings of the rendered description
+}
+
+// TestResult is the outcome of one test case.
+type TestResult struct {
+	Case     string   `json:"case"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// Passed reports whether the case passed.
+func (r TestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// LoadTestSuite reads a formula test file.
+func LoadTestSuite(path string) (*TestSuite, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted formula directory
+	if err != nil {
+		return nil, fmt.Errorf("reading formula tests: %w", err)
+	}
+	var s TestSuite
+	if _, err := toml.Decode(string(data), &s); err != nil {
+		return nil, fmt.Errorf("parsing formula tests: %w", err)
+	}
+	if len(s.Cases) == 0 {
+		return nil, fmt.Errorf("%s declares no [[case]] entries", path)
+	}
+	for i := range s.Cases {
+		if s.Cases[i].Name == "" {
+			s.Cases[i].Name = fmt.Sprintf("case %d", i+1)
+		}
+	}
+	return &s, nil
+}
+
+// Run runs every case against the formula source, resolving composition
+// with load.
+func (s *TestSuite) Run(data []byte, load Loader) []TestResult {
+	var results []TestResult
+	for _, tc := range s.Cases {
+		results = append(results, TestResult{Case: tc.Name, Failures: tc.run(data, load)})
+	}
+	return results
+}
+
+// run checks one case and returns its failures.
+func (tc *TestCase) run(data []byte, load Loader) []string {
+	var failures []string
+	fail := func(format string, args ...any) {
+		failures = append(failures, fmt.Sprintf(format, args...))
+	}
+
+	f, err := ParseWithLoader(data, load)
+	var values map[string]string
+	if err == nil {
+		err = f.ValidateTemplateVariables()
+	}
+	if err == nil {
+		values, err = f.ResolveVars(tc.Vars)
+	}
+	if tc.Error != "" {
+		if err == nil {
+			fail("expected error containing %q, got none", tc.Error)
+		} else if !strings.Contains(err.Error(), tc.Error) {
+			fail("expected error containing %q, got: %v", tc.Error, err)
+		}
+		return failures
+	}
+	if err != nil {
+		fail("%v", err)
+		return failures
+	}
+
+	if tc.Steps != nil {
+		if got := f.GetAllIDs(); !reflect.DeepEqual(got, tc.Steps) {
+			fail("steps = %v, want %v", got, tc.Steps)
+		}
+	}
+	if tc.Order != nil {
+		if got, err := f.TopologicalSort(); err != nil {
+			fail("topological sort: %v", err)
+		} else if !reflect.DeepEqual(got, tc.Order) {
+			fail("order = %v, want %v", got, tc.Order)
+		}
+	}
+
+	ids := make([]string, 0, len(tc.Prompts))
+	for id := range tc.Prompts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		want := tc.Prompts[id]
+		title, description, ok := f.itemText(id)
+		if !ok {
+			fail("prompt for unknown step %q", id)
+			continue
+		}
+		title, description = RenderText(title, values), RenderText(description, values)
+		if want.Title != "" && title != want.Title {
+			fail("step %s title = %q, want %q", id, title, want.Title)
+		}
+		for _, sub := range want.Contains {
+			if !strings.Contains(description, sub) {
+				fail("step %s description does not contain %q", id, sub)
+			}
+		}
+		if left := ExtractTemplateVariables(title + "\n" + description); len(left) > 0 {
+			fail("step %s has unrendered variables: %s", id, strings.Join(left, ", "))
+		}
+	}
+
+	if tc.Outcomes != nil || tc.Failures != nil || tc.Path != nil || tc.Skipped != nil || tc.Status != "" {
+		failures = append(failures, tc.simulate(f, values)...)
+	}
+	return failures
+}
+
+// itemText returns the title and description of a step, leg, template or
+// aspect.
+func (f *Formula) itemText(id string) (title, description string, ok bool) {
+	if s := f.GetStep(id); s != nil {
+		return s.Title, s.Description, true
+	}
+	if l := f.GetLeg(id); l != nil {
+		return l.Title, l.Description, true
+	}
+	if t := f.GetTemplate(id); t != nil {
+		return t.Title, t.Description, true
+	}
+	if a := f.GetAspect(id); a != nil {
+		return a.Title, a.Description, true
+	}
+	return "", "", false
+}
+
+// simulate runs a workflow with mocked outcomes and checks the path taken.
+func (tc *TestCase) simulate(f *Formula, values map[string]string) []string {
+	var failures []string
+	if f.Type != TypeWorkflow {
+		return []string{fmt.Sprintf("simulated runs need a workflow formula, not %s", f.Type)}
+	}
+	outcomes, err := normalizeOutcomes(tc.Outcomes)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	state := NewRunState(values)
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC) // Fixed clock for deterministic backoffs
+	completions := make(map[string]int)
+	failed := make(map[string]int)
+	var path []string
+
+	for round := 0; ; round++ {
+		if round > 10000 {
+			return append(failures, "simulation did not terminate")
+		}
+		ready := f.Ready(state, now)
+		if len(ready) == 0 {
+			// Wait out any retry backoff
+			var next time.Time
+			for _, st := range state.Steps {
+				if st.Status == StepPending && st.NotBefore.After(now) && (next.IsZero() || st.NotBefore.Before(next)) {
+					next = st.NotBefore
+				}
+			}
+			if next.IsZero() {
+				break
+			}
+			now = next
+			continue
+		}
+		for _, id := range ready {
+			f.Start(state, id, now)
+			if failed[id] < tc.Failures[id] {
+				failed[id]++
+				f.Fail(state, id, "mocked failure", now)
+				continue
+			}
+			outcome := ""
+			if list := outcomes[id]; len(list) > 0 {
+				outcome = list[min(completions[id], len(list)-1)]
+			}
+			completions[id]++
+			path = append(path, id)
+			f.Complete(state, id, outcome)
+		}
+	}
+
+	if tc.Path != nil && !reflect.DeepEqual(path, tc.Path) {
+		failures = append(failures, fmt.Sprintf("path = %v, want %v", path, tc.Path))
+	}
+	if tc.Skipped != nil {
+		var skipped []string
+		for _, step := range f.Steps {
+			if state.status(step.ID) == StepSkipped {
+				skipped = append(skipped, step.ID)
+			}
+		}
+		if !reflect.DeepEqual(skipped, tc.Skipped) && (len(skipped) > 0 || len(tc.Skipped) > 0) {
+			failures = append(failures, fmt.Sprintf("skipped = %v, want %v", skipped, tc.Skipped))
+		}
+	}
+	if tc.Status != "" {
+		if got := f.RunStatus(state); got != tc.Status {
+			failures = append(failures, fmt.Sprintf("status = %s, want %s", got, tc.Status))
+		}
+	}
+	return failures
+}
+
+// normalizeOutcomes accepts a string or a list of strings per step.
+func normalizeOutcomes(raw map[string]any) (map[string][]string, error) {
+	out := make(map[string][]string)
+	for id, v := range raw {
+		switch val := v.(type) {
+		case string:
+			out[id] = []string{val}
+		case []any:
+			for _, item := range val {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("outcomes for %s must be strings", id)
+				}
+				out[id] = append(out[id], s)
+			}
+		default:
+			return nil, fmt.Errorf("outcomes for %s must be a string or list of strings", id)
+		}
+	}
+	return out, nil
+}
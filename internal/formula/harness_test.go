@@ -0,0 +1,121 @@
+package formula
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const reviewLoopTests = `
+[[case]]
+name = "dev run, approved first time"
+steps = ["implement", "review", "fix", "deploy", "submit"]
+order = ["implement", "review", "fix", "deploy", "submit"]
+outcomes = { review = "approved" }
+path = ["implement", "review", "submit"]
+skipped = ["fix", "deploy"]
+status = "done"
+
+[[case]]
+name = "prod run after one review round and a flaky implement"
+vars = { env = "prod" }
+outcomes = { review = ["changes-requested", "approved"] }
+failures = { implement = 1 }
+path = ["implement", "review", "fix", "review", "deploy", "submit"]
+status = "done"
+
+[case.prompts.implement]
+title = "Implement"
+
+[[case]]
+name = "never approved"
+outcomes = { review = "changes-requested" }
+status = "failed"
+`
+
+func writeFormulaWithTests(t *testing.T, formulaSrc, testSrc string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "review-loop.formula.toml")
+	if err := os.WriteFile(path, []byte(formulaSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TestFilePath(path), []byte(testSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTestSuiteRun(t *testing.T) {
+	path := writeFormulaWithTests(t, reviewLoopFormula, reviewLoopTests)
+	suite, err := LoadTestSuite(TestFilePath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range suite.Run([]byte(reviewLoopFormula), DirLoader(filepath.Dir(path))) {
+		if !r.Passed() {
+			t.Errorf("%s: %v", r.Case, r.Failures)
+		}
+	}
+}
+
+func TestTestSuiteReportsFailures(t *testing.T) {
+	src := `
+formula = "greet"
+
+[vars.who]
+required = true
+
+[[steps]]
+id = "hello"
+title = "Hello {{who}}"
+description = "Say hello to {{who}}."
+
+[[steps]]
+id = "bye"
+needs = ["hello"]
+`
+	tests := `
+[[case]]
+name = "wrong expectations"
+vars = { who = "world" }
+order = ["bye", "hello"]
+path = ["hello"]
+
+[case.prompts.hello]
+title = "Hello there"
+contains = ["goodbye"]
+
+[[case]]
+name = "missing variable"
+error = "missing required variables: who"
+
+[[case]]
+name = "unexpected success"
+vars = { who = "world" }
+error = "cycle"
+`
+	path := writeFormulaWithTests(t, src, tests)
+	suite, err := LoadTestSuite(TestFilePath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := suite.Run([]byte(src), nil)
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+
+	got := strings.Join(results[0].Failures, "\n")
+	for _, want := range []string{"order = [hello bye]", `title = "Hello world"`, `does not contain "goodbye"`, "path = [hello bye]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("failures missing %q:\n%s", want, got)
+		}
+	}
+	if !results[1].Passed() {
+		t.Errorf("expected-error case failed: %v", results[1].Failures)
+	}
+	if results[2].Passed() {
+		t.Error("case expecting an error passed without one")
+	}
+}
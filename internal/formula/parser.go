@@ -3,25 +3,39 @@ package formula
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
-// ParseFile reads and parses a formula.toml file.
+// ParseFile reads and parses a formula.toml file. Formulas it composes
+// are loaded from the same directory, then from the embedded formulas.
 func ParseFile(path string) (*Formula, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted formula directory
 	if err != nil {
 		return nil, fmt.Errorf("reading formula file: %w", err)
 	}
-	return Parse(data)
+	return ParseWithLoader(data, DirLoader(filepath.Dir(path)))
 }
 
-// Parse parses formula.toml content from bytes.
+// Parse parses formula.toml content from bytes. Formulas it composes are
+// loaded from the embedded formulas.
 func Parse(data []byte) (*Formula, error) {
-	var f Formula
-	if _, err := toml.Decode(string(data), &f); err != nil {
-		return nil, fmt.Errorf("parsing TOML: %w", err)
+	return ParseWithLoader(data, EmbeddedLoader)
+}
+
+// ParseWithLoader parses formula.toml content, resolving extends, include
+// and compose with formulas from load, and validates the flattened result.
+func ParseWithLoader(data []byte, load Loader) (*Formula, error) {
+	f, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.resolve(load, nil); err != nil {
+		return nil, fmt.Errorf("resolving formula %s: %w", f.Name, err)
 	}
 
 	// Infer type from content if not explicitly set
@@ -31,6 +45,15 @@ func Parse(data []byte) (*Formula, error) {
 		return nil, err
 	}
 
+	return f, nil
+}
+
+// decode parses formula TOML without resolving or validating it.
+func decode(data []byte) (*Formula, error) {
+	var f Formula
+	if _, err := toml.Decode(string(data), &f); err != nil {
+		return nil, fmt.Errorf("parsing TOML: %w", err)
+	}
 	return &f, nil
 }
 
@@ -47,7 +70,7 @@ func (f *Formula) inferType() {
 		f.Type = TypeConvoy
 	} else if len(f.Template) > 0 {
 		f.Type = TypeExpansion
-	} else if len(f.Aspects) > 0 {
+	} else if len(f.Aspects) > 0 || len(f.Advice) > 0 {
 		f.Type = TypeAspect
 	}
 }
@@ -147,6 +170,11 @@ func (f *Formula) validateWorkflow() error {
 		return err
 	}
 
+	// Check conditions, loops, retries and timeouts
+	if err := f.validateControl(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -185,8 +213,8 @@ func (f *Formula) validateExpansion() error {
 }
 
 func (f *Formula) validateAspect() error {
-	if len(f.Aspects) == 0 {
-		return fmt.Errorf("aspect formula requires at least one aspect")
+	if len(f.Aspects) == 0 && len(f.Advice) == 0 {
+		return fmt.Errorf("aspect formula requires at least one aspect or advice")
 	}
 
 	// Check aspect IDs are unique
@@ -357,26 +385,21 @@ func (f *Formula) TopologicalSort() ([]string, error) {
 
 // ReadySteps returns steps that have no unmet dependencies.
 // completed is a set of step IDs that have been completed.
+// For workflows, steps whose "when" condition is false given the variable
+// defaults are skipped and count as met; use Ready with a RunState to
+// evaluate conditions against variable values and step outcomes.
 func (f *Formula) ReadySteps(completed map[string]bool) []string {
 	var ready []string
 
 	switch f.Type {
 	case TypeWorkflow:
-		for _, step := range f.Steps {
-			if completed[step.ID] {
-				continue
-			}
-			allMet := true
-			for _, need := range step.Needs {
-				if !completed[need] {
-					allMet = false
-					break
-				}
-			}
-			if allMet {
-				ready = append(ready, step.ID)
+		state := NewRunState(nil)
+		for id, done := range completed {
+			if done {
+				state.Step(id).Status = StepDone
 			}
 		}
+		ready = f.Ready(state, time.Time{})
 	case TypeExpansion:
 		for _, tmpl := range f.Template {
 			if completed[tmpl.ID] {
@@ -0,0 +1,441 @@
+package formula
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RegistryEnv configures the registry URL. There is no public default
+// registry yet, so one must be configured with this or --registry.
+const RegistryEnv = "GT_FORMULA_REGISTRY"
+
+// registryIndexFile is the index at the root of a registry.
+const registryIndexFile = "index.json"
+
+// lockFile records formulas installed from a registry, next to the
+// .installed.json record of embedded formulas.
+const lockFile = ".lock.json"
+
+// RegistryIndex lists a registry's formulas and their versions.
+//
+//	{"formulas": {"mol-deploy": {"latest": "1.3.0", "versions": {
+//	  "1.3.0": {"checksum": "sha256:…", "path": "mol-deploy/1.3.0.formula.toml"}}}}}
+type RegistryIndex struct {
+	Formulas map[string]RegistryFormula `json:"formulas"`
+}
+
+// RegistryFormula is one formula in a registry index.
+type RegistryFormula struct {
+	Description string                     `json:"description,omitempty"`
+	Latest      string                     `json:"latest"`
+	Versions    map[string]RegistryVersion `json:"versions"`
+}
+
+// RegistryVersion is one published version of a formula.
+type RegistryVersion struct {
+	Checksum  string `json:"checksum"` // "sha256:<hex>"
+	Path      string `json:"path"`     // Content location, relative to the registry root
+	Changelog string `json:"changelog,omitempty"`
+}
+
+// Registry is a formula registry: an HTTP(S) directory, a git repository
+// (URLs ending in .git or starting with git+), or a local directory. Each
+// serves index.json and the formula files it lists.
+type Registry struct {
+	URL      string
+	CacheDir string // Clone location for git registries
+	client   *http.Client
+}
+
+// NewRegistry returns a registry client for url. Git registries are cloned
+// under cacheDir.
+func NewRegistry(url, cacheDir string) *Registry {
+	return &Registry{URL: strings.TrimRight(url, "/"), CacheDir: cacheDir, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// RegistryURL returns the configured registry: flag value, then
+// $GT_FORMULA_REGISTRY, else "" (none configured).
+func RegistryURL(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return os.Getenv(RegistryEnv)
+}
+
+// isGit reports whether the registry is a git repository.
+func (r *Registry) isGit() bool {
+	return strings.HasPrefix(r.URL, "git+") || strings.HasSuffix(r.URL, ".git")
+}
+
+// isHTTP reports whether the registry is served over HTTP(S).
+func (r *Registry) isHTTP() bool {
+	return strings.HasPrefix(r.URL, "http://") || strings.HasPrefix(r.URL, "https://")
+}
+
+// read returns a file from the registry.
+func (r *Registry) read(rel string) ([]byte, error) {
+	if strings.Contains(rel, "..") || filepath.IsAbs(rel) {
+		return nil, fmt.Errorf("invalid registry path %q", rel)
+	}
+	switch {
+	case r.isGit():
+		return os.ReadFile(filepath.Join(r.CacheDir, filepath.FromSlash(rel))) //nolint:gosec // G304: path is inside the registry clone
+	case r.isHTTP():
+		resp, err := r.client.Get(r.URL + "/" + rel)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", rel, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching %s: %s", rel, resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	default:
+		dir := strings.TrimPrefix(r.URL, "file://")
+		return os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel))) //nolint:gosec // G304: path is inside the configured registry
+	}
+}
+
+// sync clones or updates a git registry.
+func (r *Registry) sync() error {
+	if !r.isGit() {
+		return nil
+	}
+	if r.CacheDir == "" {
+		return fmt.Errorf("git registry %s needs a cache directory", r.URL)
+	}
+	url := strings.TrimPrefix(r.URL, "git+")
+	var cmd *exec.Cmd
+	if _, err := os.Stat(filepath.Join(r.CacheDir, ".git")); err == nil {
+		cmd = exec.Command("git", "-C", r.CacheDir, "pull", "--ff-only", "--quiet")
+	} else {
+		if err := os.MkdirAll(filepath.Dir(r.CacheDir), 0755); err != nil {
+			return fmt.Errorf("creating registry cache: %w", err)
+		}
+		cmd = exec.Command("git", "clone", "--depth", "1", "--quiet", url, r.CacheDir)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("syncing registry %s: %v: %s", url, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Index fetches the registry index.
+func (r *Registry) Index() (*RegistryIndex, error) {
+	if err := r.sync(); err != nil {
+		return nil, err
+	}
+	data, err := r.read(registryIndexFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading registry index: %w", err)
+	}
+	var idx RegistryIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing registry index: %w", err)
+	}
+	return &idx, nil
+}
+
+// Resolve picks the version of name matching want: "" for the latest, an
+// exact version, or a prefix such as "4" or "4.1" for the highest match.
+func (idx *RegistryIndex) Resolve(name, want string) (string, RegistryVersion, error) {
+	f, ok := idx.Formulas[name]
+	if !ok {
+		return "", RegistryVersion{}, fmt.Errorf("%w in registry: %s", ErrFormulaNotFound, name)
+	}
+	if want == "" && f.Latest != "" {
+		want = f.Latest
+	}
+	if v, ok := f.Versions[want]; ok {
+		return want, v, nil
+	}
+	var best string
+	for version := range f.Versions {
+		if (want == "" || strings.HasPrefix(version, want+".")) && (best == "" || compareVersions(version, best) > 0) {
+			best = version
+		}
+	}
+	if best == "" {
+		return "", RegistryVersion{}, fmt.Errorf("no version of %s matches %q", name, want)
+	}
+	return best, f.Versions[best], nil
+}
+
+// compareVersions compares dotted numeric versions.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// SplitFormulaSpec splits "name@version" into its parts.
+func SplitFormulaSpec(spec string) (name, version string) {
+	name, version, _ = strings.Cut(spec, "@")
+	return name, version
+}
+
+// LockFile records formulas installed from a registry.
+// Stored in .beads/formulas/.lock.json.
+type LockFile struct {
+	Version  int                  `json:"version"`
+	Formulas map[string]LockEntry `json:"formulas"`
+}
+
+// LockEntry is one registry-installed formula.
+type LockEntry struct {
+	Version     string    `json:"version"`
+	Pinned      bool      `json:"pinned"`
+	Checksum    string    `json:"checksum"` // "sha256:<hex>" of the installed file
+	InstalledAt time.Time `json:"installed_at"`
+	Source      string    `json:"source"` // Registry URL
+}
+
+// LoadLockFile loads the registry lockfile of a town.
+func LoadLockFile(beadsPath string) (*LockFile, error) {
+	path := filepath.Join(beadsPath, ".beads", "formulas", lockFile)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if os.IsNotExist(err) {
+		return &LockFile{Version: 1, Formulas: make(map[string]LockEntry)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading lockfile: %w", err)
+	}
+	var l LockFile
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("parsing lockfile: %w", err)
+	}
+	if l.Formulas == nil {
+		l.Formulas = make(map[string]LockEntry)
+	}
+	return &l, nil
+}
+
+// save writes the lockfile.
+func (l *LockFile) save(beadsPath string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding lockfile: %w", err)
+	}
+	return os.WriteFile(filepath.Join(beadsPath, ".beads", "formulas", lockFile), data, 0644)
+}
+
+// sha256Checksum returns the lockfile/registry checksum form of data.
+func sha256Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ErrFormulaModified is returned when an install or upgrade would
+// overwrite a formula the user changed.
+var ErrFormulaModified = errors.New("formula modified locally")
+
+// InstallOptions controls InstallFormula.
+type InstallOptions struct {
+	Force bool // Overwrite local modifications
+	Now   time.Time
+}
+
+// InstallFormula installs name@version (spec) from the registry into
+// beadsPath's .beads/formulas/ and records it in the lockfile. An exact or
+// partial version pins the formula. The content must match the registry
+// checksum. A formula the user modified since it was installed (from the
+// registry or as an embedded formula) is not overwritten without Force.
+func InstallFormula(beadsPath string, reg *Registry, idx *RegistryIndex, spec string, opts InstallOptions) (LockEntry, error) {
+	name, want := SplitFormulaSpec(spec)
+	version, rv, err := idx.Resolve(name, want)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	return installVersion(beadsPath, reg, name, version, rv, want != "", opts)
+}
+
+func installVersion(beadsPath string, reg *Registry, name, version string, rv RegistryVersion, pinned bool, opts InstallOptions) (LockEntry, error) {
+	content, err := reg.read(rv.Path)
+	if err != nil {
+		return LockEntry{}, fmt.Errorf("downloading %s@%s: %w", name, version, err)
+	}
+	if sum := sha256Checksum(content); !strings.EqualFold(sum, rv.Checksum) {
+		return LockEntry{}, fmt.Errorf("checksum mismatch for %s@%s: registry has %s, downloaded %s", name, version, rv.Checksum, sum)
+	}
+	if _, err := decode(content); err != nil {
+		return LockEntry{}, fmt.Errorf("%s@%s is not a valid formula: %w", name, version, err)
+	}
+
+	formulasDir := filepath.Join(beadsPath, ".beads", "formulas")
+	if err := os.MkdirAll(formulasDir, 0755); err != nil {
+		return LockEntry{}, fmt.Errorf("creating formulas directory: %w", err)
+	}
+	lock, err := LoadLockFile(beadsPath)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	installed, err := loadInstalledRecord(formulasDir)
+	if err != nil {
+		return LockEntry{}, err
+	}
+
+	filename := name + ".formula.toml"
+	destPath := filepath.Join(formulasDir, filename)
+	if !opts.Force {
+		if modified, err := locallyModified(destPath, lock, installed, name); err != nil {
+			return LockEntry{}, err
+		} else if modified {
+			return LockEntry{}, fmt.Errorf("%w: %s (use --force to overwrite)", ErrFormulaModified, filename)
+		}
+	}
+
+	if err := os.WriteFile(destPath, content, 0644); err != nil {
+		return LockEntry{}, fmt.Errorf("writing %s: %w", filename, err)
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	entry := LockEntry{
+		Version:     version,
+		Pinned:      pinned,
+		Checksum:    sha256Checksum(content),
+		InstalledAt: now.UTC(),
+		Source:      reg.URL,
+	}
+	lock.Formulas[name] = entry
+	if err := lock.save(beadsPath); err != nil {
+		return LockEntry{}, err
+	}
+
+	// The registry owns the file now; stop embedded updates from replacing it.
+	if _, ok := installed.Formulas[filename]; ok {
+		delete(installed.Formulas, filename)
+		if err := saveInstalledRecord(formulasDir, installed); err != nil {
+			return entry, fmt.Errorf("saving installed record: %w", err)
+		}
+	}
+	return entry, nil
+}
+
+// locallyModified reports whether the formula file differs from what gt
+// last installed there. Untracked files count as modified: gt has no
+// record of them, so they may be the user's own.
+func locallyModified(path string, lock *LockFile, installed *InstalledRecord, name string) (bool, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	if entry, ok := lock.Formulas[name]; ok {
+		return !strings.EqualFold(sha256Checksum(data), entry.Checksum), nil
+	}
+	if hash, ok := installed.Formulas[filepath.Base(path)]; ok {
+		return computeHash(data) != hash, nil
+	}
+	if embedded, err := formulasFS.ReadFile("formulas/" + filepath.Base(path)); err == nil {
+		return computeHash(data) != computeHash(embedded), nil
+	}
+	return true, nil
+}
+
+// OutdatedFormula is a registry-installed formula and its available update.
+type OutdatedFormula struct {
+	Name     string
+	Current  string
+	Latest   string // Newest version in the registry
+	Wanted   string // Version upgrade would install: Latest, or Current when pinned
+	Pinned   bool
+	Modified bool // Local file differs from the installed checksum
+}
+
+// OutdatedFormulas compares the lockfile against the registry index and
+// returns the installed formulas with a newer version available.
+func OutdatedFormulas(beadsPath string, idx *RegistryIndex) ([]OutdatedFormula, error) {
+	lock, err := LoadLockFile(beadsPath)
+	if err != nil {
+		return nil, err
+	}
+	formulasDir := filepath.Join(beadsPath, ".beads", "formulas")
+
+	var outdated []OutdatedFormula
+	for name, entry := range lock.Formulas {
+		latest, _, err := idx.Resolve(name, "")
+		if err != nil || compareVersions(latest, entry.Version) <= 0 {
+			continue
+		}
+		o := OutdatedFormula{Name: name, Current: entry.Version, Latest: latest, Wanted: latest, Pinned: entry.Pinned}
+		if entry.Pinned {
+			o.Wanted = entry.Version
+		}
+		data, err := os.ReadFile(filepath.Join(formulasDir, name+".formula.toml")) //nolint:gosec // G304: path is constructed internally
+		o.Modified = err == nil && !strings.EqualFold(sha256Checksum(data), entry.Checksum)
+		outdated = append(outdated, o)
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].Name < outdated[j].Name })
+	return outdated, nil
+}
+
+// UpgradeFormulas installs the latest version of each outdated formula, or
+// of the named ones. Pinned formulas are upgraded only when named, which
+// unpins them; modified formulas are skipped unless opts.Force. Returns
+// the upgraded entries by name and the names skipped with their reason.
+func UpgradeFormulas(beadsPath string, reg *Registry, idx *RegistryIndex, names []string, opts InstallOptions) (map[string]LockEntry, map[string]string, error) {
+	outdated, err := OutdatedFormulas(beadsPath, idx)
+	if err != nil {
+		return nil, nil, err
+	}
+	named := make(map[string]bool)
+	for _, n := range names {
+		named[n] = true
+	}
+
+	upgraded := make(map[string]LockEntry)
+	skipped := make(map[string]string)
+	for _, o := range outdated {
+		if len(named) > 0 && !named[o.Name] {
+			continue
+		}
+		if o.Pinned && !named[o.Name] {
+			skipped[o.Name] = "pinned at " + o.Current
+			continue
+		}
+		if o.Modified && !opts.Force {
+			skipped[o.Name] = "modified locally"
+			continue
+		}
+		_, rv, err := idx.Resolve(o.Name, o.Latest)
+		if err != nil {
+			return upgraded, skipped, err
+		}
+		entry, err := installVersion(beadsPath, reg, o.Name, o.Latest, rv, false, InstallOptions{Force: true, Now: opts.Now})
+		if err != nil {
+			return upgraded, skipped, err
+		}
+		upgraded[o.Name] = entry
+	}
+	return upgraded, skipped, nil
+}
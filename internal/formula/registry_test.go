@@ -0,0 +1,222 @@
+package formula
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRegistry lays out a local registry with the given formula versions
+// (name -> version -> content) and returns its directory.
+func writeRegistry(t *testing.T, formulas map[string]map[string]string, latest map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	idx := RegistryIndex{Formulas: make(map[string]RegistryFormula)}
+	for name, versions := range formulas {
+		rf := RegistryFormula{Latest: latest[name], Versions: make(map[string]RegistryVersion)}
+		for version, content := range versions {
+			rel := name + "/" + version + ".formula.toml"
+			if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(rel)), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			rf.Versions[version] = RegistryVersion{Checksum: sha256Checksum([]byte(content)), Path: rel}
+		}
+		idx.Formulas[name] = rf
+	}
+	data, _ := json.Marshal(idx)
+	if err := os.WriteFile(filepath.Join(dir, registryIndexFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func deployFormula(version string) string {
+	return "formula = \"mol-deploy\"\ndescription = \"v" + version + "\"\n[[steps]]\nid = \"deploy\"\n"
+}
+
+func TestRegistryResolve(t *testing.T) {
+	idx := &RegistryIndex{Formulas: map[string]RegistryFormula{
+		"mol-deploy": {Latest: "2.0.0", Versions: map[string]RegistryVersion{
+			"1.2.0": {}, "1.10.0": {}, "2.0.0": {}, "2.1.0-rc1": {},
+		}},
+	}}
+	tests := []struct{ want, got string }{
+		{"", "2.0.0"},
+		{"1", "1.10.0"},
+		{"1.2.0", "1.2.0"},
+	}
+	for _, tt := range tests {
+		got, _, err := idx.Resolve("mol-deploy", tt.want)
+		if err != nil || got != tt.got {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", tt.want, got, err, tt.got)
+		}
+	}
+	if _, _, err := idx.Resolve("mol-deploy", "3"); err == nil {
+		t.Error("Resolve(3) matched a version")
+	}
+	if _, _, err := idx.Resolve("nope", ""); !errors.Is(err, ErrFormulaNotFound) {
+		t.Errorf("Resolve(nope) err = %v", err)
+	}
+}
+
+func TestInstallOutdatedUpgrade(t *testing.T) {
+	regDir := writeRegistry(t, map[string]map[string]string{
+		"mol-deploy": {"1.0.0": deployFormula("1.0.0"), "1.1.0": deployFormula("1.1.0")},
+	}, map[string]string{"mol-deploy": "1.0.0"})
+	reg := NewRegistry(regDir, "")
+	town := t.TempDir()
+
+	idx, err := reg.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := InstallFormula(town, reg, idx, "mol-deploy", InstallOptions{})
+	if err != nil {
+		t.Fatalf("InstallFormula: %v", err)
+	}
+	if entry.Version != "1.0.0" || entry.Pinned {
+		t.Errorf("entry = %+v, want unpinned 1.0.0", entry)
+	}
+	lock, _ := LoadLockFile(town)
+	if lock.Formulas["mol-deploy"].Checksum != sha256Checksum([]byte(deployFormula("1.0.0"))) {
+		t.Errorf("lockfile = %+v", lock.Formulas)
+	}
+
+	// The registry publishes 1.1.0.
+	idx.Formulas["mol-deploy"] = RegistryFormula{Latest: "1.1.0", Versions: idx.Formulas["mol-deploy"].Versions}
+	outdated, err := OutdatedFormulas(town, idx)
+	if err != nil || len(outdated) != 1 || outdated[0].Latest != "1.1.0" || outdated[0].Modified {
+		t.Fatalf("OutdatedFormulas = %+v, %v", outdated, err)
+	}
+
+	// A local edit blocks the upgrade.
+	path := filepath.Join(town, ".beads", "formulas", "mol-deploy.formula.toml")
+	if err := os.WriteFile(path, []byte(deployFormula("1.0.0")+"# mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	upgraded, skipped, err := UpgradeFormulas(town, reg, idx, nil, InstallOptions{})
+	if err != nil || len(upgraded) != 0 || skipped["mol-deploy"] != "modified locally" {
+		t.Fatalf("UpgradeFormulas (modified) = %v, %v, %v", upgraded, skipped, err)
+	}
+	if got := registryHealth(t, town, "mol-deploy"); got != "modified" {
+		t.Errorf("health status = %q, want modified", got)
+	}
+
+	upgraded, _, err = UpgradeFormulas(town, reg, idx, nil, InstallOptions{Force: true})
+	if err != nil || upgraded["mol-deploy"].Version != "1.1.0" {
+		t.Fatalf("UpgradeFormulas (force) = %v, %v", upgraded, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != deployFormula("1.1.0") {
+		t.Errorf("installed content = %q", data)
+	}
+	if got := registryHealth(t, town, "mol-deploy"); got != "ok" {
+		t.Errorf("health status after upgrade = %q, want ok", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := registryHealth(t, town, "mol-deploy"); got != "missing" {
+		t.Errorf("health status after removal = %q, want missing", got)
+	}
+}
+
+// registryHealth returns the health status of a registry-installed formula.
+func registryHealth(t *testing.T, town, name string) string {
+	t.Helper()
+	report, err := CheckFormulaHealth(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range report.Formulas {
+		if f.Name == name+".formula.toml" {
+			if !f.Registry {
+				t.Errorf("%s not reported as a registry formula", name)
+			}
+			return f.Status
+		}
+	}
+	return ""
+}
+
+func TestInstallPinnedAndChecksum(t *testing.T) {
+	regDir := writeRegistry(t, map[string]map[string]string{
+		"mol-deploy": {"1.0.0": deployFormula("1.0.0"), "1.1.0": deployFormula("1.1.0")},
+	}, map[string]string{"mol-deploy": "1.1.0"})
+	reg := NewRegistry(regDir, "")
+	town := t.TempDir()
+	idx, err := reg.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry, err := InstallFormula(town, reg, idx, "mol-deploy@1.0.0", InstallOptions{}); err != nil || !entry.Pinned {
+		t.Fatalf("pinned install = %+v, %v", entry, err)
+	}
+	upgraded, skipped, err := UpgradeFormulas(town, reg, idx, nil, InstallOptions{})
+	if err != nil || len(upgraded) != 0 || !strings.HasPrefix(skipped["mol-deploy"], "pinned") {
+		t.Errorf("UpgradeFormulas (pinned) = %v, %v, %v", upgraded, skipped, err)
+	}
+
+	// Tampered content fails verification.
+	if err := os.WriteFile(filepath.Join(regDir, "mol-deploy", "1.1.0.formula.toml"), []byte("formula = \"evil\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallFormula(town, reg, idx, "mol-deploy@1.1.0", InstallOptions{}); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("tampered install err = %v", err)
+	}
+}
+
+func TestInstallOverEmbeddedFormula(t *testing.T) {
+	town := t.TempDir()
+	if _, err := ProvisionFormulas(town); err != nil {
+		t.Fatal(err)
+	}
+	content := "formula = \"shiny\"\ndescription = \"community shiny\"\n[[steps]]\nid = \"go\"\n"
+	srv := httptest.NewServer(http.FileServer(http.Dir(writeRegistry(t,
+		map[string]map[string]string{"shiny": {"2.0.0": content}}, map[string]string{"shiny": "2.0.0"}))))
+	defer srv.Close()
+
+	reg := NewRegistry(srv.URL, "")
+	idx, err := reg.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallFormula(town, reg, idx, "shiny", InstallOptions{}); err != nil {
+		t.Fatalf("installing over an unmodified embedded formula: %v", err)
+	}
+
+	// Embedded updates leave the registry version alone; health follows the lockfile.
+	if _, _, _, err := UpdateFormulas(town); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(town, ".beads", "formulas", "shiny.formula.toml")
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Error("UpdateFormulas replaced the registry-installed formula")
+	}
+	if err := os.WriteFile(path, []byte(content+"# local\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := CheckFormulaHealth(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range report.Formulas {
+		if f.Name == "shiny.formula.toml" && f.Status != "modified" {
+			t.Errorf("shiny status = %s, want modified", f.Status)
+		}
+	}
+
+	// Modified files are not overwritten without force.
+	if _, err := InstallFormula(town, reg, idx, "shiny", InstallOptions{}); !errors.Is(err, ErrFormulaModified) {
+		t.Errorf("reinstall over modified file err = %v", err)
+	}
+}
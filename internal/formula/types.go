@@ -47,7 +47,51 @@ type Formula struct {
 	Template []Template `toml:"template"`
 
 	// Aspect-specific (similar to convoy but for analysis)
-	Aspects []Aspect `toml:"aspects"`
+	Aspects   []Aspect   `toml:"aspects"`
+	Advice    []Advice   `toml:"advice"`
+	Pointcuts []Pointcut `toml:"pointcuts"`
+
+	// Composition, resolved away by Parse
+	Extends []string  `toml:"extends"`
+	Include []Include `toml:"include"`
+	Compose *Compose  `toml:"compose"`
+}
+
+// Include pulls another formula's steps into a workflow under a namespace.
+type Include struct {
+	Formula string            `toml:"formula"`
+	As      string            `toml:"as"`    // Namespace for step IDs (default: formula name)
+	Needs   []string          `toml:"needs"` // Steps the included entry steps wait on
+	Vars    map[string]string `toml:"vars"`  // Bindings for the included formula's variables
+}
+
+// Compose applies other formulas to a workflow's steps.
+type Compose struct {
+	Aspects []string     `toml:"aspects"` // Aspect formulas whose advice wraps matching steps
+	Expand  []ExpandRule `toml:"expand"`
+}
+
+// ExpandRule replaces a step with the templates of an expansion formula.
+type ExpandRule struct {
+	Target string `toml:"target"`
+	With   string `toml:"with"`
+}
+
+// Advice wraps the steps matching Target (a glob) with extra steps.
+type Advice struct {
+	Target string        `toml:"target"`
+	Around *AroundAdvice `toml:"around"`
+}
+
+// AroundAdvice lists the steps inserted before and after an advised step.
+type AroundAdvice struct {
+	Before []Step `toml:"before"`
+	After  []Step `toml:"after"`
+}
+
+// Pointcut limits the steps an aspect's advice applies to.
+type Pointcut struct {
+	Glob string `toml:"glob"`
 }
 
 // Aspect represents a parallel analysis aspect in an aspect formula.
@@ -96,6 +140,29 @@ type Step struct {
 	Description string   `toml:"description"`
 	Needs       []string `toml:"needs"`
 	Parallel    bool     `toml:"parallel"` // If true, this step can run concurrently with other parallel steps that share the same needs
+	Before      []string `toml:"before"`   // Composition only: inserts this step ahead of the named steps
+
+	// Control flow (see Ready, Complete and Fail)
+	When    string `toml:"when"`    // Condition; the step is skipped when false
+	Loop    *Loop  `toml:"loop"`    // Repeat from Loop.Back until a condition holds
+	Retry   *Retry `toml:"retry"`   // Retry policy for failed attempts
+	Timeout string `toml:"timeout"` // Max duration of one attempt, e.g. "30m"
+}
+
+// Loop repeats the steps from Back through the looping step until Until
+// holds, at most Max times. Used for cycles like "review → fix until the
+// reviewer approves".
+type Loop struct {
+	Back        string `toml:"back"`         // First step of the loop body (default: the looping step)
+	Until       string `toml:"until"`        // Condition that ends the loop
+	Max         int    `toml:"max"`          // Max iterations of the body
+	OnExhausted string `toml:"on_exhausted"` // "fail" (default) or "continue" when Max is reached
+}
+
+// Retry re-runs a failed step up to Max more times, Backoff apart.
+type Retry struct {
+	Max     int    `toml:"max"`
+	Backoff string `toml:"backoff"` // e.g. "30s"; default no wait
 }
 
 // Template represents a template step in an expansion formula.
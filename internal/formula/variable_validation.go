@@ -159,3 +159,46 @@ func (f *Formula) ValidateTemplateVariables() error {
 	return nil
 }
 
+
+// ResolveVars returns the variable values for a run: vars overlaid on the
+// [vars] and [inputs] defaults. Returns an error listing the required
+// variables left without a value.
+func (f *Formula) ResolveVars(vars map[string]string) (map[string]string, error) {
+	values := make(map[string]string)
+	for name, v := range f.Vars {
+		if v.Default != "" {
+			values[name] = v.Default
+		}
+	}
+	for name, in := range f.Inputs {
+		if in.Default != "" {
+			values[name] = in.Default
+		}
+	}
+	for name, v := range vars {
+		values[name] = v
+	}
+
+	var missing []string
+	for name, v := range f.Vars {
+		if _, ok := values[name]; v.Required && !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return values, fmt.Errorf("missing required variables: %s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// RenderText substitutes {{variable}} placeholders with values. Unknown
+// placeholders and Handlebars keywords are left as is.
+func RenderText(text string, values map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(text, func(m string) string {
+		if v, ok := values[variablePattern.FindStringSubmatch(m)[1]]; ok {
+			return v
+		}
+		return m
+	})
+}
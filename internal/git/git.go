@@ -1046,6 +1046,22 @@ func (g *Git) BranchCreatedDate(branch string) (string, error) {
 	return out, nil
 }
 
+// Diff returns the patch for a diff spec such as "main...HEAD", "HEAD~1" or
+// "a..b", as passed to git diff.
+func (g *Git) Diff(spec string) (string, error) {
+	if strings.HasPrefix(spec, "-") {
+		return "", fmt.Errorf("invalid diff spec %q", spec)
+	}
+	out, err := g.run("diff", spec, "--")
+	if err != nil {
+		return "", err
+	}
+	if out != "" {
+		out += "\n"
+	}
+	return out, nil
+}
+
 // CommitsAhead returns the number of commits that branch has ahead of base.
 // For example, CommitsAhead("main", "feature") returns how many commits
 // are on feature that are not on main.
@@ -0,0 +1,480 @@
+// Package headless provides a tmux-free session backend.
+//
+// Sessions are child processes attached to a pseudo-terminal (Linux) or plain
+// pipes (elsewhere). Backend supervises them inside the current process; Host
+// serves one such session from its own detached process, and Client reaches
+// hosted sessions from any process through their status, scrollback and
+// input files. Output is retained in a per-session scrollback ring buffer so
+// that capture (gt peek) and liveness checks keep working without a tmux
+// server. This is intended for CI boxes and containers where tmux is not
+// installed.
+package headless
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSessionNotFound is returned when an operation targets an unknown session.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrSessionExists is returned when creating a session whose name is in use.
+var ErrSessionExists = errors.New("session already exists")
+
+// killGracePeriod is how long KillSessionWithProcesses waits after SIGTERM
+// before escalating to SIGKILL.
+const killGracePeriod = 2 * time.Second
+
+// mirrorInterval throttles how often scrollback is mirrored to disk.
+const mirrorInterval = 500 * time.Millisecond
+
+// Backend manages headless sessions owned by the current process.
+// The zero value is not usable; create one with New.
+type Backend struct {
+	mu         sync.Mutex
+	sessions   map[string]*Session
+	dir        string
+	scrollback int
+}
+
+// Session is a single supervised headless process.
+type Session struct {
+	Name    string
+	WorkDir string
+	Command string
+	Created time.Time
+
+	cmd  *exec.Cmd
+	conn io.ReadWriteCloser
+	ring *Ring
+
+	mu       sync.Mutex
+	env      map[string]string
+	activity time.Time
+	mirrored time.Time
+	flush    *time.Timer // Pending trailing mirror write, if any
+	exitErr  error
+	done     chan struct{}
+}
+
+// New creates a headless backend. If dir is non-empty, each session's
+// scrollback is mirrored to <dir>/<name>.log so that other processes
+// (e.g., gt peek) can read it with CaptureFile.
+func New(dir string) *Backend {
+	return &Backend{
+		sessions:   make(map[string]*Session),
+		dir:        dir,
+		scrollback: DefaultScrollbackBytes,
+	}
+}
+
+// SetScrollback sets the ring buffer capacity for sessions created afterwards.
+func (b *Backend) SetScrollback(bytes int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.scrollback = bytes
+}
+
+// NewSessionWithCommand starts command in workDir as a new headless session.
+// Like tmux, the command is run through the platform shell.
+func (b *Backend) NewSessionWithCommand(name, workDir, command string) error {
+	if name == "" {
+		return fmt.Errorf("session name is required")
+	}
+
+	b.mu.Lock()
+	if s, ok := b.sessions[name]; ok {
+		if s.alive() {
+			b.mu.Unlock()
+			return fmt.Errorf("%w: %s", ErrSessionExists, name)
+		}
+		// Replace a dead session, as tmux would after the pane exits.
+		delete(b.sessions, name)
+	}
+	capacity := b.scrollback
+	b.mu.Unlock()
+
+	cmd := shellCommand(command)
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+
+	conn, err := startProcess(cmd)
+	if err != nil {
+		return fmt.Errorf("starting headless session %s: %w", name, err)
+	}
+
+	now := time.Now()
+	s := &Session{
+		Name:     name,
+		WorkDir:  workDir,
+		Command:  command,
+		Created:  now,
+		cmd:      cmd,
+		conn:     conn,
+		ring:     NewRing(capacity),
+		env:      make(map[string]string),
+		activity: now,
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.sessions[name] = s
+	b.mu.Unlock()
+
+	go b.pump(s)
+	go func() {
+		err := cmd.Wait()
+		s.mu.Lock()
+		s.exitErr = err
+		s.mu.Unlock()
+		close(s.done)
+	}()
+	return nil
+}
+
+// pump copies session output into the scrollback ring until EOF.
+func (b *Backend) pump(s *Session) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := s.conn.Read(buf)
+		if n > 0 {
+			_, _ = s.ring.Write(buf[:n])
+			s.touch()
+			b.mirror(s, false)
+		}
+		if err != nil {
+			b.mirror(s, true)
+			return
+		}
+	}
+}
+
+// mirror writes the session's scrollback to disk when a mirror dir is set.
+// Writes are throttled to mirrorInterval unless force is true; a throttled
+// write schedules a trailing flush so the last burst of output still lands.
+func (b *Backend) mirror(s *Session, force bool) {
+	if b.dir == "" {
+		return
+	}
+	s.mu.Lock()
+	if since := time.Since(s.mirrored); !force && since < mirrorInterval {
+		if s.flush == nil {
+			s.flush = time.AfterFunc(mirrorInterval-since, func() {
+				s.mu.Lock()
+				s.flush = nil
+				s.mu.Unlock()
+				b.mirror(s, true)
+			})
+		}
+		s.mu.Unlock()
+		return
+	}
+	s.mirrored = time.Now()
+	s.mu.Unlock()
+
+	if err := os.MkdirAll(b.dir, 0755); err != nil {
+		return
+	}
+	path := mirrorPath(b.dir, s.Name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, s.ring.Bytes(), 0644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, path)
+}
+
+func (b *Backend) get(name string) (*Session, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.sessions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, name)
+	}
+	return s, nil
+}
+
+// HasSession reports whether a session exists and its process is running.
+func (b *Backend) HasSession(name string) (bool, error) {
+	s, err := b.get(name)
+	if err != nil {
+		return false, nil
+	}
+	return s.alive(), nil
+}
+
+// ListSessions returns the names of all running sessions, sorted.
+func (b *Backend) ListSessions() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var names []string
+	for name, s := range b.sessions {
+		if s.alive() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// KillSession is an alias for KillSessionWithProcesses. Headless sessions
+// always own their whole process group, so there is no lighter variant.
+func (b *Backend) KillSession(name string) error {
+	return b.KillSessionWithProcesses(name)
+}
+
+// KillSessionWithProcesses terminates the session's process group, escalating
+// to SIGKILL after a grace period, and forgets the session.
+func (b *Backend) KillSessionWithProcesses(name string) error {
+	s, err := b.get(name)
+	if err != nil {
+		return err
+	}
+
+	if s.alive() {
+		pid := s.cmd.Process.Pid
+		terminateGroup(pid)
+		select {
+		case <-s.done:
+		case <-time.After(killGracePeriod):
+			killGroup(pid)
+			<-s.done
+		}
+	}
+	_ = s.conn.Close()
+
+	b.mu.Lock()
+	if b.sessions[name] == s {
+		delete(b.sessions, name)
+	}
+	b.mu.Unlock()
+	return nil
+}
+
+// SendKeysRaw sends tmux-style key names (e.g., "Enter", "C-c", "Escape")
+// or literal text to the session without appending Enter.
+func (b *Backend) SendKeysRaw(session, keys string) error {
+	s, err := b.get(session)
+	if err != nil {
+		return err
+	}
+	if keys == "C-c" && !ptyAvailable {
+		// Without a terminal there is no line discipline to turn ^C into
+		// SIGINT, so deliver the signal directly.
+		interruptGroup(s.cmd.Process.Pid)
+		return nil
+	}
+	return s.write(translateKeys(keys))
+}
+
+// SendKeys sends literal text followed by Enter.
+func (b *Backend) SendKeys(session, keys string) error {
+	s, err := b.get(session)
+	if err != nil {
+		return err
+	}
+	return s.write(keys + "\r")
+}
+
+// NudgeSession delivers a message to the agent as typed input followed by
+// Enter. Headless sessions have a single writer, so no nudge lock is needed.
+func (b *Backend) NudgeSession(session, message string) error {
+	return b.SendKeys(session, message)
+}
+
+// CapturePane returns the last lines of session output.
+func (b *Backend) CapturePane(session string, lines int) (string, error) {
+	s, err := b.get(session)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(s.ring.Lines(lines), "\n"), nil
+}
+
+// CapturePaneAll returns all buffered session output.
+func (b *Backend) CapturePaneAll(session string) (string, error) {
+	return b.CapturePane(session, 0)
+}
+
+// SetEnvironment records a session environment variable. As with tmux, it
+// affects processes started after the call (e.g., respawns), not the running
+// agent.
+func (b *Backend) SetEnvironment(session, key, value string) error {
+	s, err := b.get(session)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.env[key] = value
+	return nil
+}
+
+// GetEnvironment returns a session environment variable set via SetEnvironment.
+func (b *Backend) GetEnvironment(session, key string) (string, error) {
+	s, err := b.get(session)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.env[key]
+	if !ok {
+		return "", fmt.Errorf("unknown variable: %s", key)
+	}
+	return v, nil
+}
+
+// IsAgentAlive reports whether the session's process is still running.
+func (b *Backend) IsAgentAlive(session string) bool {
+	s, err := b.get(session)
+	if err != nil {
+		return false
+	}
+	return s.alive()
+}
+
+// GetSessionActivity returns the time of the last output or input.
+func (b *Backend) GetSessionActivity(session string) (time.Time, error) {
+	s, err := b.get(session)
+	if err != nil {
+		return time.Time{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activity, nil
+}
+
+// ExitErr returns the process exit error once the session has exited.
+func (b *Backend) ExitErr(session string) (exited bool, err error) {
+	s, gerr := b.get(session)
+	if gerr != nil {
+		return false, gerr
+	}
+	select {
+	case <-s.done:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return true, s.exitErr
+	default:
+		return false, nil
+	}
+}
+
+// Close kills every session owned by the backend.
+func (b *Backend) Close() {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.sessions))
+	for name := range b.sessions {
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+	for _, name := range names {
+		_ = b.KillSessionWithProcesses(name)
+	}
+}
+
+func (s *Session) alive() bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (s *Session) touch() {
+	s.mu.Lock()
+	s.activity = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *Session) write(data string) error {
+	if !s.alive() {
+		return fmt.Errorf("session %s has exited", s.Name)
+	}
+	if _, err := io.WriteString(s.conn, data); err != nil {
+		return fmt.Errorf("writing to session %s: %w", s.Name, err)
+	}
+	s.touch()
+	return nil
+}
+
+// keyNames maps tmux key names to the bytes a terminal would send.
+var keyNames = map[string]string{
+	"Enter":  "\r",
+	"C-m":    "\r",
+	"Escape": "\x1b",
+	"C-c":    "\x03",
+	"C-d":    "\x04",
+	"C-u":    "\x15",
+	"Tab":    "\t",
+	"BSpace": "\x7f",
+	"Up":     "\x1b[A",
+	"Down":   "\x1b[B",
+	"Right":  "\x1b[C",
+	"Left":   "\x1b[D",
+}
+
+// translateKeys converts a space-separated list of tmux key names into raw
+// input. Tokens that are not key names are sent literally.
+func translateKeys(keys string) string {
+	fields := strings.Fields(keys)
+	if len(fields) == 0 {
+		return keys
+	}
+	for _, f := range fields {
+		if _, ok := keyNames[f]; !ok {
+			return keys
+		}
+	}
+	var sb strings.Builder
+	for _, f := range fields {
+		sb.WriteString(keyNames[f])
+	}
+	return sb.String()
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if goruntime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	return exec.Command(shell, "-c", command)
+}
+
+func mirrorPath(dir, name string) string {
+	return filepath.Join(dir, name+".log")
+}
+
+// CaptureFile reads the last lines of a session's mirrored scrollback (or a
+// non-interactive run log) from dir. It lets processes other than the owning
+// daemon peek at headless sessions. Only the last DefaultScrollbackBytes of
+// the file are considered.
+func CaptureFile(dir, name string, lines int) (string, error) {
+	f, err := os.Open(mirrorPath(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrSessionNotFound, name)
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	r := NewRing(DefaultScrollbackBytes)
+	if _, err := io.Copy(r, f); err != nil {
+		return "", err
+	}
+	return strings.Join(r.Lines(lines), "\n"), nil
+}
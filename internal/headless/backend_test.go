@@ -0,0 +1,160 @@
+//go:build !windows
+
+package headless
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("condition not met within %v", timeout)
+}
+
+func TestBackendCaptureOutput(t *testing.T) {
+	b := New("")
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-echo", t.TempDir(), "echo hello-headless; sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+
+	waitFor(t, 5*time.Second, func() bool {
+		out, _ := b.CapturePane("hl-echo", 10)
+		return strings.Contains(out, "hello-headless")
+	})
+
+	if ok, _ := b.HasSession("hl-echo"); !ok {
+		t.Error("HasSession() = false for running session")
+	}
+	if !b.IsAgentAlive("hl-echo") {
+		t.Error("IsAgentAlive() = false for running session")
+	}
+	names, _ := b.ListSessions()
+	if len(names) != 1 || names[0] != "hl-echo" {
+		t.Errorf("ListSessions() = %v", names)
+	}
+}
+
+func TestBackendDuplicateSession(t *testing.T) {
+	b := New("")
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-dup", t.TempDir(), "sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	err := b.NewSessionWithCommand("hl-dup", t.TempDir(), "sleep 5")
+	if !errors.Is(err, ErrSessionExists) {
+		t.Errorf("second create error = %v, want ErrSessionExists", err)
+	}
+}
+
+func TestBackendNudgeReachesProcess(t *testing.T) {
+	b := New("")
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-read", t.TempDir(), "read line; echo got:$line; sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	if err := b.NudgeSession("hl-read", "ping"); err != nil {
+		t.Fatalf("NudgeSession: %v", err)
+	}
+
+	waitFor(t, 5*time.Second, func() bool {
+		out, _ := b.CapturePane("hl-read", 10)
+		return strings.Contains(out, "got:ping")
+	})
+}
+
+func TestBackendKillAndExit(t *testing.T) {
+	b := New("")
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-kill", t.TempDir(), "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	if err := b.KillSessionWithProcesses("hl-kill"); err != nil {
+		t.Fatalf("KillSessionWithProcesses: %v", err)
+	}
+	if ok, _ := b.HasSession("hl-kill"); ok {
+		t.Error("HasSession() = true after kill")
+	}
+	if _, err := b.CapturePane("hl-kill", 10); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("CapturePane after kill error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestBackendEnvironment(t *testing.T) {
+	b := New("")
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-env", t.TempDir(), "sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	if err := b.SetEnvironment("hl-env", "GT_ROLE", "polecat"); err != nil {
+		t.Fatalf("SetEnvironment: %v", err)
+	}
+	if v, err := b.GetEnvironment("hl-env", "GT_ROLE"); err != nil || v != "polecat" {
+		t.Errorf("GetEnvironment() = %q, %v", v, err)
+	}
+	if _, err := b.GetEnvironment("hl-env", "MISSING"); err == nil {
+		t.Error("GetEnvironment(MISSING) should error")
+	}
+}
+
+func TestCaptureFileFromMirror(t *testing.T) {
+	dir := t.TempDir()
+	b := New(dir)
+	defer b.Close()
+
+	if err := b.NewSessionWithCommand("hl-mirror", t.TempDir(), "echo mirrored-output"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+
+	waitFor(t, 5*time.Second, func() bool {
+		out, err := CaptureFile(dir, "hl-mirror", 10)
+		return err == nil && strings.Contains(out, "mirrored-output")
+	})
+}
+
+func TestMirrorFlushesTrailingOutput(t *testing.T) {
+	dir := t.TempDir()
+	b := New(dir)
+	defer b.Close()
+
+	// The second line arrives inside the throttle window while the process
+	// keeps running, so only the trailing flush can mirror it.
+	if err := b.NewSessionWithCommand("hl-trailing", t.TempDir(), "echo first-line; sleep 0.1; echo trailing-line; sleep 5"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+
+	waitFor(t, 5*time.Second, func() bool {
+		out, err := CaptureFile(dir, "hl-trailing", 10)
+		return err == nil && strings.Contains(out, "trailing-line")
+	})
+}
+
+func TestTranslateKeys(t *testing.T) {
+	tests := map[string]string{
+		"Enter":       "\r",
+		"C-c":         "\x03",
+		"Escape Down": "\x1b\x1b[B",
+		"hello world": "hello world",
+		"Enter nope":  "Enter nope",
+	}
+	for in, want := range tests {
+		if got := translateKeys(in); got != want {
+			t.Errorf("translateKeys(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
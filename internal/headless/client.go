@@ -0,0 +1,278 @@
+package headless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// hostStartTimeout bounds how long NewSessionWithCommand waits for a new
+// host to record its session.
+const hostStartTimeout = 5 * time.Second
+
+// Client operates headless sessions hosted by other processes, through
+// their files in a shared directory. Every gt process (daemon, gt sling,
+// gt nudge, ...) can use one to reach the same sessions.
+//
+// New sessions are started as detached hosts (see Host) by running the
+// host command followed by --dir, --workdir, the session name and the
+// command, so they keep running when the process that started them exits.
+type Client struct {
+	dir      string
+	hostArgv []string
+}
+
+// NewClient returns a client for the sessions hosted in dir. hostArgv is
+// the command that runs Host for a new session.
+func NewClient(dir string, hostArgv ...string) *Client {
+	return &Client{dir: dir, hostArgv: hostArgv}
+}
+
+// NewSessionWithCommand starts a detached host running command in workDir
+// and waits for it to record the session.
+func (c *Client) NewSessionWithCommand(name, workDir, command string) error {
+	if name == "" {
+		return fmt.Errorf("session name is required")
+	}
+	if len(c.hostArgv) == 0 {
+		return fmt.Errorf("no host command for headless session %s", name)
+	}
+	if RunAlive(c.dir, name) {
+		return fmt.Errorf("%w: %s", ErrSessionExists, name)
+	}
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("creating session dir: %w", err)
+	}
+	_ = os.Remove(envPath(c.dir, name))
+	return c.startHost(name, workDir, command)
+}
+
+// startHost runs a detached host for the session and waits for it to
+// record the session.
+func (c *Client) startHost(name, workDir, command string) error {
+	args := append(append([]string{}, c.hostArgv[1:]...), "--dir", c.dir, "--workdir", workDir, "--", name, command)
+	host := exec.Command(c.hostArgv[0], args...) //nolint:gosec // G204: our own binary
+	host.Dir = workDir
+	host.Env = os.Environ()
+	detachProcess(host)
+	if err := host.Start(); err != nil {
+		return fmt.Errorf("starting headless host for %s: %w", name, err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- host.Wait() }()
+
+	deadline := time.After(hostStartTimeout)
+	for {
+		if s, err := ReadRunStatus(c.dir, name); err == nil && s != nil && s.SupervisorPID == host.Process.Pid {
+			return nil
+		}
+		select {
+		case err := <-exited:
+			return fmt.Errorf("headless host for %s exited during startup: %v", name, err)
+		case <-deadline:
+			return fmt.Errorf("headless host for %s did not start within %v", name, hostStartTimeout)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// HasSession reports whether a hosted session (or non-interactive run)
+// is in progress.
+func (c *Client) HasSession(name string) (bool, error) {
+	return RunAlive(c.dir, name), nil
+}
+
+// ListSessions returns the names of all sessions in progress, sorted.
+func (c *Client) ListSessions() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if RunAlive(c.dir, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// KillSession is an alias for KillSessionWithProcesses.
+func (c *Client) KillSession(name string) error {
+	return c.KillSessionWithProcesses(name)
+}
+
+// KillSessionWithProcesses stops the session's host, which terminates the
+// agent's process group and records the exit. It returns once the host is
+// gone, so a new session of the same name can't be overwritten by the old
+// host's final status.
+func (c *Client) KillSessionWithProcesses(name string) error {
+	s, err := ReadRunStatus(c.dir, name)
+	if err != nil {
+		return err
+	}
+	if err := StopRun(c.dir, name); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(2 * killGracePeriod)
+	for s.SupervisorPID > 0 && processAlive(s.SupervisorPID) && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil
+}
+
+// RespawnSession restarts the session on command, in the same working
+// directory and keeping its environment table, like tmux respawn-pane.
+// The session may be running or already exited.
+func (c *Client) RespawnSession(name, command string) error {
+	s, err := ReadRunStatus(c.dir, name)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, name)
+	}
+	if s.Running() {
+		if err := c.KillSessionWithProcesses(name); err != nil {
+			return fmt.Errorf("stopping %s: %w", name, err)
+		}
+	}
+	return c.startHost(name, s.WorkDir, command)
+}
+
+// SendKeysRaw sends tmux-style key names or literal text without Enter.
+func (c *Client) SendKeysRaw(session, keys string) error {
+	return c.send(session, translateKeys(keys))
+}
+
+// SendKeys sends literal text followed by Enter.
+func (c *Client) SendKeys(session, keys string) error {
+	return c.send(session, keys+"\r")
+}
+
+// NudgeSession delivers a message to the agent as typed input followed by
+// Enter. Each message is a single append, so concurrent nudges don't
+// interleave.
+func (c *Client) NudgeSession(session, message string) error {
+	return c.SendKeys(session, message)
+}
+
+// send appends input for the session's host to type into the agent.
+func (c *Client) send(session, data string) error {
+	s, err := ReadRunStatus(c.dir, session)
+	if err != nil {
+		return err
+	}
+	if !s.Running() {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+	if !s.Interactive {
+		return fmt.Errorf("session %s is a non-interactive run and takes no input", session)
+	}
+	f, err := os.OpenFile(InputPath(c.dir, session), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("opening input for %s: %w", session, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		return fmt.Errorf("writing to session %s: %w", session, err)
+	}
+	return nil
+}
+
+// CapturePane returns the last lines of the session's mirrored scrollback.
+func (c *Client) CapturePane(session string, lines int) (string, error) {
+	return CaptureFile(c.dir, session, lines)
+}
+
+// CapturePaneAll returns all mirrored session output.
+func (c *Client) CapturePaneAll(session string) (string, error) {
+	return c.CapturePane(session, 0)
+}
+
+// SetEnvironment records a session environment variable next to the
+// session's other files. As with tmux, it does not affect the running agent.
+func (c *Client) SetEnvironment(session, key, value string) error {
+	if !RunAlive(c.dir, session) {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+	env, err := c.readEnv(session)
+	if err != nil {
+		return err
+	}
+	env[key] = value
+	return util.AtomicWriteJSON(envPath(c.dir, session), env)
+}
+
+// GetEnvironment returns a session environment variable set via SetEnvironment.
+func (c *Client) GetEnvironment(session, key string) (string, error) {
+	env, err := c.readEnv(session)
+	if err != nil {
+		return "", err
+	}
+	v, ok := env[key]
+	if !ok {
+		return "", fmt.Errorf("unknown variable: %s", key)
+	}
+	return v, nil
+}
+
+func (c *Client) readEnv(session string) (map[string]string, error) {
+	env := make(map[string]string)
+	data, err := os.ReadFile(envPath(c.dir, session))
+	if errors.Is(err, os.ErrNotExist) {
+		return env, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parsing environment of %s: %w", session, err)
+	}
+	return env, nil
+}
+
+// GetPaneWorkDir returns the working directory the session was started in.
+func (c *Client) GetPaneWorkDir(session string) (string, error) {
+	s, err := ReadRunStatus(c.dir, session)
+	if err != nil {
+		return "", err
+	}
+	if s == nil {
+		return "", fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+	return s.WorkDir, nil
+}
+
+// IsAgentAlive reports whether the session's agent process is running.
+func (c *Client) IsAgentAlive(session string) bool {
+	return RunAlive(c.dir, session)
+}
+
+// GetSessionActivity returns the time of the last mirrored output or input.
+func (c *Client) GetSessionActivity(session string) (time.Time, error) {
+	s, err := ReadRunStatus(c.dir, session)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s == nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+	last := s.StartedAt
+	for _, path := range []string{LogPath(c.dir, session), InputPath(c.dir, session)} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
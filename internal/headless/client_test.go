@@ -0,0 +1,175 @@
+//go:build !windows
+
+package headless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// testHostEnv makes the test binary act as a session host, standing in for
+// the gt command that serves Host.
+const testHostEnv = "GT_TEST_HEADLESS_HOST"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testHostEnv) == "1" {
+		os.Exit(runTestHost(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+// runTestHost parses the arguments Client passes to its host command:
+// --dir <dir> --workdir <dir> -- <session> <command>.
+func runTestHost(args []string) int {
+	var opts HostOptions
+	for len(args) > 0 {
+		switch args[0] {
+		case "--dir":
+			opts.Dir, args = args[1], args[2:]
+		case "--workdir":
+			opts.WorkDir, args = args[1], args[2:]
+		case "--":
+			opts.Session, opts.Command, args = args[1], args[2], nil
+		default:
+			args = args[1:]
+		}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	if _, err := Host(ctx, opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	t.Setenv(testHostEnv, "1")
+	return NewClient(t.TempDir(), os.Args[0])
+}
+
+func TestClientHostedSession(t *testing.T) {
+	c := newTestClient(t)
+	workDir := t.TempDir()
+	if err := c.NewSessionWithCommand("hl-hosted", workDir, "cat"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	t.Cleanup(func() { _ = c.KillSessionWithProcesses("hl-hosted") })
+
+	// A second client, as another gt process would use, sees the session.
+	other := NewClient(c.dir)
+	if ok, _ := other.HasSession("hl-hosted"); !ok {
+		t.Fatal("HasSession() = false from another client")
+	}
+	if names, _ := other.ListSessions(); len(names) != 1 || names[0] != "hl-hosted" {
+		t.Errorf("ListSessions() = %v, want [hl-hosted]", names)
+	}
+	if err := c.NewSessionWithCommand("hl-hosted", t.TempDir(), "cat"); !errors.Is(err, ErrSessionExists) {
+		t.Errorf("second create error = %v, want ErrSessionExists", err)
+	}
+
+	if err := other.NudgeSession("hl-hosted", "ping-from-afar"); err != nil {
+		t.Fatalf("NudgeSession: %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool {
+		out, _ := other.CapturePane("hl-hosted", 10)
+		return strings.Contains(out, "ping-from-afar")
+	})
+
+	if wd, err := other.GetPaneWorkDir("hl-hosted"); err != nil || wd != workDir {
+		t.Errorf("GetPaneWorkDir = %q, %v; want %q", wd, err, workDir)
+	}
+
+	if err := other.SetEnvironment("hl-hosted", "GT_AGENT", "codex"); err != nil {
+		t.Fatalf("SetEnvironment: %v", err)
+	}
+	if v, err := c.GetEnvironment("hl-hosted", "GT_AGENT"); err != nil || v != "codex" {
+		t.Errorf("GetEnvironment = %q, %v; want codex", v, err)
+	}
+
+	if err := other.KillSessionWithProcesses("hl-hosted"); err != nil {
+		t.Fatalf("KillSessionWithProcesses: %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool {
+		s, _ := ReadRunStatus(c.dir, "hl-hosted")
+		return s != nil && s.ExitedAt != nil
+	})
+	if c.IsAgentAlive("hl-hosted") {
+		t.Error("IsAgentAlive() = true after kill")
+	}
+}
+
+func TestClientSessionOutlivesStarter(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.NewSessionWithCommand("hl-detached", t.TempDir(), "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	t.Cleanup(func() { _ = c.KillSessionWithProcesses("hl-detached") })
+
+	// The host runs in its own session, so signals aimed at the starter's
+	// process group (a daemon being stopped) don't reach it.
+	s, err := ReadRunStatus(c.dir, "hl-detached")
+	if err != nil || s == nil {
+		t.Fatalf("ReadRunStatus: %v, %v", s, err)
+	}
+	sid, err := unix.Getsid(s.SupervisorPID)
+	if err != nil {
+		t.Fatalf("Getsid: %v", err)
+	}
+	if sid != s.SupervisorPID {
+		t.Errorf("host session ID = %d, want its own (%d)", sid, s.SupervisorPID)
+	}
+}
+
+func TestClientRespawnSession(t *testing.T) {
+	c := newTestClient(t)
+	workDir := t.TempDir()
+	if err := c.NewSessionWithCommand("hl-respawn", workDir, "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	t.Cleanup(func() { _ = c.KillSessionWithProcesses("hl-respawn") })
+	before, _ := ReadRunStatus(c.dir, "hl-respawn")
+	if err := c.SetEnvironment("hl-respawn", "GT_AGENT", "codex"); err != nil {
+		t.Fatalf("SetEnvironment: %v", err)
+	}
+
+	if err := c.RespawnSession("hl-respawn", "cat"); err != nil {
+		t.Fatalf("RespawnSession: %v", err)
+	}
+	after, _ := ReadRunStatus(c.dir, "hl-respawn")
+	if after == nil || !after.Running() || after.PID == before.PID {
+		t.Fatalf("status after respawn = %+v, want a new running agent", after)
+	}
+	if after.WorkDir != workDir {
+		t.Errorf("WorkDir = %q, want %q", after.WorkDir, workDir)
+	}
+	if v, err := c.GetEnvironment("hl-respawn", "GT_AGENT"); err != nil || v != "codex" {
+		t.Errorf("GetEnvironment after respawn = %q, %v; want codex", v, err)
+	}
+
+	// The old host is gone and can't overwrite the new session's status.
+	time.Sleep(2 * inputPollInterval)
+	if !c.IsAgentAlive("hl-respawn") {
+		t.Error("IsAgentAlive() = false after respawn")
+	}
+}
+
+func TestClientRejectsInputToRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := writeRunStatus(dir, &RunStatus{Session: "hl-run", PID: os.Getpid()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewClient(dir).NudgeSession("hl-run", "hi"); err == nil {
+		t.Error("NudgeSession to a non-interactive run should fail")
+	}
+}
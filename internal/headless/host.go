@@ -0,0 +1,132 @@
+package headless
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// inputPollInterval is how often a host checks its input file.
+const inputPollInterval = 100 * time.Millisecond
+
+// HostOptions configures a hosted interactive session.
+type HostOptions struct {
+	// Dir holds the session's scrollback mirror, status and input files.
+	Dir string
+	// Session names the session; files are <Dir>/<Session>.log, .json and .in.
+	Session string
+	// WorkDir is the working directory for the command.
+	WorkDir string
+	// Command is run through the platform shell, as tmux would.
+	Command string
+}
+
+// InputPath returns the path of a hosted session's input file. Other
+// processes append keystrokes to it and the host feeds them to the agent.
+func InputPath(dir, session string) string {
+	return filepath.Join(dir, session+".in")
+}
+
+// envPath returns the path of a hosted session's environment table.
+func envPath(dir, session string) string {
+	return filepath.Join(dir, session+".env")
+}
+
+// Host runs an interactive session and serves it to other processes until
+// the command exits or ctx is cancelled. Scrollback is mirrored to the run
+// log, the status file records the agent and host PIDs, and input appended
+// to InputPath is typed into the session. Host is meant to run in its own
+// detached process (see Client), so sessions outlive whoever started them,
+// the daemon included.
+func Host(ctx context.Context, opts HostOptions) (*RunStatus, error) {
+	if opts.Session == "" || opts.Command == "" {
+		return nil, fmt.Errorf("session and command are required")
+	}
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating session dir: %w", err)
+	}
+	if RunAlive(opts.Dir, opts.Session) {
+		return nil, fmt.Errorf("%w: %s", ErrSessionExists, opts.Session)
+	}
+	// Input left over from a previous session must not reach this one.
+	if err := os.WriteFile(InputPath(opts.Dir, opts.Session), nil, 0644); err != nil {
+		return nil, fmt.Errorf("creating input file: %w", err)
+	}
+
+	b := New(opts.Dir)
+	if err := b.NewSessionWithCommand(opts.Session, opts.WorkDir, opts.Command); err != nil {
+		return nil, err
+	}
+	s, err := b.get(opts.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &RunStatus{
+		Session:       opts.Session,
+		PID:           s.cmd.Process.Pid,
+		SupervisorPID: os.Getpid(),
+		Argv:          s.cmd.Args,
+		WorkDir:       opts.WorkDir,
+		StartedAt:     s.Created.UTC(),
+		Interactive:   true,
+	}
+	if err := writeRunStatus(opts.Dir, status); err != nil {
+		_ = b.KillSessionWithProcesses(opts.Session)
+		return nil, err
+	}
+
+	ticker := time.NewTicker(inputPollInterval)
+	defer ticker.Stop()
+	var offset int64
+	for alive := true; alive; {
+		select {
+		case <-s.done:
+			alive = false
+		case <-ctx.Done():
+			_ = b.KillSessionWithProcesses(opts.Session)
+			alive = false
+		case <-ticker.C:
+			offset = feedInput(s, InputPath(opts.Dir, opts.Session), offset)
+		}
+	}
+
+	<-s.done
+	s.mu.Lock()
+	waitErr := s.exitErr
+	s.mu.Unlock()
+	status.recordExit(waitErr)
+	return status, writeRunStatus(opts.Dir, status)
+}
+
+// feedInput types whatever was appended to the input file since offset
+// into the session and returns the new offset. Without a terminal, ^C is
+// delivered as SIGINT, as SendKeysRaw does.
+func feedInput(s *Session, path string, offset int64) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset
+	}
+	data, err := io.ReadAll(f)
+	if err != nil || len(data) == 0 {
+		return offset
+	}
+	offset += int64(len(data))
+
+	if !ptyAvailable && bytes.IndexByte(data, 0x03) >= 0 {
+		interruptGroup(s.cmd.Process.Pid)
+		data = bytes.ReplaceAll(data, []byte{0x03}, nil)
+	}
+	if len(data) > 0 {
+		_ = s.write(string(data))
+	}
+	return offset
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+
+package headless
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup places the child in its own process group so the whole
+// tree can be signalled at once.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// detachProcess starts cmd in its own session, so it survives the process
+// that launched it (and that process's signals).
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// signalGroup sends sig to the process group led by pid, falling back to the
+// process itself.
+func signalGroup(pid int, sig syscall.Signal) {
+	if err := syscall.Kill(-pid, sig); err != nil {
+		_ = syscall.Kill(pid, sig)
+	}
+}
+
+func terminateGroup(pid int) { signalGroup(pid, syscall.SIGTERM) }
+func killGroup(pid int)      { signalGroup(pid, syscall.SIGKILL) }
+func interruptGroup(pid int) { signalGroup(pid, syscall.SIGINT) }
+
+// processAlive reports whether a process with pid exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// terminateProcess sends SIGTERM to a single process.
+func terminateProcess(pid int) { _ = syscall.Kill(pid, syscall.SIGTERM) }
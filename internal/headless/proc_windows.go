@@ -0,0 +1,39 @@
+//go:build windows
+
+package headless
+
+import (
+	"os"
+	"os/exec"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// detachProcess is a no-op on Windows: the child already runs
+// independently of its parent.
+func detachProcess(cmd *exec.Cmd) {}
+
+func killPID(pid int) {
+	if p, err := os.FindProcess(pid); err == nil {
+		_ = p.Kill()
+	}
+}
+
+func terminateGroup(pid int) { killPID(pid) }
+func killGroup(pid int)      { killPID(pid) }
+func interruptGroup(pid int) { killPID(pid) }
+
+// processAlive reports whether a process with pid exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
+
+func terminateProcess(pid int) { killPID(pid) }
@@ -0,0 +1,58 @@
+//go:build !linux
+
+package headless
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// pipeConn joins the child's stdin and combined stdout/stderr pipes into a
+// single ReadWriteCloser.
+type pipeConn struct {
+	r *os.File
+	w *os.File
+}
+
+func (p *pipeConn) Read(b []byte) (int, error)  { return p.r.Read(b) }
+func (p *pipeConn) Write(b []byte) (int, error) { return p.w.Write(b) }
+
+func (p *pipeConn) Close() error {
+	_ = p.w.Close()
+	return p.r.Close()
+}
+
+// ptyAvailable reports whether sessions get a real terminal.
+const ptyAvailable = false
+
+// startProcess starts cmd with plain pipes. PTY allocation is only
+// implemented on Linux; elsewhere agents must run in a non-interactive mode
+// that does not require a terminal.
+func startProcess(cmd *exec.Cmd) (io.ReadWriteCloser, error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		_ = outR.Close()
+		_ = outW.Close()
+		return nil, err
+	}
+
+	cmd.Stdin = inR
+	cmd.Stdout = outW
+	cmd.Stderr = outW
+	setProcessGroup(cmd)
+
+	err = cmd.Start()
+	_ = inR.Close()
+	_ = outW.Close()
+	if err != nil {
+		_ = outR.Close()
+		_ = inW.Close()
+		return nil, err
+	}
+	return &pipeConn{r: outR, w: inW}, nil
+}
@@ -0,0 +1,101 @@
+package headless
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultScrollbackBytes is the default ring buffer capacity per session.
+// Sized to hold a few thousand lines of typical agent output, comparable to
+// tmux's default history-limit.
+const DefaultScrollbackBytes = 512 * 1024
+
+// Ring is a fixed-capacity byte ring buffer that retains the most recent
+// output of a session. Writes never block or fail; once full, the oldest
+// bytes are overwritten. Safe for concurrent use.
+type Ring struct {
+	mu    sync.Mutex
+	buf   []byte
+	start int   // index of the oldest byte
+	size  int   // number of valid bytes
+	total int64 // total bytes ever written
+}
+
+// NewRing creates a ring buffer holding at most capacity bytes.
+// A non-positive capacity uses DefaultScrollbackBytes.
+func NewRing(capacity int) *Ring {
+	if capacity <= 0 {
+		capacity = DefaultScrollbackBytes
+	}
+	return &Ring{buf: make([]byte, capacity)}
+}
+
+// Write appends p to the ring, discarding the oldest bytes if necessary.
+// It always reports len(p) bytes written.
+func (r *Ring) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(p)
+	r.total += int64(n)
+	capacity := len(r.buf)
+
+	// Only the tail of an oversized write can survive.
+	if n >= capacity {
+		copy(r.buf, p[n-capacity:])
+		r.start = 0
+		r.size = capacity
+		return n, nil
+	}
+
+	end := (r.start + r.size) % capacity
+	first := copy(r.buf[end:], p)
+	copy(r.buf, p[first:])
+
+	r.size += n
+	if r.size > capacity {
+		r.start = (r.start + r.size - capacity) % capacity
+		r.size = capacity
+	}
+	return n, nil
+}
+
+// Bytes returns a copy of the buffered contents, oldest first.
+func (r *Ring) Bytes() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]byte, r.size)
+	first := copy(out, r.buf[r.start:min(r.start+r.size, len(r.buf))])
+	copy(out[first:], r.buf[:r.size-first])
+	return out
+}
+
+// Total returns the number of bytes ever written, including discarded ones.
+func (r *Ring) Total() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
+
+// Lines returns the last n lines of buffered output with trailing blank
+// lines trimmed, mirroring tmux capture-pane semantics. If n <= 0 all
+// buffered lines are returned. A partial first line (cut by wraparound)
+// is dropped once the ring has overflowed.
+func (r *Ring) Lines(n int) []string {
+	data := string(r.Bytes())
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "")
+
+	lines := strings.Split(data, "\n")
+	if r.Total() > int64(len(r.buf)) && len(lines) > 1 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
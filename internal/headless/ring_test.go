@@ -0,0 +1,61 @@
+package headless
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRingWriteWithinCapacity(t *testing.T) {
+	r := NewRing(16)
+	_, _ = r.Write([]byte("hello "))
+	_, _ = r.Write([]byte("world"))
+
+	if got := string(r.Bytes()); got != "hello world" {
+		t.Errorf("Bytes() = %q, want %q", got, "hello world")
+	}
+	if r.Total() != 11 {
+		t.Errorf("Total() = %d, want 11", r.Total())
+	}
+}
+
+func TestRingWraparound(t *testing.T) {
+	r := NewRing(8)
+	_, _ = r.Write([]byte("abcdef"))
+	_, _ = r.Write([]byte("ghij"))
+
+	if got := string(r.Bytes()); got != "cdefghij" {
+		t.Errorf("Bytes() = %q, want %q", got, "cdefghij")
+	}
+}
+
+func TestRingOversizedWrite(t *testing.T) {
+	r := NewRing(4)
+	_, _ = r.Write([]byte("0123456789"))
+
+	if got := string(r.Bytes()); got != "6789" {
+		t.Errorf("Bytes() = %q, want %q", got, "6789")
+	}
+}
+
+func TestRingLines(t *testing.T) {
+	r := NewRing(0)
+	_, _ = r.Write([]byte("one\r\ntwo\nthree\n\n\n"))
+
+	if got := r.Lines(0); !reflect.DeepEqual(got, []string{"one", "two", "three"}) {
+		t.Errorf("Lines(0) = %v", got)
+	}
+	if got := r.Lines(2); !reflect.DeepEqual(got, []string{"two", "three"}) {
+		t.Errorf("Lines(2) = %v", got)
+	}
+}
+
+func TestRingLinesDropsPartialLineAfterOverflow(t *testing.T) {
+	r := NewRing(14)
+	_, _ = r.Write([]byte(strings.Repeat("x", 10) + "\nline2\nline3\n"))
+
+	got := r.Lines(0)
+	if len(got) != 2 || got[0] != "line2" || got[1] != "line3" {
+		t.Errorf("Lines(0) = %v, want [line2 line3]", got)
+	}
+}
@@ -0,0 +1,215 @@
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// RunStatus records the lifecycle of a non-interactive agent run.
+// It is persisted next to the run's log so that other processes (witness
+// patrol, gt peek, gt polecat status) can observe a run they do not own.
+type RunStatus struct {
+	Session string `json:"session"`
+	PID     int    `json:"pid"`
+	// SupervisorPID is the process that called Run. Stopping it (rather
+	// than the agent) lets the supervisor record a deliberate shutdown.
+	SupervisorPID int        `json:"supervisor_pid"`
+	Argv          []string   `json:"argv"`
+	WorkDir       string     `json:"work_dir"`
+	StartedAt     time.Time  `json:"started_at"`
+	ExitedAt      *time.Time `json:"exited_at,omitempty"`
+	ExitCode      *int       `json:"exit_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	// Interactive is set for sessions served by Host, which feed
+	// <Session>.in to the agent as typed input.
+	Interactive bool `json:"interactive,omitempty"`
+}
+
+// Running reports whether the run has not yet recorded an exit and its
+// process is still alive. A run whose supervisor was killed before it
+// could record the exit is reported as not running.
+func (s *RunStatus) Running() bool {
+	return s != nil && s.ExitedAt == nil && processAlive(s.PID)
+}
+
+// Succeeded reports whether the run exited with status 0.
+func (s *RunStatus) Succeeded() bool {
+	return s != nil && s.ExitCode != nil && *s.ExitCode == 0
+}
+
+// RunOptions configures a non-interactive run.
+type RunOptions struct {
+	// Dir holds the run's log and status files.
+	Dir string
+	// Session names the run; files are <Dir>/<Session>.log and .json.
+	Session string
+	// WorkDir is the working directory for the agent.
+	WorkDir string
+	// Argv is the agent command line (not passed through a shell).
+	Argv []string
+	// Env is the complete process environment.
+	Env []string
+}
+
+// LogPath returns the path of a run's combined stdout/stderr log.
+// It is the same file the interactive backend mirrors scrollback to, so
+// CaptureFile works for both.
+func LogPath(dir, session string) string {
+	return mirrorPath(dir, session)
+}
+
+// StatusPath returns the path of a run's status file.
+func StatusPath(dir, session string) string {
+	return filepath.Join(dir, session+".json")
+}
+
+// ReadRunStatus loads the status of a run. Returns (nil, nil) if none exists.
+func ReadRunStatus(dir, session string) (*RunStatus, error) {
+	data, err := os.ReadFile(StatusPath(dir, session))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s RunStatus
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing run status: %w", err)
+	}
+	return &s, nil
+}
+
+// RunAlive reports whether a non-interactive run for session is in progress.
+func RunAlive(dir, session string) bool {
+	s, err := ReadRunStatus(dir, session)
+	return err == nil && s.Running()
+}
+
+// StopRun terminates an in-progress run owned by another process. It signals
+// the supervisor, which cancels the run; if the supervisor is gone, the
+// agent's process group is signalled directly. Blocks until the agent exits
+// or the kill grace period elapses.
+func StopRun(dir, session string) error {
+	s, err := ReadRunStatus(dir, session)
+	if err != nil {
+		return err
+	}
+	if !s.Running() {
+		return fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+
+	if s.SupervisorPID > 0 && s.SupervisorPID != os.Getpid() && processAlive(s.SupervisorPID) {
+		terminateProcess(s.SupervisorPID)
+	} else {
+		terminateGroup(s.PID)
+	}
+
+	deadline := time.Now().Add(2 * killGracePeriod)
+	for time.Now().Before(deadline) {
+		if !processAlive(s.PID) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	killGroup(s.PID)
+	return nil
+}
+
+// Run executes an agent non-interactively and blocks until it exits.
+// Stdout and stderr are appended to the run log, and the status file is
+// written at start and at exit. Cancelling ctx terminates the process group.
+// The returned status is always non-nil once the process has started; err
+// reports failures to start or to record status, not a non-zero exit.
+func Run(ctx context.Context, opts RunOptions) (*RunStatus, error) {
+	if opts.Session == "" || len(opts.Argv) == 0 {
+		return nil, fmt.Errorf("session and argv are required")
+	}
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating run dir: %w", err)
+	}
+	if RunAlive(opts.Dir, opts.Session) {
+		return nil, fmt.Errorf("%w: %s", ErrSessionExists, opts.Session)
+	}
+
+	logFile, err := os.OpenFile(LogPath(opts.Dir, opts.Session), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening run log: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(opts.Argv[0], opts.Argv[1:]...) //nolint:gosec // G204: argv from agent config
+	cmd.Dir = opts.WorkDir
+	cmd.Env = opts.Env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting %s: %w", opts.Argv[0], err)
+	}
+
+	status := &RunStatus{
+		Session:       opts.Session,
+		PID:           cmd.Process.Pid,
+		SupervisorPID: os.Getpid(),
+		Argv:          opts.Argv,
+		WorkDir:       opts.WorkDir,
+		StartedAt:     time.Now().UTC(),
+	}
+	if err := writeRunStatus(opts.Dir, status); err != nil {
+		terminateGroup(status.PID)
+		_ = cmd.Wait()
+		return nil, err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var waitErr error
+	select {
+	case waitErr = <-done:
+	case <-ctx.Done():
+		terminateGroup(status.PID)
+		select {
+		case waitErr = <-done:
+		case <-time.After(killGracePeriod):
+			killGroup(status.PID)
+			waitErr = <-done
+		}
+	}
+
+	status.recordExit(waitErr)
+	return status, writeRunStatus(opts.Dir, status)
+}
+
+// recordExit fills in the exit time and code from the process's wait error.
+func (s *RunStatus) recordExit(waitErr error) {
+	exitedAt := time.Now().UTC()
+	code := 0
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(waitErr, &exitErr) {
+			code = exitErr.ExitCode()
+		} else {
+			code = -1
+		}
+		s.Error = waitErr.Error()
+	}
+	s.ExitedAt = &exitedAt
+	s.ExitCode = &code
+}
+
+func writeRunStatus(dir string, s *RunStatus) error {
+	if err := util.AtomicWriteJSON(StatusPath(dir, s.Session), s); err != nil {
+		return fmt.Errorf("writing run status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+//go:build !windows
+
+package headless
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunRecordsExitAndLog(t *testing.T) {
+	dir := t.TempDir()
+
+	status, err := Run(context.Background(), RunOptions{
+		Dir:     dir,
+		Session: "gt-test-p-ok",
+		WorkDir: t.TempDir(),
+		Argv:    []string{"sh", "-c", "echo out; echo err >&2; exit 3"},
+		Env:     os.Environ(),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if status.ExitCode == nil || *status.ExitCode != 3 {
+		t.Errorf("ExitCode = %v, want 3", status.ExitCode)
+	}
+	if status.Succeeded() {
+		t.Error("Succeeded() = true for exit 3")
+	}
+
+	saved, err := ReadRunStatus(dir, "gt-test-p-ok")
+	if err != nil || saved == nil {
+		t.Fatalf("ReadRunStatus: %v, %v", saved, err)
+	}
+	if saved.Running() {
+		t.Error("Running() = true after exit")
+	}
+
+	out, err := CaptureFile(dir, "gt-test-p-ok", 10)
+	if err != nil {
+		t.Fatalf("CaptureFile: %v", err)
+	}
+	if !strings.Contains(out, "out") || !strings.Contains(out, "err") {
+		t.Errorf("log = %q, want stdout and stderr", out)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if RunAlive(dir, "gt-test-p-slow") {
+				cancel()
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	status, err := Run(ctx, RunOptions{
+		Dir:     dir,
+		Session: "gt-test-p-slow",
+		WorkDir: t.TempDir(),
+		Argv:    []string{"sleep", "30"},
+		Env:     os.Environ(),
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if status.Succeeded() {
+		t.Error("cancelled run should not succeed")
+	}
+	if RunAlive(dir, "gt-test-p-slow") {
+		t.Error("RunAlive() = true after cancel")
+	}
+}
+
+func TestReadRunStatusMissing(t *testing.T) {
+	s, err := ReadRunStatus(t.TempDir(), "nope")
+	if err != nil || s != nil {
+		t.Errorf("ReadRunStatus(missing) = %v, %v; want nil, nil", s, err)
+	}
+}
@@ -0,0 +1,244 @@
+package mail
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+)
+
+// Attachments keep large content (files, diffs, pane captures) out of the
+// message body. Content is stored once per SHA-256 under
+// <townRoot>/.runtime/mail_blobs/<hh>/<hash>, and the message carries an
+// attachment:<kind>:<hash>:<size>:<name> label per attachment.
+
+// Attachment kinds.
+const (
+	AttachmentFile = "file"
+	AttachmentDiff = "diff"
+	AttachmentPane = "pane"
+)
+
+// MaxAttachmentSize caps a single attachment.
+const MaxAttachmentSize = 8 << 20
+
+// attachmentLabelPrefix labels a message with one of its attachments.
+const attachmentLabelPrefix = "attachment:"
+
+// ErrAttachmentMissing is returned when an attachment's blob has been
+// garbage-collected or was never stored in this town.
+var ErrAttachmentMissing = errors.New("attachment content not found")
+
+// Attachment references a content-addressed blob.
+type Attachment struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Hash string `json:"hash"` // hex SHA-256 of the content
+	Size int64  `json:"size"`
+}
+
+// blobDir returns the attachment blob store.
+func blobDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "mail_blobs")
+}
+
+func blobPath(townRoot, hash string) string {
+	return filepath.Join(blobDir(townRoot), hash[:2], hash)
+}
+
+// StoreAttachment writes content to the blob store and returns a reference
+// to it. Storing the same content twice reuses the existing blob.
+func StoreAttachment(townRoot, kind, name string, content []byte) (Attachment, error) {
+	if len(content) > MaxAttachmentSize {
+		return Attachment{}, fmt.Errorf("attachment %s is %d bytes (max %d)", name, len(content), MaxAttachmentSize)
+	}
+	sum := sha256.Sum256(content)
+	a := Attachment{Kind: kind, Name: name, Hash: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+
+	path := blobPath(townRoot, a.Hash)
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		_ = os.Chtimes(path, now, now) // Refresh so GC's grace period covers the new reference
+		return a, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return Attachment{}, fmt.Errorf("creating blob dir: %w", err)
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return Attachment{}, fmt.Errorf("writing attachment: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return Attachment{}, fmt.Errorf("writing attachment: %w", err)
+	}
+	return a, nil
+}
+
+// AttachFile stores a file from disk as an attachment named by its base name.
+func AttachFile(townRoot, path string) (Attachment, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if info.IsDir() {
+		return Attachment{}, fmt.Errorf("%s is a directory", path)
+	}
+	if info.Size() > MaxAttachmentSize {
+		return Attachment{}, fmt.Errorf("attachment %s is %d bytes (max %d)", path, info.Size(), MaxAttachmentSize)
+	}
+	content, err := os.ReadFile(path) //nolint:gosec // G304: path is supplied by the sender
+	if err != nil {
+		return Attachment{}, err
+	}
+	return StoreAttachment(townRoot, AttachmentFile, filepath.Base(path), content)
+}
+
+// ReadAttachment returns an attachment's content, verifying its hash.
+func ReadAttachment(townRoot string, a Attachment) ([]byte, error) {
+	if !validHash(a.Hash) {
+		return nil, fmt.Errorf("invalid attachment hash %q", a.Hash)
+	}
+	content, err := os.ReadFile(blobPath(townRoot, a.Hash))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrAttachmentMissing, a.Name, a.Hash[:12])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(content); hex.EncodeToString(sum[:]) != a.Hash {
+		return nil, fmt.Errorf("attachment %s is corrupt (hash mismatch)", a.Name)
+	}
+	return content, nil
+}
+
+// label encodes the attachment as a message label. The name is
+// query-escaped so it cannot contain the comma that separates labels.
+func (a Attachment) label() string {
+	return fmt.Sprintf("%s%s:%s:%d:%s", attachmentLabelPrefix, a.Kind, a.Hash, a.Size, url.QueryEscape(a.Name))
+}
+
+// parseAttachmentLabel decodes a label written by Attachment.label.
+func parseAttachmentLabel(label string) (Attachment, bool) {
+	rest, ok := strings.CutPrefix(label, attachmentLabelPrefix)
+	if !ok {
+		return Attachment{}, false
+	}
+	parts := strings.SplitN(rest, ":", 4)
+	if len(parts) != 4 || !validHash(parts[1]) {
+		return Attachment{}, false
+	}
+	size, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return Attachment{}, false
+	}
+	name, err := url.QueryUnescape(parts[3])
+	if err != nil {
+		return Attachment{}, false
+	}
+	return Attachment{Kind: parts[0], Hash: parts[1], Size: size, Name: name}, true
+}
+
+// attachmentLabels returns the labels for a message's attachments.
+func attachmentLabels(attachments []Attachment) []string {
+	labels := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		labels = append(labels, a.label())
+	}
+	return labels
+}
+
+func validHash(h string) bool {
+	if len(h) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(h)
+	return err == nil
+}
+
+// GCAttachments removes blobs that no message references. Blobs modified
+// within grace are kept so a message being sent right now does not lose
+// its attachment. Returns the number of blobs removed and bytes freed.
+func GCAttachments(townRoot string, referenced map[string]bool, grace time.Duration, dryRun bool) (removed int, freed int64, err error) {
+	cutoff := time.Now().Add(-grace)
+	err = filepath.WalkDir(blobDir(townRoot), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || referenced[d.Name()] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+		removed++
+		freed += info.Size()
+		return nil
+	})
+	return removed, freed, err
+}
+
+// CollectAttachmentGarbage removes blobs referenced only by deleted mail.
+// Open messages, the archive and scheduled mail keep their attachments;
+// once an archived message is purged its blobs are collected.
+func (r *Router) CollectAttachmentGarbage(grace time.Duration, dryRun bool) (removed int, freed int64, err error) {
+	if r.townRoot == "" {
+		return 0, 0, fmt.Errorf("attachment GC requires a town root")
+	}
+	// Read the schedule before the beads: an entry released in between
+	// is then seen either as scheduled or as the message it became.
+	scheduled, err := ListScheduled(r.townRoot)
+	if err != nil {
+		return 0, 0, err
+	}
+	scheduled = append(scheduled, listClaimed(r.townRoot)...)
+
+	beadsDir := r.resolveBeadsDir("")
+	bms, err := listAllBeadsMessages(beadsDir, time.Time{})
+	if err != nil {
+		return 0, 0, err
+	}
+	archive, err := (&Mailbox{beadsDir: beadsDir}).ListArchived()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	referenced := make(map[string]bool)
+	for _, s := range scheduled {
+		for _, a := range s.Message.Attachments {
+			referenced[a.Hash] = true
+		}
+	}
+	for i := range bms {
+		if bms[i].Status == "closed" {
+			continue // Deleted or archived; an archive copy counts below
+		}
+		for _, a := range bms[i].ToMessage().Attachments {
+			referenced[a.Hash] = true
+		}
+	}
+	for _, msg := range archive {
+		for _, a := range msg.Attachments {
+			referenced[a.Hash] = true
+		}
+	}
+	return GCAttachments(r.townRoot, referenced, grace, dryRun)
+}
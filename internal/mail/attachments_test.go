@@ -0,0 +1,100 @@
+package mail
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreAttachment_RoundTripAndDedup(t *testing.T) {
+	town := t.TempDir()
+	content := []byte("diff --git a/x b/x\n")
+
+	a, err := StoreAttachment(town, AttachmentDiff, "x.patch", content)
+	if err != nil {
+		t.Fatalf("StoreAttachment: %v", err)
+	}
+	if a.Size != int64(len(content)) || !validHash(a.Hash) {
+		t.Errorf("attachment = %+v", a)
+	}
+	b, err := StoreAttachment(town, AttachmentFile, "copy.txt", content)
+	if err != nil {
+		t.Fatalf("StoreAttachment again: %v", err)
+	}
+	if b.Hash != a.Hash {
+		t.Errorf("same content stored under %s and %s", a.Hash, b.Hash)
+	}
+
+	got, err := ReadAttachment(town, b)
+	if err != nil {
+		t.Fatalf("ReadAttachment: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("content = %q", got)
+	}
+
+	if err := os.WriteFile(blobPath(town, a.Hash), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadAttachment(town, a); err == nil {
+		t.Error("corrupt blob read without error")
+	}
+	if _, err := StoreAttachment(town, AttachmentFile, "big", make([]byte, MaxAttachmentSize+1)); err == nil {
+		t.Error("oversized attachment accepted")
+	}
+}
+
+func TestAttachmentLabel_RoundTrip(t *testing.T) {
+	a := Attachment{Kind: AttachmentPane, Name: "gt-nux: pane, 1.txt", Hash: strings.Repeat("ab", 32), Size: 42}
+	got, ok := parseAttachmentLabel(a.label())
+	if !ok || got != a {
+		t.Errorf("parseAttachmentLabel(%q) = %+v, %v", a.label(), got, ok)
+	}
+	for _, bad := range []string{"attachment:file:abc:1:x", "attachment:file:" + a.Hash + ":big:x", "cc:mayor/"} {
+		if _, ok := parseAttachmentLabel(bad); ok {
+			t.Errorf("parseAttachmentLabel(%q) accepted", bad)
+		}
+	}
+
+	bm := BeadsMessage{ID: "m1", Labels: []string{"from:mayor/", a.label()}}
+	if msg := bm.ToMessage(); !reflect.DeepEqual(msg.Attachments, []Attachment{a}) {
+		t.Errorf("ToMessage attachments = %+v", msg.Attachments)
+	}
+}
+
+func TestGCAttachments(t *testing.T) {
+	town := t.TempDir()
+	keep, _ := StoreAttachment(town, AttachmentFile, "keep", []byte("keep"))
+	drop, _ := StoreAttachment(town, AttachmentFile, "drop", []byte("drop"))
+	fresh, _ := StoreAttachment(town, AttachmentFile, "fresh", []byte("fresh"))
+	old := time.Now().Add(-2 * time.Hour)
+	for _, a := range []Attachment{keep, drop} {
+		if err := os.Chtimes(blobPath(town, a.Hash), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referenced := map[string]bool{keep.Hash: true}
+
+	removed, freed, err := GCAttachments(town, referenced, time.Hour, true)
+	if err != nil || removed != 1 || freed != drop.Size {
+		t.Fatalf("dry run = %d, %d, %v", removed, freed, err)
+	}
+	if _, err := ReadAttachment(town, drop); err != nil {
+		t.Errorf("dry run removed blob: %v", err)
+	}
+
+	if removed, _, err := GCAttachments(town, referenced, time.Hour, false); err != nil || removed != 1 {
+		t.Fatalf("GC = %d, %v", removed, err)
+	}
+	if _, err := ReadAttachment(town, drop); !errors.Is(err, ErrAttachmentMissing) {
+		t.Errorf("unreferenced blob: err = %v, want ErrAttachmentMissing", err)
+	}
+	for _, a := range []Attachment{keep, fresh} {
+		if _, err := ReadAttachment(town, a); err != nil {
+			t.Errorf("%s collected: %v", a.Name, err)
+		}
+	}
+}
@@ -0,0 +1,636 @@
+package mail
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+)
+
+// The search index covers every mailbox in the town: all gt:message beads
+// (read or not) plus the archive. It is stored at
+// <townRoot>/.runtime/mail_index.json and refreshed before each search.
+// A refresh fetches only messages updated since the previous one, and
+// re-lists everything once per searchResyncInterval to drop deleted mail.
+
+// searchIndexVersion is bumped when the tokenizer or on-disk format changes,
+// forcing a rebuild.
+const searchIndexVersion = 1
+
+// searchResyncInterval is how often a refresh re-lists every message.
+const searchResyncInterval = time.Hour
+
+// searchSyncSkew overlaps incremental fetches so writes that landed while
+// the previous refresh was running are not missed.
+const searchSyncSkew = time.Minute
+
+// subjectBoost weights subject matches over body matches when ranking.
+const subjectBoost = 3
+
+// BM25 ranking parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// IndexedMessage is one message in the search index.
+type IndexedMessage struct {
+	Message  *Message `json:"message"`
+	Archived bool     `json:"archived,omitempty"`
+
+	// Term frequencies, per field.
+	SubjectTerms map[string]int `json:"subject_terms"`
+	BodyTerms    map[string]int `json:"body_terms"`
+}
+
+// length is the document length used by BM25 (subject terms boosted).
+func (d *IndexedMessage) length() int {
+	n := 0
+	for _, c := range d.SubjectTerms {
+		n += subjectBoost * c
+	}
+	for _, c := range d.BodyTerms {
+		n += c
+	}
+	return n
+}
+
+// SearchIndex is a town-wide inverted index over mail.
+type SearchIndex struct {
+	Version   int                        `json:"version"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+	SyncedAt  time.Time                  `json:"synced_at"` // Last full sync
+	Docs      map[string]*IndexedMessage `json:"docs"`
+
+	path     string
+	postings map[string][]string // term -> doc IDs, rebuilt on load
+}
+
+// searchIndexPath returns where the town's search index is stored.
+func searchIndexPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "mail_index.json")
+}
+
+// OpenSearchIndex loads the town's search index, or returns an empty one if
+// none exists or it was written by an incompatible version.
+func OpenSearchIndex(townRoot string) (*SearchIndex, error) {
+	ix := &SearchIndex{path: searchIndexPath(townRoot)}
+	data, err := os.ReadFile(ix.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, fmt.Errorf("reading search index: %w", err)
+	default:
+		if err := json.Unmarshal(data, ix); err != nil || ix.Version != searchIndexVersion {
+			ix.Docs = nil // Corrupt or stale format: rebuild
+		}
+	}
+	ix.Version = searchIndexVersion
+	if ix.Docs == nil {
+		ix.Docs = make(map[string]*IndexedMessage)
+	}
+	ix.buildPostings()
+	return ix, nil
+}
+
+// Save writes the index atomically. The index holds every message body in
+// the town, so it is readable only by its owner.
+func (ix *SearchIndex) Save() error {
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0755); err != nil {
+		return fmt.Errorf("creating index dir: %w", err)
+	}
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return fmt.Errorf("marshaling search index: %w", err)
+	}
+	tmp := ix.path + ".tmp"
+	_ = os.Remove(tmp) // WriteFile keeps the mode of an existing file
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing search index: %w", err)
+	}
+	return os.Rename(tmp, ix.path)
+}
+
+// Update brings the index in line with the complete set of messages.
+// archived marks IDs that have been archived. New messages are tokenized,
+// known ones have their metadata (read state etc.) refreshed, and messages
+// that no longer exist are dropped. Returns the number added and removed.
+func (ix *SearchIndex) Update(msgs []*Message, archived map[string]bool) (added, removed int) {
+	seen := make(map[string]bool, len(msgs))
+	added = ix.merge(msgs, archived, seen)
+	for id := range ix.Docs {
+		if !seen[id] {
+			delete(ix.Docs, id)
+			removed++
+		}
+	}
+	ix.SyncedAt = ix.UpdatedAt
+	return added, removed
+}
+
+// Merge adds or refreshes msgs without dropping anything, for incremental
+// syncs that only see recently updated messages. Returns the number added.
+func (ix *SearchIndex) Merge(msgs []*Message, archived map[string]bool) int {
+	return ix.merge(msgs, archived, make(map[string]bool, len(msgs)))
+}
+
+func (ix *SearchIndex) merge(msgs []*Message, archived, seen map[string]bool) (added int) {
+	for _, msg := range msgs {
+		if msg.ID == "" || seen[msg.ID] {
+			continue
+		}
+		seen[msg.ID] = true
+		if doc, ok := ix.Docs[msg.ID]; ok {
+			doc.Message = msg
+			doc.Archived = archived[msg.ID]
+			continue
+		}
+		ix.Docs[msg.ID] = &IndexedMessage{
+			Message:      msg,
+			Archived:     archived[msg.ID],
+			SubjectTerms: tokenize(msg.Subject),
+			BodyTerms:    tokenize(msg.Body),
+		}
+		added++
+	}
+	ix.UpdatedAt = time.Now()
+	ix.buildPostings()
+	return added
+}
+
+func (ix *SearchIndex) buildPostings() {
+	ix.postings = make(map[string][]string)
+	for id, doc := range ix.Docs {
+		for term := range doc.SubjectTerms {
+			ix.postings[term] = append(ix.postings[term], id)
+		}
+		for term := range doc.BodyTerms {
+			if _, dup := doc.SubjectTerms[term]; !dup {
+				ix.postings[term] = append(ix.postings[term], id)
+			}
+		}
+	}
+}
+
+// RefreshSearchIndex loads the town's search index and syncs it with every
+// mailbox and the archive. Only messages updated since the last refresh are
+// fetched, except for a periodic full sync. rebuild discards the stored
+// index first.
+func (r *Router) RefreshSearchIndex(rebuild bool) (*SearchIndex, error) {
+	if r.townRoot == "" {
+		return nil, fmt.Errorf("mail search index requires a town root")
+	}
+	ix, err := OpenSearchIndex(r.townRoot)
+	if err != nil {
+		return nil, err
+	}
+	if rebuild {
+		ix.Docs = make(map[string]*IndexedMessage)
+		ix.SyncedAt = time.Time{}
+	}
+
+	beadsDir := r.resolveBeadsDir("")
+	full := time.Since(ix.SyncedAt) > searchResyncInterval
+	var msgs []*Message
+	if !full {
+		msgs, err = listAllMessages(beadsDir, ix.UpdatedAt.Add(-searchSyncSkew))
+		full = err != nil // bd without --updated-after: fall back to a full list
+	}
+	if full {
+		if msgs, err = listAllMessages(beadsDir, time.Time{}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Archived copies win over the closed bead they were archived from
+	archive, err := (&Mailbox{beadsDir: beadsDir}).ListArchived()
+	if err != nil {
+		return nil, err
+	}
+	archived := make(map[string]bool, len(archive))
+	for _, msg := range archive {
+		archived[msg.ID] = true
+	}
+	all := append(archive, msgs...)
+
+	if full {
+		ix.Update(all, archived)
+	} else {
+		ix.Merge(all, archived)
+	}
+	if err := ix.Save(); err != nil {
+		return nil, err
+	}
+	return ix, nil
+}
+
+// listAllMessages returns every message, open or closed, in beadsDir that
+// was updated after since (all of them if since is zero).
+func listAllMessages(beadsDir string, since time.Time) ([]*Message, error) {
+	bms, err := listAllBeadsMessages(beadsDir, since)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]*Message, 0, len(bms))
+	for i := range bms {
+		msgs = append(msgs, bms[i].ToMessage())
+	}
+	return msgs, nil
+}
+
+// listAllBeadsMessages returns every message bead, open or closed, in
+// beadsDir that was updated after since (all of them if since is zero).
+func listAllBeadsMessages(beadsDir string, since time.Time) ([]BeadsMessage, error) {
+	if err := beads.EnsureCustomTypes(beadsDir); err != nil {
+		return nil, fmt.Errorf("ensuring custom types: %w", err)
+	}
+	args := []string{"list",
+		"--label", "gt:message",
+		"--all",
+		"--json",
+		"--limit", "0",
+	}
+	if !since.IsZero() {
+		args = append(args, "--updated-after", since.Format(time.RFC3339))
+	}
+	ctx, cancel := bdReadCtx()
+	defer cancel()
+	stdout, err := runBdCommand(ctx, args, filepath.Dir(beadsDir), beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(stdout) == 0 || string(stdout) == "null" {
+		return nil, nil
+	}
+	var bms []BeadsMessage
+	if err := json.Unmarshal(stdout, &bms); err != nil {
+		return nil, fmt.Errorf("parsing messages: %w", err)
+	}
+	return bms, nil
+}
+
+// SearchQuery is a parsed mail search.
+//
+// Query syntax: free words are matched as whole terms (a trailing * matches
+// a prefix) and all must appear. Field filters narrow the results:
+//
+//	from:<addr>  to:<addr>  thread:<id>  type:<type or protocol type>
+//	after:<date> before:<date>  is:unread|read|archived|inbox
+//
+// Dates are YYYY-MM-DD, RFC 3339, or an age such as 7d or 12h.
+//
+// Setting Pattern switches from ranked term matching to a regular
+// expression over subject and body; results are then newest first.
+type SearchQuery struct {
+	Terms   []string
+	Text    string // Free text of the query, without field filters
+	Pattern *regexp.Regexp
+	From    string
+	To      string
+	Thread  string
+	Type    string
+	After   time.Time
+	Before  time.Time
+	Is      string
+
+	// SubjectOnly and BodyOnly restrict which fields terms are matched in.
+	SubjectOnly bool
+	BodyOnly    bool
+
+	// Mailbox limits results to messages addressed to this identity (To or
+	// CC). Empty searches every mailbox Reader may read.
+	Mailbox string
+
+	// Reader is who is searching; results are limited to what they may read.
+	Reader string
+}
+
+// ParseSearchQuery parses query text (see SearchQuery).
+func ParseSearchQuery(text string, now time.Time) (*SearchQuery, error) {
+	q := &SearchQuery{}
+	var free []string
+	for _, word := range strings.Fields(text) {
+		key, value, ok := strings.Cut(word, ":")
+		if ok && value != "" {
+			var err error
+			switch strings.ToLower(key) {
+			case "from":
+				q.From = value
+			case "to":
+				q.To = value
+			case "thread":
+				q.Thread = value
+			case "type":
+				q.Type = value
+			case "after":
+				q.After, err = parseSearchDate(value, now)
+			case "before":
+				q.Before, err = parseSearchDate(value, now)
+			case "is":
+				switch v := strings.ToLower(value); v {
+				case "unread", "read", "archived", "inbox":
+					q.Is = v
+				default:
+					err = fmt.Errorf("want unread, read, archived or inbox")
+				}
+			default:
+				ok = false
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", word, err)
+			}
+			if ok {
+				continue
+			}
+		}
+
+		free = append(free, word)
+		base, prefix := strings.CutSuffix(word, "*")
+		words := termWords(base)
+		for i, w := range words {
+			if prefix && i == len(words)-1 {
+				q.Terms = append(q.Terms, w+"*")
+				continue
+			}
+			for term := range tokenize(w) {
+				q.Terms = append(q.Terms, term)
+			}
+		}
+	}
+	sort.Strings(q.Terms)
+	q.Text = strings.Join(free, " ")
+	return q, nil
+}
+
+// parseSearchDate parses after:/before: values.
+func parseSearchDate(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if d, err := ParseDeliverIn(value); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("want YYYY-MM-DD, RFC 3339 or an age like 7d")
+}
+
+// SearchResult is a ranked search hit.
+type SearchResult struct {
+	Message  *Message `json:"message"`
+	Archived bool     `json:"archived,omitempty"`
+	Score    float64  `json:"score"`
+}
+
+// Search returns matching messages, best first. Without terms every message
+// passing the filters matches and results are newest first.
+func (ix *SearchIndex) Search(q *SearchQuery) []SearchResult {
+	candidates := ix.candidates(q)
+
+	var avgLen float64
+	for _, doc := range ix.Docs {
+		avgLen += float64(doc.length())
+	}
+	if len(ix.Docs) > 0 {
+		avgLen /= float64(len(ix.Docs))
+	}
+
+	var results []SearchResult
+	for id := range candidates {
+		doc := ix.Docs[id]
+		if !q.matches(doc) {
+			continue
+		}
+		score, ok := ix.score(doc, q, avgLen)
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{Message: doc.Message, Archived: doc.Archived, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Message.Timestamp.After(results[j].Message.Timestamp)
+	})
+	return results
+}
+
+// candidates narrows the search to documents containing the rarest term.
+func (ix *SearchIndex) candidates(q *SearchQuery) map[string]bool {
+	terms := q.Terms
+	out := make(map[string]bool)
+	if len(terms) == 0 || q.Pattern != nil {
+		for id := range ix.Docs {
+			out[id] = true
+		}
+		return out
+	}
+	var best []string
+	for i, term := range terms {
+		var ids []string
+		for _, t := range ix.expand(term) {
+			ids = append(ids, ix.postings[t]...)
+		}
+		if i == 0 || len(ids) < len(best) {
+			best = ids
+		}
+	}
+	for _, id := range best {
+		out[id] = true
+	}
+	return out
+}
+
+// expand resolves a query term to index terms (prefix terms end in *).
+func (ix *SearchIndex) expand(term string) []string {
+	prefix, ok := strings.CutSuffix(term, "*")
+	if !ok {
+		return []string{term}
+	}
+	var out []string
+	for t := range ix.postings {
+		if strings.HasPrefix(t, prefix) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// score computes the BM25 score of doc; ok is false if a term is missing.
+// Pattern matches all score zero.
+func (ix *SearchIndex) score(doc *IndexedMessage, q *SearchQuery, avgLen float64) (float64, bool) {
+	if q.Pattern != nil {
+		msg := doc.Message
+		return 0, (!q.BodyOnly && q.Pattern.MatchString(msg.Subject)) ||
+			(!q.SubjectOnly && q.Pattern.MatchString(msg.Body))
+	}
+	n := float64(len(ix.Docs))
+	docLen := float64(doc.length())
+	var total float64
+	for _, term := range q.Terms {
+		var tf, df float64
+		for _, t := range ix.expand(term) {
+			if !q.BodyOnly {
+				tf += float64(subjectBoost * doc.SubjectTerms[t])
+			}
+			if !q.SubjectOnly {
+				tf += float64(doc.BodyTerms[t])
+			}
+			df += float64(len(ix.postings[t]))
+		}
+		if tf == 0 {
+			return 0, false
+		}
+		df = math.Min(df, n)
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		norm := 1 - bm25B
+		if avgLen > 0 {
+			norm += bm25B * docLen / avgLen
+		}
+		total += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return total, true
+}
+
+// matches applies the field filters, mailbox scope and read permission.
+func (q *SearchQuery) matches(doc *IndexedMessage) bool {
+	msg := doc.Message
+	recipients := append([]string{msg.To, msg.Queue, msg.Channel}, msg.CC...)
+
+	if q.From != "" && !containsFold(msg.From, q.From) {
+		return false
+	}
+	if q.To != "" && !anyContainsFold(recipients, q.To) {
+		return false
+	}
+	if q.Thread != "" && !strings.EqualFold(msg.ThreadID, q.Thread) {
+		return false
+	}
+	if q.Type != "" && !strings.EqualFold(string(msg.Type), q.Type) && !strings.EqualFold(msg.Protocol, q.Type) {
+		return false
+	}
+	if !q.After.IsZero() && msg.Timestamp.Before(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !msg.Timestamp.Before(q.Before) {
+		return false
+	}
+	switch q.Is {
+	case "unread":
+		if msg.Read || doc.Archived {
+			return false
+		}
+	case "read":
+		if !msg.Read && !doc.Archived {
+			return false
+		}
+	case "archived":
+		if !doc.Archived {
+			return false
+		}
+	case "inbox":
+		if doc.Archived {
+			return false
+		}
+	}
+
+	if q.Mailbox != "" && !addressedTo(msg, q.Mailbox) {
+		return false
+	}
+	return q.Reader == "" || CanReadMessage(q.Reader, msg)
+}
+
+// CanReadMailbox reports whether reader may search owner's mailbox. The
+// overseer, mayor and deacon may read every mailbox; a rig's witness and
+// refinery may read the mailboxes in their rig; everyone may read their own.
+func CanReadMailbox(reader, owner string) bool {
+	reader, owner = AddressToIdentity(reader), AddressToIdentity(owner)
+	switch reader {
+	case owner, "overseer", "mayor/", "deacon/":
+		return true
+	}
+	if rig, role, ok := strings.Cut(reader, "/"); ok && (role == "witness" || role == "refinery") {
+		return strings.HasPrefix(owner, rig+"/")
+	}
+	return false
+}
+
+// CanReadMessage reports whether reader may see msg: they sent it, it is in
+// a mailbox they may read, or it went to a shared queue or channel.
+func CanReadMessage(reader string, msg *Message) bool {
+	if msg.Queue != "" || msg.Channel != "" {
+		return true
+	}
+	if msg.From != "" && AddressToIdentity(msg.From) == AddressToIdentity(reader) {
+		return true
+	}
+	for _, to := range append([]string{msg.To}, msg.CC...) {
+		if to != "" && CanReadMailbox(reader, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// addressedTo reports whether msg was sent or CC'd to identity.
+func addressedTo(msg *Message, identity string) bool {
+	identity = AddressToIdentity(identity)
+	for _, to := range append([]string{msg.To}, msg.CC...) {
+		if to != "" && AddressToIdentity(to) == identity {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func anyContainsFold(values []string, substr string) bool {
+	for _, v := range values {
+		if v != "" && containsFold(v, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenize splits text into lowercase terms with their frequencies. Words
+// joined by - or _ (bead IDs, branch names) are indexed whole and by part.
+func tokenize(text string) map[string]int {
+	terms := make(map[string]int)
+	for _, word := range termWords(text) {
+		terms[word]++
+		if strings.ContainsAny(word, "-_") {
+			for _, part := range strings.FieldsFunc(word, func(r rune) bool { return r == '-' || r == '_' }) {
+				terms[part]++
+			}
+		}
+	}
+	return terms
+}
+
+// termWords splits text into lowercase words of letters, digits, - and _.
+func termWords(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	}) {
+		if w = strings.Trim(w, "-_"); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,203 @@
+package mail
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+)
+
+func testIndex(t *testing.T, msgs ...*Message) *SearchIndex {
+	t.Helper()
+	ix, err := OpenSearchIndex(t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenSearchIndex: %v", err)
+	}
+	ix.Update(msgs, map[string]bool{"m4": true})
+	return ix
+}
+
+func indexMsg(id, from, to, subject, body string, at time.Time) *Message {
+	return &Message{ID: id, From: from, To: to, Subject: subject, Body: body, Timestamp: at, Type: TypeNotification}
+}
+
+func searchIDs(results []SearchResult) []string {
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Message.ID)
+	}
+	return ids
+}
+
+func TestSearchIndex_RanksAndFilters(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ix := testIndex(t,
+		indexMsg("m1", "mayor/", "gastown/refinery", "Rebase gt-abc onto main", "Please rebase before merging.", day),
+		indexMsg("m2", "gastown/witness", "gastown/refinery", "Status", "All quiet, nothing to rebase today.", day.Add(time.Hour)),
+		indexMsg("m3", "gastown/witness", "mayor/", "Polecat nux stuck", "nux has been idle for an hour", day.Add(2*time.Hour)),
+		indexMsg("m4", "deacon/", "gastown/refinery", "Old rebase note", "archived", day.Add(-48*time.Hour)),
+	)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"rebase", []string{"m4", "m1", "m2"}}, // subject hits rank above body-only; shorter first
+		{"rebase from:mayor", []string{"m1"}},
+		{"rebase is:inbox", []string{"m1", "m2"}},
+		{"rebase is:archived", []string{"m4"}},
+		{"rebas*", []string{"m4", "m1", "m2"}},
+		{"gt-abc", []string{"m1"}},
+		{"abc", []string{"m1"}},
+		{"nux to:mayor", []string{"m3"}},
+		{"rebase after:2026-03-01", []string{"m1", "m2"}},
+		{"before:2026-03-01", []string{"m4"}},
+		{"rebase merging", []string{"m1"}},
+		{"missing", nil},
+	}
+	for _, tt := range tests {
+		q, err := ParseSearchQuery(tt.query, day)
+		if err != nil {
+			t.Fatalf("ParseSearchQuery(%q): %v", tt.query, err)
+		}
+		if got := searchIDs(ix.Search(q)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIndex_Pattern(t *testing.T) {
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ix := testIndex(t,
+		indexMsg("m1", "mayor/", "gastown/refinery", "Status check", "rig health ok", day),
+		indexMsg("m2", "gastown/witness", "gastown/refinery", "Patrol", "status: rechecked polecats", day.Add(time.Hour)),
+	)
+
+	tests := []struct {
+		pattern string
+		subject bool
+		want    []string
+	}{
+		{`status.*check`, false, []string{"m2", "m1"}}, // newest first
+		{`status.*check`, true, []string{"m1"}},
+		{`^rig`, false, []string{"m1"}},
+		{`heal`, false, []string{"m1"}}, // substrings match
+	}
+	if q, _ := ParseSearchQuery("from:mayor/ status.*check", day); q.Text != "status.*check" {
+		t.Errorf("Text = %q, want the query without field filters", q.Text)
+	}
+	for _, tt := range tests {
+		q, _ := ParseSearchQuery(tt.pattern, day)
+		q.SubjectOnly = tt.subject
+		q.Pattern = regexp.MustCompile("(?i)" + q.Text)
+		if got := searchIDs(ix.Search(q)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(/%s/, subject=%v) = %v, want %v", tt.pattern, tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIndex_Scope(t *testing.T) {
+	now := time.Now()
+	ix := testIndex(t,
+		indexMsg("m1", "mayor/", "gastown/refinery", "deploy", "", now),
+		indexMsg("m2", "mayor/", "gastown/nux", "deploy", "", now),
+		indexMsg("m3", "mayor/", "beads/witness", "deploy", "", now),
+		indexMsg("m5", "gastown/nux", "mayor/", "deploy", "", now),
+	)
+	search := func(reader, mailbox string) []string {
+		q, _ := ParseSearchQuery("deploy", now)
+		q.Reader, q.Mailbox = reader, mailbox
+		ids := searchIDs(ix.Search(q))
+		sort.Strings(ids)
+		return ids
+	}
+
+	if got := search("gastown/nux", "gastown/nux"); !reflect.DeepEqual(got, []string{"m2"}) {
+		t.Errorf("own mailbox = %v", got)
+	}
+	if got := search("gastown/nux", ""); !reflect.DeepEqual(got, []string{"m2", "m5"}) {
+		t.Errorf("polecat town-wide = %v, want own and sent mail", got)
+	}
+	if got := search("gastown/witness", ""); !reflect.DeepEqual(got, []string{"m1", "m2"}) {
+		t.Errorf("witness town-wide = %v, want its rig's mail", got)
+	}
+	if got := search("overseer", ""); len(got) != 4 {
+		t.Errorf("overseer town-wide = %v, want all", got)
+	}
+}
+
+func TestCanReadMailbox(t *testing.T) {
+	tests := []struct {
+		reader, owner string
+		want          bool
+	}{
+		{"gastown/nux", "gastown/nux", true},
+		{"gastown/polecats/nux", "gastown/nux", true},
+		{"gastown/nux", "gastown/refinery", false},
+		{"gastown/witness", "gastown/crew/max", true},
+		{"gastown/refinery", "beads/nux", false},
+		{"mayor", "beads/nux", true},
+		{"overseer", "deacon/", true},
+	}
+	for _, tt := range tests {
+		if got := CanReadMailbox(tt.reader, tt.owner); got != tt.want {
+			t.Errorf("CanReadMailbox(%q, %q) = %v, want %v", tt.reader, tt.owner, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIndex_UpdatePersists(t *testing.T) {
+	town := t.TempDir()
+	ix, _ := OpenSearchIndex(town)
+	msg := indexMsg("m1", "mayor/", "gastown/nux", "hello", "world", time.Now())
+	if added, _ := ix.Update([]*Message{msg}, nil); added != 1 {
+		t.Fatalf("added = %d, want 1", added)
+	}
+	if err := ix.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reloaded, err := OpenSearchIndex(town)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	read := *msg
+	read.Read = true
+	added, removed := reloaded.Update([]*Message{&read}, nil)
+	if added != 0 || removed != 0 || !reloaded.Docs["m1"].Message.Read {
+		t.Errorf("re-sync added %d removed %d, read=%v", added, removed, reloaded.Docs["m1"].Message.Read)
+	}
+	if q, _ := ParseSearchQuery("world", time.Now()); len(reloaded.Search(q)) != 1 {
+		t.Error("reloaded index lost postings")
+	}
+	other := indexMsg("m2", "mayor/", "gastown/nux", "again", "", time.Now())
+	if added := reloaded.Merge([]*Message{other}, nil); added != 1 || len(reloaded.Docs) != 2 {
+		t.Errorf("Merge added %d, have %d docs; want 1 and 2", added, len(reloaded.Docs))
+	}
+	if _, removed := reloaded.Update(nil, nil); removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	if info, err := os.Stat(searchIndexPath(town)); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("index mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	q, err := ParseSearchQuery("Rebase thread:t-1 type:MERGED after:7d is:unread Branch: gt-x*", now)
+	if err != nil {
+		t.Fatalf("ParseSearchQuery: %v", err)
+	}
+	if !reflect.DeepEqual(q.Terms, []string{"branch", "gt-x*", "rebase"}) {
+		t.Errorf("Terms = %v", q.Terms)
+	}
+	if q.Thread != "t-1" || q.Type != "MERGED" || q.Is != "unread" || !q.After.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("filters = %+v", q)
+	}
+	if _, err := ParseSearchQuery("is:sometimes", now); err == nil {
+		t.Error("is:sometimes accepted")
+	}
+}
@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Queue claims are leases: a claimant renews claimed-at while it works, and
+// a claim left unrenewed past the queue's TTL is released back to the queue
+// with its claim-attempts:<n> label incremented. Once the attempts reach
+// the queue's limit the message is dead-lettered instead, so work whose
+// claimants keep dying does not circulate forever or vanish.
+
+// ClaimAttemptsLabelPrefix labels a queue message with its expired claims.
+const ClaimAttemptsLabelPrefix = "claim-attempts:"
+
+// ClaimAttemptsLabel returns the label recording n expired claims.
+func ClaimAttemptsLabel(n int) string {
+	return ClaimAttemptsLabelPrefix + strconv.Itoa(n)
+}
+
+// DeadLetterQueueMessage delivers a copy of a queue message to the
+// dead-letter mailbox, recording why it was pulled from the queue. The
+// caller is responsible for closing the original.
+func (r *Router) DeadLetterQueueMessage(msg *Message, reason error) (*Message, error) {
+	dead := newDeadLetter(msg, reason)
+	dead.ClaimedBy = ""
+	dead.ClaimedAt = nil
+	dead.ClaimAttempts = 0
+	if err := r.sendToSingle(dead); err != nil {
+		return nil, fmt.Errorf("dead-lettering %s: %w", msg.ID, err)
+	}
+	return dead, nil
+}
@@ -0,0 +1,82 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DeadLetterAddress is the mailbox that receives protocol messages that
+// failed validation on send and queue messages whose claims kept expiring.
+// Read it with `gt mail inbox deadletter`.
+const DeadLetterAddress = "deadletter"
+
+// protocolLabelPrefix labels a message with its protocol message type.
+const protocolLabelPrefix = "protocol:"
+
+// ErrInvalidProtocol is returned by Send when a protocol message is unknown
+// or malformed. The message is delivered to DeadLetterAddress instead.
+var ErrInvalidProtocol = errors.New("invalid protocol message")
+
+// ProtocolValidator inspects an outgoing message. It returns the message's
+// protocol type ("" for ordinary mail) and an error if the message claims
+// to be a protocol message but is unknown or malformed.
+//
+// The mail package cannot import the protocol package (which builds on
+// mail), so the protocol registry installs itself here.
+type ProtocolValidator func(msg *Message) (string, error)
+
+var (
+	protocolMu        sync.RWMutex
+	protocolValidator ProtocolValidator
+)
+
+// RegisterProtocolValidator installs the validator used by Router.Send.
+func RegisterProtocolValidator(v ProtocolValidator) {
+	protocolMu.Lock()
+	defer protocolMu.Unlock()
+	protocolValidator = v
+}
+
+// checkProtocol validates a protocol message and records its type. Invalid
+// messages are re-addressed to the dead-letter mailbox so they are not
+// silently dropped, and the sender gets an error wrapping ErrInvalidProtocol.
+func (r *Router) checkProtocol(msg *Message) error {
+	protocolMu.RLock()
+	validate := protocolValidator
+	protocolMu.RUnlock()
+	if validate == nil || AddressToIdentity(msg.To) == DeadLetterAddress {
+		return nil
+	}
+
+	msgType, verr := validate(msg)
+	if verr == nil {
+		if msgType != "" {
+			msg.Protocol = msgType
+		}
+		return nil
+	}
+
+	dead := newDeadLetter(msg, verr)
+	if err := r.sendToSingle(dead); err != nil {
+		return fmt.Errorf("%w: %v (dead-letter delivery failed: %v)", ErrInvalidProtocol, verr, err)
+	}
+	return fmt.Errorf("%w: %v (delivered to %s as %s)", ErrInvalidProtocol, verr, DeadLetterAddress, dead.ID)
+}
+
+// newDeadLetter wraps a rejected message for the dead-letter mailbox.
+func newDeadLetter(msg *Message, reason error) *Message {
+	dead := *msg
+	dead.ID = ""
+	dead.To = DeadLetterAddress
+	dead.Queue = ""
+	dead.Channel = ""
+	dead.CC = nil
+	dead.SuppressNotify = true
+	original := msg.To
+	if original == "" {
+		original = msg.Queue + msg.Channel
+	}
+	dead.Body = fmt.Sprintf("Dead-Letter: %v\nOriginal-To: %s\n\n%s", reason, original, msg.Body)
+	return &dead
+}
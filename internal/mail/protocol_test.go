@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckProtocol_RecordsType(t *testing.T) {
+	RegisterProtocolValidator(func(msg *Message) (string, error) {
+		if strings.HasPrefix(msg.Subject, "MERGED ") {
+			return "MERGED", nil
+		}
+		return "", nil
+	})
+	defer RegisterProtocolValidator(nil)
+
+	r := NewRouterWithTownRoot(t.TempDir(), t.TempDir())
+	msg := NewMessage("gastown/refinery", "gastown/witness", "MERGED nux", "Branch: b")
+	if err := r.checkProtocol(msg); err != nil {
+		t.Fatalf("checkProtocol: %v", err)
+	}
+	if msg.Protocol != "MERGED" {
+		t.Errorf("Protocol = %q, want MERGED", msg.Protocol)
+	}
+
+	plain := NewMessage("mayor/", "gastown/witness", "hello", "")
+	if err := r.checkProtocol(plain); err != nil || plain.Protocol != "" {
+		t.Errorf("checkProtocol(plain) = %v, Protocol %q", err, plain.Protocol)
+	}
+}
+
+func TestNewDeadLetter(t *testing.T) {
+	msg := NewMessage("gastown/refinery", "gastown/witness", "MERGE_READDY nux", "Branch: b")
+	msg.CC = []string{"mayor/"}
+	dead := newDeadLetter(msg, ErrInvalidProtocol)
+
+	if dead.To != DeadLetterAddress || dead.ID != "" || len(dead.CC) != 0 || !dead.SuppressNotify {
+		t.Errorf("dead letter routing = %+v", dead)
+	}
+	if dead.Subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", dead.Subject, msg.Subject)
+	}
+	for _, want := range []string{"Dead-Letter: invalid protocol message", "Original-To: gastown/witness", "Branch: b"} {
+		if !strings.Contains(dead.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, dead.Body)
+		}
+	}
+	if msg.To != "gastown/witness" || len(msg.CC) != 1 {
+		t.Error("newDeadLetter modified the original message")
+	}
+}
+
+func TestBeadsMessageProtocolLabel(t *testing.T) {
+	bm := &BeadsMessage{ID: "hq-1", Title: "MERGED nux", Labels: []string{"from:gastown/refinery", "protocol:MERGED"}}
+	if got := bm.ToMessage().Protocol; got != "MERGED" {
+		t.Errorf("Protocol = %q, want MERGED", got)
+	}
+}
+
+func TestMessageLabels(t *testing.T) {
+	msg := &Message{From: "gastown/refinery", ThreadID: "t-1", Protocol: "MERGED", CC: []string{"mayor/"}}
+	got := messageLabels(msg, "queue:merges")
+	want := []string{"gt:message", "from:gastown/refinery", "queue:merges", "thread:t-1", "protocol:MERGED", "cc:mayor/"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("messageLabels = %v, want %v", got, want)
+	}
+}
@@ -769,7 +769,24 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // Supports single-copy delivery for:
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
+// Messages with a future DeliverAt are spooled instead (see Schedule).
 func (r *Router) Send(msg *Message) error {
+	// Hold back mail scheduled for later; the daemon releases it when due
+	if msg.DeliverAt != nil && msg.DeliverAt.After(time.Now()) {
+		_, err := r.Schedule(msg, "")
+		return err
+	}
+
+	// Throttle floods and reply loops
+	if err := r.throttle(msg); err != nil {
+		return err
+	}
+
+	// Validate protocol messages; invalid ones go to the dead-letter mailbox
+	if err := r.checkProtocol(msg); err != nil {
+		return err
+	}
+
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -844,6 +861,11 @@ func (r *Router) validateRecipient(identity string) error {
 		return nil
 	}
 
+	// Dead-letter mailbox is not an agent either
+	if identity == DeadLetterAddress {
+		return nil
+	}
+
 	// Query agents from town-level beads
 	agents := r.queryAgents("")
 
@@ -880,6 +902,28 @@ func (r *Router) validateRecipient(identity string) error {
 	return fmt.Errorf("no agent found")
 }
 
+// messageLabels builds the labels every delivery path stores on a message
+// bead: type and sender, then extra (routing and delivery metadata), then
+// thread, reply-to, attachments, protocol type and one cc: per CC.
+func messageLabels(msg *Message, extra ...string) []string {
+	labels := []string{"gt:message", "from:" + msg.From}
+	labels = append(labels, extra...)
+	if msg.ThreadID != "" {
+		labels = append(labels, "thread:"+msg.ThreadID)
+	}
+	if msg.ReplyTo != "" {
+		labels = append(labels, "reply-to:"+msg.ReplyTo)
+	}
+	labels = append(labels, attachmentLabels(msg.Attachments)...)
+	if msg.Protocol != "" {
+		labels = append(labels, protocolLabelPrefix+msg.Protocol)
+	}
+	for _, cc := range msg.CC {
+		labels = append(labels, "cc:"+AddressToIdentity(cc))
+	}
+	return labels
+}
+
 // sendToSingle sends a message to a single recipient.
 func (r *Router) sendToSingle(msg *Message) error {
 	// Ensure message has an ID (callers may omit it; bd create doesn't generate one)
@@ -900,22 +944,7 @@ func (r *Router) sendToSingle(msg *Message) error {
 		return fmt.Errorf("invalid recipient %q: %w", msg.To, err)
 	}
 
-	// Build labels for type, from/thread/reply-to/cc
-	var labels []string
-	labels = append(labels, "gt:message")
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, DeliverySendLabels()...)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	// Add CC labels (one per recipient)
-	for _, cc := range msg.CC {
-		ccIdentity := AddressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
+	labels := messageLabels(msg, DeliverySendLabels()...)
 
 	// Build command: bd create --assignee=<recipient> -d <body> --labels=gt:message,... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
@@ -1028,22 +1057,8 @@ func (r *Router) sendToQueue(msg *Message) error {
 		return err
 	}
 
-	// Build labels for type, from/thread/reply-to/cc plus queue metadata
-	var labels []string
-	labels = append(labels, "gt:message")
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, "queue:"+queueName)
-	labels = append(labels, DeliverySendLabels()...)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	for _, cc := range msg.CC {
-		ccIdentity := AddressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
+	// Queue metadata on top of the usual labels
+	labels := messageLabels(msg, append([]string{"queue:" + queueName}, DeliverySendLabels()...)...)
 
 	// Build command: bd create --assignee=queue:<name> -d <body> ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
@@ -1110,24 +1125,11 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 		}
 	}
 
-	// Build labels for type, from/thread/reply-to/cc plus announce metadata.
+	// Announce metadata on top of the usual labels.
 	// Note: delivery:pending is intentionally omitted for announce messages —
 	// broadcast messages have no single recipient to ack against. Subscriber
 	// fan-out copies go through sendToSingle which adds delivery tracking.
-	var labels []string
-	labels = append(labels, "gt:message")
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, "announce:"+announceName)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	for _, cc := range msg.CC {
-		ccIdentity := AddressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
+	labels := messageLabels(msg, "announce:"+announceName)
 
 	// Build command: bd create --assignee=announce:<name> -d <body> ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
@@ -1196,24 +1198,11 @@ func (r *Router) sendToChannel(msg *Message) error {
 		return fmt.Errorf("channel %s is closed", channelName)
 	}
 
-	// Build labels for type, from/thread/reply-to/cc plus channel metadata.
+	// Channel metadata on top of the usual labels.
 	// Note: delivery:pending is intentionally omitted for the channel-origin
 	// copy — it has no single recipient to ack. Subscriber fan-out copies go
 	// through sendToSingle which adds delivery tracking.
-	var labels []string
-	labels = append(labels, "gt:message")
-	labels = append(labels, "from:"+msg.From)
-	labels = append(labels, "channel:"+channelName)
-	if msg.ThreadID != "" {
-		labels = append(labels, "thread:"+msg.ThreadID)
-	}
-	if msg.ReplyTo != "" {
-		labels = append(labels, "reply-to:"+msg.ReplyTo)
-	}
-	for _, cc := range msg.CC {
-		ccIdentity := AddressToIdentity(cc)
-		labels = append(labels, "cc:"+ccIdentity)
-	}
+	labels := messageLabels(msg, "channel:"+channelName)
 
 	// Build command: bd create --assignee=channel:<name> -d <body> ... -- <subject>
 	// Flags go first, then -- to end flag parsing, then the positional subject.
@@ -1427,6 +1416,7 @@ func (r *Router) notifyRecipient(msg *Message) error {
 			return nudge.Enqueue(r.townRoot, sessionID, nudge.QueuedNudge{
 				Sender:  msg.From,
 				Message: notification,
+				Kind:    nudge.KindMail,
 			})
 		}
 		// Fallback to direct nudge if town root unavailable
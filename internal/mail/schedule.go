@@ -0,0 +1,342 @@
+package mail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+)
+
+// Scheduled mail is held in a spool outside beads until it is due, so it
+// never shows up in an inbox early. The daemon releases due entries on each
+// heartbeat (gt mail scheduled --release), which sends them normally and
+// notifies the recipient.
+//
+// Spool location: <townRoot>/.runtime/mail_scheduled/<id>.json
+
+// maxReleaseAttempts is how many heartbeats a scheduled message may fail to
+// send before it is delivered to the dead-letter mailbox.
+const maxReleaseAttempts = 5
+
+// claimTimeout is how long a release may hold an entry claimed (renamed to
+// <id>.json.claimed) before a later pass treats the releaser as crashed and
+// returns the entry to the spool.
+const claimTimeout = 10 * time.Minute
+
+// ScheduledMessage is a pending delivery in the spool.
+type ScheduledMessage struct {
+	// ID identifies the entry for gt mail scheduled --cancel.
+	ID string `json:"id"`
+
+	// Message is the mail to send. Message.DeliverAt is the next delivery.
+	Message *Message `json:"message"`
+
+	// Cron recurs the delivery; empty for one-shot mail.
+	Cron string `json:"cron,omitempty"`
+
+	// NoNotify carries Message.SuppressNotify, which is not serialized.
+	NoNotify bool `json:"no_notify,omitempty"`
+
+	// CreatedAt is when the delivery was scheduled.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Delivered counts releases so far (recurring mail).
+	Delivered int `json:"delivered,omitempty"`
+
+	// Attempts counts consecutive failed releases; LastError is the latest.
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// ErrScheduledNotFound is returned by CancelScheduled for an unknown ID.
+var ErrScheduledNotFound = errors.New("scheduled message not found")
+
+// scheduleDir returns the scheduled-mail spool directory.
+func scheduleDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "mail_scheduled")
+}
+
+// ParseDeliverAt parses a --at value: RFC 3339, "2006-01-02 15:04", or a
+// bare "15:04", which means the next time that clock time comes round.
+func ParseDeliverAt(text string, now time.Time) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	if t, err := time.Parse(time.RFC3339, text); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if clock, err := time.ParseInLocation("15:04", text, now.Location()); err == nil {
+		t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (want HH:MM, \"YYYY-MM-DD HH:MM\" or RFC 3339)", text)
+}
+
+// ParseDeliverIn parses a --in value: a Go duration ("30m", "2h") or a
+// whole number of days ("1d").
+func ParseDeliverIn(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	var d time.Duration
+	if n := len(text); n > 1 && text[n-1] == 'd' {
+		days, err := strconv.Atoi(text[:n-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid delay %q", text)
+		}
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(text)
+		if err != nil {
+			return 0, fmt.Errorf("invalid delay %q (want e.g. 30m, 2h, 1d)", text)
+		}
+		d = parsed
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("delay must be positive, got %q", text)
+	}
+	return d, nil
+}
+
+// Schedule spools msg for delivery at msg.DeliverAt, or at the next match
+// of cron when DeliverAt is unset. Protocol messages are validated now so a
+// malformed one fails at the sender rather than at release time.
+func (r *Router) Schedule(msg *Message, cron string) (*ScheduledMessage, error) {
+	if r.townRoot == "" {
+		return nil, fmt.Errorf("scheduling mail requires a town root")
+	}
+	if msg.To == "" {
+		return nil, fmt.Errorf("scheduled message has no recipient")
+	}
+	now := time.Now()
+	if cron != "" {
+		sched, err := ParseCron(cron)
+		if err != nil {
+			return nil, err
+		}
+		if msg.DeliverAt == nil {
+			next := sched.Next(now)
+			if next.IsZero() {
+				return nil, fmt.Errorf("cron spec %q never matches", cron)
+			}
+			msg.DeliverAt = &next
+		}
+	}
+	if msg.DeliverAt == nil {
+		return nil, fmt.Errorf("scheduled message has no delivery time")
+	}
+	if err := r.checkProtocol(msg); err != nil {
+		return nil, err
+	}
+
+	entry := &ScheduledMessage{
+		ID:        "sched-" + strings.TrimPrefix(generateID(), "msg-"),
+		Message:   msg,
+		Cron:      cron,
+		NoNotify:  msg.SuppressNotify,
+		CreatedAt: now,
+	}
+	if err := writeScheduled(r.townRoot, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListScheduled returns pending deliveries, soonest first.
+func ListScheduled(townRoot string) ([]*ScheduledMessage, error) {
+	entries, err := os.ReadDir(scheduleDir(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading scheduled mail: %w", err)
+	}
+	var out []*ScheduledMessage
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		s, err := readScheduled(filepath.Join(scheduleDir(townRoot), e.Name()))
+		if err != nil {
+			continue // Skip corrupt or half-written entries
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Message.DeliverAt.Before(*out[j].Message.DeliverAt)
+	})
+	return out, nil
+}
+
+// CancelScheduled removes a pending delivery.
+func CancelScheduled(townRoot, id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("%w: %q", ErrScheduledNotFound, id)
+	}
+	err := os.Remove(filepath.Join(scheduleDir(townRoot), id+".json"))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrScheduledNotFound, id)
+	}
+	return err
+}
+
+// ReleaseDue sends every scheduled message due at now. One-shot entries are
+// removed once sent; recurring entries move to their next cron match.
+// Entries that fail to send stay in the spool and are retried on the next
+// call, up to maxReleaseAttempts, then go to the dead-letter mailbox.
+// Returns the entries that were sent.
+func (r *Router) ReleaseDue(now time.Time) ([]*ScheduledMessage, error) {
+	recoverClaims(r.townRoot)
+	pending, err := ListScheduled(r.townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var released []*ScheduledMessage
+	var errs []string
+	for _, s := range pending {
+		if s.Message.DeliverAt.After(now) {
+			break // Sorted by delivery time
+		}
+		path := filepath.Join(scheduleDir(r.townRoot), s.ID+".json")
+		claimed := path + ".claimed"
+		// Claim by rename so concurrent releasers don't double-send
+		if err := os.Rename(path, claimed); err != nil {
+			continue
+		}
+		// Rename keeps the old mtime; stamp the claim so recoverClaims can age it
+		claimedAt := time.Now()
+		_ = os.Chtimes(claimed, claimedAt, claimedAt)
+
+		msg := *s.Message
+		msg.ID = ""
+		msg.DeliverAt = nil
+		msg.Timestamp = now
+		msg.SuppressNotify = s.NoNotify
+		sendErr := r.Send(&msg)
+		if sendErr != nil {
+			s.Attempts++
+			s.LastError = sendErr.Error()
+			errs = append(errs, fmt.Sprintf("%s: %v", s.ID, sendErr))
+			if s.Attempts >= maxReleaseAttempts || errors.Is(sendErr, ErrInvalidProtocol) {
+				if !errors.Is(sendErr, ErrInvalidProtocol) { // already dead-lettered by Send
+					_ = r.sendToSingle(newDeadLetter(&msg, sendErr))
+				}
+				_ = os.Remove(claimed)
+				continue
+			}
+		} else {
+			s.Attempts = 0
+			s.LastError = ""
+			s.Delivered++
+			released = append(released, s)
+			next := s.next(now)
+			if next.IsZero() {
+				_ = os.Remove(claimed)
+				continue
+			}
+			s.Message.DeliverAt = &next
+		}
+
+		if err := writeScheduled(r.townRoot, s); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", s.ID, err))
+			_ = os.Rename(claimed, path)
+			continue
+		}
+		_ = os.Remove(claimed)
+	}
+
+	if len(errs) > 0 {
+		return released, fmt.Errorf("releasing scheduled mail: %s", strings.Join(errs, "; "))
+	}
+	return released, nil
+}
+
+// recoverClaims returns entries left claimed by a releaser that crashed
+// between claiming and rewriting them. If the rewrite landed, the claim is
+// just removed; otherwise the entry goes back to the spool and is retried,
+// so a crash after sending can deliver the message twice rather than never.
+func recoverClaims(townRoot string) {
+	claims, _ := filepath.Glob(filepath.Join(scheduleDir(townRoot), "*.json.claimed"))
+	for _, claimed := range claims {
+		info, err := os.Stat(claimed)
+		if err != nil || time.Since(info.ModTime()) < claimTimeout {
+			continue // Gone, or still held by a live releaser
+		}
+		path := strings.TrimSuffix(claimed, ".claimed")
+		if _, err := os.Stat(path); err == nil {
+			_ = os.Remove(claimed)
+			continue
+		}
+		_ = os.Rename(claimed, path)
+	}
+}
+
+// listClaimed returns entries a releaser currently holds claimed. They are
+// mid-delivery, not gone: their attachments are still referenced.
+func listClaimed(townRoot string) []*ScheduledMessage {
+	claims, _ := filepath.Glob(filepath.Join(scheduleDir(townRoot), "*.json.claimed"))
+	var out []*ScheduledMessage
+	for _, claimed := range claims {
+		if s, err := readScheduled(claimed); err == nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// next returns the entry's next delivery after now, or zero for one-shot mail.
+func (s *ScheduledMessage) next(now time.Time) time.Time {
+	if s.Cron == "" {
+		return time.Time{}
+	}
+	sched, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.Next(now)
+}
+
+func writeScheduled(townRoot string, s *ScheduledMessage) error {
+	dir := scheduleDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating scheduled mail dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling scheduled mail: %w", err)
+	}
+	// Write-then-rename so ListScheduled never sees a partial entry
+	path := filepath.Join(dir, s.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing scheduled mail: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func readScheduled(path string) (*ScheduledMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s ScheduledMessage
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Message == nil || s.Message.DeliverAt == nil {
+		return nil, fmt.Errorf("scheduled entry %s has no delivery time", s.ID)
+	}
+	return &s, nil
+}
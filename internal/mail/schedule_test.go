@@ -0,0 +1,198 @@
+package mail
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2026, 3, 6, 10, 7, 30, 0, time.Local) // Friday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 6, 10, 15, 0, 0, time.Local)},
+		{"0 9 * * *", time.Date(2026, 3, 7, 9, 0, 0, 0, time.Local)},
+		{"0 9 * * 1-5", time.Date(2026, 3, 9, 9, 0, 0, 0, time.Local)},
+		{"30 10 * * *", time.Date(2026, 3, 6, 10, 30, 0, 0, time.Local)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)},
+		{"0 12 15 * 0", time.Date(2026, 3, 8, 12, 0, 0, 0, time.Local)}, // day 15 OR Sunday
+		{"0 0 * * 7", time.Date(2026, 3, 8, 0, 0, 0, 0, time.Local)},
+		{"@hourly", time.Date(2026, 3, 6, 11, 0, 0, 0, time.Local)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.spec, err)
+		}
+		if got := c.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q.Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestParseDeliverAt(t *testing.T) {
+	now := time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"11:30", time.Date(2026, 3, 6, 11, 30, 0, 0, time.Local)},
+		{"09:00", time.Date(2026, 3, 7, 9, 0, 0, 0, time.Local)},
+		{"2026-03-10 08:15", time.Date(2026, 3, 10, 8, 15, 0, 0, time.Local)},
+		{"2026-03-10T08:15:00Z", time.Date(2026, 3, 10, 8, 15, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseDeliverAt(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseDeliverAt(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseDeliverAt("tomorrow", now); err == nil {
+		t.Error("ParseDeliverAt(tomorrow) succeeded, want error")
+	}
+
+	if d, err := ParseDeliverIn("2d"); err != nil || d != 48*time.Hour {
+		t.Errorf("ParseDeliverIn(2d) = %v, %v", d, err)
+	}
+	if _, err := ParseDeliverIn("-5m"); err == nil {
+		t.Error("ParseDeliverIn(-5m) succeeded, want error")
+	}
+}
+
+func TestScheduleListCancel(t *testing.T) {
+	town := t.TempDir()
+	r := NewRouterWithTownRoot(town, town)
+
+	later := time.Now().Add(2 * time.Hour)
+	soon := time.Now().Add(time.Hour)
+	first := NewMessage("mayor/", "gastown/witness", "later", "")
+	first.DeliverAt = &later
+	if err := r.Send(first); err != nil { // future DeliverAt spools instead of sending
+		t.Fatalf("Send: %v", err)
+	}
+	second := NewMessage("mayor/", "mayor/", "standup", "")
+	second.DeliverAt = &soon
+	second.SuppressNotify = true
+	recurring, err := r.Schedule(second, "0 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+
+	pending, err := ListScheduled(town)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("ListScheduled = %d entries, %v", len(pending), err)
+	}
+	if pending[0].ID != recurring.ID || pending[0].Cron == "" || !pending[0].NoNotify {
+		t.Errorf("first pending = %+v, want the recurring entry", pending[0])
+	}
+	if pending[1].Message.Subject != "later" {
+		t.Errorf("second pending subject = %q", pending[1].Message.Subject)
+	}
+
+	released, err := r.ReleaseDue(time.Now())
+	if err != nil || len(released) != 0 {
+		t.Errorf("ReleaseDue before due = %v, %v", released, err)
+	}
+
+	if err := CancelScheduled(town, recurring.ID); err != nil {
+		t.Fatalf("CancelScheduled: %v", err)
+	}
+	if err := CancelScheduled(town, recurring.ID); !errors.Is(err, ErrScheduledNotFound) {
+		t.Errorf("second cancel = %v, want ErrScheduledNotFound", err)
+	}
+	if err := CancelScheduled(town, "../x"); !errors.Is(err, ErrScheduledNotFound) {
+		t.Errorf("cancel with path = %v, want ErrScheduledNotFound", err)
+	}
+	if pending, _ := ListScheduled(town); len(pending) != 1 {
+		t.Errorf("after cancel: %d pending, want 1", len(pending))
+	}
+}
+
+func TestReleaseDue_RecoversStaleClaims(t *testing.T) {
+	town := t.TempDir()
+	r := NewRouterWithTownRoot(town, town)
+	later := time.Now().Add(time.Hour)
+
+	var ids []string
+	for _, subject := range []string{"stale", "fresh", "rewritten"} {
+		msg := NewMessage("mayor/", "gastown/witness", subject, "")
+		msg.DeliverAt = &later
+		entry, err := r.Schedule(msg, "")
+		if err != nil {
+			t.Fatalf("Schedule: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+	path := func(id string) string { return filepath.Join(scheduleDir(town), id+".json") }
+	old := time.Now().Add(-2 * claimTimeout)
+
+	// A releaser crashed after claiming "stale" and "rewritten"; it had
+	// already rewritten "rewritten". "fresh" is held by a live releaser.
+	for _, id := range ids {
+		if err := os.Rename(path(id), path(id)+".claimed"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(path(ids[2]), mustRead(t, path(ids[2])+".claimed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{ids[0], ids[2]} {
+		if err := os.Chtimes(path(id)+".claimed", old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := r.ReleaseDue(time.Now()); err != nil {
+		t.Fatalf("ReleaseDue: %v", err)
+	}
+	pending, err := ListScheduled(town)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("ListScheduled = %d entries, %v; want stale and rewritten", len(pending), err)
+	}
+	for _, id := range []string{ids[0], ids[2]} {
+		if _, err := os.Stat(path(id) + ".claimed"); !os.IsNotExist(err) {
+			t.Errorf("claim for %s not cleared: %v", id, err)
+		}
+	}
+	if _, err := os.Stat(path(ids[1]) + ".claimed"); err != nil {
+		t.Errorf("live claim was recovered: %v", err)
+	}
+	// Attachment GC must still see the live claim's references.
+	if claimed := listClaimed(town); len(claimed) != 1 || claimed[0].ID != ids[1] {
+		t.Errorf("listClaimed = %v, want only %s", claimed, ids[1])
+	}
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestScheduledNext(t *testing.T) {
+	now := time.Date(2026, 3, 6, 9, 0, 30, 0, time.Local)
+	oneShot := &ScheduledMessage{}
+	if !oneShot.next(now).IsZero() {
+		t.Error("one-shot entry has a next delivery")
+	}
+	daily := &ScheduledMessage{Cron: "@daily"}
+	if got, want := daily.next(now), time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("daily next = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/ratelimit"
+)
+
+// Mail flood limits. A sender over any of them gets an error wrapping
+// ratelimit.ErrRateLimited, and the first violation in a window files an
+// escalation against the sender.
+var (
+	// MailSenderLimit caps everything one agent sends.
+	MailSenderLimit = ratelimit.Rule{Max: 100, Window: 10 * time.Minute, Reason: "per-sender mail limit"}
+
+	// MailPairLimit caps what one agent sends to one recipient.
+	MailPairLimit = ratelimit.Rule{Max: 30, Window: 10 * time.Minute, Reason: "per-recipient mail limit"}
+
+	// ReplyVelocityLimit catches two agents auto-replying to each other:
+	// many replies between them on one thread in a short window. Other
+	// participants replying on the same thread count separately.
+	ReplyVelocityLimit = ratelimit.Rule{Max: 12, Window: 10 * time.Minute, Reason: "reply loop (thread velocity)"}
+
+	// ReplyDepthLimit catches slow loops: a thread that keeps growing all day.
+	ReplyDepthLimit = ratelimit.Rule{Max: 100, Window: 24 * time.Hour, Reason: "reply loop (thread depth)"}
+)
+
+// throttle checks msg against the mail flood limits and records the send.
+// The overseer is a human and is never throttled; internal dead-letter
+// deliveries are exempt too.
+func (r *Router) throttle(msg *Message) error {
+	from := AddressToIdentity(msg.From)
+	if r.townRoot == "" || from == "" || from == "overseer" || AddressToIdentity(msg.To) == DeadLetterAddress {
+		return nil
+	}
+	to := AddressToIdentity(msg.To)
+
+	sender, pair := MailSenderLimit, MailPairLimit
+	sender.Key = "sender:" + from
+	pair.Key = "pair:" + from + "->" + to
+	rules := []ratelimit.Rule{sender, pair}
+	if msg.ReplyTo != "" && msg.ThreadID != "" {
+		velocity, depth := ReplyVelocityLimit, ReplyDepthLimit
+		a, b := from, to
+		if b < a {
+			a, b = b, a
+		}
+		velocity.Key = "thread:" + msg.ThreadID + ":" + a + "<->" + b
+		depth.Key = velocity.Key
+		rules = append(rules, velocity, depth)
+	}
+
+	v, err := ratelimit.New(r.townRoot, "mail").Allow(time.Now(), rules...)
+	if err != nil || v == nil {
+		return nil // A broken history file must not block mail
+	}
+	ratelimit.Report(r.townRoot, "mail", from, v)
+	return fmt.Errorf("mail from %s to %s: %w", from, to, v)
+}
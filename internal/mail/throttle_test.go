@@ -0,0 +1,66 @@
+package mail
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/ratelimit"
+)
+
+func TestThrottle_ReplyLoop(t *testing.T) {
+	town := t.TempDir()
+	r := NewRouterWithTownRoot(town, town)
+	saved := ReplyVelocityLimit
+	ReplyVelocityLimit.Max = 3
+	defer func() { ReplyVelocityLimit = saved }()
+
+	reply := func(from, to string) error {
+		return r.throttle(&Message{From: from, To: to, ThreadID: "thread-1", ReplyTo: "m0", Subject: "Re: ping"})
+	}
+	for i := 0; i < 3; i++ {
+		from, to := "gastown/nux", "gastown/witness"
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		if err := reply(from, to); err != nil {
+			t.Fatalf("reply %d: %v", i, err)
+		}
+	}
+	// A busy thread with other participants is not a loop between them
+	if err := reply("mayor/", "gastown/witness"); err != nil {
+		t.Errorf("other pair on thread: %v", err)
+	}
+	if err := reply("gastown/witness", "gastown/nux"); !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Errorf("4th reply on thread: err = %v, want ErrRateLimited", err)
+	}
+
+	// New threads and non-replies are not part of the loop
+	if err := r.throttle(&Message{From: "gastown/nux", To: "gastown/witness", ThreadID: "thread-2"}); err != nil {
+		t.Errorf("new thread: %v", err)
+	}
+}
+
+func TestThrottle_PairAndExemptions(t *testing.T) {
+	town := t.TempDir()
+	r := NewRouterWithTownRoot(town, town)
+	saved := MailPairLimit
+	MailPairLimit.Max = 1
+	defer func() { MailPairLimit = saved }()
+
+	msg := &Message{From: "gastown/polecats/nux", To: "mayor/"}
+	if err := r.throttle(msg); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	// crew/polecats forms normalize to the same sender
+	if err := r.throttle(&Message{From: "gastown/nux", To: "mayor"}); !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Errorf("second send to same recipient: err = %v", err)
+	}
+	if err := r.throttle(&Message{From: "gastown/nux", To: DeadLetterAddress}); err != nil {
+		t.Errorf("dead-letter delivery throttled: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := r.throttle(&Message{From: "overseer", To: "mayor/"}); err != nil {
+			t.Errorf("overseer throttled: %v", err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -123,6 +124,10 @@ type Message struct {
 	// Only set for queue messages after claiming.
 	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
 
+	// ClaimAttempts counts claims on this queue message whose lease expired
+	// before the claimant finished. Stored as a claim-attempts:<n> label.
+	ClaimAttempts int `json:"claim_attempts,omitempty"`
+
 	// DeliveryState tracks two-phase mailbox delivery state: pending or acked.
 	DeliveryState string `json:"delivery_state,omitempty"`
 	// DeliveryAckedBy is the recipient identity that acknowledged receipt.
@@ -130,6 +135,18 @@ type Message struct {
 	// DeliveryAckedAt is when receipt was acknowledged.
 	DeliveryAckedAt *time.Time `json:"delivery_acked_at,omitempty"`
 
+	// Protocol is the protocol message type (e.g., "MERGE_READY") for
+	// structured inter-agent messages. Stored as a protocol:<type> label.
+	Protocol string `json:"protocol,omitempty"`
+
+	// Attachments reference content stored outside the body (see
+	// StoreAttachment). Stored as attachment:<kind>:<hash>:<size>:<name> labels.
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// DeliverAt holds the message back until the given time. Router.Send
+	// spools messages with a future DeliverAt; the daemon sends them when due.
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+
 	// SuppressNotify tells the router to skip all recipient notification
 	// (no nudge, no banner). Set by the CLI when --no-notify is passed.
 	// In-memory only — not serialized.
@@ -313,6 +330,9 @@ type BeadsMessage struct {
 	channel   string     // Channel name (for broadcast messages)
 	claimedBy string     // Who claimed the queue message
 	claimedAt *time.Time // When the queue message was claimed
+	attempts  int        // Expired claims on the queue message
+	protocol  string     // Protocol message type
+	attached  []Attachment
 	// Two-phase delivery metadata
 	deliveryState   string
 	deliveryAckedBy string
@@ -331,6 +351,9 @@ func (bm *BeadsMessage) ParseLabels() {
 	bm.channel = ""
 	bm.claimedBy = ""
 	bm.claimedAt = nil
+	bm.attempts = 0
+	bm.protocol = ""
+	bm.attached = nil
 	bm.deliveryState = ""
 	bm.deliveryAckedBy = ""
 	bm.deliveryAckedAt = nil
@@ -357,6 +380,14 @@ func (bm *BeadsMessage) ParseLabels() {
 			if t, err := time.Parse(time.RFC3339, ts); err == nil {
 				bm.claimedAt = &t
 			}
+		} else if strings.HasPrefix(label, ClaimAttemptsLabelPrefix) {
+			if n, err := strconv.Atoi(strings.TrimPrefix(label, ClaimAttemptsLabelPrefix)); err == nil {
+				bm.attempts = n
+			}
+		} else if strings.HasPrefix(label, protocolLabelPrefix) {
+			bm.protocol = strings.TrimPrefix(label, protocolLabelPrefix)
+		} else if a, ok := parseAttachmentLabel(label); ok {
+			bm.attached = append(bm.attached, a)
 		}
 	}
 
@@ -427,9 +458,12 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		Channel:         bm.channel,
 		ClaimedBy:       bm.claimedBy,
 		ClaimedAt:       bm.claimedAt,
+		ClaimAttempts:   bm.attempts,
 		DeliveryState:   bm.deliveryState,
 		DeliveryAckedBy: bm.deliveryAckedBy,
 		DeliveryAckedAt: bm.deliveryAckedAt,
+		Protocol:        bm.protocol,
+		Attachments:     bm.attached,
 	}
 }
 
@@ -614,12 +614,16 @@ func TestBeadsMessageParseQueueChannelLabels(t *testing.T) {
 			"queue:work-requests",
 			"claimed-by:gastown/nux",
 			"claimed-at:" + claimedAtStr,
+			"claim-attempts:2",
 		},
 		Priority: 2,
 	}
 
 	msg := bm.ToMessage()
 
+	if msg.ClaimAttempts != 2 {
+		t.Errorf("ClaimAttempts = %d, want 2", msg.ClaimAttempts)
+	}
 	if msg.Queue != "work-requests" {
 		t.Errorf("Queue = %q, want 'work-requests'", msg.Queue)
 	}
@@ -0,0 +1,171 @@
+package nudge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Nudge kinds. Pending nudges with the same sender and kind collapse to the
+// latest, so an agent that was busy for a while sees one of each.
+const (
+	// KindMail announces new mail.
+	KindMail = "mail"
+	// KindWork announces dispatched work.
+	KindWork = "work"
+	// KindMerge announces work waiting in the merge queue.
+	KindMerge = "merge"
+)
+
+// coalesceKey returns the identity two nudges must share to collapse, or ""
+// if the nudge never collapses. Only a sender's own nudges collapse, so one
+// agent cannot drop another's.
+func coalesceKey(n QueuedNudge) string {
+	switch {
+	case n.Key != "":
+		return "key:" + n.Sender + "\x00" + n.Key
+	case n.Kind != "":
+		return "kind:" + n.Sender + "\x00" + n.Kind
+	}
+	return ""
+}
+
+// fold merges an older nudge into a newer one it supersedes. The newer
+// message wins; urgency and the longer expiry are kept.
+func fold(newer *QueuedNudge, older QueuedNudge) {
+	newer.Coalesced += older.Coalesced + 1
+	if older.Priority == PriorityUrgent {
+		newer.Priority = PriorityUrgent
+	}
+	if older.ExpiresAt.After(newer.ExpiresAt) {
+		newer.ExpiresAt = older.ExpiresAt
+	}
+}
+
+// Coalesce collapses superseded nudges and orders the rest for delivery:
+// urgent nudges first, then normal ones, each in FIFO order. The input must
+// be in FIFO order; a collapsed nudge takes the position of its latest copy.
+func Coalesce(nudges []QueuedNudge) []QueuedNudge {
+	kept := make([]QueuedNudge, 0, len(nudges))
+	latest := make(map[string]int) // coalesce key -> index in kept
+	for i := len(nudges) - 1; i >= 0; i-- {
+		n := nudges[i]
+		key := coalesceKey(n)
+		if j, ok := latest[key]; ok && key != "" {
+			fold(&kept[j], n)
+			continue
+		}
+		if key != "" {
+			latest[key] = len(kept)
+		}
+		kept = append(kept, n)
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Priority == PriorityUrgent && kept[j].Priority != PriorityUrgent
+	})
+	return kept
+}
+
+// queuedFile is a pending nudge and the file it is stored in.
+type queuedFile struct {
+	path  string
+	nudge QueuedNudge
+}
+
+// readPending returns the unclaimed, unexpired nudges in dir in FIFO order
+// without claiming them.
+func readPending(dir string) ([]queuedFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading nudge queue: %w", err)
+	}
+
+	now := time.Now()
+	var files []queuedFile
+	for _, entry := range entries { // ReadDir sorts by name, i.e. FIFO
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // Claimed by a concurrent Drain
+		}
+		var n QueuedNudge
+		if err := json.Unmarshal(data, &n); err != nil {
+			continue
+		}
+		if !n.ExpiresAt.IsZero() && now.After(n.ExpiresAt) {
+			continue
+		}
+		files = append(files, queuedFile{path: path, nudge: n})
+	}
+	return files, nil
+}
+
+// List returns the pending nudges for a session as Drain would deliver
+// them, without removing anything.
+func List(townRoot, session string) ([]QueuedNudge, error) {
+	files, err := readPending(queueDir(townRoot, session))
+	if err != nil {
+		return nil, err
+	}
+	nudges := make([]QueuedNudge, 0, len(files))
+	for _, f := range files {
+		nudges = append(nudges, f.nudge)
+	}
+	return Coalesce(nudges), nil
+}
+
+// superseded returns the pending files n replaces and folds them into n.
+// The caller removes them once n is queued. Best-effort: a nudge a
+// concurrent Drain claims first is delivered anyway, and Drain coalesces
+// anything that slips through.
+func superseded(files []queuedFile, n *QueuedNudge) []queuedFile {
+	key := coalesceKey(*n)
+	if key == "" {
+		return nil
+	}
+	var out []queuedFile
+	for _, f := range files {
+		if coalesceKey(f.nudge) == key {
+			fold(n, f.nudge)
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// oldestNormal returns the oldest pending normal-priority file not in skip,
+// which an urgent nudge may evict to make room. Returns nil if every
+// pending nudge is urgent or skipped.
+func oldestNormal(files, skip []queuedFile) *queuedFile {
+	skipped := make(map[string]bool, len(skip))
+	for _, f := range skip {
+		skipped[f.path] = true
+	}
+	for i, f := range files {
+		if f.nudge.Priority != PriorityUrgent && !skipped[f.path] {
+			return &files[i]
+		}
+	}
+	return nil
+}
+
+// coalescedNote describes how many older nudges were folded into n.
+func coalescedNote(n QueuedNudge) string {
+	if n.Coalesced == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (+%d earlier)", n.Coalesced)
+}
@@ -0,0 +1,166 @@
+package nudge
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCoalesce(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	nudges := []QueuedNudge{
+		{Sender: "mayor/", Message: "mail 1", Kind: KindMail, Timestamp: at},
+		{Sender: "sling", Message: "work", Kind: KindWork, Timestamp: at.Add(1)},
+		{Sender: "deacon/", Message: "status: 3 idle", Key: "status", Timestamp: at.Add(2)},
+		{Sender: "mayor/", Message: "mail 2", Kind: KindMail, Timestamp: at.Add(3)},
+		{Sender: "deacon/", Message: "mail from deacon", Kind: KindMail, Timestamp: at.Add(4)},
+		{Sender: "deacon/", Message: "status: 1 idle", Key: "status", Priority: PriorityUrgent, Timestamp: at.Add(5)},
+		{Sender: "mayor/", Message: "plain", Timestamp: at.Add(6)},
+		{Sender: "mayor/", Message: "plain", Timestamp: at.Add(7)},
+		{Sender: "gastown/witness", Message: "status: witness view", Key: "status", Timestamp: at.Add(8)},
+	}
+
+	got := Coalesce(nudges)
+	want := []struct {
+		msg       string
+		coalesced int
+	}{
+		{"status: 1 idle", 1}, // urgent preempts
+		{"work", 0},
+		{"mail 2", 1},
+		{"mail from deacon", 0},
+		{"plain", 0}, // unkeyed nudges never collapse
+		{"plain", 0},
+		{"status: witness view", 0}, // keys are per sender
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Coalesce returned %d nudges, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Message != w.msg || got[i].Coalesced != w.coalesced {
+			t.Errorf("got[%d] = %q (+%d), want %q (+%d)", i, got[i].Message, got[i].Coalesced, w.msg, w.coalesced)
+		}
+	}
+}
+
+func TestCoalesce_KeepsUrgency(t *testing.T) {
+	got := Coalesce([]QueuedNudge{
+		{Sender: "a", Message: "old", Kind: KindMail, Priority: PriorityUrgent},
+		{Sender: "a", Message: "new", Kind: KindMail, Priority: PriorityNormal},
+	})
+	if len(got) != 1 || got[0].Message != "new" || got[0].Priority != PriorityUrgent {
+		t.Errorf("Coalesce = %+v, want newer message at urgent priority", got)
+	}
+}
+
+func TestEnqueueSupersedes(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-supersede"
+
+	for i := 0; i < 5; i++ {
+		if err := Enqueue(townRoot, session, QueuedNudge{Sender: "mayor/", Message: "check mail", Kind: KindMail}); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+	if pending, _ := Pending(townRoot, session); pending != 1 {
+		t.Errorf("Pending = %d, want 1", pending)
+	}
+
+	listed, err := List(townRoot, session)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Coalesced != 4 {
+		t.Errorf("List = %+v, want one nudge replacing 4", listed)
+	}
+	if pending, _ := Pending(townRoot, session); pending != 1 {
+		t.Error("List consumed the queue")
+	}
+}
+
+func TestEnqueueRejectedKeepsSuperseded(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-supersede-rejected"
+	saved := PairLimit
+	PairLimit.Max = 1
+	defer func() { PairLimit = saved }()
+
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "mayor/", Message: "first", Kind: KindMail}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "mayor/", Message: "second", Kind: KindMail}); err == nil {
+		t.Fatal("second Enqueue was not throttled")
+	}
+
+	listed, err := List(townRoot, session)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Message != "first" {
+		t.Errorf("List = %+v, want the first nudge still queued", listed)
+	}
+}
+
+func TestEnqueueUrgentIgnoresExpired(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-expired"
+
+	past := time.Now().Add(-time.Hour)
+	for i := 0; i < MaxQueueDepth; i++ {
+		n := QueuedNudge{Sender: "sender", Message: "stale", Timestamp: past, ExpiresAt: past.Add(time.Minute)}
+		if err := Enqueue(townRoot, session, n); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "stuck", Priority: PriorityUrgent}); err != nil {
+		t.Fatalf("urgent Enqueue on queue of expired nudges: %v", err)
+	}
+}
+
+func TestEnqueueThrottledUrgentEvictsNothing(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-preempt-throttled"
+	saved := PairLimit
+	PairLimit.Max = 1
+	defer func() { PairLimit = saved }()
+
+	for i := 0; i < MaxQueueDepth; i++ {
+		if err := Enqueue(townRoot, session, QueuedNudge{Sender: fmt.Sprintf("sender-%d", i), Message: "normal"}); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "first", Priority: PriorityUrgent}); err != nil {
+		t.Fatalf("urgent Enqueue: %v", err)
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "second", Priority: PriorityUrgent}); err == nil {
+		t.Fatal("second urgent Enqueue was not throttled")
+	}
+	if pending, _ := Pending(townRoot, session); pending != MaxQueueDepth {
+		t.Errorf("Pending = %d, want %d: a throttled nudge evicted another", pending, MaxQueueDepth)
+	}
+}
+
+func TestEnqueueUrgentPreemptsFullQueue(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-preempt"
+
+	for i := 0; i < MaxQueueDepth; i++ {
+		if err := Enqueue(townRoot, session, QueuedNudge{Sender: "sender", Message: "normal"}); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "stuck", Priority: PriorityUrgent}); err != nil {
+		t.Fatalf("urgent Enqueue on full queue: %v", err)
+	}
+
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(nudges) != MaxQueueDepth {
+		t.Errorf("Drain returned %d, want %d", len(nudges), MaxQueueDepth)
+	}
+	if nudges[0].Message != "stuck" {
+		t.Errorf("first nudge = %q, want the urgent one", nudges[0].Message)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/ratelimit"
 )
 
 // Priority levels for nudge delivery.
@@ -50,6 +51,17 @@ const (
 	staleClaimThreshold = 5 * time.Minute
 )
 
+// Nudge flood limits, checked by Enqueue. A sender over either one gets an
+// error wrapping ratelimit.ErrRateLimited, and the first violation in a
+// window files an escalation against the sender.
+var (
+	// SenderLimit caps the nudges one agent sends across all sessions.
+	SenderLimit = ratelimit.Rule{Max: 120, Window: 10 * time.Minute, Reason: "per-sender nudge limit"}
+
+	// PairLimit caps the nudges one agent sends to one session.
+	PairLimit = ratelimit.Rule{Max: 60, Window: 10 * time.Minute, Reason: "per-session nudge limit"}
+)
+
 // QueuedNudge represents a nudge message stored in the queue.
 type QueuedNudge struct {
 	Sender    string    `json:"sender"`
@@ -57,6 +69,17 @@ type QueuedNudge struct {
 	Priority  string    `json:"priority"`
 	Timestamp time.Time `json:"timestamp"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// Kind classifies the nudge (see KindMail etc.). Pending nudges with the
+	// same sender and kind collapse to the latest one.
+	Kind string `json:"kind,omitempty"`
+
+	// Key names the state this nudge reports on. A newer nudge with the same
+	// key supersedes the sender's older ones.
+	Key string `json:"key,omitempty"`
+
+	// Coalesced counts the older nudges folded into this one.
+	Coalesced int `json:"coalesced,omitempty"`
 }
 
 // queueDir returns the nudge queue directory for a given session.
@@ -77,19 +100,14 @@ func randomSuffix() string {
 
 // Enqueue writes a nudge to the queue for the given session.
 // The nudge will be picked up by the agent's hook at the next turn boundary.
-// Returns an error if the queue is full (MaxQueueDepth reached).
+// Returns an error if the queue is full (MaxQueueDepth reached) or the
+// sender is over SenderLimit or PairLimit.
 func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	dir := queueDir(townRoot, session)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating nudge queue dir: %w", err)
 	}
 
-	// Check queue depth before writing to prevent runaway senders.
-	pending, _ := Pending(townRoot, session)
-	if pending >= MaxQueueDepth {
-		return fmt.Errorf("nudge queue for %s is full (%d/%d pending)", session, pending, MaxQueueDepth)
-	}
-
 	if nudge.Timestamp.IsZero() {
 		nudge.Timestamp = time.Now()
 	}
@@ -107,6 +125,29 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 		}
 	}
 
+	// Pending nudges this one supersedes are dropped once it is written,
+	// so a busy agent's queue holds one "check your mail" rather than fifty.
+	// Expired nudges don't count: Drain discards them.
+	files, _ := readPending(dir)
+	replaced := superseded(files, &nudge)
+
+	// Check queue depth before writing to prevent runaway senders.
+	// Urgent nudges preempt: they evict the oldest normal nudge instead
+	// of failing, once they are safely queued.
+	pending := len(files) - len(replaced)
+	var evict *queuedFile
+	if pending >= MaxQueueDepth {
+		if nudge.Priority == PriorityUrgent {
+			evict = oldestNormal(files, replaced)
+		}
+		if evict == nil {
+			return fmt.Errorf("nudge queue for %s is full (%d/%d pending)", session, pending, MaxQueueDepth)
+		}
+	}
+	if err := throttle(townRoot, session, nudge.Sender); err != nil {
+		return err
+	}
+
 	data, err := json.MarshalIndent(nudge, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling nudge: %w", err)
@@ -121,12 +162,36 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("writing nudge to queue: %w", err)
 	}
+	for _, f := range replaced {
+		_ = os.Remove(f.path)
+	}
+	if evict != nil {
+		_ = os.Remove(evict.path)
+	}
 
 	return nil
 }
 
+// throttle checks a send against the nudge flood limits and records it.
+// Anonymous senders and the overseer are not limited.
+func throttle(townRoot, session, sender string) error {
+	if sender == "" || sender == "overseer" {
+		return nil
+	}
+	bySender, pair := SenderLimit, PairLimit
+	bySender.Key = "sender:" + sender
+	pair.Key = "pair:" + sender + "->" + session
+	v, err := ratelimit.New(townRoot, "nudge").Allow(time.Now(), bySender, pair)
+	if err != nil || v == nil {
+		return nil // A broken history file must not block nudges
+	}
+	ratelimit.Report(townRoot, "nudge", sender, v)
+	return fmt.Errorf("nudge to %s from %s: %w", session, sender, v)
+}
+
 // Drain reads and removes all queued nudges for a session, returning them
-// in FIFO order. This is called by the hook to pick up pending nudges.
+// coalesced (see Coalesce): urgent nudges first, then FIFO. This is called
+// by the hook to pick up pending nudges.
 //
 // Uses rename-then-process to prevent concurrent Drain calls from delivering
 // the same nudge twice: each file is atomically renamed to a .claimed suffix
@@ -240,7 +305,7 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		}
 	}
 
-	return nudges, nil
+	return Coalesce(nudges), nil
 }
 
 // Pending returns the count of queued nudges for a session without draining.
@@ -289,19 +354,19 @@ func FormatForInjection(nudges []QueuedNudge) string {
 	if len(urgent) > 0 {
 		b.WriteString(fmt.Sprintf("QUEUED NUDGE (%d urgent):\n\n", len(urgent)))
 		for _, n := range urgent {
-			b.WriteString(fmt.Sprintf("  [URGENT from %s] %s\n", n.Sender, n.Message))
+			b.WriteString(fmt.Sprintf("  [URGENT from %s] %s%s\n", n.Sender, n.Message, coalescedNote(n)))
 		}
 		if len(normal) > 0 {
 			b.WriteString(fmt.Sprintf("\nPlus %d non-urgent nudge(s):\n", len(normal)))
 			for _, n := range normal {
-				b.WriteString(fmt.Sprintf("  [from %s] %s\n", n.Sender, n.Message))
+				b.WriteString(fmt.Sprintf("  [from %s] %s%s\n", n.Sender, n.Message, coalescedNote(n)))
 			}
 		}
 		b.WriteString("\nHandle urgent nudges before continuing current work.\n")
 	} else {
 		b.WriteString(fmt.Sprintf("QUEUED NUDGE (%d message(s)):\n\n", len(normal)))
 		for _, n := range normal {
-			b.WriteString(fmt.Sprintf("  [from %s] %s\n", n.Sender, n.Message))
+			b.WriteString(fmt.Sprintf("  [from %s] %s%s\n", n.Sender, n.Message, coalescedNote(n)))
 		}
 		b.WriteString("\nThis is a background notification. Continue current work unless the nudge is higher priority.\n")
 	}
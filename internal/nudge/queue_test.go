@@ -1,12 +1,15 @@
 package nudge
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/ratelimit"
 )
 
 func TestEnqueueAndDrain(t *testing.T) {
@@ -52,12 +55,12 @@ func TestEnqueueAndDrain(t *testing.T) {
 		t.Fatalf("Drain returned %d nudges, want 2", len(nudges))
 	}
 
-	// Verify FIFO order
-	if nudges[0].Sender != "mayor" {
-		t.Errorf("nudges[0].Sender = %q, want %q", nudges[0].Sender, "mayor")
+	// Verify the urgent nudge preempts the earlier normal one
+	if nudges[0].Sender != "gastown/witness" {
+		t.Errorf("nudges[0].Sender = %q, want %q", nudges[0].Sender, "gastown/witness")
 	}
-	if nudges[1].Sender != "gastown/witness" {
-		t.Errorf("nudges[1].Sender = %q, want %q", nudges[1].Sender, "gastown/witness")
+	if nudges[1].Sender != "mayor" {
+		t.Errorf("nudges[1].Sender = %q, want %q", nudges[1].Sender, "mayor")
 	}
 
 	// After drain, pending should be 0
@@ -564,3 +567,31 @@ func TestConcurrentDrainNoDoubleDeli(t *testing.T) {
 		t.Errorf("double delivery detected: got %d total nudges, want exactly %d", total, count)
 	}
 }
+
+func TestEnqueueRateLimit(t *testing.T) {
+	townRoot := t.TempDir()
+	saved := PairLimit
+	PairLimit.Max = 2
+	defer func() { PairLimit = saved }()
+
+	for i := 0; i < 2; i++ {
+		if err := Enqueue(townRoot, "gt-a", QueuedNudge{Sender: "flood", Message: "hi"}); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+	err := Enqueue(townRoot, "gt-a", QueuedNudge{Sender: "flood", Message: "hi"})
+	if !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Fatalf("third nudge: err = %v, want ErrRateLimited", err)
+	}
+	if pending, _ := Pending(townRoot, "gt-a"); pending != 2 {
+		t.Errorf("Pending = %d, want 2", pending)
+	}
+
+	// Other sessions and senders are unaffected
+	if err := Enqueue(townRoot, "gt-b", QueuedNudge{Sender: "flood", Message: "hi"}); err != nil {
+		t.Errorf("nudge to another session: %v", err)
+	}
+	if err := Enqueue(townRoot, "gt-a", QueuedNudge{Sender: "mayor", Message: "hi"}); err != nil {
+		t.Errorf("nudge from another sender: %v", err)
+	}
+}
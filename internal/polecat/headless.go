@@ -0,0 +1,145 @@
+package polecat
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/git"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+	"github.com/xcawolfe-amzn/gastown/internal/runtime"
+	"github.com/xcawolfe-amzn/gastown/internal/session"
+)
+
+// HeadlessCommand returns the argv and environment for running a polecat via
+// its agent's non-interactive mode (e.g., "claude -p", "codex exec").
+//
+// Non-interactive runs get the full beacon with work instructions up front,
+// since there is no pane to deliver startup nudges into. The environment
+// mirrors what Start injects into a tmux session.
+func (m *SessionManager) HeadlessCommand(polecat string, opts SessionStartOptions) (argv, env []string, workDir string, err error) {
+	if !m.hasPolecat(polecat) {
+		return nil, nil, "", fmt.Errorf("%w: %s", ErrPolecatNotFound, polecat)
+	}
+
+	workDir = opts.WorkDir
+	if workDir == "" {
+		workDir = m.clonePath(polecat)
+	}
+	townRoot := filepath.Dir(m.rig.Path)
+
+	runtimeConfig := config.ResolveRoleAgentConfig("polecat", townRoot, m.rig.Path)
+	if opts.Agent != "" {
+		rc, _, rerr := config.ResolveAgentConfigWithOverride(townRoot, m.rig.Path, opts.Agent)
+		if rerr != nil {
+			return nil, nil, "", rerr
+		}
+		runtimeConfig = rc
+	}
+
+	// Hooks still matter in print mode for agents that support them
+	// (e.g., Claude's SessionStart runs gt prime).
+	polecatSettingsDir := config.RoleSettingsDir("polecat", m.rig.Path)
+	if err := runtime.EnsureSettingsForRole(polecatSettingsDir, workDir, "polecat", runtimeConfig); err != nil {
+		return nil, nil, "", fmt.Errorf("ensuring runtime settings: %w", err)
+	}
+
+	beacon := session.FormatStartupBeacon(session.BeaconConfig{
+		Recipient:               session.BeaconRecipient("polecat", polecat, m.rig.Name),
+		Sender:                  "witness",
+		Topic:                   "assigned",
+		MolID:                   opts.Issue,
+		IncludePrimeInstruction: true,
+	})
+	prompt := beacon + "\n\n" + runtime.StartupNudgeContent()
+
+	argv, err = config.BuildNonInteractiveArgv(runtimeConfig, prompt)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	vars := config.AgentEnv(config.AgentEnvConfig{
+		Role:             "polecat",
+		Rig:              m.rig.Name,
+		AgentName:        polecat,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: opts.RuntimeConfigDir,
+		Agent:            opts.Agent,
+	})
+	for k, v := range runtimeConfig.Env {
+		vars[k] = v
+	}
+	vars["GT_POLECAT_PATH"] = workDir
+	vars["GT_TOWN_ROOT"] = townRoot
+	vars["GT_HEADLESS"] = "1"
+	vars["BD_DOLT_AUTO_COMMIT"] = "off"
+	if opts.DoltBranch != "" {
+		vars["BD_BRANCH"] = opts.DoltBranch
+	}
+	if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && opts.RuntimeConfigDir != "" {
+		vars[runtimeConfig.Session.ConfigDirEnv] = opts.RuntimeConfigDir
+	}
+	if g := git.NewGit(workDir); g != nil {
+		if b, berr := g.CurrentBranch(); berr == nil && b != "" {
+			vars["GT_BRANCH"] = b
+		}
+	}
+
+	env = os.Environ()
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+vars[k])
+	}
+	return argv, env, workDir, nil
+}
+
+// RunHeadless runs a polecat non-interactively and blocks until the agent
+// exits. Output goes to the run log under session.HeadlessDir, where gt peek
+// and the witness can find it. The caller feeds the exit status back into
+// the done flow.
+func (m *SessionManager) RunHeadless(ctx context.Context, polecat string, opts SessionStartOptions) (*headless.RunStatus, error) {
+	sessionID := m.SessionName(polecat)
+
+	if running, _ := m.sessions.HasSession(sessionID); running {
+		return nil, fmt.Errorf("%w: %s", ErrSessionRunning, sessionID)
+	}
+
+	argv, env, workDir, err := m.HeadlessCommand(polecat, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Issue != "" {
+		agentID := fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat)
+		if err := m.hookIssue(opts.Issue, agentID, workDir); err != nil {
+			return nil, fmt.Errorf("hooking issue %s: %w", opts.Issue, err)
+		}
+	}
+
+	return headless.Run(ctx, headless.RunOptions{
+		Dir:     session.HeadlessDir(filepath.Dir(m.rig.Path)),
+		Session: sessionID,
+		WorkDir: workDir,
+		Argv:    argv,
+		Env:     env,
+	})
+}
+
+// StopHeadless terminates a polecat's non-interactive run. The supervisor is
+// asked to stop, so it does not feed the killed run into gt done.
+func (m *SessionManager) StopHeadless(polecat string) error {
+	return headless.StopRun(session.HeadlessDir(filepath.Dir(m.rig.Path)), m.SessionName(polecat))
+}
+
+// IsHeadlessRunning reports whether a non-interactive run is in progress
+// for the polecat.
+func (m *SessionManager) IsHeadlessRunning(polecat string) bool {
+	return headless.RunAlive(session.HeadlessDir(filepath.Dir(m.rig.Path)), m.SessionName(polecat))
+}
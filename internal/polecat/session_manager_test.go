@@ -5,9 +5,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
@@ -122,6 +125,47 @@ func TestIsRunningNoSession(t *testing.T) {
 	}
 }
 
+func TestSessionManagerHeadlessBackend(t *testing.T) {
+	setupTestRegistryForSession(t)
+
+	townRoot := t.TempDir()
+	settings := config.NewTownSettings()
+	settings.SessionBackend = session.BackendHeadless
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+	r := &rig.Rig{
+		Name:     "gastown",
+		Path:     filepath.Join(townRoot, "gastown"),
+		Polecats: []string{"Toast"},
+	}
+	m := NewSessionManager(tmux.NewTmux(), r)
+
+	// A session hosted for this town, as another gt process would see it.
+	dir := session.HeadlessDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	status := `{"session":"gt-Toast","pid":` + strconv.Itoa(os.Getpid()) + `,"interactive":true}`
+	if err := os.WriteFile(headless.StatusPath(dir, "gt-Toast"), []byte(status), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if running, err := m.IsRunning("Toast"); err != nil || !running {
+		t.Fatalf("IsRunning = %v, %v; want true from the headless backend", running, err)
+	}
+	if err := m.Inject("Toast", "check your hook"); err != nil {
+		t.Fatalf("Inject: %v", err)
+	}
+	input, err := os.ReadFile(headless.InputPath(dir, "gt-Toast"))
+	if err != nil || !strings.Contains(string(input), "check your hook") {
+		t.Errorf("session input = %q, %v; want the injected message", input, err)
+	}
+	if err := m.Attach("Toast"); err == nil {
+		t.Error("Attach to a headless session should fail")
+	}
+}
+
 func TestSessionManagerListEmpty(t *testing.T) {
 	requireTmux(t)
 
@@ -11,25 +11,33 @@ import (
 // NewMergeReadyMessage creates a MERGE_READY protocol message.
 // Sent by Witness to Refinery when a polecat's work is verified and ready.
 func NewMergeReadyMessage(rig, polecat, branch, issue string) *mail.Message {
-	payload := MergeReadyPayload{
-		Branch:    branch,
-		Issue:     issue,
-		Polecat:   polecat,
-		Rig:       rig,
-		Verified:  "clean git state, issue closed",
-		Timestamp: time.Now(),
+	return NewMergeReadyMessageFromPayload(MergeReadyPayload{
+		Branch:   branch,
+		Issue:    issue,
+		Polecat:  polecat,
+		Rig:      rig,
+		Verified: "clean git state, issue closed",
+	})
+}
+
+// NewMergeReadyMessageFromPayload creates a MERGE_READY protocol message
+// from a filled-in payload. A zero Timestamp is set to now.
+func NewMergeReadyMessageFromPayload(payload MergeReadyPayload) *mail.Message {
+	if payload.Timestamp.IsZero() {
+		payload.Timestamp = time.Now()
 	}
 
 	body := formatMergeReadyBody(payload)
 
 	msg := mail.NewMessage(
-		fmt.Sprintf("%s/witness", rig),
-		fmt.Sprintf("%s/refinery", rig),
-		fmt.Sprintf("MERGE_READY %s", polecat),
+		fmt.Sprintf("%s/witness", payload.Rig),
+		fmt.Sprintf("%s/refinery", payload.Rig),
+		fmt.Sprintf("MERGE_READY %s", payload.Polecat),
 		body,
 	)
 	msg.Priority = mail.PriorityHigh
 	msg.Type = mail.TypeTask
+	attach(msg, TypeMergeReady, &payload)
 
 	return msg
 }
@@ -41,6 +49,9 @@ func formatMergeReadyBody(p MergeReadyPayload) string {
 	sb.WriteString(fmt.Sprintf("Issue: %s\n", p.Issue))
 	sb.WriteString(fmt.Sprintf("Polecat: %s\n", p.Polecat))
 	sb.WriteString(fmt.Sprintf("Rig: %s\n", p.Rig))
+	if p.MR != "" {
+		sb.WriteString(fmt.Sprintf("MR: %s\n", p.MR))
+	}
 	if p.Verified != "" {
 		sb.WriteString(fmt.Sprintf("Verified: %s\n", p.Verified))
 	}
@@ -71,6 +82,8 @@ func NewMergedMessage(rig, polecat, branch, issue, targetBranch, mergeCommit str
 	msg.Priority = mail.PriorityHigh
 	msg.Type = mail.TypeNotification
 
+	attach(msg, TypeMerged, &payload)
+
 	return msg
 }
 
@@ -114,6 +127,8 @@ func NewMergeFailedMessage(rig, polecat, branch, issue, targetBranch, failureTyp
 	msg.Priority = mail.PriorityHigh
 	msg.Type = mail.TypeTask
 
+	attach(msg, TypeMergeFailed, &payload)
+
 	return msg
 }
 
@@ -156,6 +171,8 @@ func NewReworkRequestMessage(rig, polecat, branch, issue, targetBranch string, c
 	msg.Priority = mail.PriorityHigh
 	msg.Type = mail.TypeTask
 
+	attach(msg, TypeReworkRequest, &payload)
+
 	return msg
 }
 
@@ -194,13 +211,17 @@ The Refinery will retry the merge after rebase is complete.`, targetBranch, targ
 // ParseMergeReadyPayload parses a MERGE_READY message body into a payload.
 // Returns an error if required fields (Branch, Polecat, Rig) are missing.
 func ParseMergeReadyPayload(body string) (*MergeReadyPayload, error) {
-	payload := &MergeReadyPayload{
-		Branch:    parseField(body, "Branch"),
-		Issue:     parseField(body, "Issue"),
-		Polecat:   parseField(body, "Polecat"),
-		Rig:       parseField(body, "Rig"),
-		Verified:  parseField(body, "Verified"),
-		Timestamp: time.Now(), // Use current time if not parseable
+	payload := &MergeReadyPayload{}
+	if !fromBlock(body, TypeMergeReady, payload) {
+		payload = &MergeReadyPayload{
+			Branch:    parseField(body, "Branch"),
+			Issue:     parseField(body, "Issue"),
+			Polecat:   parseField(body, "Polecat"),
+			Rig:       parseField(body, "Rig"),
+			MR:        parseField(body, "MR"),
+			Verified:  parseField(body, "Verified"),
+			Timestamp: time.Now(), // Use current time if not parseable
+		}
 	}
 
 	var errs []string
@@ -223,13 +244,16 @@ func ParseMergeReadyPayload(body string) (*MergeReadyPayload, error) {
 // ParseMergedPayload parses a MERGED message body into a payload.
 // Returns an error if required fields (Branch, Polecat, Rig) are missing.
 func ParseMergedPayload(body string) (*MergedPayload, error) {
-	payload := &MergedPayload{
-		Branch:       parseField(body, "Branch"),
-		Issue:        parseField(body, "Issue"),
-		Polecat:      parseField(body, "Polecat"),
-		Rig:          parseField(body, "Rig"),
-		TargetBranch: parseField(body, "Target"),
-		MergeCommit:  parseField(body, "Merge-Commit"),
+	payload := &MergedPayload{}
+	if !fromBlock(body, TypeMerged, payload) {
+		payload = &MergedPayload{
+			Branch:       parseField(body, "Branch"),
+			Issue:        parseField(body, "Issue"),
+			Polecat:      parseField(body, "Polecat"),
+			Rig:          parseField(body, "Rig"),
+			TargetBranch: parseField(body, "Target"),
+			MergeCommit:  parseField(body, "Merge-Commit"),
+		}
 	}
 
 	// Parse timestamp
@@ -259,14 +283,17 @@ func ParseMergedPayload(body string) (*MergedPayload, error) {
 // ParseMergeFailedPayload parses a MERGE_FAILED message body into a payload.
 // Returns an error if required fields (Branch, Polecat, Rig) are missing.
 func ParseMergeFailedPayload(body string) (*MergeFailedPayload, error) {
-	payload := &MergeFailedPayload{
-		Branch:       parseField(body, "Branch"),
-		Issue:        parseField(body, "Issue"),
-		Polecat:      parseField(body, "Polecat"),
-		Rig:          parseField(body, "Rig"),
-		TargetBranch: parseField(body, "Target"),
-		FailureType:  parseField(body, "Failure-Type"),
-		Error:        parseField(body, "Error"),
+	payload := &MergeFailedPayload{}
+	if !fromBlock(body, TypeMergeFailed, payload) {
+		payload = &MergeFailedPayload{
+			Branch:       parseField(body, "Branch"),
+			Issue:        parseField(body, "Issue"),
+			Polecat:      parseField(body, "Polecat"),
+			Rig:          parseField(body, "Rig"),
+			TargetBranch: parseField(body, "Target"),
+			FailureType:  parseField(body, "Failure-Type"),
+			Error:        parseField(body, "Error"),
+		}
 	}
 
 	// Parse timestamp
@@ -296,12 +323,15 @@ func ParseMergeFailedPayload(body string) (*MergeFailedPayload, error) {
 // ParseReworkRequestPayload parses a REWORK_REQUEST message body into a payload.
 // Returns an error if required fields (Branch, Polecat, Rig) are missing.
 func ParseReworkRequestPayload(body string) (*ReworkRequestPayload, error) {
-	payload := &ReworkRequestPayload{
-		Branch:       parseField(body, "Branch"),
-		Issue:        parseField(body, "Issue"),
-		Polecat:      parseField(body, "Polecat"),
-		Rig:          parseField(body, "Rig"),
-		TargetBranch: parseField(body, "Target"),
+	payload := &ReworkRequestPayload{}
+	if !fromBlock(body, TypeReworkRequest, payload) {
+		payload = &ReworkRequestPayload{
+			Branch:       parseField(body, "Branch"),
+			Issue:        parseField(body, "Issue"),
+			Polecat:      parseField(body, "Polecat"),
+			Rig:          parseField(body, "Rig"),
+			TargetBranch: parseField(body, "Target"),
+		}
 	}
 
 	// Parse timestamp
@@ -334,10 +364,17 @@ func ParseReworkRequestPayload(body string) (*ReworkRequestPayload, error) {
 }
 
 // ParsePolecatDonePayload parses a POLECAT_DONE notification body.
-// Unlike formal protocol messages, POLECAT_DONE is a mail convention — no
-// required fields are enforced. Returns a best-effort parse of available fields.
+// No required fields are enforced here (the router validates on send).
+// Returns a best-effort parse of available fields.
 func ParsePolecatDonePayload(polecatName, body string) *PolecatDonePayload {
-	payload := &PolecatDonePayload{
+	payload := &PolecatDonePayload{}
+	if fromBlock(body, TypePolecatDone, payload) {
+		if payload.Polecat == "" {
+			payload.Polecat = polecatName
+		}
+		return payload
+	}
+	payload = &PolecatDonePayload{
 		Polecat:       polecatName,
 		ExitType:      parseField(body, "Exit"),
 		Issue:         parseField(body, "Issue"),
@@ -346,6 +383,7 @@ func ParsePolecatDonePayload(polecatName, body string) *PolecatDonePayload {
 		ConvoyID:      parseField(body, "ConvoyID"),
 		MergeStrategy: parseField(body, "MergeStrategy"),
 		Errors:        parseField(body, "Errors"),
+		Gate:          parseField(body, "Gate"),
 	}
 
 	if parseField(body, "ConvoyOwned") == "true" {
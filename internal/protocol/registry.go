@@ -0,0 +1,546 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+)
+
+// Payload is the typed body of a protocol message.
+type Payload interface {
+	// Validate reports missing or invalid fields.
+	Validate() error
+}
+
+// Spec registers a protocol message type.
+type Spec struct {
+	Type    MessageType
+	Version int    // Current payload version; newer blocks are rejected
+	From    string // Sending role (documentation)
+	To      string // Receiving role (documentation)
+
+	// New returns an empty payload to decode a structured block into.
+	New func() Payload
+
+	// Legacy parses a message without a structured block from its
+	// "Key: value" body lines, for mail sent by hand or by older agents.
+	Legacy func(subject, body string) Payload
+
+	// LegacyValidate, if set, replaces Validate for legacy (version 0)
+	// mail, accepting what agents sent before the registry existed.
+	LegacyValidate func(Payload) error
+}
+
+// Envelope is the structured block carried in a protocol message body.
+type Envelope struct {
+	Type    MessageType     `json:"type"`
+	Version int             `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Errors returned by Decode and Validate.
+var (
+	ErrUnknownType = errors.New("unknown protocol message type")
+	ErrMalformed   = errors.New("malformed protocol message")
+)
+
+// blockFence opens the structured block in a message body.
+const blockFence = "```gt-protocol"
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[MessageType]Spec)
+)
+
+// Register adds a message type to the protocol registry.
+func Register(spec Spec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[spec.Type] = spec
+}
+
+// Lookup returns the spec for a message type.
+func Lookup(t MessageType) (Spec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	spec, ok := registry[t]
+	return spec, ok
+}
+
+// Types returns the registered message types, sorted.
+func Types() []MessageType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	types := make([]MessageType, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+func init() {
+	Register(Spec{
+		Type: TypeMergeReady, Version: 1, From: "witness", To: "refinery",
+		New: func() Payload { return &MergeReadyPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &MergeReadyPayload{
+				Branch:   parseField(body, "Branch"),
+				Issue:    parseField(body, "Issue"),
+				MR:       parseField(body, "MR"),
+				Polecat:  orSubject(parseField(body, "Polecat"), subject),
+				Rig:      parseField(body, "Rig"),
+				Verified: parseField(body, "Verified"),
+			}
+		},
+	})
+	Register(Spec{
+		Type: TypeMerged, Version: 1, From: "refinery", To: "witness",
+		New: func() Payload { return &MergedPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &MergedPayload{
+				Branch:       parseField(body, "Branch"),
+				Issue:        parseField(body, "Issue"),
+				Polecat:      orSubject(parseField(body, "Polecat"), subject),
+				Rig:          parseField(body, "Rig"),
+				TargetBranch: parseField(body, "Target"),
+				MergeCommit:  parseField(body, "Merge-Commit"),
+				MergedAt:     parseTime(body, "Merged-At"),
+			}
+		},
+	})
+	Register(Spec{
+		Type: TypeMergeFailed, Version: 1, From: "refinery", To: "witness",
+		New: func() Payload { return &MergeFailedPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &MergeFailedPayload{
+				Branch:       parseField(body, "Branch"),
+				Issue:        parseField(body, "Issue"),
+				Polecat:      orSubject(parseField(body, "Polecat"), subject),
+				Rig:          parseField(body, "Rig"),
+				TargetBranch: parseField(body, "Target"),
+				FailureType:  orField(body, "Failure-Type", "FailureType"),
+				Error:        parseField(body, "Error"),
+				FailedAt:     parseTime(body, "Failed-At"),
+			}
+		},
+		// Version 0 failure reports often name only the polecat
+		LegacyValidate: func(p Payload) error {
+			return requireFields([2]string{"Polecat", p.(*MergeFailedPayload).Polecat})
+		},
+	})
+	Register(Spec{
+		Type: TypeReworkRequest, Version: 1, From: "refinery", To: "witness",
+		New: func() Payload { return &ReworkRequestPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &ReworkRequestPayload{
+				Branch:       parseField(body, "Branch"),
+				Issue:        parseField(body, "Issue"),
+				Polecat:      orSubject(parseField(body, "Polecat"), subject),
+				Rig:          parseField(body, "Rig"),
+				TargetBranch: parseField(body, "Target"),
+			}
+		},
+	})
+	Register(Spec{
+		Type: TypePolecatDone, Version: 1, From: "polecat", To: "witness",
+		New: func() Payload { return &PolecatDonePayload{} },
+		Legacy: func(subject, body string) Payload {
+			return ParsePolecatDonePayload(ExtractPolecat(subject), body)
+		},
+		// Version 0 exit values were free-form (e.g. "Exit: MERGED")
+		LegacyValidate: func(p Payload) error {
+			done := p.(*PolecatDonePayload)
+			return requireFields([2]string{"Polecat", done.Polecat}, [2]string{"Exit", done.ExitType})
+		},
+	})
+	Register(Spec{
+		Type: TypeHelp, Version: 1, From: "any", To: "witness",
+		New: func() Payload { return &HelpPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &HelpPayload{
+				Topic:       subjectText(subject, TypeHelp),
+				Agent:       parseField(body, "Agent"),
+				Issue:       parseField(body, "Issue"),
+				Problem:     parseField(body, "Problem"),
+				Tried:       parseField(body, "Tried"),
+				RequestedAt: time.Now(),
+			}
+		},
+	})
+	Register(Spec{
+		Type: TypeLifecycle, Version: 1, From: "any", To: "witness, daemon",
+		New:    func() Payload { return &LifecyclePayload{} },
+		Legacy: parseLifecycle,
+	})
+	Register(Spec{
+		Type: TypeHandoff, Version: 1, From: "any", To: "self",
+		New: func() Payload { return &HandoffPayload{} },
+		Legacy: func(subject, body string) Payload {
+			return &HandoffPayload{
+				Context:          subjectText(subject, TypeHandoff),
+				AttachedMolecule: parseField(body, "attached_molecule"),
+			}
+		},
+	})
+	Register(Spec{
+		Type: TypeSwarmStart, Version: 1, From: "mayor", To: "witness",
+		New: func() Payload { return &SwarmStartPayload{} },
+		Legacy: func(_, body string) Payload {
+			p := &SwarmStartPayload{SwarmID: parseField(body, "SwarmID"), StartedAt: time.Now()}
+			for _, b := range strings.Split(parseField(body, "Beads"), ",") {
+				if b = strings.TrimSpace(b); b != "" {
+					p.BeadIDs = append(p.BeadIDs, b)
+				}
+			}
+			p.Total, _ = strconv.Atoi(parseField(body, "Total"))
+			return p
+		},
+	})
+
+	mail.RegisterProtocolValidator(func(msg *mail.Message) (string, error) {
+		t, _, err := Decode(msg)
+		return string(t), err
+	})
+}
+
+// orSubject falls back to the polecat name in a "TYPE <polecat>" subject.
+func orSubject(value, subject string) string {
+	if value != "" {
+		return value
+	}
+	return ExtractPolecat(subject)
+}
+
+// orField returns the first non-empty field among keys.
+func orField(body string, keys ...string) string {
+	for _, key := range keys {
+		if v := parseField(body, key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseTime parses an RFC 3339 timestamp field, or returns the zero time.
+func parseTime(body, key string) time.Time {
+	t, _ := time.Parse(time.RFC3339, parseField(body, key))
+	return t
+}
+
+// subjectText returns the subject text after its type and separator:
+// "HELP: tests fail" → "tests fail".
+func subjectText(subject string, t MessageType) string {
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(subject), handoffMarker))
+	rest = strings.TrimPrefix(rest, string(t))
+	rest = strings.TrimPrefix(rest, ":")
+	return strings.TrimSpace(rest)
+}
+
+// parseLifecycle parses a LIFECYCLE message without a structured block.
+// The action leads the subject ("LIFECYCLE:Shutdown nux", targeting nux)
+// or is given by the body: a JSON {"action": ...} object, a bare action
+// word, or an "Action: <action>" line.
+func parseLifecycle(subject, body string) Payload {
+	p := &LifecyclePayload{Reason: parseField(body, "Reason")}
+	words := strings.Fields(subjectText(subject, TypeLifecycle))
+	if len(words) > 0 && lifecycleAction(words[0]) != "" {
+		p.Action = lifecycleAction(words[0])
+		if len(words) > 1 {
+			p.Target = words[1]
+		}
+		return p
+	}
+
+	var js struct {
+		Action string `json:"action"`
+	}
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case json.Unmarshal([]byte(trimmed), &js) == nil:
+		p.Action = js.Action
+	case !strings.Contains(trimmed, "\n"):
+		// "restart" or "Action: restart"
+		if key, value, ok := strings.Cut(trimmed, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "action") {
+			p.Action = strings.TrimSpace(value)
+		} else {
+			p.Action = trimmed
+		}
+	default:
+		p.Action = parseField(body, "Action")
+	}
+	if a := lifecycleAction(p.Action); a != "" {
+		p.Action = a
+	}
+	return p
+}
+
+// lifecycleAction normalizes a lifecycle action word, or returns "".
+func lifecycleAction(word string) string {
+	switch strings.ToLower(strings.TrimSpace(word)) {
+	case LifecycleShutdown, "stop":
+		return LifecycleShutdown
+	case LifecycleRestart:
+		return LifecycleRestart
+	case LifecycleCycle:
+		return LifecycleCycle
+	}
+	return ""
+}
+
+// Attach sets a message's protocol type and appends the payload as a
+// structured block, keeping any human-readable body above it.
+func Attach(msg *mail.Message, t MessageType, p Payload) error {
+	spec, ok := Lookup(t)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownType, t)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding %s payload: %w", t, err)
+	}
+	env, err := json.Marshal(Envelope{Type: t, Version: spec.Version, Payload: data})
+	if err != nil {
+		return fmt.Errorf("encoding %s envelope: %w", t, err)
+	}
+	body := strings.TrimRight(msg.Body, "\n")
+	if body != "" {
+		body += "\n\n"
+	}
+	msg.Body = body + blockFence + "\n" + string(env) + "\n```\n"
+	msg.Protocol = string(t)
+	return nil
+}
+
+// attach is Attach for the package's own constructors, whose types are
+// always registered.
+func attach(msg *mail.Message, t MessageType, p Payload) {
+	_ = Attach(msg, t, p)
+}
+
+// fromBlock decodes the structured block of a body into p if it holds a
+// supported payload of type t.
+func fromBlock(body string, t MessageType, p Payload) bool {
+	env, ok, err := extractBlock(body)
+	if !ok || err != nil || env.Type != t {
+		return false
+	}
+	if spec, found := Lookup(t); !found || env.Version < 1 || env.Version > spec.Version {
+		return false
+	}
+	return json.Unmarshal(env.Payload, p) == nil
+}
+
+// Decode identifies a protocol message and returns its validated payload.
+// Ordinary mail returns ("", nil, nil). The type comes from the message's
+// protocol label, its structured block, or its subject; a subject that is
+// a near miss of a registered type (e.g. "MERGE_READDY nux") is reported
+// as ErrUnknownType rather than passing as ordinary mail.
+func Decode(msg *mail.Message) (MessageType, Payload, error) {
+	env, hasBlock, err := extractBlock(msg.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	t := MessageType(msg.Protocol)
+	if t == "" && hasBlock {
+		t = env.Type
+	}
+	if t == "" {
+		t = ParseMessageType(msg.Subject)
+	}
+	if t == "" {
+		if near := nearMiss(msg.Subject); near != "" {
+			return "", nil, fmt.Errorf("%w: subject %q (did you mean %s?)", ErrUnknownType, msg.Subject, near)
+		}
+		return "", nil, nil
+	}
+
+	spec, ok := Lookup(t)
+	if !ok {
+		return t, nil, fmt.Errorf("%w: %s", ErrUnknownType, t)
+	}
+	if subjectType := ParseMessageType(msg.Subject); subjectType != "" && subjectType != t {
+		return t, nil, fmt.Errorf("%w: subject is %s but message is %s", ErrMalformed, subjectType, t)
+	}
+
+	var p Payload
+	if hasBlock {
+		if env.Type != t {
+			return t, nil, fmt.Errorf("%w: block type %s does not match %s", ErrMalformed, env.Type, t)
+		}
+		if env.Version < 1 || env.Version > spec.Version {
+			return t, nil, fmt.Errorf("%w: %s version %d not supported (max %d)", ErrMalformed, t, env.Version, spec.Version)
+		}
+		p = spec.New()
+		dec := json.NewDecoder(strings.NewReader(string(env.Payload)))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(p); err != nil {
+			return t, nil, fmt.Errorf("%w: %s payload: %v", ErrMalformed, t, err)
+		}
+	} else {
+		p = spec.Legacy(msg.Subject, msg.Body)
+	}
+	validate := p.Validate
+	if !hasBlock && spec.LegacyValidate != nil {
+		validate = func() error { return spec.LegacyValidate(p) }
+	}
+	if err := validate(); err != nil {
+		return t, nil, fmt.Errorf("%w: %s: %v", ErrMalformed, t, err)
+	}
+	return t, p, nil
+}
+
+// extractBlock finds and parses the structured block in a body.
+func extractBlock(body string) (Envelope, bool, error) {
+	var env Envelope
+	start := -1
+	for i := 0; i < len(body); {
+		j := strings.Index(body[i:], blockFence+"\n")
+		if j < 0 {
+			break
+		}
+		// Only a fence at the start of a line counts; quoted blocks in
+		// replies ("> ```gt-protocol") do not.
+		if i+j == 0 || body[i+j-1] == '\n' {
+			start = i + j
+			break
+		}
+		i += j + len(blockFence)
+	}
+	if start < 0 {
+		return env, false, nil
+	}
+	rest := body[start+len(blockFence)+1:]
+	end := strings.Index(rest, "```")
+	if end < 0 {
+		return env, true, fmt.Errorf("unterminated %s block", strings.TrimPrefix(blockFence, "```"))
+	}
+	if err := json.Unmarshal([]byte(rest[:end]), &env); err != nil {
+		return env, true, fmt.Errorf("protocol block: %v", err)
+	}
+	return env, true, nil
+}
+
+// protocolToken matches subject words shaped like a protocol type.
+var protocolToken = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)+$`)
+
+// nearMiss returns the registered type a subject's first word is a likely
+// typo of, or "".
+func nearMiss(subject string) MessageType {
+	word := strings.TrimSpace(subject)
+	if i := strings.IndexAny(word, " :"); i >= 0 {
+		word = word[:i]
+	}
+	if !protocolToken.MatchString(word) {
+		return ""
+	}
+	for _, t := range Types() {
+		if d := editDistance(word, string(t)); d > 0 && d <= 2 {
+			return t
+		}
+	}
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// requireFields reports empty required fields as a single error.
+func requireFields(fields ...[2]string) error {
+	var missing []string
+	for _, f := range fields {
+		if strings.TrimSpace(f[1]) == "" {
+			missing = append(missing, f[0])
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Validate checks a MERGE_READY payload.
+func (p *MergeReadyPayload) Validate() error {
+	return requireFields([2]string{"Branch", p.Branch}, [2]string{"Polecat", p.Polecat})
+}
+
+// Validate checks a MERGED payload.
+func (p *MergedPayload) Validate() error {
+	return requireFields([2]string{"Branch", p.Branch}, [2]string{"Polecat", p.Polecat})
+}
+
+// Validate checks a MERGE_FAILED payload.
+func (p *MergeFailedPayload) Validate() error {
+	return requireFields([2]string{"Branch", p.Branch}, [2]string{"Polecat", p.Polecat})
+}
+
+// Validate checks a REWORK_REQUEST payload.
+func (p *ReworkRequestPayload) Validate() error {
+	return requireFields([2]string{"Branch", p.Branch}, [2]string{"Polecat", p.Polecat})
+}
+
+// Validate checks a HELP payload.
+func (p *HelpPayload) Validate() error {
+	return requireFields([2]string{"Topic", p.Topic})
+}
+
+// Validate checks a LIFECYCLE payload.
+func (p *LifecyclePayload) Validate() error {
+	if err := requireFields([2]string{"Action", p.Action}); err != nil {
+		return err
+	}
+	if lifecycleAction(p.Action) != p.Action {
+		return fmt.Errorf("invalid Action %q (want shutdown, restart or cycle)", p.Action)
+	}
+	return nil
+}
+
+// Validate checks a HANDOFF payload. The body is freeform, so any
+// HANDOFF is valid.
+func (p *HandoffPayload) Validate() error {
+	return nil
+}
+
+// Validate checks a SWARM_START payload.
+func (p *SwarmStartPayload) Validate() error {
+	return requireFields([2]string{"SwarmID", p.SwarmID})
+}
+
+// Validate checks a POLECAT_DONE payload.
+func (p *PolecatDonePayload) Validate() error {
+	if err := requireFields([2]string{"Polecat", p.Polecat}, [2]string{"Exit", p.ExitType}); err != nil {
+		return err
+	}
+	switch p.ExitType {
+	case "COMPLETED", "ESCALATED", "DEFERRED", "PHASE_COMPLETE":
+		return nil
+	}
+	return fmt.Errorf("invalid Exit %q (want COMPLETED, ESCALATED, DEFERRED or PHASE_COMPLETE)", p.ExitType)
+}
@@ -0,0 +1,177 @@
+package protocol
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+)
+
+func TestAttachDecodeRoundTrip(t *testing.T) {
+	msg := NewMergeFailedMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", "tests", "3 failed")
+	if msg.Protocol != string(TypeMergeFailed) {
+		t.Errorf("Protocol = %q, want %s", msg.Protocol, TypeMergeFailed)
+	}
+	if !strings.Contains(msg.Body, "Branch: polecat/nux/gt-abc") || !strings.Contains(msg.Body, "```gt-protocol\n") {
+		t.Errorf("body lacks readable fields or protocol block:\n%s", msg.Body)
+	}
+
+	typ, p, err := Decode(msg)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	got, ok := p.(*MergeFailedPayload)
+	if typ != TypeMergeFailed || !ok {
+		t.Fatalf("Decode = %s %T", typ, p)
+	}
+	if got.Error != "3 failed" || got.Rig != "gastown" || got.FailedAt.IsZero() {
+		t.Errorf("payload = %+v", got)
+	}
+
+	parsed, err := ParseMergeFailedPayload(msg.Body)
+	if err != nil || parsed.FailureType != "tests" {
+		t.Errorf("ParseMergeFailedPayload = %+v, %v", parsed, err)
+	}
+}
+
+func TestDecode_Legacy(t *testing.T) {
+	// Hand-written mail from the refinery patrol formula: no block, no Rig.
+	msg := mail.NewMessage("gastown/refinery", "gastown/witness", "MERGED nux",
+		"Branch: polecat/nux/gt-abc\nIssue: gt-abc\nMerged-At: 2026-01-01T00:00:00Z")
+	typ, p, err := Decode(msg)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if typ != TypeMerged || p.(*MergedPayload).Polecat != "nux" {
+		t.Errorf("Decode = %s %+v", typ, p)
+	}
+
+	plain := mail.NewMessage("mayor/", "gastown/witness", "Status update", "All good")
+	if typ, p, err := Decode(plain); typ != "" || p != nil || err != nil {
+		t.Errorf("Decode(ordinary mail) = %q, %v, %v", typ, p, err)
+	}
+}
+
+func TestDecode_LegacyLifecycle(t *testing.T) {
+	tests := []struct {
+		subject, body  string
+		action, target string
+	}{
+		{"LIFECYCLE:Shutdown nux", "", LifecycleShutdown, "nux"},
+		{"LIFECYCLE: requesting action", `{"action": "cycle"}`, LifecycleCycle, ""},
+		{"LIFECYCLE: action", "stop", LifecycleShutdown, ""},
+		{"LIFECYCLE: action", "Action: restart", LifecycleRestart, ""},
+		{"LIFECYCLE: polecat-nux", "Lifecycle request.\n\nAction: shutdown\nReason: done", LifecycleShutdown, ""},
+	}
+	for _, tt := range tests {
+		msg := mail.NewMessage("gastown/nux", "gastown/witness", tt.subject, tt.body)
+		typ, p, err := Decode(msg)
+		if err != nil {
+			t.Errorf("Decode(%q, %q): %v", tt.subject, tt.body, err)
+			continue
+		}
+		got := p.(*LifecyclePayload)
+		if typ != TypeLifecycle || got.Action != tt.action || got.Target != tt.target {
+			t.Errorf("Decode(%q, %q) = %s %+v", tt.subject, tt.body, typ, got)
+		}
+	}
+}
+
+func TestDecode_LegacyWitnessInbox(t *testing.T) {
+	help := mail.NewMessage("gastown/nux", "gastown/witness", "HELP: Tests failing",
+		"Agent: gastown/polecats/nux\nIssue: gt-1\nProblem: flaky")
+	if typ, p, err := Decode(help); err != nil || typ != TypeHelp || p.(*HelpPayload).Topic != "Tests failing" {
+		t.Errorf("Decode(HELP) = %s %+v, %v", typ, p, err)
+	}
+
+	handoff := mail.NewMessage("gastown/witness", "gastown/witness", "🤝 HANDOFF: Patrol context", "Context: cycle 3")
+	if typ, _, err := Decode(handoff); err != nil || typ != TypeHandoff {
+		t.Errorf("Decode(HANDOFF) = %s, %v", typ, err)
+	}
+
+	swarm := mail.NewMessage("mayor/", "gastown/witness", "SWARM_START",
+		"SwarmID: swarm-1\nBeads: gt-a, gt-b\nTotal: 2")
+	typ, p, err := Decode(swarm)
+	if err != nil || typ != TypeSwarmStart {
+		t.Fatalf("Decode(SWARM_START) = %s, %v", typ, err)
+	}
+	if got := p.(*SwarmStartPayload); got.SwarmID != "swarm-1" || len(got.BeadIDs) != 2 || got.Total != 2 {
+		t.Errorf("SWARM_START payload = %+v", got)
+	}
+}
+
+func TestDecode_LegacyVersion0(t *testing.T) {
+	// Mail the pre-registry witness accepted still decodes.
+	for _, m := range []*mail.Message{
+		mail.NewMessage("gastown/refinery", "gastown/witness", "MERGE_FAILED ace", "FailureType: build"),
+		mail.NewMessage("gastown/nux", "gastown/witness", "POLECAT_DONE nux", "Exit: MERGED\nBranch: b"),
+	} {
+		if _, _, err := Decode(m); err != nil {
+			t.Errorf("Decode(%q) = %v", m.Subject, err)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	block := func(s string) string { return "```gt-protocol\n" + s + "\n```\n" }
+	tests := []struct {
+		name     string
+		protocol string
+		subject  string
+		body     string
+		want     error
+	}{
+		{"typo in subject", "", "MERGE_READDY nux", "Branch: b", ErrUnknownType},
+		{"unregistered label", "MERGE_MAYBE", "hello", "", ErrUnknownType},
+		{"missing branch", "", "MERGE_READY nux", "Issue: gt-1", ErrMalformed},
+		{"missing polecat", "", "MERGED", "Branch: b", ErrMalformed},
+		{"bad exit", "", "POLECAT_DONE nux", block(`{"type":"POLECAT_DONE","version":1,"payload":{"polecat":"nux","exit_type":"FINISHED"}}`), ErrMalformed},
+		{"legacy done without exit", "", "POLECAT_DONE nux", "Issue: gt-1", ErrMalformed},
+		{"help without topic", "", "HELP:", "Problem: stuck", ErrMalformed},
+		{"unknown lifecycle action", "", "LIFECYCLE: action", "explode", ErrMalformed},
+		{"swarm without id", "", "SWARM_START", "Total: 2", ErrMalformed},
+		{"future version", "", "MERGED nux", block(`{"type":"MERGED","version":9,"payload":{"branch":"b","polecat":"nux"}}`), ErrMalformed},
+		{"unknown field", "", "MERGED nux", block(`{"type":"MERGED","version":1,"payload":{"branch":"b","polecat":"nux","colour":"red"}}`), ErrMalformed},
+		{"type mismatch", "", "MERGED nux", block(`{"type":"MERGE_FAILED","version":1,"payload":{"branch":"b","polecat":"nux"}}`), ErrMalformed},
+		{"bad json", "", "MERGED nux", block(`{"type":`), ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := mail.NewMessage("gastown/refinery", "gastown/witness", tt.subject, tt.body)
+			msg.Protocol = tt.protocol
+			_, _, err := Decode(msg)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Decode error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_QuotedBlockIgnored(t *testing.T) {
+	orig := NewMergedMessage("gastown", "nux", "b", "gt-1", "main", "abc123")
+	quoted := "Thanks!\n\n> " + strings.ReplaceAll(orig.Body, "\n", "\n> ")
+	reply := mail.NewMessage("gastown/witness", "gastown/refinery", "Re: MERGED nux", quoted)
+	if typ, _, err := Decode(reply); typ != "" || err != nil {
+		t.Errorf("Decode(reply) = %q, %v; want ordinary mail", typ, err)
+	}
+}
+
+func TestRegistryTypes(t *testing.T) {
+	want := []MessageType{
+		TypeHandoff, TypeHelp, TypeLifecycle, TypeMerged, TypeMergeFailed,
+		TypeMergeReady, TypePolecatDone, TypeReworkRequest, TypeSwarmStart,
+	}
+	got := Types()
+	if len(got) != len(want) {
+		t.Fatalf("Types() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Types()[%d] = %s, want %s", i, got[i], want[i])
+		}
+		if spec, ok := Lookup(want[i]); !ok || spec.Version < 1 || spec.New == nil || spec.Legacy == nil {
+			t.Errorf("Lookup(%s) = %+v, %v", want[i], spec, ok)
+		}
+	}
+}
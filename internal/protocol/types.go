@@ -8,6 +8,16 @@
 //   - MERGED: Refinery → Witness (merge succeeded, cleanup ok)
 //   - MERGE_FAILED: Refinery → Witness (merge failed, needs rework)
 //   - REWORK_REQUEST: Refinery → Witness (rebase needed)
+//   - POLECAT_DONE: Polecat → Witness (work finished)
+//   - HELP: Any → Witness or escalation target (intervention needed)
+//   - LIFECYCLE: Any → Witness or Daemon (shutdown, restart, cycle)
+//   - HANDOFF: Agent → self or successor (session continuity)
+//   - SWARM_START: Mayor → Witness (batch work started)
+//
+// Every type is registered with a versioned payload struct (see Register).
+// Messages carry the payload as a structured gt-protocol block after the
+// human-readable body, and the mail router validates them on send: unknown
+// or malformed protocol messages go to the dead-letter mailbox.
 package protocol
 
 import (
@@ -38,24 +48,48 @@ const (
 	// branch needs rebasing due to conflicts with the target branch.
 	// Subject format: "REWORK_REQUEST <polecat-name>"
 	TypeReworkRequest MessageType = "REWORK_REQUEST"
+
+	// TypePolecatDone is sent from a polecat to its Witness by gt done.
+	// Subject format: "POLECAT_DONE <polecat-name>"
+	TypePolecatDone MessageType = "POLECAT_DONE"
+
+	// TypeHelp is sent by an agent that needs intervention.
+	// Subject format: "HELP: <topic>"
+	TypeHelp MessageType = "HELP"
+
+	// TypeLifecycle asks the Witness or Daemon to shut down, restart or
+	// cycle an agent session.
+	// Subject format: "LIFECYCLE:<Action> <target>" or "LIFECYCLE: <text>"
+	// with the action in the body.
+	TypeLifecycle MessageType = "LIFECYCLE"
+
+	// TypeHandoff carries session context to the agent's successor.
+	// Subject format: "🤝 HANDOFF: <context>"
+	TypeHandoff MessageType = "HANDOFF"
+
+	// TypeSwarmStart is sent from Mayor to Witness when batch work starts.
+	// Subject format: "SWARM_START"
+	TypeSwarmStart MessageType = "SWARM_START"
 )
 
+// handoffMarker prefixes HANDOFF subjects.
+const handoffMarker = "🤝"
+
 // ParseMessageType extracts the protocol message type from a mail subject.
-// Returns empty string if subject doesn't match a known protocol type.
+// The type is the subject's first word, followed by a space, a colon or
+// nothing ("MERGED nux", "HELP: topic", "LIFECYCLE:Shutdown nux"); a
+// leading 🤝 is ignored. Returns empty string if subject doesn't match a
+// known protocol type.
 func ParseMessageType(subject string) MessageType {
-	subject = strings.TrimSpace(subject)
-
-	// Check each known prefix
-	prefixes := []MessageType{
-		TypeMergeReady,
-		TypeMerged,
-		TypeMergeFailed,
-		TypeReworkRequest,
-	}
+	subject = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(subject), handoffMarker))
 
-	for _, prefix := range prefixes {
+	// Check each registered type
+	for _, prefix := range Types() {
 		p := string(prefix)
-		if subject == p || strings.HasPrefix(subject, p+" ") {
+		if !strings.HasPrefix(subject, p) {
+			continue
+		}
+		if rest := subject[len(p):]; rest == "" || rest[0] == ' ' || rest[0] == ':' {
 			return prefix
 		}
 	}
@@ -78,6 +112,9 @@ type MergeReadyPayload struct {
 	// Rig is the rig name containing the polecat.
 	Rig string `json:"rig"`
 
+	// MR is the merge-request bead ID, if one was created.
+	MR string `json:"mr,omitempty"`
+
 	// Verified contains verification notes.
 	Verified string `json:"verified,omitempty"`
 
@@ -167,8 +204,7 @@ type ReworkRequestPayload struct {
 }
 
 // PolecatDonePayload contains the data from a POLECAT_DONE notification.
-// This is not a formal protocol message (it's a mail convention), but the
-// payload is structured for programmatic parsing by witness handlers.
+// Sent by gt done; parsed by witness handlers.
 type PolecatDonePayload struct {
 	// Polecat is the worker name.
 	Polecat string `json:"polecat"`
@@ -194,6 +230,9 @@ type PolecatDonePayload struct {
 	// MergeStrategy is the convoy's merge strategy (direct, mr, local).
 	MergeStrategy string `json:"merge_strategy,omitempty"`
 
+	// Gate is the gate ID the polecat waits on when ExitType is PHASE_COMPLETE.
+	Gate string `json:"gate,omitempty"`
+
 	// Errors contains any non-fatal errors encountered during gt done.
 	Errors string `json:"errors,omitempty"`
 }
@@ -204,6 +243,74 @@ func (p *PolecatDonePayload) SkipMergeFlow() bool {
 	return p.ConvoyOwned && p.MergeStrategy == "direct"
 }
 
+// HelpPayload contains the data for a HELP message.
+// Sent by an agent that cannot proceed without intervention.
+type HelpPayload struct {
+	// Topic is the brief description from the subject.
+	Topic string `json:"topic"`
+
+	// Agent is the requesting agent's address.
+	Agent string `json:"agent,omitempty"`
+
+	// Issue is the beads issue ID, if the request concerns one.
+	Issue string `json:"issue,omitempty"`
+
+	// Problem describes what is blocking the agent.
+	Problem string `json:"problem,omitempty"`
+
+	// Tried describes what the agent already attempted.
+	Tried string `json:"tried,omitempty"`
+
+	// RequestedAt is when help was requested.
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Lifecycle actions.
+const (
+	LifecycleShutdown = "shutdown"
+	LifecycleRestart  = "restart"
+	LifecycleCycle    = "cycle"
+)
+
+// LifecyclePayload contains the data for a LIFECYCLE message.
+type LifecyclePayload struct {
+	// Action is shutdown, restart or cycle.
+	Action string `json:"action"`
+
+	// Target is the agent to act on (a polecat name for the Witness);
+	// empty means the sender.
+	Target string `json:"target,omitempty"`
+
+	// Reason explains the request.
+	Reason string `json:"reason,omitempty"`
+}
+
+// HandoffPayload contains the data for a HANDOFF message. The body is
+// freeform notes for the successor.
+type HandoffPayload struct {
+	// Context is the brief context from the subject.
+	Context string `json:"context,omitempty"`
+
+	// AttachedMolecule is the molecule in progress, if any.
+	AttachedMolecule string `json:"attached_molecule,omitempty"`
+}
+
+// SwarmStartPayload contains the data for a SWARM_START message.
+// Sent by the Mayor when dispatching batch work.
+type SwarmStartPayload struct {
+	// SwarmID identifies the batch.
+	SwarmID string `json:"swarm_id"`
+
+	// BeadIDs lists the issues in the batch.
+	BeadIDs []string `json:"bead_ids,omitempty"`
+
+	// Total is the number of issues in the batch.
+	Total int `json:"total,omitempty"`
+
+	// StartedAt is when the batch started.
+	StartedAt time.Time `json:"started_at"`
+}
+
 // IsProtocolMessage returns true if the subject matches a known protocol type.
 func IsProtocolMessage(subject string) bool {
 	return ParseMessageType(subject) != ""
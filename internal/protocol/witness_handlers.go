@@ -6,9 +6,34 @@ import (
 	"os"
 
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
-	"github.com/xcawolfe-amzn/gastown/internal/witness"
 )
 
+// PolecatCleanup is the outcome of an automatic polecat cleanup.
+type PolecatCleanup struct {
+	Nuked   bool
+	Skipped bool
+	Reason  string
+	Error   error
+}
+
+// polecatCleaner nukes a polecat if that is safe. The witness package
+// registers it, since witness imports protocol.
+var polecatCleaner func(workDir, rig, polecat string) PolecatCleanup
+
+// RegisterPolecatCleaner sets the cleanup the default witness handler runs
+// after a merge. Without one, cleanup is left to the witness patrol.
+func RegisterPolecatCleaner(fn func(workDir, rig, polecat string) PolecatCleanup) {
+	polecatCleaner = fn
+}
+
+// autoNuke runs the registered polecat cleanup, if any.
+func autoNuke(workDir, rig, polecat string) PolecatCleanup {
+	if polecatCleaner == nil {
+		return PolecatCleanup{}
+	}
+	return polecatCleaner(workDir, rig, polecat)
+}
+
 // DefaultWitnessHandler provides the default implementation for Witness protocol handlers.
 // It receives messages from the Refinery about merge outcomes and takes appropriate action.
 type DefaultWitnessHandler struct {
@@ -62,7 +87,7 @@ func (h *DefaultWitnessHandler) HandleMerged(payload *MergedPayload) error {
 
 	// Initiate polecat cleanup using AutoNukeIfClean
 	// This verifies cleanup_status before nuking to prevent work loss.
-	nukeResult := witness.AutoNukeIfClean(h.WorkDir, h.Rig, payload.Polecat)
+	nukeResult := autoNuke(h.WorkDir, h.Rig, payload.Polecat)
 	if nukeResult.Nuked {
 		fmt.Fprintf(h.Output, "[Witness] ✓ Auto-nuked polecat %s: %s\n", payload.Polecat, nukeResult.Reason)
 	} else if nukeResult.Skipped {
@@ -122,7 +147,7 @@ func (h *DefaultWitnessHandler) HandlePolecatDone(payload *PolecatDonePayload) e
 		_, _ = fmt.Fprintf(h.Output, "  Polecat already pushed to main. Proceeding with cleanup only.\n")
 
 		// Initiate polecat cleanup (same as HandleMerged)
-		nukeResult := witness.AutoNukeIfClean(h.WorkDir, h.Rig, payload.Polecat)
+		nukeResult := autoNuke(h.WorkDir, h.Rig, payload.Polecat)
 		if nukeResult.Nuked {
 			fmt.Fprintf(h.Output, "[Witness] ✓ Auto-nuked polecat %s: %s\n", payload.Polecat, nukeResult.Reason)
 		} else if nukeResult.Skipped {
@@ -0,0 +1,356 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// FallbackReason records why a session was moved off its primary agent.
+type FallbackReason string
+
+const (
+	// FallbackLimited means the session was rate-limited and no spare
+	// account was available to rotate to.
+	FallbackLimited FallbackReason = "rate_limited"
+
+	// FallbackDown means the session's provider was failing outright.
+	FallbackDown FallbackReason = "provider_down"
+)
+
+// FallbackRetryAfter is the minimum time a session stays on a fallback agent
+// before the primary is considered again. A session only returns once a scan
+// shows the primary working: another session runs it cleanly, or a single
+// probe session moved back to it scans clean on the next pass.
+const FallbackRetryAfter = 30 * time.Minute
+
+// FallbackEntry tracks one session running on a fallback agent.
+type FallbackEntry struct {
+	Role     string         `json:"role"`
+	Primary  string         `json:"primary"` // agent the session ran before the first fallback
+	Current  string         `json:"current"` // agent the session runs now
+	Reason   FallbackReason `json:"reason"`
+	Since    time.Time      `json:"since"`
+	ResetsAt string         `json:"resets_at,omitempty"`
+	Probing  bool           `json:"probing,omitempty"` // moved back to Primary to test it
+}
+
+// FallbackState is the persisted set of sessions running on fallback agents.
+type FallbackState struct {
+	Sessions map[string]FallbackEntry `json:"sessions"`
+}
+
+// FallbackStatePath returns the path to the fallback state file.
+func FallbackStatePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirMayor, constants.DirRuntime, "agent-fallback.json")
+}
+
+// LoadFallbackState reads fallback state. Returns an empty state if the file
+// doesn't exist yet.
+func LoadFallbackState(townRoot string) (*FallbackState, error) {
+	state := &FallbackState{Sessions: make(map[string]FallbackEntry)}
+	data, err := os.ReadFile(FallbackStatePath(townRoot))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading fallback state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing fallback state: %w", err)
+	}
+	if state.Sessions == nil {
+		state.Sessions = make(map[string]FallbackEntry)
+	}
+	return state, nil
+}
+
+// SaveFallbackState writes fallback state atomically.
+func SaveFallbackState(townRoot string, state *FallbackState) error {
+	return util.EnsureDirAndWriteJSON(FallbackStatePath(townRoot), state)
+}
+
+// Record updates state after an action was carried out: a fallback starts
+// or advances an entry, a restore removes it, and a probe keeps the entry
+// until a scan confirms the primary.
+func (s *FallbackState) Record(a FallbackAction, now time.Time) {
+	if a.Restore && !a.Probe {
+		delete(s.Sessions, a.Session)
+		return
+	}
+	if a.Restore {
+		entry := s.Sessions[a.Session]
+		entry.Current = a.To
+		entry.Probing = true
+		entry.Since = now
+		s.Sessions[a.Session] = entry
+		return
+	}
+	entry, ok := s.Sessions[a.Session]
+	if !ok {
+		entry = FallbackEntry{Role: a.Role, Primary: a.From}
+	}
+	entry.Current = a.To
+	entry.Reason = a.Reason
+	entry.Since = now
+	entry.ResetsAt = a.ResetsAt
+	entry.Probing = false
+	s.Sessions[a.Session] = entry
+}
+
+// Prune drops entries for sessions that no longer exist.
+func (s *FallbackState) Prune(live map[string]SessionAgent) {
+	for session := range s.Sessions {
+		if _, ok := live[session]; !ok {
+			delete(s.Sessions, session)
+		}
+	}
+}
+
+// SessionAgent identifies which role a session plays and which agent runs it.
+type SessionAgent struct {
+	Role  string
+	Agent string
+}
+
+// FallbackAction is a planned agent switch for one session.
+type FallbackAction struct {
+	Session  string         `json:"session"`
+	Role     string         `json:"role"`
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Reason   FallbackReason `json:"reason,omitempty"`
+	ResetsAt string         `json:"resets_at,omitempty"`
+	Restore  bool           `json:"restore,omitempty"` // switching back to the primary
+	Probe    bool           `json:"probe,omitempty"`   // restore that tests whether the primary works
+	Account  string         `json:"account,omitempty"` // account to use on restore (Claude only)
+	Switched bool           `json:"switched"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// NextAgent returns the agent after current in chain, or "" when the chain
+// is exhausted. An agent not in the chain is treated as the primary.
+func NextAgent(chain []string, current string) string {
+	idx := slices.Index(chain, current)
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(chain) {
+		return ""
+	}
+	for _, next := range chain[idx+1:] {
+		if next != current {
+			return next
+		}
+	}
+	return ""
+}
+
+// PlanFallback decides which sessions to move along their role's fallback
+// chain and which to return to their primary agent.
+//
+// limited holds rate-limited sessions that account rotation could not place;
+// down holds sessions whose provider is failing. agents maps every live
+// session to its role and current agent. spareAccounts reports whether a
+// rate-limited primary has somewhere to go back to (an available account,
+// or no account pool at all). A session returns to its primary once
+// FallbackRetryAfter has passed, for rate limits an account is spare, and
+// the scan shows the primary working: some session runs it without failing.
+// Otherwise one session per primary is restored as a probe; probes that
+// scan clean are dropped from state, confirming the primary for the rest.
+func PlanFallback(limited, down []ScanResult, agents map[string]SessionAgent, chains map[string][]string,
+	state *FallbackState, spareAccounts bool, now time.Time) []FallbackAction {
+	var actions []FallbackAction
+	failing := make(map[string]bool)
+
+	plan := func(r ScanResult, reason FallbackReason) {
+		if failing[r.Session] {
+			return
+		}
+		failing[r.Session] = true
+		sa, ok := agents[r.Session]
+		if !ok {
+			return
+		}
+		next := NextAgent(chains[sa.Role], sa.Agent)
+		if next == "" {
+			return
+		}
+		actions = append(actions, FallbackAction{
+			Session:  r.Session,
+			Role:     sa.Role,
+			From:     sa.Agent,
+			To:       next,
+			Reason:   reason,
+			ResetsAt: r.ResetsAt,
+		})
+	}
+	for _, r := range down {
+		plan(r, FallbackDown)
+	}
+	for _, r := range limited {
+		plan(r, FallbackLimited)
+	}
+
+	// Agents some session is running without failing. A session still on
+	// a fallback agent says nothing about its primary.
+	working := make(map[string]bool)
+	for session, sa := range agents {
+		if failing[session] {
+			continue
+		}
+		entry, onFallback := state.Sessions[session]
+		if onFallback && (!entry.Probing || sa.Agent != entry.Primary) {
+			continue
+		}
+		working[sa.Agent] = true
+		if onFallback {
+			delete(state.Sessions, session) // Probe scanned clean
+		}
+	}
+
+	sessions := make([]string, 0, len(state.Sessions))
+	probing := make(map[string]bool)
+	for session, entry := range state.Sessions {
+		sessions = append(sessions, session)
+		if entry.Probing {
+			probing[entry.Primary] = true
+		}
+	}
+	sort.Strings(sessions)
+	for _, session := range sessions {
+		entry := state.Sessions[session]
+		sa, ok := agents[session]
+		if failing[session] || !ok || entry.Primary == "" || entry.Probing {
+			continue
+		}
+		if now.Sub(entry.Since) < FallbackRetryAfter {
+			continue
+		}
+		if entry.Reason == FallbackLimited && !spareAccounts {
+			continue
+		}
+		probe := !working[entry.Primary]
+		if probe {
+			if probing[entry.Primary] {
+				continue // Wait for the probe already in flight
+			}
+			probing[entry.Primary] = true
+		}
+		actions = append(actions, FallbackAction{
+			Session: session,
+			Role:    entry.Role,
+			From:    sa.Agent,
+			To:      entry.Primary,
+			Reason:  entry.Reason,
+			Restore: true,
+			Probe:   probe,
+		})
+	}
+	return actions
+}
+
+// AgentSwitcher restarts sessions on a different agent using the same
+// respawn-pane lifecycle as the Rotator.
+type AgentSwitcher struct {
+	tmuxExec       TmuxExecutor
+	accounts       *config.AccountsConfig               // may be nil
+	restartCommand func(session string) (string, error) // builds the respawn command from session env
+	checkpoint     func(session, note string) error     // optional: saves work state before the restart
+	log            Logger
+}
+
+// NewAgentSwitcher creates an AgentSwitcher. restartCmd must read the agent
+// from the session's GT_AGENT, which Switch sets before calling it.
+// checkpoint may be nil.
+func NewAgentSwitcher(
+	tmuxExec TmuxExecutor,
+	accounts *config.AccountsConfig,
+	restartCmd func(string) (string, error),
+	checkpoint func(session, note string) error,
+	log Logger,
+) *AgentSwitcher {
+	return &AgentSwitcher{
+		tmuxExec:       tmuxExec,
+		accounts:       accounts,
+		restartCommand: restartCmd,
+		checkpoint:     checkpoint,
+		log:            log,
+	}
+}
+
+// Switch restarts the action's session on action.To. The hook lives in beads
+// and is picked up again by gt prime; the checkpoint carries git and
+// molecule state across so the new agent can resume.
+func (s *AgentSwitcher) Switch(a FallbackAction) error {
+	pane, err := s.tmuxExec.GetPaneID(a.Session)
+	if err != nil {
+		return fmt.Errorf("getting pane: %w", err)
+	}
+
+	var configDir string
+	if a.Account != "" && s.accounts != nil {
+		acct, ok := s.accounts.Accounts[a.Account]
+		if !ok {
+			return fmt.Errorf("account %q not found in config", a.Account)
+		}
+		configDir = util.ExpandHome(acct.ConfigDir)
+	}
+
+	if s.checkpoint != nil {
+		note := fmt.Sprintf("Agent fallback: restarted on %s (was %s, %s).", a.To, a.From, a.Reason)
+		if a.Restore {
+			note = fmt.Sprintf("Agent fallback: returned to %s from %s.", a.To, a.From)
+		}
+		if err := s.checkpoint(a.Session, note); err != nil {
+			s.log.Warn("could not checkpoint %s: %v", a.Session, err)
+		}
+	}
+
+	if err := s.tmuxExec.SetEnvironment(a.Session, "GT_AGENT", a.To); err != nil {
+		return fmt.Errorf("setting GT_AGENT: %w", err)
+	}
+	respawnCmd, err := s.restartCommand(a.Session)
+	if err != nil {
+		// Leave the session on the agent it was running.
+		_ = s.tmuxExec.SetEnvironment(a.Session, "GT_AGENT", a.From)
+		return fmt.Errorf("building restart command: %w", err)
+	}
+	// Liveness checks read GT_PROCESS_NAMES from the session environment.
+	processNames := strings.Join(config.ResolveProcessNames(a.To, ""), ",")
+	if err := s.tmuxExec.SetEnvironment(a.Session, "GT_PROCESS_NAMES", processNames); err != nil {
+		s.log.Warn("could not set GT_PROCESS_NAMES for %s: %v", a.Session, err)
+	}
+	if configDir != "" {
+		if err := s.tmuxExec.SetEnvironment(a.Session, "CLAUDE_CONFIG_DIR", configDir); err != nil {
+			s.log.Warn("could not set CLAUDE_CONFIG_DIR for %s: %v", a.Session, err)
+		}
+		respawnCmd = fmt.Sprintf("export CLAUDE_CONFIG_DIR=%q && %s", configDir, respawnCmd)
+	}
+
+	if err := s.tmuxExec.SetRemainOnExit(pane, true); err != nil {
+		s.log.Warn("could not set remain-on-exit for %s: %v", a.Session, err)
+	}
+	if err := s.tmuxExec.KillPaneProcesses(pane); err != nil {
+		s.log.Warn("could not kill pane processes for %s: %v", a.Session, err)
+	}
+	if err := s.tmuxExec.ClearHistory(pane); err != nil {
+		s.log.Warn("could not clear history for %s: %v", a.Session, err)
+	}
+	if err := s.tmuxExec.RespawnPane(pane, respawnCmd); err != nil {
+		return fmt.Errorf("respawning pane: %w", err)
+	}
+	if err := s.tmuxExec.AcceptBypassPermissionsWarning(a.Session); err != nil {
+		s.log.Warn("could not accept bypass permissions for %s: %v", a.Session, err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package quota
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+func TestNextAgent(t *testing.T) {
+	chain := []string{"claude", "codex", "gemini"}
+	tests := []struct {
+		current, want string
+	}{
+		{"claude", "codex"},
+		{"codex", "gemini"},
+		{"gemini", ""},
+		{"claude-sonnet", "codex"}, // not in chain: treated as primary
+	}
+	for _, tt := range tests {
+		if got := NextAgent(chain, tt.current); got != tt.want {
+			t.Errorf("NextAgent(%q) = %q, want %q", tt.current, got, tt.want)
+		}
+	}
+	if got := NextAgent(nil, "claude"); got != "" {
+		t.Errorf("NextAgent(nil) = %q, want empty", got)
+	}
+}
+
+func TestPlanFallback(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	chains := map[string][]string{"polecat": {"claude", "codex", "gemini"}}
+	agents := map[string]SessionAgent{
+		"gt-gastown-toast":   {Role: "polecat", Agent: "claude"},
+		"gt-gastown-nux":     {Role: "polecat", Agent: "codex"},
+		"gt-gastown-furia":   {Role: "polecat", Agent: "gemini"},
+		"gt-gastown-witness": {Role: "witness", Agent: "claude"},
+		"gt-gastown-slit":    {Role: "polecat", Agent: "codex"},
+		"gt-gastown-rictus":  {Role: "polecat", Agent: "gemini"},
+	}
+	state := &FallbackState{Sessions: map[string]FallbackEntry{
+		"gt-gastown-slit":   {Role: "polecat", Primary: "claude", Current: "codex", Reason: FallbackDown, Since: now.Add(-time.Hour)},
+		"gt-gastown-rictus": {Role: "polecat", Primary: "claude", Current: "gemini", Reason: FallbackLimited, Since: now.Add(-time.Hour)},
+		"gt-gastown-nux":    {Role: "polecat", Primary: "claude", Current: "codex", Reason: FallbackDown, Since: now.Add(-time.Minute)},
+	}}
+	limited := []ScanResult{
+		{Session: "gt-gastown-toast", RateLimited: true, ResetsAt: "7pm"},
+		{Session: "gt-gastown-witness", RateLimited: true}, // no chain for witness
+	}
+	down := []ScanResult{{Session: "gt-gastown-furia", RateLimited: true}} // chain exhausted
+
+	actions := PlanFallback(limited, down, agents, chains, state, false, now)
+	got := make(map[string]FallbackAction)
+	for _, a := range actions {
+		got[a.Session] = a
+	}
+	if len(actions) != 2 {
+		t.Fatalf("got %d actions, want 2: %+v", len(actions), actions)
+	}
+	if a := got["gt-gastown-toast"]; a.To != "codex" || a.Reason != FallbackLimited || a.ResetsAt != "7pm" || a.Restore {
+		t.Errorf("toast action = %+v", a)
+	}
+	// slit fell back for an outage an hour ago. Every claude session is
+	// failing, so slit goes back only as a probe.
+	if a := got["gt-gastown-slit"]; !a.Restore || !a.Probe || a.To != "claude" || a.From != "codex" {
+		t.Errorf("slit action = %+v", a)
+	}
+	// rictus fell back for a rate limit and no account is spare yet.
+	if _, ok := got["gt-gastown-rictus"]; ok {
+		t.Error("rictus restored without a spare account")
+	}
+
+	// Once toast and witness run claude cleanly, both restore outright.
+	actions = PlanFallback(nil, nil, agents, chains, state, true, now)
+	if len(actions) != 2 {
+		t.Errorf("with spare accounts got %d actions, want slit and rictus restores: %+v", len(actions), actions)
+	}
+	for _, a := range actions {
+		if a.Probe {
+			t.Errorf("%s probed with claude known working", a.Session)
+		}
+	}
+}
+
+func TestPlanFallbackProbe(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	chains := map[string][]string{"polecat": {"claude", "codex"}}
+	agents := map[string]SessionAgent{
+		"gt-gastown-slit":   {Role: "polecat", Agent: "codex"},
+		"gt-gastown-rictus": {Role: "polecat", Agent: "codex"},
+	}
+	state := &FallbackState{Sessions: map[string]FallbackEntry{
+		"gt-gastown-slit":   {Role: "polecat", Primary: "claude", Current: "codex", Reason: FallbackDown, Since: now.Add(-time.Hour)},
+		"gt-gastown-rictus": {Role: "polecat", Primary: "claude", Current: "codex", Reason: FallbackDown, Since: now.Add(-time.Hour)},
+	}}
+
+	// Nothing runs claude: probe with one session only.
+	actions := PlanFallback(nil, nil, agents, chains, state, true, now)
+	if len(actions) != 1 || !actions[0].Probe || actions[0].Session != "gt-gastown-rictus" {
+		t.Fatalf("actions = %+v, want a single rictus probe", actions)
+	}
+	state.Record(actions[0], now)
+	agents["gt-gastown-rictus"] = SessionAgent{Role: "polecat", Agent: "claude"}
+
+	// The probe fails again: it falls back, slit stays put.
+	down := []ScanResult{{Session: "gt-gastown-rictus", RateLimited: true}}
+	actions = PlanFallback(nil, down, agents, chains, state, true, now.Add(time.Minute))
+	if len(actions) != 1 || actions[0].Restore || actions[0].To != "codex" {
+		t.Fatalf("actions = %+v, want rictus back on codex only", actions)
+	}
+
+	// If instead the probe scans clean, it is confirmed and slit follows.
+	actions = PlanFallback(nil, nil, agents, chains, state, true, now.Add(time.Minute))
+	if _, ok := state.Sessions["gt-gastown-rictus"]; ok {
+		t.Error("clean probe still tracked as on fallback")
+	}
+	if len(actions) != 1 || actions[0].Session != "gt-gastown-slit" || actions[0].Probe {
+		t.Errorf("actions = %+v, want slit restored outright", actions)
+	}
+}
+
+func TestFallbackStateRecord(t *testing.T) {
+	townRoot := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Second)
+	state, err := LoadFallbackState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state.Record(FallbackAction{Session: "s", Role: "polecat", From: "claude", To: "codex", Reason: FallbackDown}, now)
+	state.Record(FallbackAction{Session: "s", Role: "polecat", From: "codex", To: "gemini", Reason: FallbackDown}, now)
+	if e := state.Sessions["s"]; e.Primary != "claude" || e.Current != "gemini" {
+		t.Errorf("entry = %+v, want primary claude current gemini", e)
+	}
+	if err := SaveFallbackState(townRoot, state); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFallbackState(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := loaded.Sessions["s"]; e.Current != "gemini" || !e.Since.Equal(now) {
+		t.Errorf("round trip = %+v", e)
+	}
+
+	loaded.Prune(map[string]SessionAgent{})
+	if len(loaded.Sessions) != 0 {
+		t.Errorf("Prune kept dead sessions: %v", loaded.Sessions)
+	}
+	state.Record(FallbackAction{Session: "s", To: "claude", Restore: true, Probe: true}, now)
+	if e := state.Sessions["s"]; !e.Probing || e.Current != "claude" || e.Primary != "claude" {
+		t.Errorf("probe entry = %+v, want probing on claude", e)
+	}
+	state.Record(FallbackAction{Session: "s", To: "claude", Restore: true}, now)
+	if _, ok := state.Sessions["s"]; ok {
+		t.Error("restore did not clear entry")
+	}
+}
+
+func TestAgentSwitcher_Switch(t *testing.T) {
+	exec := newMockExecutor()
+	exec.paneIDs["gt-gastown-toast"] = "%1"
+	log := &mockLogger{}
+	accounts := &config.AccountsConfig{Accounts: map[string]config.Account{
+		"work": {ConfigDir: "/accounts/work"},
+	}}
+	var checkpointed string
+	restart := func(session string) (string, error) {
+		return "exec " + exec.envSets[session]["GT_AGENT"], nil
+	}
+	sw := NewAgentSwitcher(exec, accounts, restart, func(session, note string) error {
+		checkpointed = note
+		return nil
+	}, log)
+
+	err := sw.Switch(FallbackAction{Session: "gt-gastown-toast", From: "claude", To: "codex", Reason: FallbackDown})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec.envSets["gt-gastown-toast"]["GT_AGENT"] != "codex" {
+		t.Errorf("GT_AGENT = %q", exec.envSets["gt-gastown-toast"]["GT_AGENT"])
+	}
+	if exec.envSets["gt-gastown-toast"]["GT_PROCESS_NAMES"] == "" {
+		t.Error("GT_PROCESS_NAMES not set")
+	}
+	if exec.respawned["%1"] != "exec codex" {
+		t.Errorf("respawn command = %q", exec.respawned["%1"])
+	}
+	if !strings.Contains(checkpointed, "codex") {
+		t.Errorf("checkpoint note = %q", checkpointed)
+	}
+
+	// Restoring to Claude with an account exports its config dir.
+	err = sw.Switch(FallbackAction{Session: "gt-gastown-toast", From: "codex", To: "claude", Restore: true, Account: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := exec.respawned["%1"]; !strings.HasPrefix(cmd, `export CLAUDE_CONFIG_DIR="/accounts/work" && exec claude`) {
+		t.Errorf("restore command = %q", cmd)
+	}
+}
+
+func TestAgentSwitcher_RestartCommandFailure(t *testing.T) {
+	exec := newMockExecutor()
+	exec.paneIDs["gt-gastown-toast"] = "%1"
+	sw := NewAgentSwitcher(exec, nil, func(string) (string, error) {
+		return "", errors.New("boom")
+	}, nil, &mockLogger{})
+
+	if err := sw.Switch(FallbackAction{Session: "gt-gastown-toast", From: "claude", To: "codex"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if exec.envSets["gt-gastown-toast"]["GT_AGENT"] != "claude" {
+		t.Errorf("GT_AGENT not reverted: %q", exec.envSets["gt-gastown-toast"]["GT_AGENT"])
+	}
+	if len(exec.respawned) != 0 {
+		t.Error("pane respawned despite failure")
+	}
+}
@@ -0,0 +1,162 @@
+package quota
+
+import (
+	"sort"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+	"github.com/xcawolfe-amzn/gastown/internal/util"
+)
+
+// rateWindow is the trailing period used to measure an account's burn rate.
+const rateWindow = time.Hour
+
+// DefaultPreemptLead is how far ahead of a forecast exhaustion sessions are
+// rotated off an account.
+const DefaultPreemptLead = 20 * time.Minute
+
+// Forecast is the projected quota outlook for one account.
+type Forecast struct {
+	Handle         string                    `json:"handle"`
+	Status         config.AccountQuotaStatus `json:"status"`
+	WindowSpendUSD float64                   `json:"window_spend_usd"`       // spend in the trailing limit window
+	RateUSDPerHour float64                   `json:"rate_usd_per_hour"`      // spend rate over the last hour
+	CapacityUSD    float64                   `json:"capacity_usd,omitempty"` // learned spend at the last limit
+	ExhaustsAt     *time.Time                `json:"exhausts_at,omitempty"`  // nil when there's no capacity or no burn
+	ResetsAt       *time.Time                `json:"resets_at,omitempty"`    // for limited accounts
+}
+
+// AtRisk reports whether the account is forecast to run out within lead.
+func (f Forecast) AtRisk(now time.Time, lead time.Duration) bool {
+	return f.ExhaustsAt != nil && !f.ExhaustsAt.After(now.Add(lead))
+}
+
+// accountSpend sums entries attributed to handle that ended in [from, to].
+// entries are spend increments from costs.ReadSpendSince, not the raw log's
+// cumulative session costs.
+func accountSpend(entries []costs.LogEntry, handle string, from, to time.Time) float64 {
+	var total float64
+	for _, e := range entries {
+		if e.Account != handle || e.EndedAt.Before(from) || e.EndedAt.After(to) {
+			continue
+		}
+		total += e.CostUSD
+	}
+	return total
+}
+
+// LearnCapacity records, for each limited account, the spend observed in
+// the limit window that ended when it was limited. entries must cover at
+// least DefaultLimitWindow before each LimitedAt. Returns true if any
+// capacity changed.
+func LearnCapacity(state *config.QuotaState, entries []costs.LogEntry) bool {
+	changed := false
+	for handle, st := range state.Accounts {
+		if st.Status != config.QuotaStatusLimited {
+			continue
+		}
+		limitedAt, err := time.Parse(time.RFC3339, st.LimitedAt)
+		if err != nil {
+			continue
+		}
+		spent := accountSpend(entries, handle, limitedAt.Add(-DefaultLimitWindow), limitedAt)
+		if spent <= 0 || spent == st.CapacityUSD {
+			continue
+		}
+		st.CapacityUSD = spent
+		state.Accounts[handle] = st
+		changed = true
+	}
+	return changed
+}
+
+// ForecastAccounts projects when each tracked account will hit its limit,
+// from its spend in the trailing window against its learned capacity.
+// Results are sorted by handle.
+func ForecastAccounts(state *config.QuotaState, entries []costs.LogEntry, now time.Time) []Forecast {
+	handles := make([]string, 0, len(state.Accounts))
+	for handle := range state.Accounts {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+
+	forecasts := make([]Forecast, 0, len(handles))
+	for _, handle := range handles {
+		st := state.Accounts[handle]
+		f := Forecast{
+			Handle:         handle,
+			Status:         st.Status,
+			WindowSpendUSD: accountSpend(entries, handle, now.Add(-DefaultLimitWindow), now),
+			RateUSDPerHour: accountSpend(entries, handle, now.Add(-rateWindow), now) / rateWindow.Hours(),
+			CapacityUSD:    st.CapacityUSD,
+		}
+		if f.Status == "" {
+			f.Status = config.QuotaStatusAvailable
+		}
+		if f.Status != config.QuotaStatusAvailable {
+			if reset, ok := ResetTime(st); ok {
+				f.ResetsAt = &reset
+			}
+		} else if f.CapacityUSD > 0 && f.RateUSDPerHour > 0 {
+			remaining := f.CapacityUSD - f.WindowSpendUSD
+			if remaining < 0 {
+				remaining = 0
+			}
+			at := now.Add(time.Duration(remaining / f.RateUSDPerHour * float64(time.Hour)))
+			f.ExhaustsAt = &at
+		}
+		forecasts = append(forecasts, f)
+	}
+	return forecasts
+}
+
+// PlanPreemptiveRotation plans moving sessions off accounts forecast to be
+// exhausted within lead, onto available accounts that are not themselves at
+// risk (least recently used first). The returned plan's LimitedSessions are
+// the at-risk sessions; it can be passed to Rotator.Execute as-is.
+func PlanPreemptiveRotation(results []ScanResult, state *config.QuotaState, forecasts []Forecast,
+	mgr *Manager, now time.Time, lead time.Duration) *RotatePlan {
+	atRisk := make(map[string]bool)
+	for _, f := range forecasts {
+		if f.AtRisk(now, lead) {
+			atRisk[f.Handle] = true
+		}
+	}
+
+	var targets []string
+	for _, handle := range mgr.AvailableAccounts(state) {
+		if !atRisk[handle] {
+			targets = append(targets, handle)
+		}
+	}
+
+	plan := &RotatePlan{AvailableAccounts: targets, Assignments: make(map[string]string)}
+	for _, r := range results {
+		if r.RateLimited || r.AccountHandle == "" || !atRisk[r.AccountHandle] {
+			continue // limited sessions are regular rotation's job
+		}
+		plan.LimitedSessions = append(plan.LimitedSessions, r)
+	}
+	for i, r := range plan.LimitedSessions {
+		if len(targets) == 0 {
+			break
+		}
+		plan.Assignments[r.Session] = targets[i%len(targets)]
+	}
+	return plan
+}
+
+// AccountForConfigDir maps a CLAUDE_CONFIG_DIR value back to its account
+// handle. Returns "" if no registered account uses it.
+func AccountForConfigDir(accounts *config.AccountsConfig, configDir string) string {
+	if accounts == nil || configDir == "" {
+		return ""
+	}
+	for handle, acct := range accounts.Accounts {
+		if acct.ConfigDir == configDir || util.ExpandHome(acct.ConfigDir) == configDir {
+			return handle
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
+)
+
+func TestLearnCapacityAndForecast(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	limitedAt := now.Add(-2 * time.Hour)
+	entries := []costs.LogEntry{
+		{Account: "work", CostUSD: 30, EndedAt: limitedAt.Add(-3 * time.Hour)},
+		{Account: "work", CostUSD: 10, EndedAt: limitedAt.Add(-time.Minute)},
+		{Account: "personal", CostUSD: 20, EndedAt: now.Add(-4 * time.Hour)},
+		{Account: "personal", CostUSD: 10, EndedAt: now.Add(-30 * time.Minute)},
+		{Account: "", CostUSD: 99, EndedAt: now.Add(-time.Minute)},
+	}
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"work":     {Status: config.QuotaStatusLimited, LimitedAt: limitedAt.Format(time.RFC3339)},
+		"personal": {Status: config.QuotaStatusAvailable, CapacityUSD: 40},
+		"spare":    {},
+	}}
+
+	if !LearnCapacity(state, entries) {
+		t.Fatal("LearnCapacity reported no change")
+	}
+	if got := state.Accounts["work"].CapacityUSD; got != 40 {
+		t.Errorf("work capacity = %v, want 40", got)
+	}
+	if LearnCapacity(state, entries) {
+		t.Error("second LearnCapacity changed state")
+	}
+
+	forecasts := ForecastAccounts(state, entries, now)
+	if len(forecasts) != 3 {
+		t.Fatalf("got %d forecasts", len(forecasts))
+	}
+	personal := forecasts[0]
+	if personal.Handle != "personal" || personal.WindowSpendUSD != 30 || personal.RateUSDPerHour != 10 {
+		t.Errorf("personal forecast = %+v", personal)
+	}
+	// $10 of headroom at $10/h.
+	if personal.ExhaustsAt == nil || !personal.ExhaustsAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("personal ExhaustsAt = %v, want %v", personal.ExhaustsAt, now.Add(time.Hour))
+	}
+	if spare := forecasts[1]; spare.ExhaustsAt != nil || spare.Status != config.QuotaStatusAvailable {
+		t.Errorf("spare forecast = %+v", spare)
+	}
+	if work := forecasts[2]; work.ResetsAt == nil || !work.ResetsAt.Equal(limitedAt.Add(DefaultLimitWindow)) {
+		t.Errorf("work forecast = %+v", work)
+	}
+
+	if personal.AtRisk(now, 30*time.Minute) {
+		t.Error("personal at risk with an hour left and 30m lead")
+	}
+	if !personal.AtRisk(now, time.Hour) {
+		t.Error("personal not at risk with 1h lead")
+	}
+}
+
+func TestForecastCumulativeLog(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	// One session logging its running total every turn: $6 in the last hour.
+	log := []costs.LogEntry{
+		{SessionID: "gt-a", Account: "work", CostUSD: 10, EndedAt: now.Add(-2 * time.Hour)},
+		{SessionID: "gt-a", Account: "work", CostUSD: 12, EndedAt: now.Add(-50 * time.Minute)},
+		{SessionID: "gt-a", Account: "work", CostUSD: 16, EndedAt: now.Add(-10 * time.Minute)},
+	}
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{"work": {}}}
+
+	f := ForecastAccounts(state, costs.Increments(log), now)[0]
+	if f.WindowSpendUSD != 16 || f.RateUSDPerHour != 6 {
+		t.Errorf("forecast = %+v, want window $16 at $6/h", f)
+	}
+}
+
+func TestPlanPreemptiveRotation(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(10 * time.Minute)
+	later := now.Add(3 * time.Hour)
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"hot":  {Status: config.QuotaStatusAvailable, LastUsed: "2026-01-01T00:00:00Z"},
+		"warm": {Status: config.QuotaStatusAvailable, LastUsed: "2026-01-02T00:00:00Z"},
+		"cold": {Status: config.QuotaStatusAvailable, LastUsed: "2026-01-03T00:00:00Z"},
+		"gone": {Status: config.QuotaStatusLimited},
+	}}
+	forecasts := []Forecast{
+		{Handle: "hot", ExhaustsAt: &soon},
+		{Handle: "warm", ExhaustsAt: &later},
+	}
+	results := []ScanResult{
+		{Session: "gt-a", AccountHandle: "hot"},
+		{Session: "gt-b", AccountHandle: "hot", RateLimited: true},
+		{Session: "gt-c", AccountHandle: "warm"},
+	}
+
+	plan := PlanPreemptiveRotation(results, state, forecasts, NewManager(t.TempDir()), now, DefaultPreemptLead)
+	if len(plan.LimitedSessions) != 1 || plan.LimitedSessions[0].Session != "gt-a" {
+		t.Errorf("at-risk sessions = %+v, want gt-a", plan.LimitedSessions)
+	}
+	if plan.Assignments["gt-a"] != "warm" {
+		t.Errorf("gt-a assigned %q, want warm (LRU, not at risk)", plan.Assignments["gt-a"])
+	}
+}
@@ -0,0 +1,164 @@
+package quota
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+// DefaultLimitWindow is how long an account is assumed to stay limited when
+// the provider's reset time is unknown or unparseable. It matches the
+// rolling usage window of Claude subscription plans.
+const DefaultLimitWindow = 5 * time.Hour
+
+// resetsAtPattern matches provider reset hints:
+//
+//	"7pm (America/Los_Angeles)"
+//	"3:00 AM PST"
+//	"Jan 5, 9am (Europe/Berlin)"
+//	"14:30 UTC"
+var resetsAtPattern = regexp.MustCompile(`(?i)^(?:([a-z]{3,9})\.?\s+(\d{1,2}),?\s+(?:at\s+)?)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?\b\s*(?:\(([^)]+)\)|([a-z][a-z_/+\-0-9]*))?`)
+
+// zoneAbbreviations maps the abbreviations providers print to locations,
+// so daylight saving is applied for the actual reset date.
+var zoneAbbreviations = map[string]string{
+	"PST": "America/Los_Angeles", "PDT": "America/Los_Angeles", "PT": "America/Los_Angeles",
+	"MST": "America/Denver", "MDT": "America/Denver", "MT": "America/Denver",
+	"CST": "America/Chicago", "CDT": "America/Chicago", "CT": "America/Chicago",
+	"EST": "America/New_York", "EDT": "America/New_York", "ET": "America/New_York",
+	"UTC": "UTC", "GMT": "UTC", "Z": "UTC",
+}
+
+// ParseResetsAt resolves a provider reset hint to the next matching instant
+// after now. Times without a zone are read in now's location. Returns false
+// when the text is not a recognizable time.
+func ParseResetsAt(text string, now time.Time) (time.Time, bool) {
+	m := resetsAtPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return time.Time{}, false
+	}
+	monthText, dayText, hourText, minText, ampm := m[1], m[2], m[3], m[4], strings.ToLower(m[5])
+	zone := m[6]
+	if zone == "" {
+		zone = m[7]
+	}
+
+	hour, _ := strconv.Atoi(hourText)
+	minute := 0
+	if minText != "" {
+		minute, _ = strconv.Atoi(minText)
+	}
+	switch {
+	case ampm != "" && (hour < 1 || hour > 12):
+		return time.Time{}, false
+	case ampm == "pm" && hour != 12:
+		hour += 12
+	case ampm == "am" && hour == 12:
+		hour = 0
+	case ampm == "" && minText == "":
+		return time.Time{}, false // a bare number is not a time
+	}
+	if hour > 23 || minute > 59 {
+		return time.Time{}, false
+	}
+
+	loc := now.Location()
+	if zone != "" {
+		if l, ok := resolveZone(zone); ok {
+			loc = l
+		}
+	}
+	local := now.In(loc)
+
+	if monthText != "" {
+		month, err := time.Parse("Jan", strings.ToUpper(monthText[:1])+strings.ToLower(monthText[1:3]))
+		if err != nil {
+			return time.Time{}, false
+		}
+		day, _ := strconv.Atoi(dayText)
+		t := time.Date(local.Year(), month.Month(), day, hour, minute, 0, 0, loc)
+		if t.Before(now.Add(-24 * time.Hour)) {
+			t = t.AddDate(1, 0, 0) // "Jan 2" seen on Dec 31
+		}
+		return t, true
+	}
+
+	t := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
+
+func resolveZone(zone string) (*time.Location, bool) {
+	zone = strings.TrimSpace(zone)
+	if name, ok := zoneAbbreviations[strings.ToUpper(zone)]; ok {
+		zone = name
+	}
+	if !strings.Contains(zone, "/") && zone != "UTC" {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// LimitedState returns the state for an account detected as limited now,
+// keeping its usage history and resolving the reset hint when possible.
+func LimitedState(prev config.AccountQuotaState, resetsAt string, now time.Time) config.AccountQuotaState {
+	next := config.AccountQuotaState{
+		Status:      config.QuotaStatusLimited,
+		LimitedAt:   prev.LimitedAt,
+		ResetsAt:    resetsAt,
+		LastUsed:    prev.LastUsed,
+		CapacityUSD: prev.CapacityUSD,
+	}
+	if prev.Status != config.QuotaStatusLimited || next.LimitedAt == "" {
+		next.LimitedAt = now.UTC().Format(time.RFC3339)
+	}
+	if t, ok := ParseResetsAt(resetsAt, now); ok {
+		next.ResetsAtTime = t.UTC().Format(time.RFC3339)
+	}
+	return next
+}
+
+// ResetTime returns when a limited account becomes usable again: the parsed
+// provider reset time, else DefaultLimitWindow after it was limited.
+func ResetTime(st config.AccountQuotaState) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, st.ResetsAtTime); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, st.LimitedAt); err == nil {
+		return t.Add(DefaultLimitWindow), true
+	}
+	return time.Time{}, false
+}
+
+// ReleaseExpired marks limited and cooldown accounts whose reset time has
+// passed as available. Returns the released handles, sorted.
+func ReleaseExpired(state *config.QuotaState, now time.Time) []string {
+	var released []string
+	for handle, st := range state.Accounts {
+		if st.Status != config.QuotaStatusLimited && st.Status != config.QuotaStatusCooldown {
+			continue
+		}
+		reset, ok := ResetTime(st)
+		if !ok || reset.After(now) {
+			continue
+		}
+		state.Accounts[handle] = config.AccountQuotaState{
+			Status:      config.QuotaStatusAvailable,
+			LastUsed:    st.LastUsed,
+			CapacityUSD: st.CapacityUSD,
+		}
+		released = append(released, handle)
+	}
+	sort.Strings(released)
+	return released
+}
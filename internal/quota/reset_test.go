@@ -0,0 +1,74 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+func TestParseResetsAt(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	// 2026-01-15 10:00 in Los Angeles.
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, la)
+
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"7pm (America/Los_Angeles)", time.Date(2026, 1, 15, 19, 0, 0, 0, la)},
+		{"3:00 AM PST", time.Date(2026, 1, 16, 3, 0, 0, 0, la)}, // already past today
+		{"12am", time.Date(2026, 1, 16, 0, 0, 0, 0, la)},
+		{"14:30 UTC", time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC).Add(24 * time.Hour)},
+		{"Jan 20, 9am (America/Los_Angeles)", time.Date(2026, 1, 20, 9, 0, 0, 0, la)},
+		{"Jan 2 at 1pm", time.Date(2027, 1, 2, 13, 0, 0, 0, la)},
+	}
+	for _, tt := range tests {
+		got, ok := ParseResetsAt(tt.text, now)
+		if !ok {
+			t.Errorf("ParseResetsAt(%q) failed", tt.text)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseResetsAt(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "soon", "42", "13pm"} {
+		if got, ok := ParseResetsAt(bad, now); ok {
+			t.Errorf("ParseResetsAt(%q) = %v, want failure", bad, got)
+		}
+	}
+}
+
+func TestLimitedStateAndReleaseExpired(t *testing.T) {
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	state := &config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"parsed":   LimitedState(config.AccountQuotaState{LastUsed: "x", CapacityUSD: 12}, "11am (UTC)", now),
+		"unparsed": LimitedState(config.AccountQuotaState{}, "whenever", now),
+		"free":     {Status: config.QuotaStatusAvailable},
+	}}
+	if st := state.Accounts["parsed"]; st.ResetsAtTime != "2026-01-15T11:00:00Z" || st.LastUsed != "x" || st.CapacityUSD != 12 {
+		t.Errorf("LimitedState = %+v", st)
+	}
+
+	if released := ReleaseExpired(state, now.Add(30*time.Minute)); len(released) != 0 {
+		t.Errorf("released early: %v", released)
+	}
+	released := ReleaseExpired(state, now.Add(2*time.Hour))
+	if len(released) != 1 || released[0] != "parsed" {
+		t.Errorf("released = %v, want [parsed]", released)
+	}
+	if st := state.Accounts["parsed"]; st.Status != config.QuotaStatusAvailable || st.CapacityUSD != 12 {
+		t.Errorf("released state = %+v", st)
+	}
+
+	// Without a parseable reset the default window applies.
+	released = ReleaseExpired(state, now.Add(DefaultLimitWindow))
+	if len(released) != 1 || released[0] != "unparsed" {
+		t.Errorf("released = %v, want [unparsed]", released)
+	}
+}
@@ -2,6 +2,7 @@ package quota
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 )
@@ -53,14 +54,10 @@ func PlanRotation(scanner *Scanner, mgr *Manager, acctCfg *config.AccountsConfig
 	}
 
 	// Update state: mark detected limited accounts
+	now := time.Now()
 	for _, r := range limitedSessions {
 		if r.AccountHandle != "" {
-			state.Accounts[r.AccountHandle] = config.AccountQuotaState{
-				Status:    config.QuotaStatusLimited,
-				LimitedAt: state.Accounts[r.AccountHandle].LimitedAt,
-				ResetsAt:  r.ResetsAt,
-				LastUsed:  state.Accounts[r.AccountHandle].LastUsed,
-			}
+			state.Accounts[r.AccountHandle] = LimitedState(state.Accounts[r.AccountHandle], r.ResetsAt, now)
 		}
 	}
 
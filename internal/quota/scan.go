@@ -8,7 +8,6 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
 	"github.com/xcawolfe-amzn/gastown/internal/session"
-	"github.com/xcawolfe-amzn/gastown/internal/util"
 )
 
 // ScanResult holds the result of scanning a single tmux session.
@@ -129,15 +128,8 @@ func (s *Scanner) resolveAccountHandle(session string) string {
 		return "" // No CLAUDE_CONFIG_DIR = using default config
 	}
 
-	configDir = strings.TrimSpace(configDir)
-	for handle, acct := range s.accounts.Accounts {
-		// Compare normalized paths (accounts may use ~/... while tmux has expanded)
-		if acct.ConfigDir == configDir || util.ExpandHome(acct.ConfigDir) == configDir {
-			return handle
-		}
-	}
-
-	return "" // CLAUDE_CONFIG_DIR doesn't match any registered account
+	// Compares normalized paths (accounts may use ~/... while tmux has expanded)
+	return AccountForConfigDir(s.accounts, strings.TrimSpace(configDir))
 }
 
 // isGasTownSession returns true if the session name belongs to Gas Town.
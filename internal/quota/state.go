@@ -15,6 +15,7 @@ import (
 	"github.com/gofrs/flock"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
 	"github.com/xcawolfe-amzn/gastown/internal/util"
 )
 
@@ -109,6 +110,7 @@ func (m *Manager) SaveUnlocked(state *config.QuotaState) error {
 }
 
 // MarkLimited marks an account as rate-limited with an optional reset time.
+// A parseable reset time is also stored as a timestamp (see ReleaseExpired).
 func (m *Manager) MarkLimited(handle string, resetsAt string) error {
 	unlock, err := m.lock()
 	if err != nil {
@@ -121,13 +123,7 @@ func (m *Manager) MarkLimited(handle string, resetsAt string) error {
 		return err
 	}
 
-	now := time.Now().UTC().Format(time.RFC3339)
-	state.Accounts[handle] = config.AccountQuotaState{
-		Status:    config.QuotaStatusLimited,
-		LimitedAt: now,
-		ResetsAt:  resetsAt,
-		LastUsed:  state.Accounts[handle].LastUsed,
-	}
+	state.Accounts[handle] = LimitedState(state.Accounts[handle], resetsAt, time.Now())
 
 	return util.EnsureDirAndWriteJSON(m.statePath(), state)
 }
@@ -147,8 +143,9 @@ func (m *Manager) MarkAvailable(handle string) error {
 
 	existing := state.Accounts[handle]
 	state.Accounts[handle] = config.AccountQuotaState{
-		Status:   config.QuotaStatusAvailable,
-		LastUsed: existing.LastUsed,
+		Status:      config.QuotaStatusAvailable,
+		LastUsed:    existing.LastUsed,
+		CapacityUSD: existing.CapacityUSD,
 	}
 
 	return util.EnsureDirAndWriteJSON(m.statePath(), state)
@@ -204,3 +201,23 @@ func (m *Manager) EnsureAccountsTracked(state *config.QuotaState, accounts map[s
 		}
 	}
 }
+
+// Refresh learns account capacities from spend entries and returns
+// accounts whose reset time has passed to available, under the lock.
+// Returns the released handles.
+func (m *Manager) Refresh(entries []costs.LogEntry, now time.Time) ([]string, error) {
+	var released []string
+	err := m.WithLock(func() error {
+		state, err := m.Load()
+		if err != nil {
+			return err
+		}
+		changed := LearnCapacity(state, entries)
+		released = ReleaseExpired(state, now)
+		if !changed && len(released) == 0 {
+			return nil
+		}
+		return m.SaveUnlocked(state)
+	})
+	return released, err
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/costs"
 )
 
 // setupTestTown creates a temporary town root with mayor directory.
@@ -388,3 +390,36 @@ func TestSaveCreatesDirectory(t *testing.T) {
 		t.Fatalf("parsing saved file: %v", err)
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	townRoot := setupTestTown(t)
+	mgr := NewManager(townRoot)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	if err := mgr.Save(&config.QuotaState{Accounts: map[string]config.AccountQuotaState{
+		"work": {Status: config.QuotaStatusLimited, LimitedAt: now.Add(-time.Hour).Format(time.RFC3339),
+			ResetsAtTime: now.Add(-time.Minute).Format(time.RFC3339)},
+		"personal": {Status: config.QuotaStatusLimited, LimitedAt: now.Format(time.RFC3339)},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	entries := []costs.LogEntry{{Account: "personal", CostUSD: 25, EndedAt: now.Add(-time.Hour)}}
+
+	released, err := mgr.Refresh(entries, now)
+	if err != nil {
+		t.Fatalf("Refresh() error: %v", err)
+	}
+	if len(released) != 1 || released[0] != "work" {
+		t.Errorf("released = %v, want [work]", released)
+	}
+	state, err := mgr.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Accounts["work"].Status != config.QuotaStatusAvailable {
+		t.Errorf("work status = %q, want available", state.Accounts["work"].Status)
+	}
+	if p := state.Accounts["personal"]; p.Status != config.QuotaStatusLimited || p.CapacityUSD != 25 {
+		t.Errorf("personal = %+v, want limited with learned capacity 25", p)
+	}
+}
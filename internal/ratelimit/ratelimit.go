@@ -0,0 +1,199 @@
+// Package ratelimit throttles agents that flood each other with mail or
+// nudges, and catches reply loops between agents.
+//
+// Every gt invocation is a separate process, so send history is kept in a
+// small JSON file per channel under <townRoot>/.runtime/ratelimit/ and
+// updated under a file lock.
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/xcawolfe-amzn/gastown/internal/beads"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/events"
+)
+
+// ErrRateLimited is wrapped by every Violation.
+var ErrRateLimited = errors.New("rate limited")
+
+// retention bounds how long history is kept for keys that stop sending.
+const retention = 24 * time.Hour
+
+// Rule allows at most Max sends for Key within Window.
+type Rule struct {
+	Key    string        // e.g. "sender:gastown/nux", "pair:mayor->gastown/nux"
+	Max    int           // sends allowed per window
+	Window time.Duration // sliding window
+	Reason string        // what the rule guards, for errors and escalations
+}
+
+// Violation is returned by Allow when a send would break a rule.
+type Violation struct {
+	Rule
+	Count int // sends already recorded in the window
+
+	// Escalate is set on the first violation of Key within its window.
+	// Later violations in the same window are only logged.
+	Escalate bool
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%v: %s (%d sends in %s, max %d)", ErrRateLimited, v.Reason, v.Count, v.Window, v.Max)
+}
+
+func (v *Violation) Unwrap() error { return ErrRateLimited }
+
+// Limiter enforces rules for one channel (mail, nudge).
+type Limiter struct {
+	path string
+}
+
+// state is the persisted send history.
+type state struct {
+	Sends     map[string][]time.Time `json:"sends"`
+	Escalated map[string]time.Time   `json:"escalated,omitempty"`
+}
+
+// New returns the limiter for channel in the given town.
+func New(townRoot, channel string) *Limiter {
+	return &Limiter{path: filepath.Join(townRoot, constants.DirRuntime, "ratelimit", channel+".json")}
+}
+
+// Allow records a send at now against every rule, or returns the first
+// rule it would break without recording anything. Callers should pass the
+// returned Violation to Report.
+func (l *Limiter) Allow(now time.Time, rules ...Rule) (*Violation, error) {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return nil, fmt.Errorf("creating rate limit dir: %w", err)
+	}
+	fl := flock.New(l.path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring rate limit lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	st, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Rules can share a key with different windows (reply velocity and
+	// thread depth); keep history for the longest one.
+	keep := make(map[string]time.Duration)
+	for _, r := range rules {
+		if r.Window > keep[r.Key] {
+			keep[r.Key] = r.Window
+		}
+	}
+	for key, window := range keep {
+		st.Sends[key] = since(st.Sends[key], now.Add(-window))
+	}
+
+	for _, r := range rules {
+		count := len(since(st.Sends[r.Key], now.Add(-r.Window)))
+		if count < r.Max {
+			continue
+		}
+		v := &Violation{Rule: r, Count: count}
+		if last, ok := st.Escalated[r.Key]; !ok || now.Sub(last) >= r.Window {
+			v.Escalate = true
+			st.Escalated[r.Key] = now
+		}
+		return v, l.save(st, now)
+	}
+
+	for key := range keep {
+		st.Sends[key] = append(st.Sends[key], now)
+	}
+	return nil, l.save(st, now)
+}
+
+// since returns the times at or after cutoff. times is in send order.
+func since(times []time.Time, cutoff time.Time) []time.Time {
+	for i, t := range times {
+		if !t.Before(cutoff) {
+			return times[i:]
+		}
+	}
+	return nil
+}
+
+func (l *Limiter) load() (*state, error) {
+	st := &state{Sends: make(map[string][]time.Time), Escalated: make(map[string]time.Time)}
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading rate limit state: %w", err)
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		// Corrupt history only loosens limits briefly; start over
+		return &state{Sends: make(map[string][]time.Time), Escalated: make(map[string]time.Time)}, nil
+	}
+	if st.Sends == nil {
+		st.Sends = make(map[string][]time.Time)
+	}
+	if st.Escalated == nil {
+		st.Escalated = make(map[string]time.Time)
+	}
+	return st, nil
+}
+
+func (l *Limiter) save(st *state, now time.Time) error {
+	cutoff := now.Add(-retention)
+	for key, times := range st.Sends {
+		if len(times) == 0 || times[len(times)-1].Before(cutoff) {
+			delete(st.Sends, key)
+		}
+	}
+	for key, at := range st.Escalated {
+		if at.Before(cutoff) {
+			delete(st.Escalated, key)
+		}
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("marshaling rate limit state: %w", err)
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing rate limit state: %w", err)
+	}
+	return os.Rename(tmp, l.path)
+}
+
+// Report logs a throttled send and, on the first violation in a window,
+// files an escalation against the sender so a human or the mayor can look
+// at the misbehaving agent. Reporting is best-effort.
+func Report(townRoot, channel, sender string, v *Violation) {
+	payload := events.RateLimitPayload(channel, sender, v.Key, v.Reason, v.Count)
+	if !v.Escalate {
+		_ = events.LogAudit(events.TypeRateLimited, sender, payload)
+		return
+	}
+	_ = events.LogFeed(events.TypeRateLimited, sender, payload)
+
+	beadsDir := beads.ResolveBeadsDir(townRoot)
+	if _, err := os.Stat(beadsDir); err != nil {
+		return
+	}
+	_, _ = beads.New(beadsDir).CreateEscalationBead(
+		fmt.Sprintf("%s throttled: %s", sender, v.Reason),
+		&beads.EscalationFields{
+			Severity:    config.SeverityMedium,
+			Reason:      fmt.Sprintf("%s %s sends throttled: %v", sender, channel, v),
+			Source:      "ratelimit:" + channel,
+			EscalatedBy: sender,
+			EscalatedAt: time.Now().Format(time.RFC3339),
+		})
+}
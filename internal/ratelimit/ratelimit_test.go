@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAllow_SlidingWindow(t *testing.T) {
+	l := New(t.TempDir(), "mail")
+	rule := Rule{Key: "sender:a", Max: 3, Window: time.Minute, Reason: "test"}
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if v, err := l.Allow(start.Add(time.Duration(i)*time.Second), rule); v != nil || err != nil {
+			t.Fatalf("send %d: %v, %v", i, v, err)
+		}
+	}
+
+	v, err := l.Allow(start.Add(10*time.Second), rule)
+	if err != nil || v == nil {
+		t.Fatalf("4th send allowed: %v", err)
+	}
+	if !v.Escalate || v.Count != 3 || !errors.Is(v, ErrRateLimited) {
+		t.Errorf("first violation = %+v", v)
+	}
+	if v, _ := l.Allow(start.Add(20*time.Second), rule); v == nil || v.Escalate {
+		t.Errorf("repeat violation = %+v, want no second escalation", v)
+	}
+
+	// The first send leaves the window
+	if v, _ := l.Allow(start.Add(61*time.Second), rule); v != nil {
+		t.Errorf("send after window slid: %v", v)
+	}
+}
+
+func TestAllow_RulesAreAtomic(t *testing.T) {
+	l := New(t.TempDir(), "nudge")
+	now := time.Now()
+	sender := Rule{Key: "sender:a", Max: 10, Window: time.Minute, Reason: "sender"}
+	pairB := Rule{Key: "pair:a->b", Max: 1, Window: time.Minute, Reason: "pair"}
+	pairC := Rule{Key: "pair:a->c", Max: 1, Window: time.Minute, Reason: "pair"}
+
+	if v, _ := l.Allow(now, sender, pairB); v != nil {
+		t.Fatalf("first send: %v", v)
+	}
+	v, _ := l.Allow(now, sender, pairB)
+	if v == nil || v.Key != "pair:a->b" {
+		t.Fatalf("second send to b = %v, want pair violation", v)
+	}
+	// The rejected send was not recorded against the sender
+	st, err := l.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(st.Sends["sender:a"]); n != 1 {
+		t.Errorf("sender history = %d, want 1", n)
+	}
+	if v, _ := l.Allow(now, sender, pairC); v != nil {
+		t.Errorf("send to c: %v", v)
+	}
+}
+
+func TestAllow_SharedKeyWindows(t *testing.T) {
+	l := New(t.TempDir(), "mail")
+	velocity := Rule{Key: "thread:t1", Max: 2, Window: time.Minute, Reason: "velocity"}
+	depth := Rule{Key: "thread:t1", Max: 3, Window: time.Hour, Reason: "depth"}
+	start := time.Now()
+
+	for i, at := range []time.Duration{0, 2 * time.Minute, 4 * time.Minute} {
+		if v, _ := l.Allow(start.Add(at), velocity, depth); v != nil {
+			t.Fatalf("reply %d: %v", i, v)
+		}
+	}
+	v, _ := l.Allow(start.Add(6*time.Minute), velocity, depth)
+	if v == nil || v.Reason != "depth" {
+		t.Errorf("slow loop = %v, want depth violation", v)
+	}
+}
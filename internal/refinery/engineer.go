@@ -115,8 +115,13 @@ type MRInfo struct {
 	RetryCount      int        // Conflict retry count
 	ConvoyID        string     // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt *time.Time // Convoy creation time
+	ConvoyDueAt     *time.Time // Convoy deadline, if any
 	CreatedAt       time.Time  // MR creation time
 	BlockedBy       string     // Task ID blocking this MR
+	HeldBy          string     // Why the convoy's landing policy holds this MR
+
+	// Convoy deadline standing from the last gt convoy overdue run, if known
+	ConvoyDeadline convoy.DeadlineStatus
 
 	// Raw data for agent-side queue health analysis (ZFC: agent decides, Go transports)
 	UpdatedAt          time.Time // When the MR was last updated
@@ -167,6 +172,7 @@ type Engineer struct {
 	mergeSlotRelease      func(holder string) error
 	mergeSlotMaxRetries   int           // Max retries for slot acquisition (0 = no retry)
 	mergeSlotRetryBackoff time.Duration // Initial backoff between retries
+	landingState          func(convoyID string) *convoy.LandingState
 }
 
 // NewEngineer creates a new Engineer for the given rig.
@@ -201,6 +207,9 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		},
 		mergeSlotMaxRetries:   10,
 		mergeSlotRetryBackoff: 500 * time.Millisecond,
+		landingState: func(convoyID string) *convoy.LandingState {
+			return convoy.LoadLandingState(filepath.Dir(r.Path), convoyID)
+		},
 	}
 }
 
@@ -713,7 +722,7 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 			logger := func(format string, args ...interface{}) {
 				_, _ = fmt.Fprintf(e.output, "[Engineer] "+format+"\n", args...)
 			}
-			convoy.CheckConvoysForIssue(e.rig.Path, mr.SourceIssue, "refinery", logger)
+			convoy.CheckConvoysForIssue(filepath.Dir(e.rig.Path), mr.SourceIssue, "refinery", logger)
 		}
 	}
 
@@ -942,6 +951,12 @@ func issueToMRInfo(issue *beads.Issue, fields *beads.MRFields) *MRInfo {
 			convoyCreatedAt = &t
 		}
 	}
+	var convoyDueAt *time.Time
+	if fields.ConvoyDueAt != "" {
+		if t, err := time.Parse(time.RFC3339, fields.ConvoyDueAt); err == nil {
+			convoyDueAt = &t
+		}
+	}
 
 	// Parse issue timestamps
 	var createdAt, updatedAt time.Time
@@ -969,6 +984,7 @@ func issueToMRInfo(issue *beads.Issue, fields *beads.MRFields) *MRInfo {
 		RetryCount:      fields.RetryCount,
 		ConvoyID:        fields.ConvoyID,
 		ConvoyCreatedAt: convoyCreatedAt,
+		ConvoyDueAt:     convoyDueAt,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
 		Assignee:        issue.Assignee,
@@ -1002,6 +1018,8 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 
 	// Convert beads issues to MRInfo
 	var mrs []*MRInfo
+	landing := make(map[string]*convoy.LandingState)
+	deadlines := convoy.LoadDeadlineStatuses(filepath.Dir(e.rig.Path))
 	for _, issue := range issues {
 		// Skip closed MRs (workaround for bd list not respecting --status filter)
 		if issue.Status != "open" {
@@ -1037,14 +1055,39 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 				issue.ID, issue.Assignee, issue.UpdatedAt)
 		}
 
-		mrs = append(mrs, issueToMRInfo(issue, fields))
+		mr := issueToMRInfo(issue, fields)
+		mr.ConvoyDeadline = deadlines[mr.ConvoyID]
+		if reason := e.landingHold(mr, landing); reason != "" {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Holding MR %s: %s\n", issue.ID, reason)
+			continue
+		}
+		mrs = append(mrs, mr)
 	}
 
 	return mrs, nil
 }
 
-// ListBlockedMRs returns MRs that are blocked by open tasks.
-// Useful for monitoring/reporting.
+// landingHold returns why the landing policy of mr's convoy holds it, or ""
+// when it may be merged. states caches each convoy's landing state for the
+// duration of one queue scan.
+func (e *Engineer) landingHold(mr *MRInfo, states map[string]*convoy.LandingState) string {
+	if mr.ConvoyID == "" || e.landingState == nil {
+		return ""
+	}
+	state, ok := states[mr.ConvoyID]
+	if !ok {
+		state = e.landingState(mr.ConvoyID)
+		states[mr.ConvoyID] = state
+	}
+	rigName := mr.Rig
+	if rigName == "" && e.rig != nil {
+		rigName = e.rig.Name
+	}
+	return state.Hold(rigName, mr.Target)
+}
+
+// ListBlockedMRs returns MRs that are blocked by open tasks or held by
+// their convoy's landing policy. Useful for monitoring/reporting.
 //
 // This queries beads for blocked merge-request issues.
 func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
@@ -1058,27 +1101,28 @@ func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
 		return nil, fmt.Errorf("querying beads for merge-requests: %w", err)
 	}
 
-	// Filter for blocked issues (those with open blockers)
+	// Filter for blocked issues (those with open blockers) and MRs held by
+	// their convoy's landing policy
 	var mrs []*MRInfo
+	landing := make(map[string]*convoy.LandingState)
 	for _, issue := range issues {
-		// Skip if not blocked
-		if len(issue.BlockedBy) == 0 {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil {
 			continue
 		}
 
 		// Check if any blocker is still open
-		blockedBy := e.firstOpenBlocker(issue)
-		if blockedBy == "" {
-			continue // All blockers are closed, not blocked
-		}
-
-		fields := beads.ParseMRFields(issue)
-		if fields == nil {
-			continue
+		var blockedBy string
+		if len(issue.BlockedBy) > 0 {
+			blockedBy = e.firstOpenBlocker(issue)
 		}
 
 		mr := issueToMRInfo(issue, fields)
 		mr.BlockedBy = blockedBy
+		mr.HeldBy = e.landingHold(mr, landing)
+		if mr.BlockedBy == "" && mr.HeldBy == "" {
+			continue // Not blocked or held
+		}
 		mrs = append(mrs, mr)
 	}
 
@@ -1101,6 +1145,7 @@ func (e *Engineer) ListAllOpenMRs() ([]*MRInfo, error) {
 	}
 
 	var mrs []*MRInfo
+	landing := make(map[string]*convoy.LandingState)
 	for _, issue := range issues {
 		if issue.Status != "open" {
 			continue
@@ -1117,6 +1162,7 @@ func (e *Engineer) ListAllOpenMRs() ([]*MRInfo, error) {
 		mr.BranchExistsLocal, _ = e.git.BranchExists(fields.Branch)
 		mr.BranchExistsRemote, _ = e.git.RemoteTrackingBranchExists("origin", fields.Branch)
 		mr.BlockedBy = e.firstOpenBlocker(issue)
+		mr.HeldBy = e.landingHold(mr, landing)
 
 		mrs = append(mrs, mr)
 	}
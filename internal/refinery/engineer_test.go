@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
 )
 
@@ -329,3 +330,38 @@ func TestIsClaimStale(t *testing.T) {
 		})
 	}
 }
+
+func TestLandingHold(t *testing.T) {
+	loads := 0
+	e := &Engineer{
+		rig: &rig.Rig{Name: "client"},
+		landingState: func(convoyID string) *convoy.LandingState {
+			loads++
+			return &convoy.LandingState{
+				ConvoyID: convoyID,
+				Policy:   convoy.LandingOrdered,
+				Order:    []string{"api", "client"},
+				Pending:  map[string]int{"api": 1},
+			}
+		},
+	}
+	states := make(map[string]*convoy.LandingState)
+
+	if reason := e.landingHold(&MRInfo{ID: "gt-mr1", Target: "main"}, states); reason != "" {
+		t.Errorf("MR outside a convoy held: %s", reason)
+	}
+	if reason := e.landingHold(&MRInfo{ID: "gt-mr2", ConvoyID: "hq-cv-abc", Target: "main"}, states); reason == "" {
+		t.Error("client MR not held while api has open issues")
+	}
+	if reason := e.landingHold(&MRInfo{ID: "gt-mr3", ConvoyID: "hq-cv-abc", Rig: "api", Target: "main"}, states); reason != "" {
+		t.Errorf("api MR held: %s", reason)
+	}
+	if loads != 1 {
+		t.Errorf("landing state loaded %d times, want 1 per scan", loads)
+	}
+
+	e.landingState = nil
+	if reason := e.landingHold(&MRInfo{ID: "gt-mr4", ConvoyID: "hq-cv-abc", Target: "main"}, states); reason != "" {
+		t.Errorf("engineer without landing state held MR: %s", reason)
+	}
+}
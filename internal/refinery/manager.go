@@ -14,6 +14,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/config"
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
 	"github.com/xcawolfe-amzn/gastown/internal/runtime"
@@ -237,12 +238,13 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 		score float64
 	}
 	scored := make([]scoredIssue, 0, len(issues))
+	deadlines := convoy.LoadDeadlineStatuses(filepath.Dir(m.rig.Path))
 	for _, issue := range issues {
 		// Defensive filter: bd status filters can drift; queue must only include open MRs.
 		if issue == nil || issue.Status != "open" {
 			continue
 		}
-		score := m.calculateIssueScore(issue, now)
+		score := m.calculateIssueScore(issue, deadlines, now)
 		scored = append(scored, scoredIssue{issue: issue, score: score})
 	}
 
@@ -270,7 +272,7 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 
 // calculateIssueScore computes the priority score for an MR issue.
 // Higher scores mean higher priority (process first).
-func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64 {
+func (m *Manager) calculateIssueScore(issue *beads.Issue, deadlines map[string]convoy.DeadlineStatus, now time.Time) float64 {
 	fields := beads.ParseMRFields(issue)
 
 	// Parse MR creation time
@@ -296,6 +298,12 @@ func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64
 				input.ConvoyCreatedAt = &convoyTime
 			}
 		}
+		if fields.ConvoyDueAt != "" {
+			if dueTime := parseTime(fields.ConvoyDueAt); !dueTime.IsZero() {
+				input.ConvoyDueAt = &dueTime
+			}
+		}
+		input.ConvoyDeadline = deadlines[fields.ConvoyID]
 	}
 
 	return ScoreMRWithDefaults(input)
@@ -5,6 +5,8 @@ package refinery
 
 import (
 	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 )
 
 // ScoreConfig contains tunable weights for MR priority scoring.
@@ -37,6 +39,15 @@ type ScoreConfig struct {
 	// MaxRetryPenalty caps the total retry penalty to prevent permanent deprioritization.
 	// Default: 300.0 (after 6 retries, penalty is capped)
 	MaxRetryPenalty float64
+
+	// OverdueWeight is points added for MRs from convoys past their deadline.
+	// Default: 500.0 (an overdue convoy outranks a P0 priority bonus)
+	OverdueWeight float64
+
+	// AtRiskWeight is points added for MRs from convoys projected to land
+	// after their deadline.
+	// Default: 250.0
+	AtRiskWeight float64
 }
 
 // DefaultScoreConfig returns sensible defaults for MR scoring.
@@ -48,6 +59,8 @@ func DefaultScoreConfig() ScoreConfig {
 		RetryPenalty:    50.0,
 		MRAgeWeight:     1.0,
 		MaxRetryPenalty: 300.0,
+		OverdueWeight:   500.0,
+		AtRiskWeight:    250.0,
 	}
 }
 
@@ -65,6 +78,15 @@ type ScoreInput struct {
 	// Nil if MR is not part of a convoy (standalone work).
 	ConvoyCreatedAt *time.Time
 
+	// ConvoyDueAt is the convoy's deadline (from --due/--sla).
+	// Nil if the convoy has no deadline.
+	ConvoyDueAt *time.Time
+
+	// ConvoyDeadline is the convoy's projected deadline standing from the
+	// last gt convoy overdue run. Empty if unknown; a passed ConvoyDueAt
+	// still counts as overdue.
+	ConvoyDeadline convoy.DeadlineStatus
+
 	// RetryCount is how many times this MR has been retried after conflicts.
 	// 0 = first attempt.
 	RetryCount int
@@ -84,6 +106,7 @@ type ScoreInput struct {
 //	      + PriorityWeight * (4 - priority)          // P0=+400, P4=+0
 //	      - min(RetryPenalty * retryCount, MaxRetryPenalty)  // Prevent thrashing
 //	      + MRAgeWeight * hoursOld(MR)               // FIFO tiebreaker
+//	      + OverdueWeight or AtRiskWeight            // Convoy deadline standing
 func ScoreMR(input ScoreInput, config ScoreConfig) float64 {
 	now := input.Now
 	if now.IsZero() {
@@ -125,6 +148,18 @@ func ScoreMR(input ScoreInput, config ScoreConfig) float64 {
 		score += config.MRAgeWeight * mrHours
 	}
 
+	// Deadline factor: convoys projected to miss their deadline go first
+	status := input.ConvoyDeadline
+	if input.ConvoyDueAt != nil && !now.Before(*input.ConvoyDueAt) {
+		status = convoy.DeadlineOverdue
+	}
+	switch status {
+	case convoy.DeadlineOverdue:
+		score += config.OverdueWeight
+	case convoy.DeadlineAtRisk:
+		score += config.AtRiskWeight
+	}
+
 	return score
 }
 
@@ -145,6 +180,8 @@ func (mr *MRInfo) ScoreAt(now time.Time) float64 {
 		Priority:        mr.Priority,
 		MRCreatedAt:     mr.CreatedAt,
 		ConvoyCreatedAt: mr.ConvoyCreatedAt,
+		ConvoyDueAt:     mr.ConvoyDueAt,
+		ConvoyDeadline:  mr.ConvoyDeadline,
 		RetryCount:      mr.RetryCount,
 		Now:             now,
 	}
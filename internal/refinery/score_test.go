@@ -0,0 +1,36 @@
+package refinery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+func TestScoreMR_DeadlineBoost(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	config := DefaultScoreConfig()
+	base := ScoreMR(ScoreInput{Priority: 2, MRCreatedAt: now, Now: now}, config)
+
+	tests := []struct {
+		name   string
+		due    time.Duration // from now
+		status convoy.DeadlineStatus
+		boost  float64
+	}{
+		{"on track", 2 * time.Hour, convoy.DeadlineOnTrack, 0},
+		{"close but unknown", 2 * time.Hour, "", 0},
+		{"at risk", 48 * time.Hour, convoy.DeadlineAtRisk, 250},
+		{"overdue", -6 * time.Hour, convoy.DeadlineOverdue, 500},
+		{"passed since snapshot", -time.Minute, convoy.DeadlineAtRisk, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due := now.Add(tt.due)
+			got := ScoreMR(ScoreInput{Priority: 2, MRCreatedAt: now, ConvoyDueAt: &due, ConvoyDeadline: tt.status, Now: now}, config)
+			if got-base != tt.boost {
+				t.Errorf("boost = %v, want %v", got-base, tt.boost)
+			}
+		})
+	}
+}
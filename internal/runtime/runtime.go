@@ -12,7 +12,6 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/kiro"
 	"github.com/xcawolfe-amzn/gastown/internal/opencode"
 	"github.com/xcawolfe-amzn/gastown/internal/templates/commands"
-	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 )
 
 // EnsureSettingsForRole provisions all agent-specific configuration for a role.
@@ -106,8 +105,14 @@ func StartupFallbackCommands(role string, rc *config.RuntimeConfig) []string {
 	return []string{command}
 }
 
-// RunStartupFallback sends the startup fallback commands via tmux.
-func RunStartupFallback(t *tmux.Tmux, sessionID, role string, rc *config.RuntimeConfig) error {
+// Nudger delivers a message to an agent session. Both tmux and the headless
+// session backend implement it.
+type Nudger interface {
+	NudgeSession(session, message string) error
+}
+
+// RunStartupFallback sends the startup fallback commands to the session.
+func RunStartupFallback(t Nudger, sessionID, role string, rc *config.RuntimeConfig) error {
 	commands := StartupFallbackCommands(role, rc)
 	for _, cmd := range commands {
 		if err := t.NudgeSession(sessionID, cmd); err != nil {
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
+)
+
+// Session backend names, as used in town settings ("session_backend").
+const (
+	BackendTmux     = "tmux"
+	BackendHeadless = "headless"
+)
+
+// SessionBackend is the set of operations Gas Town needs from whatever hosts
+// agent sessions. tmux is the default implementation; the headless backend
+// runs agents as PTY-attached processes, each served by a detached
+// 'gt session host' process, for machines without tmux.
+//
+// Backend-specific extras (themes, key bindings, pane-died hooks) are not
+// part of the interface. Callers that need them type-assert to *tmux.Tmux.
+type SessionBackend interface {
+	// NewSessionWithCommand creates a session running command in workDir.
+	NewSessionWithCommand(name, workDir, command string) error
+	// HasSession reports whether the named session exists.
+	HasSession(name string) (bool, error)
+	// ListSessions returns the names of all sessions.
+	ListSessions() ([]string, error)
+	// KillSessionWithProcesses kills the session and all its descendants.
+	KillSessionWithProcesses(name string) error
+
+	// SendKeysRaw sends keys without appending Enter (e.g., "C-c").
+	SendKeysRaw(session, keys string) error
+	// NudgeSession delivers a message to the agent followed by Enter.
+	NudgeSession(session, message string) error
+	// CapturePane returns the last lines of session output.
+	CapturePane(session string, lines int) (string, error)
+
+	// SetEnvironment sets a session-level environment variable.
+	SetEnvironment(session, key, value string) error
+	// GetEnvironment reads a session-level environment variable.
+	GetEnvironment(session, key string) (string, error)
+
+	// IsAgentAlive reports whether the agent process is still running.
+	IsAgentAlive(session string) bool
+	// GetSessionActivity returns the time of last session activity.
+	GetSessionActivity(session string) (time.Time, error)
+}
+
+var (
+	_ SessionBackend = (*tmux.Tmux)(nil)
+	_ SessionBackend = (*headless.Backend)(nil)
+	_ SessionBackend = (*headless.Client)(nil)
+)
+
+// HeadlessDir returns the directory holding headless sessions' scrollback
+// mirrors, status and input files.
+func HeadlessDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "headless")
+}
+
+// NewBackend returns the session backend with the given name.
+// An empty name selects tmux. The headless backend reaches sessions through
+// their files under HeadlessDir(townRoot), so any gt process can operate
+// them, and starts each new one as a detached 'gt session host'.
+func NewBackend(name, townRoot string) (SessionBackend, error) {
+	switch name {
+	case "", BackendTmux:
+		return tmux.NewTmux(), nil
+	case BackendHeadless:
+		if townRoot == "" {
+			return nil, fmt.Errorf("headless sessions need a town root")
+		}
+		gt, err := os.Executable()
+		if err != nil {
+			gt = "gt"
+		}
+		return headless.NewClient(HeadlessDir(townRoot), gt, "session", "host"), nil
+	default:
+		return nil, fmt.Errorf("unknown session backend %q (want %q or %q)", name, BackendTmux, BackendHeadless)
+	}
+}
+
+// BackendForTown returns the session backend configured in town settings
+// ("session_backend"). It returns t when tmux is configured or the setting
+// is unusable.
+func BackendForTown(townRoot string, t *tmux.Tmux) SessionBackend {
+	if townRoot == "" {
+		return t
+	}
+	ts, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || ts.SessionBackend == "" || ts.SessionBackend == BackendTmux {
+		return t
+	}
+	b, err := NewBackend(ts.SessionBackend, townRoot)
+	if err != nil {
+		return t
+	}
+	return b
+}
+
+// CaptureHeadless reads recent output of a headless session from its
+// scrollback mirror, without needing town settings to select the backend.
+func CaptureHeadless(townRoot, sessionID string, lines int) (string, error) {
+	return headless.CaptureFile(HeadlessDir(townRoot), sessionID, lines)
+}
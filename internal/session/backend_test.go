@@ -0,0 +1,58 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
+	"github.com/xcawolfe-amzn/gastown/internal/tmux"
+)
+
+func TestNewBackend(t *testing.T) {
+	townRoot := t.TempDir()
+
+	for _, name := range []string{"", BackendTmux} {
+		b, err := NewBackend(name, townRoot)
+		if err != nil {
+			t.Fatalf("NewBackend(%q): %v", name, err)
+		}
+		if _, ok := b.(*tmux.Tmux); !ok {
+			t.Errorf("NewBackend(%q) = %T, want *tmux.Tmux", name, b)
+		}
+	}
+
+	b, err := NewBackend(BackendHeadless, townRoot)
+	if err != nil {
+		t.Fatalf("NewBackend(headless): %v", err)
+	}
+	if _, ok := b.(*headless.Client); !ok {
+		t.Errorf("NewBackend(headless) = %T, want *headless.Client", b)
+	}
+
+	if _, err := NewBackend("screen", townRoot); err == nil {
+		t.Error("NewBackend(screen) should fail")
+	}
+}
+
+func TestBackendForTown(t *testing.T) {
+	tm := tmux.NewTmux()
+	townRoot := t.TempDir()
+	if got := BackendForTown(townRoot, tm); got != tm {
+		t.Errorf("BackendForTown(no settings) = %T, want the tmux backend", got)
+	}
+
+	settings := config.NewTownSettings()
+	settings.SessionBackend = BackendHeadless
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+	if got, ok := BackendForTown(townRoot, tm).(*headless.Client); !ok {
+		t.Errorf("BackendForTown(headless) = %T, want *headless.Client", got)
+	}
+}
+
+func TestCaptureHeadlessMissing(t *testing.T) {
+	if _, err := CaptureHeadless(t.TempDir(), "gt-nope", 10); err == nil {
+		t.Error("CaptureHeadless for unknown session should fail")
+	}
+}
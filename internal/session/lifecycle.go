@@ -11,7 +11,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/tmux"
 )
 
-// SessionConfig describes how to create and start a tmux session.
+// SessionConfig describes how to create and start an agent session.
 // This unifies the common startup pattern that was previously duplicated
 // across polecat, mayor, boot, deacon, witness, refinery, crew, and dog
 // session managers. Each of those managers previously had to coordinate
@@ -75,6 +75,7 @@ type SessionConfig struct {
 	ExtraEnv map[string]string
 
 	// Theme is the tmux theme to apply. Nil means no theme is applied.
+	// Ignored by non-tmux backends.
 	Theme *tmux.Theme
 
 	// Post-start behavior options.
@@ -93,6 +94,8 @@ type SessionConfig struct {
 	ReadyDelay bool
 
 	// AutoRespawn sets the auto-respawn hook so the session survives crashes.
+	// Only supported by the tmux backend; the daemon restarts dead headless
+	// sessions on its heartbeat instead.
 	AutoRespawn bool
 
 	// RemainOnExit sets remain-on-exit immediately after session creation.
@@ -113,7 +116,9 @@ type StartResult struct {
 	RuntimeConfig *config.RuntimeConfig
 }
 
-// StartSession creates a tmux session following the standard Gas Town lifecycle.
+// StartSession creates a session following the standard Gas Town lifecycle.
+// The backend is usually a *tmux.Tmux; tmux-only steps (theme, remain-on-exit,
+// auto-respawn, bypass dialog, PID tracking) are skipped for other backends.
 //
 // The lifecycle handles:
 //  1. Resolve runtime config for the role
@@ -128,7 +133,7 @@ type StartResult struct {
 // Role-specific concerns (issue validation, fallback nudges, pane-died hooks,
 // crew cycle bindings, etc.) should be handled by the caller before/after
 // calling StartSession.
-func StartSession(t *tmux.Tmux, cfg SessionConfig) (*StartResult, error) {
+func StartSession(t SessionBackend, cfg SessionConfig) (*StartResult, error) {
 	if cfg.SessionID == "" {
 		return nil, fmt.Errorf("SessionID is required")
 	}
@@ -174,14 +179,15 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (*StartResult, error) {
 		command = config.PrependEnv(command, cfg.ExtraEnv)
 	}
 
-	// 4. Create tmux session with command.
+	// 4. Create session with command.
 	if err := t.NewSessionWithCommand(cfg.SessionID, cfg.WorkDir, command); err != nil {
 		return nil, fmt.Errorf("creating session: %w", err)
 	}
+	tm, isTmux := t.(*tmux.Tmux)
 
 	// 5. Set remain-on-exit immediately if requested (before anything else can fail).
-	if cfg.RemainOnExit {
-		_ = t.SetRemainOnExit(cfg.SessionID, true)
+	if cfg.RemainOnExit && isTmux {
+		_ = tm.SetRemainOnExit(cfg.SessionID, true)
 	}
 
 	// 6. Set environment variables.
@@ -201,13 +207,13 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (*StartResult, error) {
 	}
 
 	// 7. Apply theme.
-	if cfg.Theme != nil {
-		_ = t.ConfigureGasTownSession(cfg.SessionID, *cfg.Theme, cfg.RigName, cfg.AgentName, cfg.Role)
+	if cfg.Theme != nil && isTmux {
+		_ = tm.ConfigureGasTownSession(cfg.SessionID, *cfg.Theme, cfg.RigName, cfg.AgentName, cfg.Role)
 	}
 
 	// 8. Wait for agent to start.
 	if cfg.WaitForAgent {
-		if err := t.WaitForCommand(cfg.SessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
+		if err := waitForAgent(t, cfg.SessionID, constants.ClaudeStartTimeout); err != nil {
 			if cfg.WaitFatal {
 				_ = t.KillSessionWithProcesses(cfg.SessionID)
 				return nil, fmt.Errorf("waiting for %s to start: %w", cfg.Role, err)
@@ -216,15 +222,15 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (*StartResult, error) {
 	}
 
 	// 9. Auto-respawn hook.
-	if cfg.AutoRespawn {
-		if err := t.SetAutoRespawnHook(cfg.SessionID); err != nil {
+	if cfg.AutoRespawn && isTmux {
+		if err := tm.SetAutoRespawnHook(cfg.SessionID); err != nil {
 			fmt.Printf("warning: failed to set auto-respawn hook for %s: %v\n", cfg.Role, err)
 		}
 	}
 
 	// 10. Accept bypass permissions warning.
-	if cfg.AcceptBypass {
-		_ = t.AcceptBypassPermissionsWarning(cfg.SessionID)
+	if cfg.AcceptBypass && isTmux {
+		_ = tm.AcceptBypassPermissionsWarning(cfg.SessionID)
 	}
 
 	// 11. Ready delay.
@@ -246,18 +252,18 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (*StartResult, error) {
 	}
 
 	// 13. Track PID for defense-in-depth orphan cleanup.
-	if cfg.TrackPID && cfg.TownRoot != "" {
-		_ = TrackSessionPID(cfg.TownRoot, cfg.SessionID, t)
+	if cfg.TrackPID && cfg.TownRoot != "" && isTmux {
+		_ = TrackSessionPID(cfg.TownRoot, cfg.SessionID, tm)
 	}
 
 	return &StartResult{RuntimeConfig: runtimeConfig}, nil
 }
 
-// StopSession stops a tmux session with optional graceful shutdown.
+// StopSession stops a session with optional graceful shutdown.
 //
 // If graceful is true, sends Ctrl-C first and waits for the session to exit
 // before force-killing. This allows the agent to clean up.
-func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
+func StopSession(t SessionBackend, sessionID string, graceful bool) error {
 	running, err := t.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
@@ -284,7 +290,7 @@ func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
 // If checkAlive is true, only kills zombie sessions (tmux alive but agent dead).
 // If the session exists and the agent is alive, returns ErrAlreadyRunning.
 // If checkAlive is false, kills any existing session unconditionally.
-func KillExistingSession(t *tmux.Tmux, sessionID string, checkAlive bool) (bool, error) {
+func KillExistingSession(t SessionBackend, sessionID string, checkAlive bool) (bool, error) {
 	running, err := t.HasSession(sessionID)
 	if err != nil {
 		return false, fmt.Errorf("checking session: %w", err)
@@ -304,6 +310,19 @@ func KillExistingSession(t *tmux.Tmux, sessionID string, checkAlive bool) (bool,
 	return true, nil
 }
 
+// waitForAgent waits for the agent to replace the shell in the session.
+// tmux inspects the pane's foreground command; other backends own the agent
+// process directly, so it is enough that the process is still alive.
+func waitForAgent(t SessionBackend, sessionID string, timeout time.Duration) error {
+	if tm, ok := t.(*tmux.Tmux); ok {
+		return tm.WaitForCommand(sessionID, constants.SupportedShells, timeout)
+	}
+	if !t.IsAgentAlive(sessionID) {
+		return fmt.Errorf("agent process in %s exited", sessionID)
+	}
+	return nil
+}
+
 // buildPrompt creates the startup prompt from beacon + instructions.
 func buildPrompt(cfg SessionConfig) string {
 	if cfg.Instructions != "" {
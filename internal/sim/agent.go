@@ -0,0 +1,223 @@
+package sim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Identity is who a sim agent is and where it works.
+type Identity struct {
+	Rig     string
+	Polecat string
+	Issue   string // Hooked issue, if any
+	WorkDir string // Worktree the actions operate on
+}
+
+// Event is one line of the simulation log.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Rig     string    `json:"rig,omitempty"`
+	Polecat string    `json:"polecat,omitempty"`
+	Issue   string    `json:"issue,omitempty"`
+	Action  string    `json:"action"`
+	Detail  string    `json:"detail,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// ExitError is returned by Run when the script crashes the agent.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("simulated crash (exit %d)", e.Code)
+}
+
+// ErrHang is returned by Run when the script hangs the agent. The caller
+// blocks until the session is killed.
+var ErrHang = errors.New("simulated hang")
+
+// Runner performs scripted actions for one agent.
+type Runner struct {
+	Identity Identity
+
+	// LogPath receives an Event per action; "" disables logging.
+	LogPath string
+
+	// Exec runs a command in dir. Defaults to running it with output on
+	// the agent's terminal.
+	Exec func(dir, name string, args ...string) error
+
+	// Sleep pauses. Defaults to time.Sleep.
+	Sleep func(time.Duration)
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Run performs actions in order. It stops at the first failing action,
+// returns *ExitError for a crash and ErrHang for a hang.
+func (r *Runner) Run(actions []Action) error {
+	for _, a := range actions {
+		kind := a.Kind()
+		detail, err := r.perform(kind, a)
+		r.log(kind, detail, err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Runner) perform(kind string, a Action) (string, error) {
+	id := r.Identity
+	switch kind {
+	case ActionCommit:
+		msg := r.expand(a.Commit)
+		file := r.expand(a.File)
+		if file == "" {
+			name := id.Issue
+			if name == "" {
+				name = id.Polecat
+			}
+			file = filepath.Join(".sim", name+".txt")
+		}
+		abs := filepath.Join(id.WorkDir, file)
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			return file, err
+		}
+		f, err := os.OpenFile(abs, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: worktree file
+		if err != nil {
+			return file, err
+		}
+		_, err = fmt.Fprintln(f, msg)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return file, err
+		}
+		if err := r.exec("git", "add", "--", file); err != nil {
+			return file, err
+		}
+		return fmt.Sprintf("%s: %s", file, msg), r.exec("git", "commit", "-m", msg)
+	case ActionDone:
+		return a.Done, r.exec("gt", "done", "--status", a.Done)
+	case ActionHelp:
+		topic := r.expand(a.Help)
+		body := fmt.Sprintf("Agent: %s/polecats/%s\nIssue: %s\nProblem: %s", id.Rig, id.Polecat, id.Issue, topic)
+		return topic, r.exec("gt", "mail", "send", id.Rig+"/witness", "-s", "HELP: "+topic, "-m", body)
+	case ActionCrash:
+		return fmt.Sprintf("exit %d", a.Crash), &ExitError{Code: a.Crash}
+	case ActionHang:
+		return "", ErrHang
+	case ActionSleep:
+		d, _ := time.ParseDuration(a.Sleep)
+		r.sleep(d)
+		return a.Sleep, nil
+	case ActionRun:
+		cmd := r.expand(a.Run)
+		return cmd, r.exec("sh", "-c", cmd)
+	}
+	return "", fmt.Errorf("invalid action")
+}
+
+// expand substitutes {{issue}}, {{polecat}} and {{rig}}.
+func (r *Runner) expand(s string) string {
+	return strings.NewReplacer(
+		"{{issue}}", r.Identity.Issue,
+		"{{polecat}}", r.Identity.Polecat,
+		"{{rig}}", r.Identity.Rig,
+	).Replace(s)
+}
+
+func (r *Runner) exec(name string, args ...string) error {
+	if r.Exec != nil {
+		return r.Exec(r.Identity.WorkDir, name, args...)
+	}
+	cmd := exec.Command(name, args...) //nolint:gosec // G204: scripted commands
+	cmd.Dir = r.Identity.WorkDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+func (r *Runner) sleep(d time.Duration) {
+	if r.Sleep != nil {
+		r.Sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// Log appends an event to the simulation log. Logging is best-effort.
+func (r *Runner) Log(action, detail string) {
+	r.log(action, detail, nil)
+}
+
+func (r *Runner) log(action, detail string, err error) {
+	if r.LogPath == "" {
+		return
+	}
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	e := Event{
+		Time:    now().UTC(),
+		Rig:     r.Identity.Rig,
+		Polecat: r.Identity.Polecat,
+		Issue:   r.Identity.Issue,
+		Action:  action,
+		Detail:  detail,
+	}
+	if err != nil && !errors.Is(err, ErrHang) {
+		var exit *ExitError
+		if !errors.As(err, &exit) {
+			e.Error = err.Error()
+		}
+	}
+	data, merr := json.Marshal(e)
+	if merr != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(r.LogPath), 0755)
+	f, ferr := os.OpenFile(r.LogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: log is not secret
+	if ferr != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.Write(append(data, '\n'))
+}
+
+// ReadLog returns the events in a simulation log.
+func ReadLog(path string) ([]Event, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the town sim log
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var events []Event
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue // Skip torn writes
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
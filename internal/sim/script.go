@@ -0,0 +1,287 @@
+// Package sim provides a scripted stand-in for an LLM agent.
+//
+// A simulation script lists the actions a fake polecat performs on its
+// real worktree: commit, gt done, ask the witness for help, crash, hang.
+// Polecats spawned with the "sim" agent (see RegisterAgent) run
+// `gt sim agent`, which looks up the actions for its polecat name or hooked
+// issue and performs them. This lets witness, refinery and convoy flows be exercised end to
+// end without an LLM.
+//
+// Scripts are TOML. The first [[agent]] whose match fits the polecat name
+// or issue ID (a glob, "*" by default) supplies the actions:
+//
+//	[[agent]]
+//	match = "nux"
+//	actions = [{ help = "tests fail on CI" }, { hang = true }]
+//
+//	[[agent]]
+//	actions = [
+//	  { commit = "Implement {{issue}}" },
+//	  { sleep = "5s" },
+//	  { done = "COMPLETED" },
+//	]
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+// Action kinds.
+const (
+	ActionCommit = "commit" // Write a file and git commit it
+	ActionDone   = "done"   // Run gt done
+	ActionHelp   = "help"   // Mail a HELP request to the rig's witness
+	ActionCrash  = "crash"  // Exit with a non-zero code
+	ActionHang   = "hang"   // Block until killed
+	ActionSleep  = "sleep"  // Pause
+	ActionRun    = "run"    // Run a shell command in the worktree
+)
+
+// Done exit statuses accepted by gt done.
+var doneStatuses = map[string]bool{"COMPLETED": true, "ESCALATED": true, "DEFERRED": true}
+
+// Script is a parsed simulation script.
+type Script struct {
+	Agents []Agent `toml:"agent"`
+}
+
+// Agent is the behavior of the polecats an entry matches.
+type Agent struct {
+	Match   string   `toml:"match"` // Glob against polecat name or issue ID; "" matches all
+	Actions []Action `toml:"actions"`
+}
+
+// Action is one scripted step. Exactly one field besides File is set.
+// Text fields may use {{issue}}, {{polecat}} and {{rig}}.
+type Action struct {
+	Commit string `toml:"commit"` // Commit message
+	File   string `toml:"file"`   // File a commit writes (default .sim/<issue>.txt)
+	Done   string `toml:"done"`   // Exit status: COMPLETED, ESCALATED or DEFERRED
+	Help   string `toml:"help"`   // HELP topic
+	Crash  int    `toml:"crash"`  // Exit code
+	Hang   bool   `toml:"hang"`
+	Sleep  string `toml:"sleep"` // Duration
+	Run    string `toml:"run"`   // Shell command
+}
+
+// Kind returns the action kind, or "" if no action field is set.
+func (a Action) Kind() string {
+	kinds := a.kinds()
+	if len(kinds) != 1 {
+		return ""
+	}
+	return kinds[0]
+}
+
+func (a Action) kinds() []string {
+	var kinds []string
+	if a.Commit != "" {
+		kinds = append(kinds, ActionCommit)
+	}
+	if a.Done != "" {
+		kinds = append(kinds, ActionDone)
+	}
+	if a.Help != "" {
+		kinds = append(kinds, ActionHelp)
+	}
+	if a.Crash != 0 {
+		kinds = append(kinds, ActionCrash)
+	}
+	if a.Hang {
+		kinds = append(kinds, ActionHang)
+	}
+	if a.Sleep != "" {
+		kinds = append(kinds, ActionSleep)
+	}
+	if a.Run != "" {
+		kinds = append(kinds, ActionRun)
+	}
+	return kinds
+}
+
+// LoadScript reads and validates a simulation script.
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is user-supplied script
+	if err != nil {
+		return nil, fmt.Errorf("reading sim script: %w", err)
+	}
+	return ParseScript(data)
+}
+
+// ParseScript parses and validates a simulation script.
+func ParseScript(data []byte) (*Script, error) {
+	var s Script
+	md, err := toml.Decode(string(data), &s)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sim script: %w", err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("sim script: unknown field %q", undecoded[0].String())
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks that every action sets exactly one kind with a valid value.
+func (s *Script) Validate() error {
+	if len(s.Agents) == 0 {
+		return fmt.Errorf("sim script declares no [[agent]] entries")
+	}
+	for i, ag := range s.Agents {
+		name := ag.Match
+		if name == "" {
+			name = fmt.Sprintf("agent %d", i+1)
+		}
+		if _, err := path.Match(ag.Match, ""); err != nil {
+			return fmt.Errorf("%s: invalid match pattern: %w", name, err)
+		}
+		if len(ag.Actions) == 0 {
+			return fmt.Errorf("%s: no actions", name)
+		}
+		for j, a := range ag.Actions {
+			kinds := a.kinds()
+			switch {
+			case len(kinds) == 0:
+				return fmt.Errorf("%s: action %d sets no action", name, j+1)
+			case len(kinds) > 1:
+				return fmt.Errorf("%s: action %d sets several actions: %s", name, j+1, strings.Join(kinds, ", "))
+			}
+			if a.File != "" && a.Commit == "" {
+				return fmt.Errorf("%s: action %d: file is only valid with commit", name, j+1)
+			}
+			if a.File != "" && (filepath.IsAbs(a.File) || strings.HasPrefix(filepath.Clean(a.File), "..")) {
+				return fmt.Errorf("%s: action %d: file %q must be inside the worktree", name, j+1, a.File)
+			}
+			if a.Done != "" && !doneStatuses[a.Done] {
+				return fmt.Errorf("%s: action %d: done status %q must be COMPLETED, ESCALATED or DEFERRED", name, j+1, a.Done)
+			}
+			if a.Crash < 0 {
+				return fmt.Errorf("%s: action %d: crash exit code must be positive", name, j+1)
+			}
+			if a.Sleep != "" {
+				if d, err := time.ParseDuration(a.Sleep); err != nil || d < 0 {
+					return fmt.Errorf("%s: action %d: invalid sleep %q", name, j+1, a.Sleep)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ActionsFor returns the actions of the first agent entry matching the
+// polecat name or issue ID, or nil if none matches.
+func (s *Script) ActionsFor(polecat, issue string) []Action {
+	for _, ag := range s.Agents {
+		if ag.Match == "" || ag.Match == "*" {
+			return ag.Actions
+		}
+		for _, name := range []string{polecat, issue} {
+			if name == "" {
+				continue
+			}
+			if ok, _ := path.Match(ag.Match, name); ok {
+				return ag.Actions
+			}
+		}
+	}
+	return nil
+}
+
+// Dir returns the town directory holding the active script and the log.
+func Dir(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "sim")
+}
+
+// ScriptPath returns the path of the town's active simulation script.
+func ScriptPath(townRoot string) string {
+	return filepath.Join(Dir(townRoot), "script.toml")
+}
+
+// LogPath returns the path of the town's simulation event log.
+func LogPath(townRoot string) string {
+	return filepath.Join(Dir(townRoot), "log.jsonl")
+}
+
+// AgentName is the agent preset that runs `gt sim agent`.
+const AgentName = "sim"
+
+// agentProcess is the name the sim agent runs under, so liveness checks
+// never mistake an ordinary gt command for it (or it for one).
+const agentProcess = "gt-sim"
+
+// RegisterAgent adds the sim agent to the town's agent registry
+// (settings/agents.json). It is not a built-in preset: only towns that run
+// gt sim have it. The agent runs through a gt-sim link to the gt binary so
+// its process name is distinct. gtPath is the gt binary to link to. Commands
+// started afterwards (gt sling, respawns) pick the agent up from the file.
+func RegisterAgent(townRoot, gtPath string) error {
+	binDir := filepath.Join(Dir(townRoot), "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return fmt.Errorf("creating sim bin directory: %w", err)
+	}
+	link := filepath.Join(binDir, agentProcess)
+	if target, err := os.Readlink(link); err != nil || target != gtPath {
+		_ = os.Remove(link)
+		if err := os.Symlink(gtPath, link); err != nil {
+			return fmt.Errorf("linking %s: %w", agentProcess, err)
+		}
+	}
+
+	path := config.DefaultAgentRegistryPath(townRoot)
+	registry := &config.AgentRegistry{Version: config.CurrentAgentRegistryVersion}
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // G304: path is the town's agent registry
+		if err := json.Unmarshal(data, registry); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if registry.Agents == nil {
+		registry.Agents = make(map[string]*config.AgentPresetInfo)
+	}
+	registry.Agents[AgentName] = &config.AgentPresetInfo{
+		Name:         AgentName,
+		Command:      link,
+		Args:         []string{"sim", "agent"},
+		ProcessNames: []string{agentProcess},
+		// Runtime defaults: the script is read from the town, not the prompt
+		PromptMode:       "none",
+		ReadyDelayMs:     1000,
+		InstructionsFile: "AGENTS.md",
+	}
+	if err := config.SaveAgentRegistry(path, registry); err != nil {
+		return fmt.Errorf("registering sim agent: %w", err)
+	}
+	return nil
+}
+
+// Install validates a script and makes it the town's active script, read
+// by every sim agent that starts afterwards.
+func Install(townRoot, scriptPath string) (*Script, error) {
+	data, err := os.ReadFile(scriptPath) //nolint:gosec // G304: path is user-supplied script
+	if err != nil {
+		return nil, fmt.Errorf("reading sim script: %w", err)
+	}
+	s, err := ParseScript(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(Dir(townRoot), 0755); err != nil {
+		return nil, fmt.Errorf("creating sim directory: %w", err)
+	}
+	if err := os.WriteFile(ScriptPath(townRoot), data, 0644); err != nil { //nolint:gosec // G306: script is not secret
+		return nil, fmt.Errorf("installing sim script: %w", err)
+	}
+	return s, nil
+}
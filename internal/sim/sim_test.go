@@ -0,0 +1,249 @@
+package sim
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/config"
+)
+
+const testScript = `
+[[agent]]
+match = "nux"
+actions = [{ help = "stuck on {{issue}}" }, { hang = true }]
+
+[[agent]]
+match = "gt-crash*"
+actions = [{ crash = 3 }]
+
+[[agent]]
+actions = [
+  { commit = "Implement {{issue}}" },
+  { sleep = "2s" },
+  { done = "COMPLETED" },
+]
+`
+
+func TestParseScript(t *testing.T) {
+	s, err := ParseScript([]byte(testScript))
+	if err != nil {
+		t.Fatalf("ParseScript: %v", err)
+	}
+
+	tests := []struct {
+		polecat, issue string
+		want           []string
+	}{
+		{"nux", "gt-1", []string{ActionHelp, ActionHang}},
+		{"toast", "gt-crash-7", []string{ActionCrash}},
+		{"toast", "gt-2", []string{ActionCommit, ActionSleep, ActionDone}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, a := range s.ActionsFor(tt.polecat, tt.issue) {
+			got = append(got, a.Kind())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ActionsFor(%q, %q) = %v, want %v", tt.polecat, tt.issue, got, tt.want)
+		}
+	}
+}
+
+func TestParseScript_Invalid(t *testing.T) {
+	tests := []struct {
+		script, want string
+	}{
+		{``, "no [[agent]]"},
+		{"[[agent]]\nmatch = \"x\"", "no actions"},
+		{"[[agent]]\nactions = [{ commit = \"a\", done = \"COMPLETED\" }]", "several actions"},
+		{"[[agent]]\nactions = [{ done = \"FINISHED\" }]", "done status"},
+		{"[[agent]]\nactions = [{ sleep = \"soon\" }]", "invalid sleep"},
+		{"[[agent]]\nactions = [{ file = \"x\" }]", "sets no action"},
+		{"[[agent]]\nactions = [{ commit = \"a\", file = \"../x\" }]", "inside the worktree"},
+		{"[[agent]]\nactions = [{ explode = true }]", "unknown field"},
+	}
+	for _, tt := range tests {
+		_, err := ParseScript([]byte(tt.script))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseScript(%q) error = %v, want containing %q", tt.script, err, tt.want)
+		}
+	}
+}
+
+func TestRunner(t *testing.T) {
+	s, err := ParseScript([]byte(testScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "log.jsonl")
+
+	var calls []string
+	var slept time.Duration
+	r := &Runner{
+		Identity: Identity{Rig: "gastown", Polecat: "toast", Issue: "gt-2", WorkDir: dir},
+		LogPath:  logPath,
+		Exec: func(_, name string, args ...string) error {
+			calls = append(calls, name+" "+strings.Join(args, " "))
+			return nil
+		},
+		Sleep: func(d time.Duration) { slept += d },
+	}
+	if err := r.Run(s.ActionsFor("toast", "gt-2")); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ".sim", "gt-2.txt"))
+	if err != nil || string(data) != "Implement gt-2\n" {
+		t.Errorf("commit file = %q, %v", data, err)
+	}
+	want := []string{
+		"git add -- .sim/gt-2.txt",
+		"git commit -m Implement gt-2",
+		"gt done --status COMPLETED",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if slept != 2*time.Second {
+		t.Errorf("slept %v, want 2s", slept)
+	}
+
+	events, err := ReadLog(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var actions []string
+	for _, e := range events {
+		actions = append(actions, e.Action)
+	}
+	if !reflect.DeepEqual(actions, []string{ActionCommit, ActionSleep, ActionDone}) {
+		t.Errorf("logged actions = %v", actions)
+	}
+}
+
+func TestRunner_HelpCrashHang(t *testing.T) {
+	s, err := ParseScript([]byte(testScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	r := &Runner{
+		Identity: Identity{Rig: "gastown", Polecat: "nux", Issue: "gt-1", WorkDir: t.TempDir()},
+		Exec: func(_, name string, args ...string) error {
+			calls = append(calls, name+" "+strings.Join(args[:4], " "))
+			return nil
+		},
+	}
+	if err := r.Run(s.ActionsFor("nux", "gt-1")); !errors.Is(err, ErrHang) {
+		t.Errorf("Run = %v, want ErrHang", err)
+	}
+	if want := []string{"gt mail send gastown/witness -s"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	err = r.Run(s.ActionsFor("toast", "gt-crash-1"))
+	var exit *ExitError
+	if !errors.As(err, &exit) || exit.Code != 3 {
+		t.Errorf("Run = %v, want exit 3", err)
+	}
+}
+
+func TestRunner_CommitInGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "sim@example.com"},
+		{"config", "user.name", "sim"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	r := &Runner{Identity: Identity{Polecat: "toast", WorkDir: dir}}
+	actions := []Action{{Commit: "first", File: "notes/{{polecat}}.md"}, {Commit: "second", File: "notes/{{polecat}}.md"}}
+	if err := r.Run(actions); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	out, err := exec.Command("git", "-C", dir, "log", "--format=%s").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(string(out)); !reflect.DeepEqual(got, []string{"second", "first"}) {
+		t.Errorf("git log = %v", got)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	town := t.TempDir()
+	src := filepath.Join(t.TempDir(), "script.toml")
+	if err := os.WriteFile(src, []byte(testScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(town, src); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if _, err := LoadScript(ScriptPath(town)); err != nil {
+		t.Errorf("LoadScript(installed): %v", err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.toml")
+	if err := os.WriteFile(bad, []byte("[[agent]]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(town, bad); err == nil {
+		t.Error("Install accepted an invalid script")
+	}
+}
+
+func TestRegisterAgent(t *testing.T) {
+	town := t.TempDir()
+	settings := filepath.Join(town, "settings", "agents.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := `{"version": 1, "agents": {"mine": {"command": "my-agent"}}}`
+	if err := os.WriteFile(settings, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gtPath := filepath.Join(town, "gt")
+
+	for i := 0; i < 2; i++ { // idempotent
+		if err := RegisterAgent(town, gtPath); err != nil {
+			t.Fatalf("RegisterAgent: %v", err)
+		}
+	}
+
+	var registry config.AgentRegistry
+	data, err := os.ReadFile(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &registry); err != nil {
+		t.Fatal(err)
+	}
+	if registry.Agents["mine"] == nil {
+		t.Error("existing agent dropped")
+	}
+	agent := registry.Agents[AgentName]
+	if agent == nil {
+		t.Fatal("sim agent not registered")
+	}
+	if filepath.Base(agent.Command) != "gt-sim" || !reflect.DeepEqual(agent.ProcessNames, []string{"gt-sim"}) {
+		t.Errorf("sim agent = command %q, process names %v; want gt-sim", agent.Command, agent.ProcessNames)
+	}
+	if target, err := os.Readlink(agent.Command); err != nil || target != gtPath {
+		t.Errorf("link %s -> %q, %v; want %s", agent.Command, target, err, gtPath)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/xcawolfe-amzn/gastown/internal/constants"
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 )
 
 // convoyIDPattern validates convoy IDs.
@@ -29,12 +30,15 @@ type Convoy struct {
 	Total     int       `json:"total"`
 	CreatedAt time.Time `json:"created_at"`
 	ClosedAt  time.Time `json:"closed_at,omitempty"`
+	DueAt     time.Time `json:"due_at,omitempty"`
+	Deadline  string    `json:"deadline,omitempty"` // "on_track", "at_risk", "overdue" or ""
 }
 
 // ConvoyState holds all convoy data for the panel
 type ConvoyState struct {
 	InProgress []Convoy
 	Landed     []Convoy
+	Abandoned  []Convoy
 	LastUpdate time.Time
 }
 
@@ -45,6 +49,7 @@ func FetchConvoys(townRoot string) (*ConvoyState, error) {
 	state := &ConvoyState{
 		InProgress: make([]Convoy, 0),
 		Landed:     make([]Convoy, 0),
+		Abandoned:  make([]Convoy, 0),
 		LastUpdate: time.Now(),
 	}
 
@@ -55,31 +60,45 @@ func FetchConvoys(townRoot string) (*ConvoyState, error) {
 		return state, nil
 	}
 
+	cycle := loadCycleTime(townRoot)
 	for _, c := range openConvoys {
 		// Get detailed status for each convoy
-		convoy := enrichConvoy(townBeads, c)
+		convoy := enrichConvoy(townBeads, c, cycle)
 		state.InProgress = append(state.InProgress, convoy)
 	}
 
-	// Fetch recently closed convoys (landed in last 24h)
+	// Fetch recently closed convoys (landed or abandoned in last 24h)
 	closedConvoys, err := listConvoys(townBeads, "closed")
 	if err == nil {
 		cutoff := time.Now().Add(-24 * time.Hour)
 		for _, c := range closedConvoys {
-			convoy := enrichConvoy(townBeads, c)
-			if !convoy.ClosedAt.IsZero() && convoy.ClosedAt.After(cutoff) {
+			convoy := enrichConvoy(townBeads, c, 0)
+			if convoy.ClosedAt.IsZero() || !convoy.ClosedAt.After(cutoff) {
+				continue
+			}
+			if convoy.Status == "abandoned" {
+				state.Abandoned = append(state.Abandoned, convoy)
+			} else {
 				state.Landed = append(state.Landed, convoy)
 			}
 		}
 	}
 
-	// Sort: in-progress by created (oldest first), landed by closed (newest first)
+	// Sort: in-progress overdue/at-risk first, then by created (oldest
+	// first); landed by closed (newest first)
 	sort.Slice(state.InProgress, func(i, j int) bool {
+		ri, rj := deadlineRank(state.InProgress[i].Deadline), deadlineRank(state.InProgress[j].Deadline)
+		if ri != rj {
+			return ri > rj
+		}
 		return state.InProgress[i].CreatedAt.Before(state.InProgress[j].CreatedAt)
 	})
 	sort.Slice(state.Landed, func(i, j int) bool {
 		return state.Landed[i].ClosedAt.After(state.Landed[j].ClosedAt)
 	})
+	sort.Slice(state.Abandoned, func(i, j int) bool {
+		return state.Abandoned[i].ClosedAt.After(state.Abandoned[j].ClosedAt)
+	})
 
 	return state, nil
 }
@@ -109,19 +128,22 @@ func listConvoys(beadsDir, status string) ([]convoyListItem, error) {
 }
 
 type convoyListItem struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Status    string `json:"status"`
-	CreatedAt string `json:"created_at"`
-	ClosedAt  string `json:"closed_at,omitempty"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"created_at"`
+	ClosedAt    string   `json:"closed_at,omitempty"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels,omitempty"`
 }
 
-// enrichConvoy adds tracked issue counts to a convoy
-func enrichConvoy(beadsDir string, item convoyListItem) Convoy {
+// enrichConvoy adds tracked issue counts and deadline standing to a convoy.
+// cycle is the estimated issue cycle time used to project deadlines.
+func enrichConvoy(beadsDir string, item convoyListItem, cycle time.Duration) Convoy {
 	convoy := Convoy{
 		ID:     item.ID,
 		Title:  item.Title,
-		Status: item.Status,
+		Status: convoy.Lifecycle(item.Status, item.Labels),
 	}
 
 	// Parse timestamps
@@ -139,11 +161,17 @@ func enrichConvoy(beadsDir string, item convoyListItem) Convoy {
 	// Get tracked issues and their status
 	tracked := getTrackedIssueStatus(beadsDir, item.ID)
 	convoy.Total = len(tracked)
+	inFlight := 0
 	for _, t := range tracked {
-		if t.Status == "closed" {
+		switch t.Status {
+		case "closed":
 			convoy.Completed++
+		case "in_progress", "hooked":
+			inFlight++
 		}
 	}
+	convoy.DueAt, convoy.Deadline = assessConvoyDeadline(item.Description, item.Status,
+		convoy.Total-convoy.Completed, inFlight, cycle)
 
 	return convoy
 }
@@ -178,6 +206,17 @@ var (
 
 	ConvoyAgeStyle = lipgloss.NewStyle().
 			Foreground(colorDim)
+
+	ConvoyAbandonedStyle = lipgloss.NewStyle().
+				Foreground(colorDim).
+				Bold(true)
+
+	ConvoyOverdueStyle = lipgloss.NewStyle().
+				Foreground(colorError).
+				Bold(true)
+
+	ConvoyAtRiskStyle = lipgloss.NewStyle().
+				Foreground(colorWarning)
 )
 
 // renderConvoyPanel renders the convoy status panel
@@ -224,6 +263,15 @@ func (m *Model) renderConvoys() string {
 		}
 	}
 
+	// Recently Abandoned section (only when there are any)
+	if len(m.convoyState.Abandoned) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, ConvoySectionStyle.Render("RECENTLY ABANDONED (24h)"))
+		for _, c := range m.convoyState.Abandoned {
+			lines = append(lines, renderConvoyLine(c, true))
+		}
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -240,16 +288,29 @@ func renderConvoyLine(c Convoy, landed bool) string {
 	title = ConvoyNameStyle.Render(title)
 
 	if landed {
-		// Show checkmark and time since landing
+		// Show checkmark (or cross if abandoned) and time since closing
 		age := formatAge(time.Since(c.ClosedAt))
-		status := ConvoyLandedStyle.Render("✓") + " " + ConvoyAgeStyle.Render(age+" ago")
+		mark := ConvoyLandedStyle.Render("✓")
+		if c.Status == "abandoned" {
+			mark = ConvoyAbandonedStyle.Render("✗")
+		}
+		status := mark + " " + ConvoyAgeStyle.Render(age+" ago")
 		return fmt.Sprintf("  %s  %-20s  %s", id, title, status)
 	}
 
 	// Show progress bar
 	progress := renderProgressBar(c.Completed, c.Total)
 	count := ConvoyProgressStyle.Render(fmt.Sprintf("%d/%d", c.Completed, c.Total))
-	return fmt.Sprintf("  %s  %-20s  %s %s", id, title, count, progress)
+	line := fmt.Sprintf("  %s  %-20s  %s %s", id, title, count, progress)
+	switch c.Deadline {
+	case "overdue":
+		line += "  " + ConvoyOverdueStyle.Render("overdue "+formatAge(time.Since(c.DueAt)))
+	case "at_risk":
+		line += "  " + ConvoyAtRiskStyle.Render("at risk, due in "+formatAge(time.Until(c.DueAt)))
+	case "on_track":
+		line += "  " + ConvoyAgeStyle.Render("due in "+formatAge(time.Until(c.DueAt)))
+	}
+	return line
 }
 
 // renderProgressBar creates a simple progress bar: ●●○○
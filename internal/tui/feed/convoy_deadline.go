@@ -0,0 +1,33 @@
+package feed
+
+import (
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+// loadCycleTime estimates issue cycle time from the town's history of
+// landed convoys. Returns 0 without history.
+func loadCycleTime(townRoot string) time.Duration {
+	h, err := convoy.LoadCycleHistory(townRoot)
+	if err != nil {
+		return 0
+	}
+	return convoy.EstimateCycleTime(nil, h.Durations())
+}
+
+// assessConvoyDeadline returns an open convoy's deadline and its standing.
+// The zero time means the convoy has no deadline.
+func assessConvoyDeadline(description, status string, remaining, inFlight int, cycle time.Duration) (time.Time, string) {
+	due, _, ok := convoy.ParseDeadline(description)
+	if !ok || status != "open" {
+		return time.Time{}, ""
+	}
+	a := convoy.Assess(due, time.Now(), remaining, inFlight, cycle)
+	return due, string(a.Status)
+}
+
+// deadlineRank orders deadline standings by urgency (overdue first).
+func deadlineRank(status string) int {
+	return convoy.DeadlineStatus(status).Rank()
+}
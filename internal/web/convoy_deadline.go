@@ -0,0 +1,45 @@
+package web
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+// convoyCycleTime estimates issue cycle time from the town's history of
+// landed convoys. Returns 0 without history.
+func (f *LiveConvoyFetcher) convoyCycleTime() time.Duration {
+	h, err := convoy.LoadCycleHistory(f.townRoot)
+	if err != nil {
+		return 0
+	}
+	return convoy.EstimateCycleTime(nil, h.Durations())
+}
+
+// convoyDeadline returns an open convoy's deadline standing and how long
+// until it is due (or how long overdue). Both are empty without a deadline.
+func convoyDeadline(description, status string, remaining, inFlight int, cycle time.Duration) (string, string) {
+	due, _, ok := convoy.ParseDeadline(description)
+	if !ok || status != "open" {
+		return "", ""
+	}
+	now := time.Now()
+	a := convoy.Assess(due, now, remaining, inFlight, cycle)
+	left := due.Sub(now)
+	if left < 0 {
+		left = -left
+	}
+	return string(a.Status), formatDueIn(left)
+}
+
+// formatDueIn renders a duration as "45m", "5h" or "3d".
+func formatDueIn(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xcawolfe-amzn/gastown/internal/convoy"
+)
+
+// finishedConvoyWindow is how far back the dashboard reports closed convoys.
+const finishedConvoyWindow = 24 * time.Hour
+
+// closedConvoyItem is a closed convoy as listed by bd.
+type closedConvoyItem struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	ClosedAt    string   `json:"closed_at"`
+	CloseReason string   `json:"close_reason"`
+	Labels      []string `json:"labels"`
+}
+
+// FetchFinishedConvoys fetches convoys closed within the last 24h, newest
+// first. Status is "closed" for landed convoys and "abandoned" for convoys
+// given up via gt convoy abandon.
+func (f *LiveConvoyFetcher) FetchFinishedConvoys() ([]ConvoyRow, error) {
+	stdout, err := f.runBdCmd(f.townRoot, "list", "--type=convoy", "--status=closed", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("listing closed convoys: %w", err)
+	}
+
+	var convoys []closedConvoyItem
+	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
+		return nil, fmt.Errorf("parsing closed convoy list: %w", err)
+	}
+
+	return finishedConvoyRows(convoys, time.Now()), nil
+}
+
+// finishedConvoyRows builds dashboard rows for convoys closed within
+// finishedConvoyWindow of now.
+func finishedConvoyRows(convoys []closedConvoyItem, now time.Time) []ConvoyRow {
+	type finished struct {
+		row      ConvoyRow
+		closedAt time.Time
+	}
+	var rows []finished
+	for _, c := range convoys {
+		closedAt, err := time.Parse(time.RFC3339, c.ClosedAt)
+		if err != nil || now.Sub(closedAt) > finishedConvoyWindow {
+			continue
+		}
+		row := ConvoyRow{
+			ID:        c.ID,
+			Title:     c.Title,
+			Status:    convoy.Lifecycle(c.Status, c.Labels),
+			ClosedAgo: formatDueIn(now.Sub(closedAt)),
+		}
+		if row.Status == convoy.StatusAbandoned {
+			row.CloseReason = strings.TrimPrefix(c.CloseReason, convoy.AbandonReasonPrefix)
+		}
+		rows = append(rows, finished{row, closedAt})
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].closedAt.After(rows[j].closedAt) })
+	out := make([]ConvoyRow, len(rows))
+	for i, r := range rows {
+		out[i] = r.row
+	}
+	return out
+}
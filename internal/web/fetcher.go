@@ -127,15 +127,19 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 	}
 
 	var convoys []struct {
-		ID        string `json:"id"`
-		Title     string `json:"title"`
-		Status    string `json:"status"`
-		CreatedAt string `json:"created_at"`
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Status      string `json:"status"`
+		CreatedAt   string `json:"created_at"`
+		Description string `json:"description"`
 	}
 	if err := json.Unmarshal(stdout.Bytes(), &convoys); err != nil {
 		return nil, fmt.Errorf("parsing convoy list: %w", err)
 	}
 
+	// Cycle time from landed convoys, for deadline projection
+	cycle := f.convoyCycleTime()
+
 	// Build convoy rows with activity data
 	rows := make([]ConvoyRow, 0, len(convoys))
 	for _, c := range convoys {
@@ -156,9 +160,13 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 		var mostRecentActivity time.Time
 		var mostRecentUpdated time.Time
 		var hasAssignee bool
+		inFlight := 0
 		for _, t := range tracked {
-			if t.Status == "closed" {
+			switch t.Status {
+			case "closed":
 				row.Completed++
+			case "in_progress", "hooked":
+				inFlight++
 			}
 			// Track most recent activity from workers
 			if t.LastActivity.After(mostRecentActivity) {
@@ -174,6 +182,7 @@ func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 		}
 
 		row.Progress = fmt.Sprintf("%d/%d", row.Completed, row.Total)
+		row.Deadline, row.DueIn = convoyDeadline(c.Description, c.Status, row.Total-row.Completed, inFlight, cycle)
 
 		// Calculate activity info from most recent worker activity
 		if !mostRecentActivity.IsZero() {
@@ -480,3 +480,24 @@ func TestNewDashboardMux_NilConfig(t *testing.T) {
 		t.Fatal("NewDashboardMux returned nil handler")
 	}
 }
+
+func TestFinishedConvoyRows(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	items := []closedConvoyItem{
+		{ID: "hq-cv-old", Status: "closed", ClosedAt: "2026-01-08T12:00:00Z"},
+		{ID: "hq-cv-landed", Status: "closed", ClosedAt: "2026-01-10T10:00:00Z"},
+		{ID: "hq-cv-gone", Status: "closed", ClosedAt: "2026-01-10T11:00:00Z",
+			CloseReason: "Abandoned: descoped", Labels: []string{"gt:abandoned"}},
+	}
+
+	rows := finishedConvoyRows(items, now)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 (old convoy outside window)", len(rows))
+	}
+	if rows[0].ID != "hq-cv-gone" || rows[0].Status != "abandoned" || rows[0].CloseReason != "descoped" {
+		t.Errorf("rows[0] = %+v, want abandoned hq-cv-gone with reason", rows[0])
+	}
+	if rows[1].ID != "hq-cv-landed" || rows[1].Status != "closed" || rows[1].ClosedAgo != "2h" {
+		t.Errorf("rows[1] = %+v, want landed hq-cv-landed 2h ago", rows[1])
+	}
+}
@@ -20,6 +20,7 @@ var staticFiles embed.FS
 // ConvoyFetcher defines the interface for fetching convoy data.
 type ConvoyFetcher interface {
 	FetchConvoys() ([]ConvoyRow, error)
+	FetchFinishedConvoys() ([]ConvoyRow, error)
 	FetchMergeQueue() ([]MergeQueueRow, error)
 	FetchWorkers() ([]WorkerRow, error)
 	FetchMail() ([]MailRow, error)
@@ -67,6 +68,7 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	var (
 		convoys     []ConvoyRow
+		finished    []ConvoyRow
 		mergeQueue  []MergeQueueRow
 		workers     []WorkerRow
 		mail        []MailRow
@@ -84,7 +86,7 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	)
 
 	// Run all fetches in parallel with error logging
-	wg.Add(14)
+	wg.Add(15)
 
 	go func() {
 		defer wg.Done()
@@ -94,6 +96,14 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			log.Printf("dashboard: FetchConvoys failed: %v", err)
 		}
 	}()
+	go func() {
+		defer wg.Done()
+		var err error
+		finished, err = h.fetcher.FetchFinishedConvoys()
+		if err != nil {
+			log.Printf("dashboard: FetchFinishedConvoys failed: %v", err)
+		}
+	}()
 	go func() {
 		defer wg.Done()
 		var err error
@@ -217,10 +227,11 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Compute summary from already-fetched data
-	summary := computeSummary(workers, hooks, issues, convoys, escalations, activity)
+	summary := computeSummary(workers, hooks, issues, convoys, finished, escalations, activity)
 
 	data := ConvoyData{
 		Convoys:     convoys,
+		Finished:    finished,
 		MergeQueue:  mergeQueue,
 		Workers:     workers,
 		Mail:        mail,
@@ -256,7 +267,7 @@ func (h *ConvoyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // computeSummary calculates dashboard stats and alerts from fetched data.
 func computeSummary(workers []WorkerRow, hooks []HookRow, issues []IssueRow,
-	convoys []ConvoyRow, escalations []EscalationRow, activity []ActivityRow) *DashboardSummary {
+	convoys, finished []ConvoyRow, escalations []EscalationRow, activity []ActivityRow) *DashboardSummary {
 
 	summary := &DashboardSummary{
 		PolecatCount:    len(workers),
@@ -266,6 +277,15 @@ func computeSummary(workers []WorkerRow, hooks []HookRow, issues []IssueRow,
 		EscalationCount: len(escalations),
 	}
 
+	// Separate landed from abandoned convoys
+	for _, c := range finished {
+		if c.Status == "abandoned" {
+			summary.AbandonedConvoys++
+		} else {
+			summary.LandedConvoys++
+		}
+	}
+
 	// Count stuck workers (status = "stuck")
 	for _, w := range workers {
 		if w.WorkStatus == "stuck" {
@@ -19,6 +19,7 @@ var errFetchFailed = errors.New("fetch failed")
 // MockConvoyFetcher is a mock implementation for testing.
 type MockConvoyFetcher struct {
 	Convoys     []ConvoyRow
+	Finished    []ConvoyRow
 	MergeQueue  []MergeQueueRow
 	Workers     []WorkerRow
 	Mail        []MailRow
@@ -39,6 +40,10 @@ func (m *MockConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 	return m.Convoys, m.Error
 }
 
+func (m *MockConvoyFetcher) FetchFinishedConvoys() ([]ConvoyRow, error) {
+	return m.Finished, nil
+}
+
 func (m *MockConvoyFetcher) FetchMergeQueue() ([]MergeQueueRow, error) {
 	return m.MergeQueue, nil
 }
@@ -132,6 +137,30 @@ func TestConvoyHandler_RendersTemplate(t *testing.T) {
 	}
 }
 
+func TestConvoyHandler_SeparatesLandedFromAbandoned(t *testing.T) {
+	mock := &MockConvoyFetcher{
+		Finished: []ConvoyRow{
+			{ID: "hq-cv-landed", Status: "closed", ClosedAgo: "2h"},
+			{ID: "hq-cv-gone", Status: "abandoned", ClosedAgo: "5h", CloseReason: "descoped"},
+		},
+	}
+
+	handler, err := NewConvoyHandler(mock, 8*time.Second)
+	if err != nil {
+		t.Fatalf("NewConvoyHandler() error = %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	body := w.Body.String()
+
+	for _, want := range []string{"1 landed", "1 abandoned", "hq-cv-landed", "hq-cv-gone", "descoped"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Response should contain %q", want)
+		}
+	}
+}
+
 func TestConvoyHandler_LastActivityColors(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1007,6 +1036,10 @@ func (m *MockConvoyFetcherWithErrors) FetchConvoys() ([]ConvoyRow, error) {
 	return m.Convoys, nil
 }
 
+func (m *MockConvoyFetcherWithErrors) FetchFinishedConvoys() ([]ConvoyRow, error) {
+	return nil, nil
+}
+
 func (m *MockConvoyFetcherWithErrors) FetchMergeQueue() ([]MergeQueueRow, error) {
 	return nil, m.MergeQueueError
 }
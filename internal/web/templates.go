@@ -16,6 +16,7 @@ var templateFS embed.FS
 // ConvoyData represents data passed to the convoy template.
 type ConvoyData struct {
 	Convoys     []ConvoyRow
+	Finished    []ConvoyRow // Convoys closed in the last 24h (landed or abandoned)
 	MergeQueue  []MergeQueueRow
 	Workers     []WorkerRow
 	Mail        []MailRow
@@ -144,6 +145,10 @@ type DashboardSummary struct {
 	ConvoyCount     int
 	EscalationCount int
 
+	// Convoys closed in the last 24h
+	LandedConvoys    int
+	AbandonedConvoys int
+
 	// Alerts (things needing attention)
 	StuckPolecats      int // No activity > 5 min
 	StaleHooks         int // Hooked > 1 hour
@@ -198,13 +203,17 @@ type MergeQueueRow struct {
 type ConvoyRow struct {
 	ID            string
 	Title         string
-	Status        string // "open" or "closed" (raw beads status)
+	Status        string // "open", "closed" (landed) or "abandoned"
 	WorkStatus    string // Computed: "complete", "active", "stale", "stuck", "waiting"
 	Progress      string // e.g., "2/5"
 	Completed     int
 	Total         int
 	LastActivity  activity.Info
 	TrackedIssues []TrackedIssue
+	Deadline      string // "on_track", "at_risk", "overdue" or "" (no deadline)
+	DueIn         string // e.g., "5h", or how long overdue
+	ClosedAgo     string // Finished convoys: time since close, e.g., "3h"
+	CloseReason   string // Abandoned convoys: why they were abandoned
 }
 
 // TrackedIssue represents an issue tracked by a convoy.
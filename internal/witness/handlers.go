@@ -12,6 +12,7 @@ import (
 	"github.com/xcawolfe-amzn/gastown/internal/beads"
 	"github.com/xcawolfe-amzn/gastown/internal/convoy"
 	"github.com/xcawolfe-amzn/gastown/internal/git"
+	"github.com/xcawolfe-amzn/gastown/internal/headless"
 	"github.com/xcawolfe-amzn/gastown/internal/mail"
 	"github.com/xcawolfe-amzn/gastown/internal/nudge"
 	"github.com/xcawolfe-amzn/gastown/internal/rig"
@@ -191,13 +192,11 @@ func HandleLifecycleShutdown(workDir, rigName string, msg *mail.Message) *Handle
 		ProtocolType: ProtoLifecycleShutdown,
 	}
 
-	// Extract polecat name from subject
-	matches := PatternLifecycleShutdown.FindStringSubmatch(msg.Subject)
-	if len(matches) < 2 {
-		result.Error = fmt.Errorf("invalid LIFECYCLE:Shutdown subject: %s", msg.Subject)
+	polecatName, err := ParseLifecycleShutdown(msg.Subject, msg.Body)
+	if err != nil {
+		result.Error = err
 		return result
 	}
-	polecatName := matches[1]
 
 	// Shutdown means no pending work - try to auto-nuke immediately
 	nukeResult := AutoNukeIfClean(workDir, rigName, polecatName)
@@ -665,6 +664,7 @@ func nudgeRefinery(townRoot, rigName string) error {
 		return nudge.Enqueue(townRoot, sessionName, nudge.QueuedNudge{
 			Sender:  rigName + "/witness",
 			Message: nudgeMsg,
+			Kind:    nudge.KindMerge,
 		})
 	}
 	// Fallback to direct nudge if town root unavailable
@@ -819,6 +819,9 @@ func NukePolecat(workDir, rigName, polecatName string) error {
 			// Log but continue - session might already be dead
 			// The important thing is we tried
 		}
+	} else if townRoot, err := workspace.Find(workDir); err == nil && townRoot != "" {
+		// Headless runs have no tmux session; stop the supervised process.
+		_ = headless.StopRun(session.HeadlessDir(townRoot), sessionName)
 	}
 
 	// Now run gt polecat nuke to clean up worktree, branch, and beads
@@ -1046,6 +1049,12 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 				fmt.Errorf("checking session %s: %w", sessionName, err))
 			continue
 		}
+		// A headless (gt sling --headless) run has no tmux session but is
+		// just as alive while its process runs.
+		headlessRun := !sessionAlive && headless.RunAlive(session.HeadlessDir(townRoot), sessionName)
+		if headlessRun {
+			sessionAlive = true
+		}
 		// Read agent bead labels for done-intent detection.
 		// Done early because we need it for both live and dead session paths.
 		prefix := beads.GetPrefixForRig(townRoot, rigName)
@@ -1084,7 +1093,7 @@ func DetectZombiePolecats(workDir, rigName string, router *mail.Router) *DetectZ
 			// This catches the "tmux-alive-but-agent-dead" zombie class that
 			// status.go detects but DetectZombiePolecats previously missed.
 			// See: gt-kj6r6
-			if !t.IsAgentAlive(sessionName) {
+			if !headlessRun && !t.IsAgentAlive(sessionName) {
 				// Read hook bead before nuke (nuke may clean up agent bead)
 				_, deadAgentHookBead := getAgentBeadState(workDir, agentBeadID)
 				zombie := ZombieResult{
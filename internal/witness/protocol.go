@@ -3,36 +3,11 @@ package witness
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
-)
-
-// Protocol message patterns for Witness inbox routing.
-var (
-	// POLECAT_DONE <name> - polecat signaling work completion
-	PatternPolecatDone = regexp.MustCompile(`^POLECAT_DONE\s+(\S+)`)
-
-	// LIFECYCLE:Shutdown <name> - daemon-triggered polecat shutdown
-	PatternLifecycleShutdown = regexp.MustCompile(`^LIFECYCLE:Shutdown\s+(\S+)`)
-
-	// HELP: <topic> - polecat requesting intervention
-	PatternHelp = regexp.MustCompile(`^HELP:\s+(.+)`)
-
-	// MERGED <name> - refinery confirms branch merged
-	PatternMerged = regexp.MustCompile(`^MERGED\s+(\S+)`)
-
-	// MERGE_FAILED <name> - refinery reporting merge failure
-	PatternMergeFailed = regexp.MustCompile(`^MERGE_FAILED\s+(\S+)`)
-
-	// MERGE_READY <polecat-name> - witness notifying refinery that work is ready
-	PatternMergeReady = regexp.MustCompile(`^MERGE_READY\s+(\S+)`)
-
-	// HANDOFF - session continuity message
-	PatternHandoff = regexp.MustCompile(`^🤝\s*HANDOFF`)
 
-	// SWARM_START - mayor initiating batch work
-	PatternSwarmStart = regexp.MustCompile(`^SWARM_START`)
+	"github.com/xcawolfe-amzn/gastown/internal/mail"
+	"github.com/xcawolfe-amzn/gastown/internal/protocol"
 )
 
 // ProtocolType identifies the type of protocol message.
@@ -106,28 +81,53 @@ type SwarmStartPayload struct {
 	StartedAt time.Time
 }
 
-// ClassifyMessage determines the protocol type from a message subject.
+// protoTypes maps registered protocol message types to witness routing types.
+var protoTypes = map[protocol.MessageType]ProtocolType{
+	protocol.TypePolecatDone: ProtoPolecatDone,
+	protocol.TypeHelp:        ProtoHelp,
+	protocol.TypeMerged:      ProtoMerged,
+	protocol.TypeMergeFailed: ProtoMergeFailed,
+	protocol.TypeMergeReady:  ProtoMergeReady,
+	protocol.TypeHandoff:     ProtoHandoff,
+	protocol.TypeSwarmStart:  ProtoSwarmStart,
+}
+
+func init() {
+	protocol.RegisterPolecatCleaner(func(workDir, rig, polecat string) protocol.PolecatCleanup {
+		r := AutoNukeIfClean(workDir, rig, polecat)
+		return protocol.PolecatCleanup{Nuked: r.Nuked, Skipped: r.Skipped, Reason: r.Reason, Error: r.Error}
+	})
+}
+
+// ClassifyMessage determines the protocol type from a message subject,
+// using the protocol registry. LIFECYCLE messages are routed only when the
+// subject asks for a shutdown ("LIFECYCLE:Shutdown <name>").
 func ClassifyMessage(subject string) ProtocolType {
-	switch {
-	case PatternPolecatDone.MatchString(subject):
-		return ProtoPolecatDone
-	case PatternLifecycleShutdown.MatchString(subject):
-		return ProtoLifecycleShutdown
-	case PatternHelp.MatchString(subject):
-		return ProtoHelp
-	case PatternMerged.MatchString(subject):
-		return ProtoMerged
-	case PatternMergeFailed.MatchString(subject):
-		return ProtoMergeFailed
-	case PatternMergeReady.MatchString(subject):
-		return ProtoMergeReady
-	case PatternHandoff.MatchString(subject):
-		return ProtoHandoff
-	case PatternSwarmStart.MatchString(subject):
-		return ProtoSwarmStart
-	default:
+	t := protocol.ParseMessageType(subject)
+	if t == protocol.TypeLifecycle {
+		if p, err := decode(t, subject, ""); err == nil && p.(*protocol.LifecyclePayload).Action == protocol.LifecycleShutdown {
+			return ProtoLifecycleShutdown
+		}
 		return ProtoUnknown
 	}
+	if pt, ok := protoTypes[t]; ok {
+		return pt
+	}
+	return ProtoUnknown
+}
+
+// decode decodes a message of protocol type t with protocol.Decode, which
+// reads the structured block or, for hand-written mail, the body fields,
+// and validates the payload.
+func decode(t protocol.MessageType, subject, body string) (protocol.Payload, error) {
+	got, payload, err := protocol.Decode(&mail.Message{Subject: subject, Body: body})
+	if err != nil {
+		return nil, err
+	}
+	if got != t {
+		return nil, fmt.Errorf("invalid %s subject: %s", t, subject)
+	}
+	return payload, nil
 }
 
 // ParsePolecatDone extracts payload from a POLECAT_DONE message.
@@ -140,32 +140,19 @@ func ClassifyMessage(subject string) ProtocolType {
 //	Gate: <gate-id>
 //	Branch: <branch>
 func ParsePolecatDone(subject, body string) (*PolecatDonePayload, error) {
-	matches := PatternPolecatDone.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid POLECAT_DONE subject: %s", subject)
+	p, err := decode(protocol.TypePolecatDone, subject, body)
+	if err != nil {
+		return nil, err
 	}
-
-	payload := &PolecatDonePayload{
-		PolecatName: matches[1],
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "Exit:") {
-			payload.Exit = strings.TrimSpace(strings.TrimPrefix(line, "Exit:"))
-		} else if strings.HasPrefix(line, "Issue:") {
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		} else if strings.HasPrefix(line, "MR:") {
-			payload.MRID = strings.TrimSpace(strings.TrimPrefix(line, "MR:"))
-		} else if strings.HasPrefix(line, "Gate:") {
-			payload.Gate = strings.TrimSpace(strings.TrimPrefix(line, "Gate:"))
-		} else if strings.HasPrefix(line, "Branch:") {
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		}
-	}
-
-	return payload, nil
+	done := p.(*protocol.PolecatDonePayload)
+	return &PolecatDonePayload{
+		PolecatName: done.Polecat,
+		Exit:        done.ExitType,
+		IssueID:     done.Issue,
+		MRID:        done.MR,
+		Branch:      done.Branch,
+		Gate:        done.Gate,
+	}, nil
 }
 
 // ParseHelp extracts payload from a HELP message.
@@ -177,31 +164,19 @@ func ParsePolecatDone(subject, body string) (*PolecatDonePayload, error) {
 //	Problem: <description>
 //	Tried: <what was attempted>
 func ParseHelp(subject, body string) (*HelpPayload, error) {
-	matches := PatternHelp.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid HELP subject: %s", subject)
-	}
-
-	payload := &HelpPayload{
-		Topic:       matches[1],
-		RequestedAt: time.Now(),
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "Agent:") {
-			payload.Agent = strings.TrimSpace(strings.TrimPrefix(line, "Agent:"))
-		} else if strings.HasPrefix(line, "Issue:") {
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		} else if strings.HasPrefix(line, "Problem:") {
-			payload.Problem = strings.TrimSpace(strings.TrimPrefix(line, "Problem:"))
-		} else if strings.HasPrefix(line, "Tried:") {
-			payload.Tried = strings.TrimSpace(strings.TrimPrefix(line, "Tried:"))
-		}
+	p, err := decode(protocol.TypeHelp, subject, body)
+	if err != nil {
+		return nil, err
 	}
-
-	return payload, nil
+	help := p.(*protocol.HelpPayload)
+	return &HelpPayload{
+		Topic:       help.Topic,
+		Agent:       help.Agent,
+		IssueID:     help.Issue,
+		Problem:     help.Problem,
+		Tried:       help.Tried,
+		RequestedAt: orNow(help.RequestedAt),
+	}, nil
 }
 
 // ParseMerged extracts payload from a MERGED message.
@@ -212,31 +187,17 @@ func ParseHelp(subject, body string) (*HelpPayload, error) {
 //	Issue: <issue-id>
 //	Merged-At: <timestamp>
 func ParseMerged(subject, body string) (*MergedPayload, error) {
-	matches := PatternMerged.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGED subject: %s", subject)
-	}
-
-	payload := &MergedPayload{
-		PolecatName: matches[1],
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "Branch:") {
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		} else if strings.HasPrefix(line, "Issue:") {
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		} else if strings.HasPrefix(line, "Merged-At:") {
-			ts := strings.TrimSpace(strings.TrimPrefix(line, "Merged-At:"))
-			if t, err := time.Parse(time.RFC3339, ts); err == nil {
-				payload.MergedAt = t
-			}
-		}
+	p, err := decode(protocol.TypeMerged, subject, body)
+	if err != nil {
+		return nil, err
 	}
-
-	return payload, nil
+	merged := p.(*protocol.MergedPayload)
+	return &MergedPayload{
+		PolecatName: merged.Polecat,
+		Branch:      merged.Branch,
+		IssueID:     merged.Issue,
+		MergedAt:    merged.MergedAt,
+	}, nil
 }
 
 // ParseMergeFailed extracts payload from a MERGE_FAILED message.
@@ -245,35 +206,22 @@ func ParseMerged(subject, body string) (*MergedPayload, error) {
 //
 //	Branch: <branch>
 //	Issue: <issue-id>
-//	FailureType: <type>
+//	Failure-Type: <type>
 //	Error: <error-message>
 func ParseMergeFailed(subject, body string) (*MergeFailedPayload, error) {
-	matches := PatternMergeFailed.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGE_FAILED subject: %s", subject)
-	}
-
-	payload := &MergeFailedPayload{
-		PolecatName: matches[1],
-		FailedAt:    time.Now(),
-	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(line, "Branch:"):
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		case strings.HasPrefix(line, "Issue:"):
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		case strings.HasPrefix(line, "FailureType:"):
-			payload.FailureType = strings.TrimSpace(strings.TrimPrefix(line, "FailureType:"))
-		case strings.HasPrefix(line, "Error:"):
-			payload.Error = strings.TrimSpace(strings.TrimPrefix(line, "Error:"))
-		}
+	p, err := decode(protocol.TypeMergeFailed, subject, body)
+	if err != nil {
+		return nil, err
 	}
-
-	return payload, nil
+	failed := p.(*protocol.MergeFailedPayload)
+	return &MergeFailedPayload{
+		PolecatName: failed.Polecat,
+		Branch:      failed.Branch,
+		IssueID:     failed.Issue,
+		FailureType: failed.FailureType,
+		Error:       failed.Error,
+		FailedAt:    orNow(failed.FailedAt),
+	}, nil
 }
 
 // ParseMergeReady extracts payload from a MERGE_READY message.
@@ -285,64 +233,60 @@ func ParseMergeFailed(subject, body string) (*MergeFailedPayload, error) {
 //	MR: <mr-id>
 //	Verified: clean git state
 func ParseMergeReady(subject, body string) (*MergeReadyPayload, error) {
-	matches := PatternMergeReady.FindStringSubmatch(subject)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("invalid MERGE_READY subject: %s", subject)
-	}
-
-	payload := &MergeReadyPayload{
-		PolecatName: matches[1],
-		ReadyAt:     time.Now(),
+	p, err := decode(protocol.TypeMergeReady, subject, body)
+	if err != nil {
+		return nil, err
 	}
-
-	// Parse body for structured fields
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(line, "Branch:"):
-			payload.Branch = strings.TrimSpace(strings.TrimPrefix(line, "Branch:"))
-		case strings.HasPrefix(line, "Issue:"):
-			payload.IssueID = strings.TrimSpace(strings.TrimPrefix(line, "Issue:"))
-		case strings.HasPrefix(line, "MR:"):
-			payload.MRID = strings.TrimSpace(strings.TrimPrefix(line, "MR:"))
-		}
-	}
-
-	return payload, nil
+	ready := p.(*protocol.MergeReadyPayload)
+	return &MergeReadyPayload{
+		PolecatName: ready.Polecat,
+		Branch:      ready.Branch,
+		IssueID:     ready.Issue,
+		MRID:        ready.MR,
+		ReadyAt:     orNow(ready.Timestamp),
+	}, nil
 }
 
-// ParseSwarmStart extracts payload from a SWARM_START message.
-// Subject format: SWARM_START
+// ParseSwarmStart extracts payload from a SWARM_START message body.
 // Body format:
 //
 //	SwarmID: <swarm-id>
 //	Beads: <bead-a>, <bead-b>, ...
 //	Total: <count>
 func ParseSwarmStart(body string) (*SwarmStartPayload, error) {
-	payload := &SwarmStartPayload{
-		StartedAt: time.Now(),
+	p, err := decode(protocol.TypeSwarmStart, string(protocol.TypeSwarmStart), body)
+	if err != nil {
+		return nil, err
 	}
+	swarm := p.(*protocol.SwarmStartPayload)
+	return &SwarmStartPayload{
+		SwarmID:   swarm.SwarmID,
+		BeadIDs:   swarm.BeadIDs,
+		Total:     swarm.Total,
+		StartedAt: orNow(swarm.StartedAt),
+	}, nil
+}
 
-	for _, line := range strings.Split(body, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "SwarmID:") {
-			payload.SwarmID = strings.TrimSpace(strings.TrimPrefix(line, "SwarmID:"))
-		} else if strings.HasPrefix(line, "Beads:") {
-			raw := strings.TrimSpace(strings.TrimPrefix(line, "Beads:"))
-			if raw != "" {
-				for _, b := range strings.Split(raw, ",") {
-					b = strings.TrimSpace(b)
-					if b != "" {
-						payload.BeadIDs = append(payload.BeadIDs, b)
-					}
-				}
-			}
-		} else if strings.HasPrefix(line, "Total:") {
-			_, _ = fmt.Sscanf(line, "Total: %d", &payload.Total)
-		}
+// ParseLifecycleShutdown extracts the polecat to shut down from a LIFECYCLE
+// message. Subject format: LIFECYCLE:Shutdown <polecat-name>
+func ParseLifecycleShutdown(subject, body string) (string, error) {
+	p, err := decode(protocol.TypeLifecycle, subject, body)
+	if err != nil {
+		return "", err
+	}
+	lc := p.(*protocol.LifecyclePayload)
+	if lc.Action != protocol.LifecycleShutdown || lc.Target == "" {
+		return "", fmt.Errorf("invalid LIFECYCLE:Shutdown message: %s", subject)
 	}
+	return lc.Target, nil
+}
 
-	return payload, nil
+// orNow returns t, or the current time if t is zero.
+func orNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
 }
 
 // CleanupWispLabels generates labels for a cleanup wisp.